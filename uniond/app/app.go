@@ -130,9 +130,13 @@ import (
 	wasmtypes "github.com/CosmWasm/wasmd/x/wasm/types"
 	wasmvm "github.com/CosmWasm/wasmvm/v2"
 
+	"union/app/archive"
 	unioncustomquery "union/app/custom_query"
+	"union/app/lightblock"
+	"union/app/valsetcache"
 
 	ibccometblsclient "union/app/ibc/cometbls/02-client/keeper"
+	"union/app/ibc/icacontroller"
 
 	tfmodule "union/x/tokenfactory"
 	tfbindings "union/x/tokenfactory/bindings"
@@ -144,6 +148,23 @@ import (
 	dakeeper "union/x/deferredack/keeper"
 	datypes "union/x/deferredack/types"
 
+	fmmodule "union/x/feemarket"
+	fmkeeper "union/x/feemarket/keeper"
+	fmtypes "union/x/feemarket/types"
+	icapolicymodule "union/x/icapolicy"
+	icapolicykeeper "union/x/icapolicy/keeper"
+	icapolicytypes "union/x/icapolicy/types"
+	lcmodule "union/x/lightclient"
+	lckeeper "union/x/lightclient/keeper"
+	lctypes "union/x/lightclient/types"
+	oraclemodule "union/x/oracle"
+	oraclebindings "union/x/oracle/bindings"
+	oraclekeeper "union/x/oracle/keeper"
+	oracletypes "union/x/oracle/types"
+	rlmodule "union/x/ratelimit"
+	rlkeeper "union/x/ratelimit/keeper"
+	rltypes "union/x/ratelimit/types"
+
 	ibcclienttypes "github.com/cosmos/ibc-go/v8/modules/core/02-client/types"
 	ibcconnectiontypes "github.com/cosmos/ibc-go/v8/modules/core/03-connection/types"
 	"union/docs"
@@ -237,12 +258,18 @@ type UnionApp struct {
 	TransferKeeper        ibctransferkeeper.Keeper
 	WasmClientKeeper      ibcwasmkeeper.Keeper
 	ICAHostKeeper         icahostkeeper.Keeper
+	ICAControllerKeeper   icacontrollerkeeper.Keeper
+	ICAPolicyKeeper       icapolicykeeper.Keeper
 	FeeGrantKeeper        feegrantkeeper.Keeper
 	GroupKeeper           groupkeeper.Keeper
 	ConsensusParamsKeeper consensusparamkeeper.Keeper
 	WasmKeeper            wasmkeeper.Keeper
 	TfKeeper              tfkeeper.Keeper
 	DaKeeper              dakeeper.Keeper
+	LightClientKeeper     lckeeper.Keeper
+	RateLimitKeeper       rlkeeper.Keeper
+	FeeMarketKeeper       fmkeeper.Keeper
+	OracleKeeper          oraclekeeper.Keeper
 
 	// make scoped keepers public for test purposes
 	ScopedIBCKeeper      capabilitykeeper.ScopedKeeper
@@ -256,6 +283,10 @@ type UnionApp struct {
 
 	simulationManager *module.SimulationManager
 	configurator      module.Configurator
+
+	// nil unless enabled in app.toml
+	headerArchive *archive.Archive
+	valsetCache   *valsetcache.Cache
 }
 
 // New returns a reference to an initialized blockchain app
@@ -305,6 +336,11 @@ func NewUnionApp(
 		feegrant.StoreKey, evidencetypes.StoreKey, ibctransfertypes.StoreKey, ibcwasmtypes.StoreKey, icahosttypes.StoreKey,
 		capabilitytypes.StoreKey, group.StoreKey, icacontrollertypes.StoreKey, consensusparamtypes.StoreKey,
 		ibcfeetypes.StoreKey, wasmtypes.StoreKey, tftypes.StoreKey, datypes.StoreKey,
+		lctypes.StoreKey,
+		rltypes.StoreKey,
+		fmtypes.StoreKey,
+		icapolicytypes.StoreKey,
+		oracletypes.StoreKey,
 		// this line is used by starport scaffolding # stargate/app/storeKey
 	)
 
@@ -466,6 +502,17 @@ func NewUnionApp(
 	}
 	homePath := cast.ToString(appOpts.Get(flags.FlagHome))
 
+	if archiveConfig := archive.ReadConfig(appOpts); archiveConfig.Enable {
+		archiveDB, err := dbm.NewDB("archive", server.GetAppDBBackend(appOpts), filepath.Join(homePath, "data"))
+		if err != nil {
+			panic(fmt.Errorf("failed to open the header archive: %w", err))
+		}
+		app.headerArchive = archive.New(archiveDB, archiveConfig, logger)
+	}
+	if valsetCacheConfig := valsetcache.ReadConfig(appOpts); valsetCacheConfig.Enable {
+		app.valsetCache = valsetcache.New(valsetCacheConfig, logger)
+	}
+
 	app.UpgradeKeeper = upgradekeeper.NewKeeper(
 		skipUpgradeHeights,
 		runtime.NewKVStoreService(keys[upgradetypes.StoreKey]),
@@ -504,12 +551,21 @@ func NewUnionApp(
 		app.IBCKeeper.PortKeeper, app.AccountKeeper, app.BankKeeper,
 	)
 
+	// Rate limits of the transfer stack
+	app.RateLimitKeeper = rlkeeper.NewKeeper(
+		appCodec,
+		keys[rltypes.StoreKey],
+		app.IBCFeeKeeper, // ISC4 Wrapper: fee IBC middleware
+		authtypes.NewModuleAddress(govtypes.ModuleName).String(),
+	)
+	rlModule := rlmodule.NewAppModule(app.RateLimitKeeper)
+
 	// Create Transfer Keepers
 	app.TransferKeeper = ibctransferkeeper.NewKeeper(
 		appCodec,
 		keys[ibctransfertypes.StoreKey],
 		app.GetSubspace(ibctransfertypes.ModuleName),
-		app.IBCFeeKeeper, // ISC4 Wrapper: fee IBC middleware
+		app.RateLimitKeeper, // ISC4 Wrapper: rate limit IBC middleware
 		app.IBCKeeper.ChannelKeeper,
 		app.IBCKeeper.PortKeeper,
 		app.AccountKeeper,
@@ -519,7 +575,9 @@ func NewUnionApp(
 	)
 
 	transferModule := transfer.NewAppModule(app.TransferKeeper)
-	transferIBCModule := transfer.NewIBCModule(app.TransferKeeper)
+	var transferIBCModule ibcporttypes.IBCModule
+	transferIBCModule = transfer.NewIBCModule(app.TransferKeeper)
+	transferIBCModule = rlmodule.NewIBCMiddleware(transferIBCModule, app.RateLimitKeeper)
 
 	app.ICAHostKeeper = icahostkeeper.NewKeeper(
 		appCodec,
@@ -535,7 +593,7 @@ func NewUnionApp(
 	)
 	app.ICAHostKeeper.WithQueryRouter(app.GRPCQueryRouter())
 
-	icaControllerKeeper := icacontrollerkeeper.NewKeeper(
+	app.ICAControllerKeeper = icacontrollerkeeper.NewKeeper(
 		appCodec,
 		keys[icacontrollertypes.StoreKey],
 		app.GetSubspace(icacontrollertypes.SubModuleName),
@@ -547,8 +605,20 @@ func NewUnionApp(
 		authtypes.NewModuleAddress(govtypes.ModuleName).String(),
 	)
 
-	icaModule := ica.NewAppModule(&icaControllerKeeper, &app.ICAHostKeeper)
-	icaHostIBCModule := icahost.NewIBCModule(app.ICAHostKeeper)
+	app.ICAPolicyKeeper = icapolicykeeper.NewKeeper(
+		appCodec,
+		keys[icapolicytypes.StoreKey],
+		app.IBCKeeper.ChannelKeeper,
+		app.IBCKeeper.ChannelKeeper,
+		authtypes.NewModuleAddress(govtypes.ModuleName).String(),
+	)
+	icaPolicyModule := icapolicymodule.NewAppModule(app.ICAPolicyKeeper)
+
+	icaModule := ica.NewAppModule(&app.ICAControllerKeeper, &app.ICAHostKeeper)
+	var icaHostIBCModule ibcporttypes.IBCModule
+	icaHostIBCModule = icahost.NewIBCModule(app.ICAHostKeeper)
+	icaHostIBCModule = icapolicymodule.NewIBCMiddleware(icaHostIBCModule, app.ICAPolicyKeeper)
+	icaControllerIBCModule := icacontroller.NewIBCModule(app.ICAControllerKeeper)
 
 	// Create evidence Keeper for to register the IBC light client misbehaviour evidence route
 	evidenceKeeper := evidencekeeper.NewKeeper(
@@ -589,8 +659,30 @@ func NewUnionApp(
 	)
 	daModule := damodule.NewAppModule(app.DaKeeper)
 
+	app.LightClientKeeper = lckeeper.NewKeeper(
+		appCodec,
+		keys[lctypes.StoreKey],
+		authtypes.NewModuleAddress(govtypes.ModuleName).String(),
+	)
+	lcModule := lcmodule.NewAppModule(app.LightClientKeeper)
+
+	app.FeeMarketKeeper = fmkeeper.NewKeeper(
+		appCodec,
+		keys[fmtypes.StoreKey],
+		authtypes.NewModuleAddress(govtypes.ModuleName).String(),
+	)
+	fmModule := fmmodule.NewAppModule(app.FeeMarketKeeper)
+
+	app.OracleKeeper = oraclekeeper.NewKeeper(
+		appCodec,
+		keys[oracletypes.StoreKey],
+		authtypes.NewModuleAddress(govtypes.ModuleName).String(),
+	)
+	oracleModule := oraclemodule.NewAppModule(app.OracleKeeper)
+
 	wasmOpts = append(wasmOpts, tfbindings.RegisterCustomPlugins(&appBankBaseKeeper, &app.TfKeeper)...)
 	wasmOpts = append(wasmOpts, dabindings.RegisterCustomPlugins(&appBankBaseKeeper, &app.DaKeeper)...)
+	wasmOpts = append(wasmOpts, oraclebindings.RegisterQueryPlugins(app.GRPCQueryRouter(), appCodec)...)
 
 	wasmDir := filepath.Join(homePath, "wasm")
 	wasmConfig, err := wasm.ReadWasmConfig(appOpts)
@@ -680,6 +772,7 @@ func NewUnionApp(
 
 	ibcRouter := ibcporttypes.NewRouter()
 	ibcRouter.AddRoute(icahosttypes.SubModuleName, icaHostIBCModule).
+		AddRoute(icacontrollertypes.SubModuleName, icaControllerIBCModule).
 		AddRoute(wasmtypes.ModuleName, wasmStack).
 		AddRoute(ibctransfertypes.ModuleName, transferIBCModule)
 	// this line is used by starport scaffolding # ibc/app/router
@@ -744,6 +837,11 @@ func NewUnionApp(
 		icaModule,
 		tfModule,
 		daModule,
+		lcModule,
+		rlModule,
+		fmModule,
+		icaPolicyModule,
+		oracleModule,
 		ibctm.NewAppModule(),
 		solomachine.NewAppModule(),
 	)
@@ -800,6 +898,11 @@ func NewUnionApp(
 		wasmtypes.ModuleName,
 		tftypes.ModuleName,
 		datypes.ModuleName,
+		lctypes.ModuleName,
+		rltypes.ModuleName,
+		fmtypes.ModuleName,
+		icapolicytypes.ModuleName,
+		oracletypes.ModuleName,
 	)
 
 	app.ModuleManager.SetOrderEndBlockers(
@@ -828,6 +931,11 @@ func NewUnionApp(
 		wasmtypes.ModuleName,
 		tftypes.ModuleName,
 		datypes.ModuleName,
+		lctypes.ModuleName,
+		rltypes.ModuleName,
+		fmtypes.ModuleName,
+		icapolicytypes.ModuleName,
+		oracletypes.ModuleName,
 	)
 
 	// NOTE: The genutils module must occur after staking so that pools are
@@ -861,6 +969,11 @@ func NewUnionApp(
 		wasmtypes.ModuleName,
 		tftypes.ModuleName,
 		datypes.ModuleName,
+		lctypes.ModuleName,
+		rltypes.ModuleName,
+		fmtypes.ModuleName,
+		icapolicytypes.ModuleName,
+		oracletypes.ModuleName,
 	}
 	app.ModuleManager.SetOrderInitGenesis(genesisModuleOrder...)
 	app.ModuleManager.SetOrderExportGenesis(genesisModuleOrder...)
@@ -906,6 +1019,7 @@ func NewUnionApp(
 				SignModeHandler: txConfig.SignModeHandler(),
 				FeegrantKeeper:  app.FeeGrantKeeper,
 				SigGasConsumer:  ante.DefaultSigVerificationGasConsumer,
+				TxFeeChecker:    app.FeeMarketKeeper.CheckTxFee,
 			},
 			IBCKeeper:             app.IBCKeeper,
 			WasmConfig:            &wasmConfig,
@@ -917,7 +1031,9 @@ func NewUnionApp(
 	}
 
 	app.SetAnteHandler(anteHandler)
+	app.SetPostHandler(NewPostHandler())
 	app.SetEndBlocker(app.EndBlocker)
+	app.SetPrepareProposal(NewPrepareProposalHandler(txConfig.TxDecoder()))
 
 	// must be before Loading version
 	// requires the snapshot store to be created and registered as a BaseAppOption
@@ -1107,6 +1223,10 @@ func (app *UnionApp) RegisterAPIRoutes(apiSvr *api.Server, apiConfig config.APIC
 	cmtservice.RegisterGRPCGatewayRoutes(clientCtx, apiSvr.GRPCGatewayRouter)
 	// Register node gRPC service for grpc-gateway.
 	nodeservice.RegisterGRPCGatewayRoutes(clientCtx, apiSvr.GRPCGatewayRouter)
+	// Register header archive service for grpc-gateway.
+	archive.RegisterGRPCGatewayRoutes(clientCtx, apiSvr.GRPCGatewayRouter)
+	// Register light block service for grpc-gateway.
+	lightblock.RegisterGRPCGatewayRoutes(clientCtx, apiSvr.GRPCGatewayRouter)
 
 	// Register grpc-gateway routes for all modules.
 	app.BasicModuleManager.RegisterGRPCGatewayRoutes(clientCtx, apiSvr.GRPCGatewayRouter)
@@ -1133,6 +1253,31 @@ func (app *UnionApp) RegisterTendermintService(clientCtx client.Context) {
 // RegisterNodeService implements the Application.RegisterNodeService method.
 func (app *UnionApp) RegisterNodeService(clientCtx client.Context, cfg config.Config) {
 	nodeservice.RegisterNodeService(clientCtx, app.GRPCQueryRouter(), cfg)
+	lightblock.RegisterLightBlockService(app.GRPCQueryRouter(), clientCtx.Client)
+
+	archive.RegisterArchiveService(app.GRPCQueryRouter(), app.headerArchive)
+	if app.headerArchive != nil {
+		app.headerArchive.Start(clientCtx.Client)
+	}
+
+	valsetcache.RegisterValidatorSetCacheService(app.GRPCQueryRouter(), app.valsetCache)
+	if app.valsetCache != nil {
+		app.valsetCache.Start(clientCtx.Client)
+	}
+}
+
+// Close stops the header archive and the validator set cache, if enabled,
+// and closes the BaseApp.
+func (app *UnionApp) Close() error {
+	if app.valsetCache != nil {
+		app.valsetCache.Stop()
+	}
+	if app.headerArchive != nil {
+		if err := app.headerArchive.Stop(); err != nil {
+			return err
+		}
+	}
+	return app.BaseApp.Close()
 }
 
 // initParamsKeeper init params keeper and its subspaces
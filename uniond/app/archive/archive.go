@@ -0,0 +1,126 @@
+package archive
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"cosmossdk.io/log"
+	cmttypes "github.com/cometbft/cometbft/types"
+	dbm "github.com/cosmos/cosmos-db"
+	"github.com/cosmos/cosmos-sdk/client"
+
+	"union/verifier"
+)
+
+// Archive retains the light blocks of the latest heights in its own database,
+// independently of the block store pruning.
+type Archive struct {
+	db            dbm.DB
+	retainHeights int64
+	interval      time.Duration
+	logger        log.Logger
+
+	cancel context.CancelFunc
+	done   chan struct{}
+	once   sync.Once
+}
+
+func New(db dbm.DB, cfg Config, logger log.Logger) *Archive {
+	if cfg.Interval <= 0 {
+		cfg.Interval = DefaultConfig().Interval
+	}
+	return &Archive{
+		db:            db,
+		retainHeights: int64(cfg.RetainHeights),
+		interval:      cfg.Interval,
+		logger:        logger.With("module", "archive"),
+	}
+}
+
+// Start synchronizes the archive with the node in the background until Stop
+// is called. Calling Start more than once has no effect.
+func (a *Archive) Start(node client.CometRPC) {
+	a.once.Do(func() {
+		ctx, cancel := context.WithCancel(context.Background())
+		a.cancel = cancel
+		a.done = make(chan struct{})
+		go a.run(ctx, node)
+	})
+}
+
+// Stop the background synchronization if running and close the database.
+func (a *Archive) Stop() error {
+	if a.cancel != nil {
+		a.cancel()
+		<-a.done
+	}
+	return a.db.Close()
+}
+
+func (a *Archive) run(ctx context.Context, node client.CometRPC) {
+	defer close(a.done)
+	ticker := time.NewTicker(a.interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := a.sync(ctx, node); err != nil && ctx.Err() == nil {
+				a.logger.Error("failed to synchronize the header archive", "err", err)
+			}
+		}
+	}
+}
+
+// sync archives every height committed since the last synchronization and
+// prunes the ones falling out of the retention window.
+func (a *Archive) sync(ctx context.Context, node client.CometRPC) error {
+	status, err := node.Status(ctx)
+	if err != nil {
+		return err
+	}
+	// The commit of the latest height is not canonical yet.
+	latest := status.SyncInfo.LatestBlockHeight - 1
+	if latest < 1 {
+		return nil
+	}
+	_, last, err := heightRange(a.db)
+	if err != nil {
+		return err
+	}
+	from := max(last+1, latest-a.retainHeights+1, status.SyncInfo.EarliestBlockHeight)
+	for height := from; height <= latest; height++ {
+		lightBlock, err := FetchLightBlock(ctx, node, height)
+		if err != nil {
+			return fmt.Errorf("height %d: %w", height, err)
+		}
+		if err := saveLightBlock(a.db, lightBlock); err != nil {
+			return err
+		}
+	}
+	return pruneBelow(a.db, latest-a.retainHeights+1)
+}
+
+// FetchLightBlock returns the signed header and the full validator set at the
+// given height.
+func FetchLightBlock(ctx context.Context, node client.CometRPC, height int64) (*cmttypes.LightBlock, error) {
+	commit, err := node.Commit(ctx, &height)
+	if err != nil {
+		return nil, err
+	}
+	_, vals, err := verifier.FetchValidatorSet(ctx, node, &height)
+	if err != nil {
+		return nil, err
+	}
+	lightBlock := &cmttypes.LightBlock{
+		SignedHeader: &commit.SignedHeader,
+		ValidatorSet: vals,
+	}
+	if err := lightBlock.ValidateBasic(commit.ChainID); err != nil {
+		return nil, err
+	}
+	return lightBlock, nil
+}
@@ -0,0 +1,60 @@
+package archive
+
+import (
+	"time"
+
+	servertypes "github.com/cosmos/cosmos-sdk/server/types"
+	"github.com/spf13/cast"
+)
+
+const (
+	flagEnable        = "archive.enable"
+	flagRetainHeights = "archive.retain-heights"
+	flagInterval      = "archive.interval"
+)
+
+// ConfigTemplate is the app.toml section of the header archive.
+const ConfigTemplate = `
+[archive]
+# Retain the light blocks (signed header and validator set) of the latest
+# heights independently of min-retain-blocks and serve them over gRPC.
+# The archive is filled from the local node, the gRPC or API server must be enabled.
+enable = {{ .Archive.Enable }}
+# Number of heights to retain.
+retain-heights = {{ .Archive.RetainHeights }}
+# Interval between two synchronizations with the block store.
+interval = "{{ .Archive.Interval }}"
+`
+
+// Config defines the header archive configuration.
+type Config struct {
+	Enable        bool          `mapstructure:"enable"`
+	RetainHeights uint64        `mapstructure:"retain-heights"`
+	Interval      time.Duration `mapstructure:"interval"`
+}
+
+// DefaultConfig returns the default header archive configuration, the archive
+// is disabled.
+func DefaultConfig() Config {
+	return Config{
+		Enable:        false,
+		RetainHeights: 1_000_000,
+		Interval:      5 * time.Second,
+	}
+}
+
+// ReadConfig reads the header archive configuration from the app options,
+// falling back to the defaults for unset values.
+func ReadConfig(appOpts servertypes.AppOptions) Config {
+	cfg := DefaultConfig()
+	if v := appOpts.Get(flagEnable); v != nil {
+		cfg.Enable = cast.ToBool(v)
+	}
+	if v := appOpts.Get(flagRetainHeights); v != nil {
+		cfg.RetainHeights = cast.ToUint64(v)
+	}
+	if v := appOpts.Get(flagInterval); v != nil {
+		cfg.Interval = cast.ToDuration(v)
+	}
+	return cfg
+}
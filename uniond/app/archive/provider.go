@@ -0,0 +1,103 @@
+package archive
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/cometbft/cometbft/light/provider"
+	cmttypes "github.com/cometbft/cometbft/types"
+	gogogrpc "github.com/cosmos/gogoproto/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"union/verifier"
+)
+
+var _ provider.Provider = (*Provider)(nil)
+
+// Provider serves the light clients the light blocks of the header archive of
+// a node, e.g. as an archive provider of verifier.NewArchiveFallback.
+type Provider struct {
+	chainID string
+	name    string
+	service ServiceClient
+}
+
+// NewProvider creates a light client provider of the header archive served
+// on a gRPC connection, named after its address.
+func NewProvider(chainID, name string, conn gogogrpc.ClientConn) *Provider {
+	return &Provider{chainID: chainID, name: name, service: NewServiceClient(conn)}
+}
+
+func (p *Provider) ChainID() string {
+	return p.chainID
+}
+
+func (p *Provider) String() string {
+	return fmt.Sprintf("archive{%s}", p.name)
+}
+
+// LightBlock returns the archived light block at a height, the latest
+// archived one if zero. Heights out of the archive are reported as
+// provider.ErrLightBlockNotFound, or provider.ErrHeightTooHigh above it.
+func (p *Provider) LightBlock(ctx context.Context, height int64) (*cmttypes.LightBlock, error) {
+	if height < 0 {
+		return nil, provider.ErrBadLightBlock{Reason: fmt.Errorf("expected height >= 0, got height %d", height)}
+	}
+	if height == 0 {
+		archiveStatus, err := p.service.Status(ctx, &GetStatusRequest{})
+		if err != nil {
+			return nil, p.providerError(err)
+		}
+		if archiveStatus.LatestHeight == 0 {
+			return nil, provider.ErrLightBlockNotFound
+		}
+		height = archiveStatus.LatestHeight
+	}
+	res, err := p.service.LightBlock(ctx, &GetLightBlockRequest{Height: height})
+	if status.Code(err) == codes.NotFound {
+		archiveStatus, statusErr := p.service.Status(ctx, &GetStatusRequest{})
+		if statusErr == nil && height > archiveStatus.LatestHeight {
+			return nil, provider.ErrHeightTooHigh
+		}
+		return nil, provider.ErrLightBlockNotFound
+	}
+	if err != nil {
+		return nil, p.providerError(err)
+	}
+	if res.LightBlock == nil {
+		return nil, provider.ErrBadLightBlock{Reason: errors.New("empty light block")}
+	}
+	// Decoding panics past the bounds of the voting powers.
+	if err := verifier.CheckVotingPowers(res.LightBlock.ValidatorSet); err != nil {
+		return nil, provider.ErrBadLightBlock{Reason: err}
+	}
+	lightBlock, err := cmttypes.LightBlockFromProto(res.LightBlock)
+	if err != nil {
+		return nil, provider.ErrBadLightBlock{Reason: err}
+	}
+	if lightBlock.Height != height {
+		return nil, provider.ErrBadLightBlock{Reason: fmt.Errorf("height %d responded doesn't match height %d requested", lightBlock.Height, height)}
+	}
+	if err := lightBlock.ValidateBasic(p.chainID); err != nil {
+		return nil, provider.ErrBadLightBlock{Reason: err}
+	}
+	return lightBlock, nil
+}
+
+// ReportEvidence isn't supported by the header archive, the evidence is only
+// reported to the other providers.
+func (p *Provider) ReportEvidence(context.Context, cmttypes.Evidence) error {
+	return errors.New("the header archive doesn't accept evidence")
+}
+
+// providerError reports an archive that can't be reached or is disabled as
+// not responding, which the light client tolerates.
+func (p *Provider) providerError(err error) error {
+	switch status.Code(err) {
+	case codes.Unavailable, codes.DeadlineExceeded:
+		return provider.ErrNoResponse
+	}
+	return err
+}
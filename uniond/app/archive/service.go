@@ -0,0 +1,62 @@
+package archive
+
+import (
+	"context"
+
+	gogogrpc "github.com/cosmos/gogoproto/grpc"
+	"github.com/grpc-ecosystem/grpc-gateway/runtime"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+var _ ServiceServer = queryServer{}
+
+type queryServer struct {
+	archive *Archive
+}
+
+// LightBlock implements ServiceServer.LightBlock
+func (s queryServer) LightBlock(_ context.Context, req *GetLightBlockRequest) (*GetLightBlockResponse, error) {
+	if s.archive == nil {
+		return nil, status.Error(codes.Unavailable, "the header archive is disabled on this node")
+	}
+	if req.Height <= 0 {
+		return nil, status.Errorf(codes.InvalidArgument, "height must be positive, got %d", req.Height)
+	}
+	lightBlock, err := loadLightBlock(s.archive.db, req.Height)
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	if lightBlock == nil {
+		return nil, status.Errorf(codes.NotFound, "height %d is not archived", req.Height)
+	}
+	return &GetLightBlockResponse{LightBlock: lightBlock}, nil
+}
+
+// Status implements ServiceServer.Status
+func (s queryServer) Status(_ context.Context, _ *GetStatusRequest) (*GetStatusResponse, error) {
+	if s.archive == nil {
+		return nil, status.Error(codes.Unavailable, "the header archive is disabled on this node")
+	}
+	earliest, latest, err := heightRange(s.archive.db)
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	return &GetStatusResponse{
+		EarliestHeight: earliest,
+		LatestHeight:   latest,
+		RetainHeights:  uint64(s.archive.retainHeights),
+	}, nil
+}
+
+// RegisterArchiveService registers the header archive service on the gRPC
+// router, archive may be nil if the archive is disabled.
+func RegisterArchiveService(server gogogrpc.Server, archive *Archive) {
+	RegisterServiceServer(server, queryServer{archive: archive})
+}
+
+// RegisterGRPCGatewayRoutes mounts the header archive service's GRPC-gateway
+// routes on the given Mux.
+func RegisterGRPCGatewayRoutes(clientConn gogogrpc.ClientConn, mux *runtime.ServeMux) {
+	_ = RegisterServiceHandlerClient(context.Background(), mux, NewServiceClient(clientConn))
+}
@@ -0,0 +1,109 @@
+package archive
+
+import (
+	"encoding/binary"
+	"fmt"
+
+	storetypes "cosmossdk.io/store/types"
+	cmtproto "github.com/cometbft/cometbft/proto/tendermint/types"
+	cmttypes "github.com/cometbft/cometbft/types"
+	dbm "github.com/cosmos/cosmos-db"
+)
+
+var lightBlockPrefix = []byte("lb/")
+
+func lightBlockKey(height int64) []byte {
+	key := make([]byte, len(lightBlockPrefix)+8)
+	copy(key, lightBlockPrefix)
+	binary.BigEndian.PutUint64(key[len(lightBlockPrefix):], uint64(height))
+	return key
+}
+
+func saveLightBlock(db dbm.DB, lightBlock *cmttypes.LightBlock) error {
+	pb, err := lightBlock.ToProto()
+	if err != nil {
+		return err
+	}
+	bz, err := pb.Marshal()
+	if err != nil {
+		return err
+	}
+	return db.Set(lightBlockKey(lightBlock.Height), bz)
+}
+
+func loadLightBlock(db dbm.DB, height int64) (*cmtproto.LightBlock, error) {
+	bz, err := db.Get(lightBlockKey(height))
+	if err != nil {
+		return nil, err
+	}
+	if bz == nil {
+		return nil, nil
+	}
+	var pb cmtproto.LightBlock
+	if err := pb.Unmarshal(bz); err != nil {
+		return nil, fmt.Errorf("corrupted light block at height %d: %w", height, err)
+	}
+	return &pb, nil
+}
+
+// heightRange returns the lowest and highest archived heights, both zero if
+// the archive is empty.
+func heightRange(db dbm.DB) (int64, int64, error) {
+	first, err := edgeHeight(db, false)
+	if err != nil {
+		return 0, 0, err
+	}
+	last, err := edgeHeight(db, true)
+	if err != nil {
+		return 0, 0, err
+	}
+	return first, last, nil
+}
+
+func edgeHeight(db dbm.DB, reverse bool) (int64, error) {
+	var (
+		it  dbm.Iterator
+		err error
+	)
+	end := storetypes.PrefixEndBytes(lightBlockPrefix)
+	if reverse {
+		it, err = db.ReverseIterator(lightBlockPrefix, end)
+	} else {
+		it, err = db.Iterator(lightBlockPrefix, end)
+	}
+	if err != nil {
+		return 0, err
+	}
+	defer it.Close()
+	if !it.Valid() {
+		return 0, it.Error()
+	}
+	return int64(binary.BigEndian.Uint64(it.Key()[len(lightBlockPrefix):])), nil
+}
+
+// pruneBelow deletes every light block whose height is lower than the given
+// one.
+func pruneBelow(db dbm.DB, height int64) error {
+	it, err := db.Iterator(lightBlockPrefix, lightBlockKey(height))
+	if err != nil {
+		return err
+	}
+	var keys [][]byte
+	for ; it.Valid(); it.Next() {
+		keys = append(keys, it.Key())
+	}
+	if err := it.Error(); err != nil {
+		it.Close()
+		return err
+	}
+	it.Close()
+
+	batch := db.NewBatch()
+	defer batch.Close()
+	for _, key := range keys {
+		if err := batch.Delete(key); err != nil {
+			return err
+		}
+	}
+	return batch.Write()
+}
@@ -0,0 +1,74 @@
+package lightblock
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	cmttypes "github.com/cometbft/cometbft/types"
+	gogogrpc "github.com/cosmos/gogoproto/grpc"
+
+	"union/verifier"
+)
+
+// HeaderStream follows the headers committed by a node along with their
+// validator set, which is kept across headers and updated from the changes
+// streamed by the light block service instead of being fetched again at every
+// height, see verifier.ApplyValidatorUpdates.
+type HeaderStream struct {
+	stream Service_SubscribeHeadersClient
+	vals   *cmttypes.ValidatorSet
+}
+
+// SubscribeHeaders subscribes to the headers of the node served on a gRPC
+// connection from a height on, the latest one if zero, until the context is
+// done.
+func SubscribeHeaders(ctx context.Context, conn gogogrpc.ClientConn, fromHeight int64) (*HeaderStream, error) {
+	stream, err := NewServiceClient(conn).SubscribeHeaders(ctx, &SubscribeHeadersRequest{
+		FromHeight:        fromHeight,
+		ValidatorSetDiffs: true,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &HeaderStream{stream: stream}, nil
+}
+
+// Next returns the light block of the next header streamed. Its validator
+// set is checked against the validators hash of the header, the light block
+// still has to be verified by the light client.
+func (s *HeaderStream) Next() (*cmttypes.LightBlock, error) {
+	res, err := s.stream.Recv()
+	if err != nil {
+		return nil, err
+	}
+	if res.SignedHeader == nil {
+		return nil, errors.New("empty signed header")
+	}
+	signedHeader, err := cmttypes.SignedHeaderFromProto(res.SignedHeader)
+	if err != nil {
+		return nil, err
+	}
+	vals := s.vals
+	updates := make([]*cmttypes.Validator, len(res.ValidatorUpdates))
+	if res.ValidatorSet != nil {
+		// Decoding panics past the bounds of the voting powers.
+		if err := verifier.CheckVotingPowers(res.ValidatorSet); err != nil {
+			return nil, fmt.Errorf("validator set at height %d: %w", signedHeader.Height, err)
+		}
+		if vals, err = cmttypes.ValidatorSetFromProto(res.ValidatorSet); err != nil {
+			return nil, fmt.Errorf("validator set at height %d: %w", signedHeader.Height, err)
+		}
+	} else if vals == nil {
+		return nil, fmt.Errorf("no validator set to apply the updates of height %d to", signedHeader.Height)
+	}
+	for i, update := range res.ValidatorUpdates {
+		if updates[i], err = cmttypes.ValidatorFromProto(update); err != nil {
+			return nil, fmt.Errorf("validator update #%d at height %d: %w", i, signedHeader.Height, err)
+		}
+	}
+	if s.vals, err = verifier.ApplyValidatorUpdates(vals, updates, signedHeader.Header); err != nil {
+		return nil, err
+	}
+	return &cmttypes.LightBlock{SignedHeader: signedHeader, ValidatorSet: s.vals}, nil
+}
@@ -0,0 +1,115 @@
+package lightblock
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/cometbft/cometbft/light"
+	cmtproto "github.com/cometbft/cometbft/proto/tendermint/types"
+	cmttypes "github.com/cometbft/cometbft/types"
+	"github.com/cosmos/cosmos-sdk/client"
+
+	"union/verifier"
+)
+
+// pollInterval is the interval at which the header subscriptions poll the node
+// for newly committed heights.
+const pollInterval = 500 * time.Millisecond
+
+// subscription streams the signed headers of a node, tracking the validator
+// set of the last header streamed to diff the next one against.
+type subscription struct {
+	node  client.CometRPC
+	diffs bool
+	vals  *cmttypes.ValidatorSet
+}
+
+// run streams every header from height on until the context is done, height
+// being above the latest height of the node or not.
+func (s *subscription) run(ctx context.Context, height int64, send func(*SubscribeHeadersResponse) error) error {
+	ticker := time.NewTicker(pollInterval)
+	defer ticker.Stop()
+	for {
+		status, err := s.node.Status(ctx)
+		if err != nil {
+			return err
+		}
+		if height < status.SyncInfo.EarliestBlockHeight {
+			return fmt.Errorf("%w: height %d below the earliest height %d", ErrNotCommitted, height, status.SyncInfo.EarliestBlockHeight)
+		}
+		for ; height <= status.SyncInfo.LatestBlockHeight; height++ {
+			res, err := s.header(ctx, height)
+			if err != nil {
+				return fmt.Errorf("height %d: %w", height, err)
+			}
+			if err := send(res); err != nil {
+				return err
+			}
+		}
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
+
+// header returns the signed header at a height, along with the changes of
+// its validator set if requested.
+func (s *subscription) header(ctx context.Context, height int64) (*SubscribeHeadersResponse, error) {
+	commit, err := s.node.Commit(ctx, &height)
+	if err != nil {
+		return nil, err
+	}
+	res := &SubscribeHeadersResponse{SignedHeader: commit.SignedHeader.ToProto()}
+	if !s.diffs {
+		return res, nil
+	}
+	_, vals, err := verifier.FetchValidatorSet(ctx, s.node, &height)
+	if err != nil {
+		return nil, err
+	}
+	lightBlock := &cmttypes.LightBlock{SignedHeader: &commit.SignedHeader, ValidatorSet: vals}
+	if _, err := verifier.DetectLegacy(lightBlock, verifier.AnyHeight); err != nil {
+		return nil, err
+	}
+	if s.vals == nil {
+		res.ValidatorSet, err = vals.ToProto()
+	} else {
+		res.ValidatorUpdates, err = validatorUpdates(s.vals, vals)
+	}
+	if err != nil {
+		return nil, err
+	}
+	s.vals = vals
+	return res, nil
+}
+
+// validatorUpdates returns the changes turning a validator set into the next
+// one, a removed validator having no voting power.
+func validatorUpdates(vals, next *cmttypes.ValidatorSet) ([]*cmtproto.Validator, error) {
+	// The trust level only matters to the overlap of the sets, unused here.
+	diff, err := verifier.DiffValidatorSets(vals, next, light.DefaultTrustLevel)
+	if err != nil {
+		return nil, err
+	}
+	updates := make([]*cmtproto.Validator, 0, len(diff.Added)+len(diff.Changed)+len(diff.Removed))
+	for _, changes := range [][]verifier.ValidatorChange{diff.Added, diff.Changed, diff.Removed} {
+		for _, change := range changes {
+			set := next
+			if change.PowerB == 0 {
+				set = vals
+			}
+			_, val := set.GetByAddress(change.Address)
+			pb, err := val.ToProto()
+			if err != nil {
+				return nil, err
+			}
+			pb.VotingPower = change.PowerB
+			pb.ProposerPriority = 0
+			updates = append(updates, pb)
+		}
+	}
+	return updates, nil
+}
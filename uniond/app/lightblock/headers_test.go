@@ -0,0 +1,46 @@
+package lightblock
+
+import (
+	"testing"
+
+	cmttypes "github.com/cometbft/cometbft/types"
+	"github.com/stretchr/testify/require"
+
+	"union/verifier"
+	"union/verifier/lighttest"
+)
+
+func TestValidatorUpdatesRebuildTheNextSet(t *testing.T) {
+	chain, err := lighttest.NewChain("updates-1", 6, 1, lighttest.WithChurn(2))
+	require.NoError(t, err)
+
+	for _, legacy := range []bool{false, true} {
+		vals, err := chain.ValidatorSet(1)
+		require.NoError(t, err)
+		for height := int64(2); height <= 6; height++ {
+			next, err := chain.ValidatorSet(height)
+			require.NoError(t, err)
+			header, err := chain.Header(height, legacy)
+			require.NoError(t, err)
+
+			pbs, err := validatorUpdates(vals, next)
+			require.NoError(t, err)
+			// Two validators replaced, each removed and added.
+			require.Len(t, pbs, 4)
+			updates := make([]*cmttypes.Validator, len(pbs))
+			for i, pb := range pbs {
+				updates[i], err = cmttypes.ValidatorFromProto(pb)
+				require.NoError(t, err)
+			}
+
+			rebuilt, err := verifier.ApplyValidatorUpdates(vals, updates, header)
+			require.NoError(t, err)
+			require.Equal(t, next.Hash(), rebuilt.Hash())
+
+			// A missing update leaves a set the header doesn't commit to.
+			_, err = verifier.ApplyValidatorUpdates(vals, updates[1:], header)
+			require.ErrorIs(t, err, verifier.ErrValidatorsHashMismatch)
+			vals = rebuilt
+		}
+	}
+}
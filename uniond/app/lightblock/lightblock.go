@@ -0,0 +1,88 @@
+package lightblock
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+
+	cmttypes "github.com/cometbft/cometbft/types"
+	"github.com/cosmos/cosmos-sdk/client"
+
+	"union/verifier"
+)
+
+// ErrNotCommitted is returned for a height above the latest height of the
+// node or below its earliest retained one.
+var ErrNotCommitted = errors.New("height not available on the node")
+
+// LightBlock is the light block of a height along with the validator set of
+// the next height.
+type LightBlock struct {
+	*cmttypes.LightBlock
+	NextValidatorSet *cmttypes.ValidatorSet
+	// Legacy tells whether the light block is hashed under the legacy scheme.
+	Legacy bool
+}
+
+// Fetch returns the light block and next validator set of the node at a
+// height, the latest if zero. The validator sets are fetched at the height of
+// the commit and checked against the hashes of its header, so that they
+// can't come from different heights even if the node commits in between.
+// Its hashing scheme is detected within the transition of the chain.
+func Fetch(ctx context.Context, node client.CometRPC, height int64, transition verifier.Transition) (*LightBlock, error) {
+	var pinned *int64
+	if height != 0 {
+		pinned = &height
+	}
+	commit, err := node.Commit(ctx, pinned)
+	if err != nil {
+		return nil, notCommitted(ctx, node, height, err)
+	}
+	height = commit.Height
+	_, vals, err := verifier.FetchValidatorSet(ctx, node, &height)
+	if err != nil {
+		return nil, fmt.Errorf("validator set at height %d: %w", height, err)
+	}
+	nextHeight := height + 1
+	_, nextVals, err := verifier.FetchValidatorSet(ctx, node, &nextHeight)
+	if err != nil {
+		return nil, fmt.Errorf("validator set at height %d: %w", nextHeight, err)
+	}
+
+	lightBlock := &cmttypes.LightBlock{
+		SignedHeader: &commit.SignedHeader,
+		ValidatorSet: vals,
+	}
+	legacy, err := verifier.DetectLegacy(lightBlock, transition)
+	if err != nil {
+		return nil, fmt.Errorf("light block at height %d: %w", height, err)
+	}
+	if err := nextVals.ValidateBasic(); err != nil {
+		return nil, fmt.Errorf("validator set at height %d: %w", nextHeight, err)
+	}
+	nextValsHash := nextVals.Hash()
+	if legacy {
+		nextValsHash = nextVals.HashSha256()
+	}
+	if !bytes.Equal(lightBlock.NextValidatorsHash, nextValsHash) {
+		return nil, fmt.Errorf("expected next validators hash of header at height %d to match validator set hash (%X != %X)", height, lightBlock.NextValidatorsHash, nextValsHash)
+	}
+	return &LightBlock{LightBlock: lightBlock, NextValidatorSet: nextVals, Legacy: legacy}, nil
+}
+
+// notCommitted reports a height the node failed to return a commit for as
+// ErrNotCommitted if it's out of the range of the node.
+func notCommitted(ctx context.Context, node client.CometRPC, height int64, err error) error {
+	if height == 0 {
+		return err
+	}
+	status, statusErr := node.Status(ctx)
+	if statusErr != nil {
+		return err
+	}
+	if height > status.SyncInfo.LatestBlockHeight || height < status.SyncInfo.EarliestBlockHeight {
+		return fmt.Errorf("%w: height %d out of %d-%d", ErrNotCommitted, height, status.SyncInfo.EarliestBlockHeight, status.SyncInfo.LatestBlockHeight)
+	}
+	return err
+}
@@ -0,0 +1,1279 @@
+//lint:file-ignore SA1019 This code is generated
+// Code generated by protoc-gen-gogo. DO NOT EDIT.
+// source: union/lightblock/v1/query.proto
+
+package lightblock
+
+import (
+	context "context"
+	fmt "fmt"
+	types "github.com/cometbft/cometbft/proto/tendermint/types"
+	grpc1 "github.com/cosmos/gogoproto/grpc"
+	proto "github.com/cosmos/gogoproto/proto"
+	_ "google.golang.org/genproto/googleapis/api/annotations"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+	io "io"
+	math "math"
+	math_bits "math/bits"
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ = proto.Marshal
+var _ = fmt.Errorf
+var _ = math.Inf
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the proto package it is being compiled against.
+// A compilation error at this line likely means your copy of the
+// proto package needs to be updated.
+const _ = proto.GoGoProtoPackageIsVersion3 // please upgrade the proto package
+
+// GetLightBlockRequest is the request type for the Service/LightBlock RPC
+// method.
+type GetLightBlockRequest struct {
+	// height of the light block, the latest one if zero.
+	Height int64 `protobuf:"varint,1,opt,name=height,proto3" json:"height,omitempty"`
+}
+
+func (m *GetLightBlockRequest) Reset()         { *m = GetLightBlockRequest{} }
+func (m *GetLightBlockRequest) String() string { return proto.CompactTextString(m) }
+func (*GetLightBlockRequest) ProtoMessage()    {}
+func (*GetLightBlockRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_9738f7aeabb78c89, []int{0}
+}
+func (m *GetLightBlockRequest) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *GetLightBlockRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_GetLightBlockRequest.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *GetLightBlockRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetLightBlockRequest.Merge(m, src)
+}
+func (m *GetLightBlockRequest) XXX_Size() int {
+	return m.Size()
+}
+func (m *GetLightBlockRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetLightBlockRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetLightBlockRequest proto.InternalMessageInfo
+
+func (m *GetLightBlockRequest) GetHeight() int64 {
+	if m != nil {
+		return m.Height
+	}
+	return 0
+}
+
+// GetLightBlockResponse is the response type for the Service/LightBlock RPC
+// method.
+type GetLightBlockResponse struct {
+	LightBlock *types.LightBlock `protobuf:"bytes,1,opt,name=light_block,json=lightBlock,proto3" json:"light_block,omitempty"`
+	// next_validator_set is the validator set at the next height, as committed
+	// to by the next validators hash of the header.
+	NextValidatorSet *types.ValidatorSet `protobuf:"bytes,2,opt,name=next_validator_set,json=nextValidatorSet,proto3" json:"next_validator_set,omitempty"`
+	// legacy tells whether the header and validator sets are hashed under the
+	// legacy (SHA-256) scheme instead of the current (MiMC) one.
+	Legacy bool `protobuf:"varint,3,opt,name=legacy,proto3" json:"legacy,omitempty"`
+}
+
+func (m *GetLightBlockResponse) Reset()         { *m = GetLightBlockResponse{} }
+func (m *GetLightBlockResponse) String() string { return proto.CompactTextString(m) }
+func (*GetLightBlockResponse) ProtoMessage()    {}
+func (*GetLightBlockResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_9738f7aeabb78c89, []int{1}
+}
+func (m *GetLightBlockResponse) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *GetLightBlockResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_GetLightBlockResponse.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *GetLightBlockResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetLightBlockResponse.Merge(m, src)
+}
+func (m *GetLightBlockResponse) XXX_Size() int {
+	return m.Size()
+}
+func (m *GetLightBlockResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetLightBlockResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetLightBlockResponse proto.InternalMessageInfo
+
+func (m *GetLightBlockResponse) GetLightBlock() *types.LightBlock {
+	if m != nil {
+		return m.LightBlock
+	}
+	return nil
+}
+
+func (m *GetLightBlockResponse) GetNextValidatorSet() *types.ValidatorSet {
+	if m != nil {
+		return m.NextValidatorSet
+	}
+	return nil
+}
+
+func (m *GetLightBlockResponse) GetLegacy() bool {
+	if m != nil {
+		return m.Legacy
+	}
+	return false
+}
+
+// SubscribeHeadersRequest is the request type for the Service/SubscribeHeaders
+// RPC method.
+type SubscribeHeadersRequest struct {
+	// from_height is the first height streamed, the latest one if zero.
+	FromHeight int64 `protobuf:"varint,1,opt,name=from_height,json=fromHeight,proto3" json:"from_height,omitempty"`
+	// validator_set_diffs requests the changes of the validator set along with
+	// every header.
+	ValidatorSetDiffs bool `protobuf:"varint,2,opt,name=validator_set_diffs,json=validatorSetDiffs,proto3" json:"validator_set_diffs,omitempty"`
+}
+
+func (m *SubscribeHeadersRequest) Reset()         { *m = SubscribeHeadersRequest{} }
+func (m *SubscribeHeadersRequest) String() string { return proto.CompactTextString(m) }
+func (*SubscribeHeadersRequest) ProtoMessage()    {}
+func (*SubscribeHeadersRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_9738f7aeabb78c89, []int{2}
+}
+func (m *SubscribeHeadersRequest) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *SubscribeHeadersRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_SubscribeHeadersRequest.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *SubscribeHeadersRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SubscribeHeadersRequest.Merge(m, src)
+}
+func (m *SubscribeHeadersRequest) XXX_Size() int {
+	return m.Size()
+}
+func (m *SubscribeHeadersRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_SubscribeHeadersRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SubscribeHeadersRequest proto.InternalMessageInfo
+
+func (m *SubscribeHeadersRequest) GetFromHeight() int64 {
+	if m != nil {
+		return m.FromHeight
+	}
+	return 0
+}
+
+func (m *SubscribeHeadersRequest) GetValidatorSetDiffs() bool {
+	if m != nil {
+		return m.ValidatorSetDiffs
+	}
+	return false
+}
+
+// SubscribeHeadersResponse is the response type for the
+// Service/SubscribeHeaders RPC method.
+type SubscribeHeadersResponse struct {
+	SignedHeader *types.SignedHeader `protobuf:"bytes,1,opt,name=signed_header,json=signedHeader,proto3" json:"signed_header,omitempty"`
+	// validator_set is the validator set of the first header streamed with the
+	// validator set diffs, which the following diffs apply to.
+	ValidatorSet *types.ValidatorSet `protobuf:"bytes,2,opt,name=validator_set,json=validatorSet,proto3" json:"validator_set,omitempty"`
+	// validator_updates are the changes of the validator set from the previous
+	// header streamed with the validator set diffs, a removed validator having
+	// no voting power, as applied by ValidatorSet.UpdateWithChangeSet.
+	ValidatorUpdates []*types.Validator `protobuf:"bytes,3,rep,name=validator_updates,json=validatorUpdates,proto3" json:"validator_updates,omitempty"`
+}
+
+func (m *SubscribeHeadersResponse) Reset()         { *m = SubscribeHeadersResponse{} }
+func (m *SubscribeHeadersResponse) String() string { return proto.CompactTextString(m) }
+func (*SubscribeHeadersResponse) ProtoMessage()    {}
+func (*SubscribeHeadersResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_9738f7aeabb78c89, []int{3}
+}
+func (m *SubscribeHeadersResponse) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *SubscribeHeadersResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_SubscribeHeadersResponse.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *SubscribeHeadersResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SubscribeHeadersResponse.Merge(m, src)
+}
+func (m *SubscribeHeadersResponse) XXX_Size() int {
+	return m.Size()
+}
+func (m *SubscribeHeadersResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_SubscribeHeadersResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SubscribeHeadersResponse proto.InternalMessageInfo
+
+func (m *SubscribeHeadersResponse) GetSignedHeader() *types.SignedHeader {
+	if m != nil {
+		return m.SignedHeader
+	}
+	return nil
+}
+
+func (m *SubscribeHeadersResponse) GetValidatorSet() *types.ValidatorSet {
+	if m != nil {
+		return m.ValidatorSet
+	}
+	return nil
+}
+
+func (m *SubscribeHeadersResponse) GetValidatorUpdates() []*types.Validator {
+	if m != nil {
+		return m.ValidatorUpdates
+	}
+	return nil
+}
+
+func init() {
+	proto.RegisterType((*GetLightBlockRequest)(nil), "union.lightblock.v1.GetLightBlockRequest")
+	proto.RegisterType((*GetLightBlockResponse)(nil), "union.lightblock.v1.GetLightBlockResponse")
+	proto.RegisterType((*SubscribeHeadersRequest)(nil), "union.lightblock.v1.SubscribeHeadersRequest")
+	proto.RegisterType((*SubscribeHeadersResponse)(nil), "union.lightblock.v1.SubscribeHeadersResponse")
+}
+
+func init() { proto.RegisterFile("union/lightblock/v1/query.proto", fileDescriptor_9738f7aeabb78c89) }
+
+var fileDescriptor_9738f7aeabb78c89 = []byte{
+	// 500 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x9c, 0x93, 0x41, 0x6f, 0xd3, 0x30,
+	0x14, 0xc7, 0xeb, 0x56, 0x1a, 0x93, 0x3b, 0xa4, 0xe2, 0x0d, 0x88, 0x4a, 0x95, 0x55, 0x39, 0x95,
+	0x69, 0x38, 0xac, 0x9c, 0xb9, 0x0c, 0x24, 0x7a, 0xd8, 0x29, 0x15, 0x1c, 0xb8, 0x44, 0x69, 0xf3,
+	0x9a, 0x1a, 0x32, 0x3b, 0x8d, 0x9d, 0x88, 0x0a, 0x71, 0xe1, 0x13, 0x20, 0x21, 0xc1, 0x97, 0xe1,
+	0x03, 0x70, 0x9c, 0xc4, 0x85, 0x23, 0x6a, 0x39, 0xf3, 0x19, 0x50, 0xec, 0xae, 0xcd, 0xd6, 0x0e,
+	0x55, 0x5c, 0xa2, 0xf8, 0xbd, 0xff, 0xff, 0xbd, 0x9f, 0xed, 0x67, 0x7c, 0x98, 0x71, 0x26, 0xb8,
+	0x1b, 0xb3, 0x68, 0xac, 0x06, 0xb1, 0x18, 0xbe, 0x75, 0xf3, 0x13, 0x77, 0x92, 0x41, 0x3a, 0xa5,
+	0x49, 0x2a, 0x94, 0x20, 0xfb, 0x5a, 0x40, 0x57, 0x02, 0x9a, 0x9f, 0x34, 0x5b, 0x91, 0x10, 0x51,
+	0x0c, 0x6e, 0x90, 0x30, 0x37, 0xe0, 0x5c, 0xa8, 0x40, 0x31, 0xc1, 0xa5, 0xb1, 0x34, 0x5b, 0x0a,
+	0x78, 0x08, 0xe9, 0x39, 0xe3, 0xca, 0x55, 0xd3, 0x04, 0xa4, 0xf9, 0x2e, 0xb2, 0xed, 0xb5, 0x6c,
+	0x1e, 0xc4, 0x2c, 0x0c, 0x94, 0x48, 0x8d, 0xc2, 0xa1, 0xf8, 0xe0, 0x05, 0xa8, 0xb3, 0xa2, 0xe3,
+	0x69, 0xd1, 0xd1, 0x83, 0x49, 0x06, 0x52, 0x91, 0x7b, 0x78, 0x67, 0x0c, 0x45, 0xd4, 0x42, 0x6d,
+	0xd4, 0xa9, 0x79, 0x8b, 0x95, 0xf3, 0x0d, 0xe1, 0xbb, 0xd7, 0x0c, 0x32, 0x11, 0x5c, 0x02, 0x79,
+	0x8a, 0xeb, 0x1a, 0xdc, 0xd7, 0xe4, 0xda, 0x56, 0xef, 0xb6, 0xe8, 0x8a, 0x80, 0x1a, 0xb2, 0x92,
+	0x15, 0xc7, 0xcb, 0x7f, 0x72, 0x86, 0x09, 0x87, 0x77, 0xca, 0x5f, 0x02, 0xfa, 0x12, 0x94, 0x55,
+	0xd5, 0x55, 0xec, 0xf5, 0x2a, 0xaf, 0x2e, 0x65, 0x7d, 0x50, 0x5e, 0xa3, 0x70, 0x96, 0x23, 0x05,
+	0x7e, 0x0c, 0x51, 0x30, 0x9c, 0x5a, 0xb5, 0x36, 0xea, 0xec, 0x7a, 0x8b, 0x95, 0xf3, 0x06, 0xdf,
+	0xef, 0x67, 0x03, 0x39, 0x4c, 0xd9, 0x00, 0x7a, 0x10, 0x84, 0x90, 0xca, 0xcb, 0x1d, 0x1f, 0xe2,
+	0xfa, 0x28, 0x15, 0xe7, 0xfe, 0x95, 0x6d, 0xe3, 0x22, 0xd4, 0xd3, 0x11, 0x42, 0xf1, 0xfe, 0x15,
+	0x38, 0x3f, 0x64, 0xa3, 0x91, 0xd4, 0x88, 0xbb, 0xde, 0x9d, 0xbc, 0xd4, 0xfe, 0x79, 0x91, 0x70,
+	0xfe, 0x20, 0x6c, 0xad, 0x37, 0x5b, 0x9c, 0xd6, 0x33, 0x7c, 0x5b, 0xb2, 0x88, 0x43, 0xe8, 0x8f,
+	0x75, 0xc6, 0x42, 0x37, 0xed, 0xb4, 0xaf, 0x65, 0xc6, 0xef, 0xed, 0xc9, 0xd2, 0xaa, 0x28, 0xf2,
+	0x3f, 0xc7, 0xb5, 0x57, 0x66, 0x25, 0x3d, 0xbc, 0x62, 0xf7, 0xb3, 0x24, 0x0c, 0x14, 0x48, 0xab,
+	0xd6, 0xae, 0x75, 0xea, 0xdd, 0x07, 0xff, 0x28, 0xe4, 0x35, 0x96, 0xae, 0x97, 0xc6, 0xd4, 0xfd,
+	0x52, 0xc5, 0xb7, 0xfa, 0x90, 0xe6, 0x6c, 0x08, 0xe4, 0x2b, 0xc2, 0x78, 0x75, 0xd3, 0xe4, 0x21,
+	0xdd, 0x30, 0xda, 0x74, 0xd3, 0xe4, 0x35, 0x8f, 0xb6, 0x91, 0x9a, 0x53, 0x74, 0xba, 0x1f, 0x7f,
+	0xfc, 0xfe, 0x5c, 0x3d, 0x26, 0x47, 0xee, 0xa6, 0xa7, 0x55, 0x1a, 0x47, 0xe9, 0xbe, 0x37, 0xf7,
+	0xfa, 0x81, 0x4c, 0x70, 0xe3, 0xfa, 0xad, 0x90, 0xe3, 0x8d, 0x3d, 0x6f, 0x98, 0x94, 0xe6, 0xa3,
+	0x2d, 0xd5, 0x06, 0xf2, 0x31, 0x3a, 0xa5, 0xdf, 0x67, 0x36, 0xba, 0x98, 0xd9, 0xe8, 0xd7, 0xcc,
+	0x46, 0x9f, 0xe6, 0x76, 0xe5, 0x62, 0x6e, 0x57, 0x7e, 0xce, 0xed, 0xca, 0xeb, 0x03, 0xc3, 0x1d,
+	0x24, 0x49, 0x89, 0x7d, 0xb0, 0xa3, 0xdf, 0xe6, 0x93, 0xbf, 0x03, 0x00, 0x36, 0x98, 0x41, 0x9d,
+	0x31, 0x04, 0x00, 0x00,
+}
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ context.Context
+var _ grpc.ClientConn
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+const _ = grpc.SupportPackageIsVersion4
+
+// ServiceClient is the client API for Service service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
+type ServiceClient interface {
+	// LightBlock returns the signed header, validator set and next validator
+	// set at the given height, checked against the hashes of the header.
+	LightBlock(ctx context.Context, in *GetLightBlockRequest, opts ...grpc.CallOption) (*GetLightBlockResponse, error)
+	// SubscribeHeaders streams every signed header committed by the node from
+	// the given height on, optionally along with the changes of the validator
+	// set of every header.
+	SubscribeHeaders(ctx context.Context, in *SubscribeHeadersRequest, opts ...grpc.CallOption) (Service_SubscribeHeadersClient, error)
+}
+
+type serviceClient struct {
+	cc grpc1.ClientConn
+}
+
+func NewServiceClient(cc grpc1.ClientConn) ServiceClient {
+	return &serviceClient{cc}
+}
+
+func (c *serviceClient) LightBlock(ctx context.Context, in *GetLightBlockRequest, opts ...grpc.CallOption) (*GetLightBlockResponse, error) {
+	out := new(GetLightBlockResponse)
+	err := c.cc.Invoke(ctx, "/union.lightblock.v1.Service/LightBlock", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *serviceClient) SubscribeHeaders(ctx context.Context, in *SubscribeHeadersRequest, opts ...grpc.CallOption) (Service_SubscribeHeadersClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_Service_serviceDesc.Streams[0], "/union.lightblock.v1.Service/SubscribeHeaders", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &serviceSubscribeHeadersClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type Service_SubscribeHeadersClient interface {
+	Recv() (*SubscribeHeadersResponse, error)
+	grpc.ClientStream
+}
+
+type serviceSubscribeHeadersClient struct {
+	grpc.ClientStream
+}
+
+func (x *serviceSubscribeHeadersClient) Recv() (*SubscribeHeadersResponse, error) {
+	m := new(SubscribeHeadersResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// ServiceServer is the server API for Service service.
+type ServiceServer interface {
+	// LightBlock returns the signed header, validator set and next validator
+	// set at the given height, checked against the hashes of the header.
+	LightBlock(context.Context, *GetLightBlockRequest) (*GetLightBlockResponse, error)
+	// SubscribeHeaders streams every signed header committed by the node from
+	// the given height on, optionally along with the changes of the validator
+	// set of every header.
+	SubscribeHeaders(*SubscribeHeadersRequest, Service_SubscribeHeadersServer) error
+}
+
+// UnimplementedServiceServer can be embedded to have forward compatible implementations.
+type UnimplementedServiceServer struct {
+}
+
+func (*UnimplementedServiceServer) LightBlock(ctx context.Context, req *GetLightBlockRequest) (*GetLightBlockResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method LightBlock not implemented")
+}
+func (*UnimplementedServiceServer) SubscribeHeaders(req *SubscribeHeadersRequest, srv Service_SubscribeHeadersServer) error {
+	return status.Errorf(codes.Unimplemented, "method SubscribeHeaders not implemented")
+}
+
+func RegisterServiceServer(s grpc1.Server, srv ServiceServer) {
+	s.RegisterService(&_Service_serviceDesc, srv)
+}
+
+func _Service_LightBlock_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetLightBlockRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ServiceServer).LightBlock(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/union.lightblock.v1.Service/LightBlock",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ServiceServer).LightBlock(ctx, req.(*GetLightBlockRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Service_SubscribeHeaders_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(SubscribeHeadersRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(ServiceServer).SubscribeHeaders(m, &serviceSubscribeHeadersServer{stream})
+}
+
+type Service_SubscribeHeadersServer interface {
+	Send(*SubscribeHeadersResponse) error
+	grpc.ServerStream
+}
+
+type serviceSubscribeHeadersServer struct {
+	grpc.ServerStream
+}
+
+func (x *serviceSubscribeHeadersServer) Send(m *SubscribeHeadersResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+var _Service_serviceDesc = grpc.ServiceDesc{
+	ServiceName: "union.lightblock.v1.Service",
+	HandlerType: (*ServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "LightBlock",
+			Handler:    _Service_LightBlock_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "SubscribeHeaders",
+			Handler:       _Service_SubscribeHeaders_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "union/lightblock/v1/query.proto",
+}
+
+func (m *GetLightBlockRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *GetLightBlockRequest) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *GetLightBlockRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.Height != 0 {
+		i = encodeVarintQuery(dAtA, i, uint64(m.Height))
+		i--
+		dAtA[i] = 0x8
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *GetLightBlockResponse) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *GetLightBlockResponse) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *GetLightBlockResponse) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.Legacy {
+		i--
+		if m.Legacy {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i--
+		dAtA[i] = 0x18
+	}
+	if m.NextValidatorSet != nil {
+		{
+			size, err := m.NextValidatorSet.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintQuery(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x12
+	}
+	if m.LightBlock != nil {
+		{
+			size, err := m.LightBlock.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintQuery(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *SubscribeHeadersRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *SubscribeHeadersRequest) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *SubscribeHeadersRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.ValidatorSetDiffs {
+		i--
+		if m.ValidatorSetDiffs {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i--
+		dAtA[i] = 0x10
+	}
+	if m.FromHeight != 0 {
+		i = encodeVarintQuery(dAtA, i, uint64(m.FromHeight))
+		i--
+		dAtA[i] = 0x8
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *SubscribeHeadersResponse) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *SubscribeHeadersResponse) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *SubscribeHeadersResponse) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.ValidatorUpdates) > 0 {
+		for iNdEx := len(m.ValidatorUpdates) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.ValidatorUpdates[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintQuery(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0x1a
+		}
+	}
+	if m.ValidatorSet != nil {
+		{
+			size, err := m.ValidatorSet.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintQuery(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x12
+	}
+	if m.SignedHeader != nil {
+		{
+			size, err := m.SignedHeader.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintQuery(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func encodeVarintQuery(dAtA []byte, offset int, v uint64) int {
+	offset -= sovQuery(v)
+	base := offset
+	for v >= 1<<7 {
+		dAtA[offset] = uint8(v&0x7f | 0x80)
+		v >>= 7
+		offset++
+	}
+	dAtA[offset] = uint8(v)
+	return base
+}
+func (m *GetLightBlockRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Height != 0 {
+		n += 1 + sovQuery(uint64(m.Height))
+	}
+	return n
+}
+
+func (m *GetLightBlockResponse) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.LightBlock != nil {
+		l = m.LightBlock.Size()
+		n += 1 + l + sovQuery(uint64(l))
+	}
+	if m.NextValidatorSet != nil {
+		l = m.NextValidatorSet.Size()
+		n += 1 + l + sovQuery(uint64(l))
+	}
+	if m.Legacy {
+		n += 2
+	}
+	return n
+}
+
+func (m *SubscribeHeadersRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.FromHeight != 0 {
+		n += 1 + sovQuery(uint64(m.FromHeight))
+	}
+	if m.ValidatorSetDiffs {
+		n += 2
+	}
+	return n
+}
+
+func (m *SubscribeHeadersResponse) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.SignedHeader != nil {
+		l = m.SignedHeader.Size()
+		n += 1 + l + sovQuery(uint64(l))
+	}
+	if m.ValidatorSet != nil {
+		l = m.ValidatorSet.Size()
+		n += 1 + l + sovQuery(uint64(l))
+	}
+	if len(m.ValidatorUpdates) > 0 {
+		for _, e := range m.ValidatorUpdates {
+			l = e.Size()
+			n += 1 + l + sovQuery(uint64(l))
+		}
+	}
+	return n
+}
+
+func sovQuery(x uint64) (n int) {
+	return (math_bits.Len64(x|1) + 6) / 7
+}
+func sozQuery(x uint64) (n int) {
+	return sovQuery(uint64((x << 1) ^ uint64((int64(x) >> 63))))
+}
+func (m *GetLightBlockRequest) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowQuery
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: GetLightBlockRequest: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: GetLightBlockRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Height", wireType)
+			}
+			m.Height = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowQuery
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Height |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		default:
+			iNdEx = preIndex
+			skippy, err := skipQuery(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthQuery
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *GetLightBlockResponse) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowQuery
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: GetLightBlockResponse: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: GetLightBlockResponse: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field LightBlock", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowQuery
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthQuery
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthQuery
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.LightBlock == nil {
+				m.LightBlock = &types.LightBlock{}
+			}
+			if err := m.LightBlock.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field NextValidatorSet", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowQuery
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthQuery
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthQuery
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.NextValidatorSet == nil {
+				m.NextValidatorSet = &types.ValidatorSet{}
+			}
+			if err := m.NextValidatorSet.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Legacy", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowQuery
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.Legacy = bool(v != 0)
+		default:
+			iNdEx = preIndex
+			skippy, err := skipQuery(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthQuery
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *SubscribeHeadersRequest) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowQuery
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: SubscribeHeadersRequest: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: SubscribeHeadersRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field FromHeight", wireType)
+			}
+			m.FromHeight = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowQuery
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.FromHeight |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ValidatorSetDiffs", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowQuery
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.ValidatorSetDiffs = bool(v != 0)
+		default:
+			iNdEx = preIndex
+			skippy, err := skipQuery(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthQuery
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *SubscribeHeadersResponse) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowQuery
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: SubscribeHeadersResponse: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: SubscribeHeadersResponse: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field SignedHeader", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowQuery
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthQuery
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthQuery
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.SignedHeader == nil {
+				m.SignedHeader = &types.SignedHeader{}
+			}
+			if err := m.SignedHeader.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ValidatorSet", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowQuery
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthQuery
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthQuery
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.ValidatorSet == nil {
+				m.ValidatorSet = &types.ValidatorSet{}
+			}
+			if err := m.ValidatorSet.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ValidatorUpdates", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowQuery
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthQuery
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthQuery
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.ValidatorUpdates = append(m.ValidatorUpdates, &types.Validator{})
+			if err := m.ValidatorUpdates[len(m.ValidatorUpdates)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipQuery(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthQuery
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func skipQuery(dAtA []byte) (n int, err error) {
+	l := len(dAtA)
+	iNdEx := 0
+	depth := 0
+	for iNdEx < l {
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return 0, ErrIntOverflowQuery
+			}
+			if iNdEx >= l {
+				return 0, io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		wireType := int(wire & 0x7)
+		switch wireType {
+		case 0:
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return 0, ErrIntOverflowQuery
+				}
+				if iNdEx >= l {
+					return 0, io.ErrUnexpectedEOF
+				}
+				iNdEx++
+				if dAtA[iNdEx-1] < 0x80 {
+					break
+				}
+			}
+		case 1:
+			iNdEx += 8
+		case 2:
+			var length int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return 0, ErrIntOverflowQuery
+				}
+				if iNdEx >= l {
+					return 0, io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				length |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if length < 0 {
+				return 0, ErrInvalidLengthQuery
+			}
+			iNdEx += length
+		case 3:
+			depth++
+		case 4:
+			if depth == 0 {
+				return 0, ErrUnexpectedEndOfGroupQuery
+			}
+			depth--
+		case 5:
+			iNdEx += 4
+		default:
+			return 0, fmt.Errorf("proto: illegal wireType %d", wireType)
+		}
+		if iNdEx < 0 {
+			return 0, ErrInvalidLengthQuery
+		}
+		if depth == 0 {
+			return iNdEx, nil
+		}
+	}
+	return 0, io.ErrUnexpectedEOF
+}
+
+var (
+	ErrInvalidLengthQuery        = fmt.Errorf("proto: negative length found during unmarshaling")
+	ErrIntOverflowQuery          = fmt.Errorf("proto: integer overflow")
+	ErrUnexpectedEndOfGroupQuery = fmt.Errorf("proto: unexpected end of group")
+)
@@ -0,0 +1,94 @@
+package lightblock
+
+import (
+	"context"
+	"errors"
+
+	"github.com/cosmos/cosmos-sdk/client"
+	gogogrpc "github.com/cosmos/gogoproto/grpc"
+	"github.com/grpc-ecosystem/grpc-gateway/runtime"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"union/verifier"
+)
+
+var _ ServiceServer = queryServer{}
+
+type queryServer struct {
+	node client.CometRPC
+}
+
+// LightBlock implements ServiceServer.LightBlock
+func (s queryServer) LightBlock(ctx context.Context, req *GetLightBlockRequest) (*GetLightBlockResponse, error) {
+	if s.node == nil {
+		return nil, status.Error(codes.Unavailable, "the node is not available")
+	}
+	if req.Height < 0 {
+		return nil, status.Errorf(codes.InvalidArgument, "height can't be negative, got %d", req.Height)
+	}
+	// The node only serves the headers committed by its own chain, in
+	// whichever scheme they were committed.
+	lightBlock, err := Fetch(ctx, s.node, req.Height, verifier.AnyHeight)
+	if errors.Is(err, ErrNotCommitted) {
+		return nil, status.Error(codes.NotFound, err.Error())
+	}
+	if err != nil {
+		return nil, status.Errorf(codes.Unavailable, "height %d: %s", req.Height, err)
+	}
+	lb, err := lightBlock.ToProto()
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	nextVals, err := lightBlock.NextValidatorSet.ToProto()
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	return &GetLightBlockResponse{
+		LightBlock:       lb,
+		NextValidatorSet: nextVals,
+		Legacy:           lightBlock.Legacy,
+	}, nil
+}
+
+// SubscribeHeaders implements ServiceServer.SubscribeHeaders
+func (s queryServer) SubscribeHeaders(req *SubscribeHeadersRequest, stream Service_SubscribeHeadersServer) error {
+	if s.node == nil {
+		return status.Error(codes.Unavailable, "the node is not available")
+	}
+	if req.FromHeight < 0 {
+		return status.Errorf(codes.InvalidArgument, "from height can't be negative, got %d", req.FromHeight)
+	}
+	ctx := stream.Context()
+	height := req.FromHeight
+	if height == 0 {
+		nodeStatus, err := s.node.Status(ctx)
+		if err != nil {
+			return status.Error(codes.Unavailable, err.Error())
+		}
+		height = nodeStatus.SyncInfo.LatestBlockHeight
+	}
+	sub := &subscription{node: s.node, diffs: req.ValidatorSetDiffs}
+	err := sub.run(ctx, height, stream.Send)
+	switch {
+	case ctx.Err() != nil:
+		return status.FromContextError(ctx.Err()).Err()
+	case errors.Is(err, ErrNotCommitted):
+		return status.Error(codes.NotFound, err.Error())
+	case err != nil:
+		return status.Error(codes.Unavailable, err.Error())
+	}
+	return nil
+}
+
+// RegisterLightBlockService registers the light block service on the gRPC
+// router, serving the light blocks of the node.
+func RegisterLightBlockService(server gogogrpc.Server, node client.CometRPC) {
+	RegisterServiceServer(server, queryServer{node: node})
+}
+
+// RegisterGRPCGatewayRoutes mounts the light block service's GRPC-gateway
+// routes on the given Mux.
+func RegisterGRPCGatewayRoutes(clientConn gogogrpc.ClientConn, mux *runtime.ServeMux) {
+	_ = RegisterServiceHandlerClient(context.Background(), mux, NewServiceClient(clientConn))
+}
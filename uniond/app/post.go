@@ -0,0 +1,48 @@
+package app
+
+import (
+	"strconv"
+
+	"github.com/cosmos/cosmos-sdk/telemetry"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	clienttypes "github.com/cosmos/ibc-go/v8/modules/core/02-client/types"
+	"github.com/hashicorp/go-metrics"
+)
+
+func NewPostHandler() sdk.PostHandler {
+	return sdk.ChainPostDecorators(
+		ClientUpdateMetricsDecorator{},
+	)
+}
+
+// ClientUpdateMetricsDecorator counts the light client updates delivered to
+// the clients hosted on Union, per client and outcome. Contrary to the
+// counter of ibc-go, failed updates are included, which is what a relayer
+// operator wants to be alerted on.
+type ClientUpdateMetricsDecorator struct{}
+
+func (ClientUpdateMetricsDecorator) PostHandle(ctx sdk.Context, tx sdk.Tx, simulate, success bool, next sdk.PostHandler) (sdk.Context, error) {
+	if simulate || ctx.IsCheckTx() {
+		return next(ctx, tx, simulate, success)
+	}
+	for _, msg := range tx.GetMsgs() {
+		update, ok := msg.(*clienttypes.MsgUpdateClient)
+		if !ok {
+			continue
+		}
+		clientType, _, err := clienttypes.ParseClientIdentifier(update.ClientId)
+		if err != nil {
+			continue
+		}
+		telemetry.IncrCounterWithLabels(
+			[]string{"union", "client", "updates"},
+			1,
+			[]metrics.Label{
+				telemetry.NewLabel("client_id", update.ClientId),
+				telemetry.NewLabel("client_type", clientType),
+				telemetry.NewLabel("success", strconv.FormatBool(success)),
+			},
+		)
+	}
+	return next(ctx, tx, simulate, success)
+}
@@ -0,0 +1,108 @@
+package app
+
+import (
+	abci "github.com/cometbft/cometbft/abci/types"
+	"github.com/cosmos/cosmos-sdk/baseapp"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	authsigning "github.com/cosmos/cosmos-sdk/x/auth/signing"
+	clienttypes "github.com/cosmos/ibc-go/v8/modules/core/02-client/types"
+	channeltypes "github.com/cosmos/ibc-go/v8/modules/core/04-channel/types"
+)
+
+type proposalTx struct {
+	tx sdk.Tx
+	bz []byte
+}
+
+// NewPrepareProposalHandler builds a proposal out of the transactions
+// provided by CometBFT, in two lanes. Transactions exclusively made of light
+// client updates and packet relaying messages are included first, everything
+// else comes after them in mempool order. This guarantees that the IBC
+// clients hosted on Union keep being updated and that packets don't time out
+// while blocks are filled with ordinary transactions.
+func NewPrepareProposalHandler(txDecoder sdk.TxDecoder) sdk.PrepareProposalHandler {
+	return func(ctx sdk.Context, req *abci.RequestPrepareProposal) (*abci.ResponsePrepareProposal, error) {
+		var maxBlockGas uint64
+		if block := ctx.ConsensusParams().Block; block != nil && block.MaxGas > 0 {
+			maxBlockGas = uint64(block.MaxGas)
+		}
+
+		var (
+			ibcLane     []proposalTx
+			defaultLane []proposalTx
+			// Signers having a transaction in the default lane, their
+			// subsequent transactions can't be moved ahead without breaking
+			// the sequence ordering.
+			defaultSigners = make(map[string]struct{})
+		)
+		for _, bz := range req.Txs {
+			tx, err := txDecoder(bz)
+			if err != nil {
+				// Not includable anyway, don't waste block space on it.
+				continue
+			}
+			signers, err := txSigners(tx)
+			if err != nil {
+				continue
+			}
+			if isIBCPriorityTx(tx) && !anySigner(defaultSigners, signers) {
+				ibcLane = append(ibcLane, proposalTx{tx: tx, bz: bz})
+				continue
+			}
+			for _, signer := range signers {
+				defaultSigners[string(signer)] = struct{}{}
+			}
+			defaultLane = append(defaultLane, proposalTx{tx: tx, bz: bz})
+		}
+
+		selector := baseapp.NewDefaultTxSelector()
+	lanes:
+		for _, lane := range [][]proposalTx{ibcLane, defaultLane} {
+			for _, ptx := range lane {
+				if selector.SelectTxForProposal(ctx, uint64(req.MaxTxBytes), maxBlockGas, ptx.tx, ptx.bz) {
+					break lanes
+				}
+			}
+		}
+		return &abci.ResponsePrepareProposal{Txs: selector.SelectedTxs(ctx)}, nil
+	}
+}
+
+// isIBCPriorityTx returns whether every message of the transaction either
+// updates a light client or relays a packet.
+func isIBCPriorityTx(tx sdk.Tx) bool {
+	msgs := tx.GetMsgs()
+	if len(msgs) == 0 {
+		return false
+	}
+	for _, msg := range msgs {
+		switch msg.(type) {
+		case *clienttypes.MsgUpdateClient,
+			*clienttypes.MsgSubmitMisbehaviour,
+			*channeltypes.MsgRecvPacket,
+			*channeltypes.MsgAcknowledgement,
+			*channeltypes.MsgTimeout,
+			*channeltypes.MsgTimeoutOnClose:
+		default:
+			return false
+		}
+	}
+	return true
+}
+
+func txSigners(tx sdk.Tx) ([][]byte, error) {
+	sigTx, ok := tx.(authsigning.SigVerifiableTx)
+	if !ok {
+		return nil, nil
+	}
+	return sigTx.GetSigners()
+}
+
+func anySigner(signers map[string]struct{}, candidates [][]byte) bool {
+	for _, candidate := range candidates {
+		if _, ok := signers[string(candidate)]; ok {
+			return true
+		}
+	}
+	return false
+}
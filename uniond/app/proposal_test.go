@@ -0,0 +1,83 @@
+package app_test
+
+import (
+	"testing"
+
+	storetypes "cosmossdk.io/store/types"
+	abci "github.com/cometbft/cometbft/abci/types"
+	"github.com/cosmos/cosmos-sdk/testutil"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	banktypes "github.com/cosmos/cosmos-sdk/x/bank/types"
+	clienttypes "github.com/cosmos/ibc-go/v8/modules/core/02-client/types"
+	channeltypes "github.com/cosmos/ibc-go/v8/modules/core/04-channel/types"
+	"github.com/stretchr/testify/require"
+
+	"union/app"
+	"union/app/params"
+)
+
+func TestPrepareProposalLanes(t *testing.T) {
+	encoding := params.MakeEncodingConfig()
+	banktypes.RegisterInterfaces(encoding.InterfaceRegistry)
+	clienttypes.RegisterInterfaces(encoding.InterfaceRegistry)
+	channeltypes.RegisterInterfaces(encoding.InterfaceRegistry)
+
+	alice := sdk.AccAddress("alice_______________").String()
+	bob := sdk.AccAddress("bob_________________").String()
+	carol := sdk.AccAddress("carol_______________").String()
+	send := func(from string) sdk.Msg {
+		return &banktypes.MsgSend{FromAddress: from, ToAddress: from, Amount: sdk.NewCoins(sdk.NewInt64Coin("muno", 1))}
+	}
+	update := func(signer string) sdk.Msg {
+		return &clienttypes.MsgUpdateClient{ClientId: "08-wasm-0", Signer: signer}
+	}
+	recv := func(signer string) sdk.Msg {
+		return &channeltypes.MsgRecvPacket{Signer: signer}
+	}
+	encode := func(msgs ...sdk.Msg) []byte {
+		builder := encoding.TxConfig.NewTxBuilder()
+		require.NoError(t, builder.SetMsgs(msgs...))
+		bz, err := encoding.TxConfig.TxEncoder()(builder.GetTx())
+		require.NoError(t, err)
+		return bz
+	}
+
+	for _, tc := range []struct {
+		name     string
+		txs      [][]byte
+		expected []int
+	}{
+		{
+			name:     "ibc txs moved ahead",
+			txs:      [][]byte{encode(send(alice)), encode(update(bob)), encode(send(carol)), encode(recv(carol))},
+			expected: []int{1, 0, 2, 3},
+		},
+		{
+			name:     "ibc tx of a default lane signer kept behind",
+			txs:      [][]byte{encode(send(alice)), encode(update(alice)), encode(recv(bob))},
+			expected: []int{2, 0, 1},
+		},
+		{
+			name:     "mixed tx stays in the default lane",
+			txs:      [][]byte{encode(send(alice)), encode(update(bob), send(bob)), encode(update(carol))},
+			expected: []int{2, 0, 1},
+		},
+		{
+			name:     "undecodable txs dropped",
+			txs:      [][]byte{encode(send(alice)), []byte("garbage"), encode(update(bob))},
+			expected: []int{2, 0},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			ctx := testutil.DefaultContext(storetypes.NewKVStoreKey("test"), storetypes.NewTransientStoreKey("transient"))
+			handler := app.NewPrepareProposalHandler(encoding.TxConfig.TxDecoder())
+			res, err := handler(ctx, &abci.RequestPrepareProposal{Txs: tc.txs, MaxTxBytes: 1 << 20})
+			require.NoError(t, err)
+			expected := make([][]byte, len(tc.expected))
+			for i, j := range tc.expected {
+				expected[i] = tc.txs[j]
+			}
+			require.Equal(t, expected, res.Txs)
+		})
+	}
+}
@@ -6,11 +6,12 @@ import (
 	"union/app/upgrades/v0_22_0"
 	"union/app/upgrades/v0_23_0"
 	"union/app/upgrades/v0_24_0"
+	"union/app/upgrades/v0_25_0"
 
 	upgradetypes "cosmossdk.io/x/upgrade/types"
 )
 
-var Upgrades = []upgrades.Upgrade{v0_22_0.Upgrade, v0_23_0.Upgrade, v0_24_0.Upgrade}
+var Upgrades = []upgrades.Upgrade{v0_22_0.Upgrade, v0_23_0.Upgrade, v0_24_0.Upgrade, v0_25_0.Upgrade}
 
 // configure store loader that checks if version == upgradeHeight and applies store upgrades
 func (app *UnionApp) setupUpgradeStoreLoaders() {
@@ -40,6 +41,9 @@ func (app *UnionApp) setupUpgradeHandlers() {
 				&upgrades.AppKeepers{
 					StakingKeeper: app.StakingKeeper,
 					TfKeeper:      &app.TfKeeper,
+
+					IBCKeeper:         app.IBCKeeper,
+					LightClientKeeper: &app.LightClientKeeper,
 				},
 			),
 		)
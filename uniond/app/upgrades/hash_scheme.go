@@ -0,0 +1,109 @@
+package upgrades
+
+import (
+	"context"
+	"fmt"
+
+	lctypes "union/x/lightclient/types"
+
+	upgradetypes "cosmossdk.io/x/upgrade/types"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/types/module"
+	clienttypes "github.com/cosmos/ibc-go/v8/modules/core/02-client/types"
+	"github.com/cosmos/ibc-go/v8/modules/core/exported"
+)
+
+// HashSchemeTransition describes a change of the header hashing scheme shipped
+// with a software upgrade, e.g. from legacy to CometBLS headers.
+type HashSchemeTransition struct {
+	PreviousScheme string
+	Scheme         string
+
+	// MigrateConsensusState is optional. When set, it is called with every
+	// consensus state stored by the IBC clients hosted on the chain and
+	// returns the consensus state to store in place of the given one, or nil
+	// to leave it untouched.
+	MigrateConsensusState func(ctx sdk.Context, clientID string, height exported.Height, consensusState exported.ConsensusState) (exported.ConsensusState, error)
+}
+
+// CreateHashSchemeTransitionHandler returns an upgrade handler creator that
+// runs the module migrations, then records the transition in x/lightclient and
+// migrates the hosted consensus states.
+//
+// The header of the upgrade height is still produced by the previous binary,
+// the transition therefore takes effect at the next height. Both schemes are
+// added to the allowed hash schemes so that verifiers can run in dual-hash
+// mode, governance is expected to remove the previous scheme once no verifier
+// needs to go through the transition anymore.
+func CreateHashSchemeTransitionHandler(transition HashSchemeTransition) func(*module.Manager, module.Configurator, *AppKeepers) upgradetypes.UpgradeHandler {
+	return func(mm *module.Manager, configurator module.Configurator, keepers *AppKeepers) upgradetypes.UpgradeHandler {
+		return func(ctx context.Context, plan upgradetypes.Plan, vm module.VersionMap) (module.VersionMap, error) {
+			vm, err := mm.RunMigrations(ctx, configurator, vm)
+			if err != nil {
+				return nil, err
+			}
+
+			sdkCtx := sdk.UnwrapSDKContext(ctx)
+
+			err = keepers.LightClientKeeper.SetHashSchemeTransition(
+				sdkCtx,
+				lctypes.NewHashSchemeTransition(plan.Height+1, transition.PreviousScheme, transition.Scheme),
+			)
+			if err != nil {
+				return nil, err
+			}
+
+			params := keepers.LightClientKeeper.GetParams(sdkCtx)
+			for _, scheme := range []string{transition.PreviousScheme, transition.Scheme} {
+				if !params.IsHashSchemeAllowed(scheme) {
+					params.AllowedHashSchemes = append(params.AllowedHashSchemes, scheme)
+				}
+			}
+			if err := keepers.LightClientKeeper.SetParams(sdkCtx, params); err != nil {
+				return nil, err
+			}
+
+			if transition.MigrateConsensusState != nil {
+				if err := migrateConsensusStates(sdkCtx, keepers, transition.MigrateConsensusState); err != nil {
+					return nil, err
+				}
+			}
+
+			return vm, nil
+		}
+	}
+}
+
+func migrateConsensusStates(
+	ctx sdk.Context,
+	keepers *AppKeepers,
+	migrate func(sdk.Context, string, exported.Height, exported.ConsensusState) (exported.ConsensusState, error),
+) error {
+	clientKeeper := keepers.IBCKeeper.ClientKeeper
+
+	// Collect first, the store can't be written while being iterated.
+	type clientConsensusState struct {
+		clientID       string
+		consensusState clienttypes.ConsensusStateWithHeight
+	}
+	var consensusStates []clientConsensusState
+	clientKeeper.IterateConsensusStates(ctx, func(clientID string, cs clienttypes.ConsensusStateWithHeight) bool {
+		consensusStates = append(consensusStates, clientConsensusState{clientID, cs})
+		return false
+	})
+
+	for _, cs := range consensusStates {
+		consensusState, err := clienttypes.UnpackConsensusState(cs.consensusState.ConsensusState)
+		if err != nil {
+			return err
+		}
+		migrated, err := migrate(ctx, cs.clientID, cs.consensusState.Height, consensusState)
+		if err != nil {
+			return fmt.Errorf("client %s: failed to migrate consensus state at %s: %w", cs.clientID, cs.consensusState.Height, err)
+		}
+		if migrated != nil {
+			clientKeeper.SetClientConsensusState(ctx, cs.clientID, cs.consensusState.Height, migrated)
+		}
+	}
+	return nil
+}
@@ -1,6 +1,7 @@
 package upgrades
 
 import (
+	lckeeper "union/x/lightclient/keeper"
 	tfkeeper "union/x/tokenfactory/keeper"
 
 	store "cosmossdk.io/store/types"
@@ -8,12 +9,16 @@ import (
 	"github.com/cosmos/cosmos-sdk/types/module"
 	consensuskeeper "github.com/cosmos/cosmos-sdk/x/consensus/keeper"
 	stakingkeeper "github.com/cosmos/cosmos-sdk/x/staking/keeper"
+	ibckeeper "github.com/cosmos/ibc-go/v8/modules/core/keeper"
 )
 
 type AppKeepers struct {
 	ConsensusKeeper *consensuskeeper.Keeper
 	StakingKeeper   *stakingkeeper.Keeper
 	TfKeeper        *tfkeeper.Keeper
+
+	IBCKeeper         *ibckeeper.Keeper
+	LightClientKeeper *lckeeper.Keeper
 }
 
 // source: https://github.com/osmosis-labs/osmosis/blob/c783ef52af8617d3ec613d9ce9035386ba8d4a49/app/upgrades/types.go#L24
@@ -0,0 +1,23 @@
+package v0_25_0
+
+import (
+	store "cosmossdk.io/store/types"
+	"union/app/upgrades"
+	fmtypes "union/x/feemarket/types"
+	icapolicytypes "union/x/icapolicy/types"
+	lctypes "union/x/lightclient/types"
+	oracletypes "union/x/oracle/types"
+	rltypes "union/x/ratelimit/types"
+)
+
+const UpgradeName = "v0.25.0"
+
+var Upgrade = upgrades.Upgrade{
+	UpgradeName:          UpgradeName,
+	CreateUpgradeHandler: CreateUpgradeHandler,
+	StoreUpgrades: store.StoreUpgrades{
+		Added:   []string{lctypes.ModuleName, rltypes.ModuleName, fmtypes.ModuleName, oracletypes.ModuleName, icapolicytypes.ModuleName},
+		Renamed: []store.StoreRename{},
+		Deleted: []string{},
+	},
+}
@@ -0,0 +1,15 @@
+package v0_25_0
+
+import (
+	"union/app/upgrades"
+	lctypes "union/x/lightclient/types"
+)
+
+// CreateUpgradeHandler records the transition from legacy to CometBLS headers
+// at the height following the upgrade. Newly added modules are left out of the
+// version map so that RunMigrations initializes them with their default
+// genesis.
+var CreateUpgradeHandler = upgrades.CreateHashSchemeTransitionHandler(upgrades.HashSchemeTransition{
+	PreviousScheme: lctypes.HashSchemeLegacy,
+	Scheme:         lctypes.HashSchemeCometbls,
+})
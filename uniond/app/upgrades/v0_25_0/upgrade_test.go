@@ -0,0 +1,55 @@
+package v0_25_0_test
+
+import (
+	"testing"
+
+	storetypes "cosmossdk.io/store/types"
+	upgradetypes "cosmossdk.io/x/upgrade/types"
+	"github.com/cosmos/cosmos-sdk/baseapp"
+	"github.com/cosmos/cosmos-sdk/testutil"
+	"github.com/cosmos/cosmos-sdk/types/module"
+	moduletestutil "github.com/cosmos/cosmos-sdk/types/module/testutil"
+	"github.com/stretchr/testify/require"
+
+	"union/app/upgrades"
+	"union/app/upgrades/v0_25_0"
+	"union/x/lightclient"
+	lckeeper "union/x/lightclient/keeper"
+	lctypes "union/x/lightclient/types"
+)
+
+func TestUpgradeRecordsHashSchemeTransition(t *testing.T) {
+	encoding := moduletestutil.MakeTestEncodingConfig()
+	key := storetypes.NewKVStoreKey(lctypes.StoreKey)
+	ctx := testutil.DefaultContext(key, storetypes.NewTransientStoreKey("transient_test")).WithBlockHeight(100)
+	k := lckeeper.NewKeeper(encoding.Codec, key, "authority")
+
+	mm := module.NewManager(lightclient.NewAppModule(k))
+	msgRouter := baseapp.NewMsgServiceRouter()
+	msgRouter.SetInterfaceRegistry(encoding.InterfaceRegistry)
+	queryRouter := baseapp.NewGRPCQueryRouter()
+	queryRouter.SetInterfaceRegistry(encoding.InterfaceRegistry)
+	lctypes.RegisterInterfaces(encoding.InterfaceRegistry)
+	configurator := module.NewConfigurator(encoding.Codec, msgRouter, queryRouter)
+	require.NoError(t, mm.RegisterServices(configurator))
+
+	handler := v0_25_0.Upgrade.CreateUpgradeHandler(mm, configurator, &upgrades.AppKeepers{LightClientKeeper: &k})
+	// x/lightclient is added by the upgrade and missing from the version map.
+	vm, err := handler(ctx, upgradetypes.Plan{Name: v0_25_0.UpgradeName, Height: 100}, module.VersionMap{})
+	require.NoError(t, err)
+	require.Equal(t, uint64(lightclient.ConsensusVersion), vm[lctypes.ModuleName])
+
+	res, err := k.HashSchemeTransition(ctx, &lctypes.QueryHashSchemeTransitionRequest{})
+	require.NoError(t, err)
+	require.Equal(t, &lctypes.HashSchemeTransition{
+		Height:         101,
+		PreviousScheme: lctypes.HashSchemeLegacy,
+		Scheme:         lctypes.HashSchemeCometbls,
+	}, res.Transition)
+	require.Equal(t, lctypes.HashSchemeLegacy, res.Transition.SchemeAt(100))
+	require.Equal(t, lctypes.HashSchemeCometbls, res.Transition.SchemeAt(101))
+
+	params, err := k.Params(ctx, &lctypes.QueryParamsRequest{})
+	require.NoError(t, err)
+	require.ElementsMatch(t, []string{lctypes.HashSchemeCometbls, lctypes.HashSchemeLegacy}, params.Params.AllowedHashSchemes)
+}
@@ -0,0 +1,158 @@
+package valsetcache
+
+import (
+	"container/list"
+	"context"
+	"sync"
+	"time"
+
+	"cosmossdk.io/log"
+	cmttypes "github.com/cometbft/cometbft/types"
+	"github.com/cosmos/cosmos-sdk/client"
+
+	"union/verifier"
+)
+
+// Cache keeps the latest distinct validator sets of the chain, indexed by
+// their current and legacy hashes. Validator sets rarely change from a height
+// to the next, a handful of sets covers the headers verified by the light
+// clients following the chain.
+type Cache struct {
+	maxSets  int
+	interval time.Duration
+	logger   log.Logger
+
+	mu   sync.Mutex
+	node client.CometRPC
+	// Most recently used first.
+	sets   *list.List
+	byHash map[string]*list.Element
+
+	cancel context.CancelFunc
+	done   chan struct{}
+	once   sync.Once
+}
+
+type entry struct {
+	vals   *cmttypes.ValidatorSet
+	hashes [][]byte
+}
+
+func New(cfg Config, logger log.Logger) *Cache {
+	defaults := DefaultConfig()
+	if cfg.MaxSets == 0 {
+		cfg.MaxSets = defaults.MaxSets
+	}
+	if cfg.Interval <= 0 {
+		cfg.Interval = defaults.Interval
+	}
+	return &Cache{
+		maxSets:  int(cfg.MaxSets),
+		interval: cfg.Interval,
+		logger:   logger.With("module", "valset-cache"),
+		sets:     list.New(),
+		byHash:   make(map[string]*list.Element),
+	}
+}
+
+// Start caches the latest validator set of the node in the background until
+// Stop is called, the node also serving the sets missed at a known height.
+// Calling Start more than once has no effect.
+func (c *Cache) Start(node client.CometRPC) {
+	c.once.Do(func() {
+		c.mu.Lock()
+		c.node = node
+		c.mu.Unlock()
+		ctx, cancel := context.WithCancel(context.Background())
+		c.cancel = cancel
+		c.done = make(chan struct{})
+		go c.run(ctx, node)
+	})
+}
+
+// Stop the background synchronization if running.
+func (c *Cache) Stop() {
+	if c.cancel != nil {
+		c.cancel()
+		<-c.done
+	}
+}
+
+func (c *Cache) run(ctx context.Context, node client.CometRPC) {
+	defer close(c.done)
+	ticker := time.NewTicker(c.interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			_, vals, err := verifier.FetchValidatorSet(ctx, node, nil)
+			if err != nil {
+				if ctx.Err() == nil {
+					c.logger.Error("failed to fetch the latest validator set", "err", err)
+				}
+				continue
+			}
+			c.Add(vals)
+		}
+	}
+}
+
+// Add caches a validator set, evicting the least recently used one past the
+// maximum number of sets.
+func (c *Cache) Add(vals *cmttypes.ValidatorSet) {
+	hashes := [][]byte{vals.Hash(), vals.HashSha256()}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if elem, ok := c.byHash[string(hashes[0])]; ok {
+		c.sets.MoveToFront(elem)
+		return
+	}
+	elem := c.sets.PushFront(&entry{vals: vals, hashes: hashes})
+	for _, hash := range hashes {
+		c.byHash[string(hash)] = elem
+	}
+	for c.sets.Len() > c.maxSets {
+		oldest := c.sets.Back()
+		for _, hash := range oldest.Value.(*entry).hashes {
+			delete(c.byHash, string(hash))
+		}
+		c.sets.Remove(oldest)
+	}
+}
+
+// Get returns the cached validator set of the given current or legacy hash,
+// nil if it isn't cached.
+func (c *Cache) Get(hash []byte) *cmttypes.ValidatorSet {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	elem, ok := c.byHash[string(hash)]
+	if !ok {
+		return nil
+	}
+	c.sets.MoveToFront(elem)
+	return elem.Value.(*entry).vals
+}
+
+// Fetch returns the validator set of the given hash, fetched from the node at
+// the given height and cached if it isn't cached yet. Only cached sets are
+// returned if the height is zero or the cache isn't started, and nil if the
+// set at the height has another hash.
+func (c *Cache) Fetch(ctx context.Context, hash []byte, height int64) (*cmttypes.ValidatorSet, error) {
+	if vals := c.Get(hash); vals != nil {
+		return vals, nil
+	}
+	c.mu.Lock()
+	node := c.node
+	c.mu.Unlock()
+	if height <= 0 || node == nil {
+		return nil, nil
+	}
+	_, vals, err := verifier.FetchValidatorSet(ctx, node, &height)
+	if err != nil {
+		return nil, err
+	}
+	c.Add(vals)
+	return c.Get(hash), nil
+}
@@ -0,0 +1,43 @@
+package valsetcache
+
+import (
+	"context"
+	"fmt"
+
+	cmttypes "github.com/cometbft/cometbft/types"
+	gogogrpc "github.com/cosmos/gogoproto/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"union/verifier"
+	"union/verifier/transport"
+)
+
+var _ transport.ValidatorSetCache = Client{}
+
+// Client queries the validator set cache of a node for the providers of the
+// light client, see transport.NewCachedProvider.
+type Client struct {
+	service ServiceClient
+}
+
+func NewClient(conn gogogrpc.ClientConn) Client {
+	return Client{service: NewServiceClient(conn)}
+}
+
+// ValidatorSet implements transport.ValidatorSetCache.ValidatorSet, a set
+// missing from the cache being nil.
+func (c Client) ValidatorSet(ctx context.Context, hash []byte, height int64) (*cmttypes.ValidatorSet, error) {
+	res, err := c.service.ValidatorSet(ctx, &GetValidatorSetRequest{Hash: hash, Height: height})
+	if status.Code(err) == codes.NotFound {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	// Decoding panics past the bounds of the voting powers.
+	if err := verifier.CheckVotingPowers(res.ValidatorSet); err != nil {
+		return nil, fmt.Errorf("validator set %X: %w", hash, err)
+	}
+	return cmttypes.ValidatorSetFromProto(res.ValidatorSet)
+}
@@ -0,0 +1,62 @@
+package valsetcache
+
+import (
+	"time"
+
+	servertypes "github.com/cosmos/cosmos-sdk/server/types"
+	"github.com/spf13/cast"
+)
+
+const (
+	flagEnable   = "valset-cache.enable"
+	flagMaxSets  = "valset-cache.max-sets"
+	flagInterval = "valset-cache.interval"
+)
+
+// ConfigTemplate is the app.toml section of the validator set cache.
+const ConfigTemplate = `
+[valset-cache]
+# Cache the latest validator sets of the chain and serve them by hash over
+# gRPC, so that light clients running alongside the node don't fetch the full
+# sets from the RPC for every verified header.
+# The cache is filled from the local node, the gRPC or API server must be enabled.
+enable = {{ .ValsetCache.Enable }}
+# Number of distinct validator sets to keep, the least recently used being
+# evicted first.
+max-sets = {{ .ValsetCache.MaxSets }}
+# Interval between two fetches of the latest validator set.
+interval = "{{ .ValsetCache.Interval }}"
+`
+
+// Config defines the validator set cache configuration.
+type Config struct {
+	Enable   bool          `mapstructure:"enable"`
+	MaxSets  uint64        `mapstructure:"max-sets"`
+	Interval time.Duration `mapstructure:"interval"`
+}
+
+// DefaultConfig returns the default validator set cache configuration, the
+// cache is disabled.
+func DefaultConfig() Config {
+	return Config{
+		Enable:   false,
+		MaxSets:  64,
+		Interval: 5 * time.Second,
+	}
+}
+
+// ReadConfig reads the validator set cache configuration from the app
+// options, falling back to the defaults for unset values.
+func ReadConfig(appOpts servertypes.AppOptions) Config {
+	cfg := DefaultConfig()
+	if v := appOpts.Get(flagEnable); v != nil {
+		cfg.Enable = cast.ToBool(v)
+	}
+	if v := appOpts.Get(flagMaxSets); v != nil {
+		cfg.MaxSets = cast.ToUint64(v)
+	}
+	if v := appOpts.Get(flagInterval); v != nil {
+		cfg.Interval = cast.ToDuration(v)
+	}
+	return cfg
+}
@@ -0,0 +1,46 @@
+package valsetcache
+
+import (
+	"context"
+
+	gogogrpc "github.com/cosmos/gogoproto/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+var _ ServiceServer = queryServer{}
+
+type queryServer struct {
+	cache *Cache
+}
+
+// ValidatorSet implements ServiceServer.ValidatorSet
+func (s queryServer) ValidatorSet(ctx context.Context, req *GetValidatorSetRequest) (*GetValidatorSetResponse, error) {
+	if s.cache == nil {
+		return nil, status.Error(codes.Unavailable, "the validator set cache is disabled on this node")
+	}
+	if len(req.Hash) == 0 {
+		return nil, status.Error(codes.InvalidArgument, "the hash of the validator set is required")
+	}
+	if req.Height < 0 {
+		return nil, status.Errorf(codes.InvalidArgument, "height can't be negative, got %d", req.Height)
+	}
+	vals, err := s.cache.Fetch(ctx, req.Hash, req.Height)
+	if err != nil {
+		return nil, status.Errorf(codes.Unavailable, "height %d: %s", req.Height, err)
+	}
+	if vals == nil {
+		return nil, status.Errorf(codes.NotFound, "validator set %X is not cached", req.Hash)
+	}
+	pb, err := vals.ToProto()
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	return &GetValidatorSetResponse{ValidatorSet: pb}, nil
+}
+
+// RegisterValidatorSetCacheService registers the validator set cache service
+// on the gRPC router, cache may be nil if the cache is disabled.
+func RegisterValidatorSetCacheService(server gogogrpc.Server, cache *Cache) {
+	RegisterServiceServer(server, queryServer{cache: cache})
+}
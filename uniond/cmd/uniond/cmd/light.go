@@ -0,0 +1,423 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"time"
+
+	cmtbytes "github.com/cometbft/cometbft/libs/bytes"
+	cmtjson "github.com/cometbft/cometbft/libs/json"
+	cmtmath "github.com/cometbft/cometbft/libs/math"
+	"github.com/cometbft/cometbft/light"
+	cmtproto "github.com/cometbft/cometbft/proto/tendermint/types"
+	cmttypes "github.com/cometbft/cometbft/types"
+	"github.com/cosmos/cosmos-sdk/client"
+	"github.com/cosmos/cosmos-sdk/client/flags"
+	"github.com/spf13/cobra"
+
+	"union/verifier"
+)
+
+const (
+	flagTrustingPeriod         = "trusting-period"
+	flagMaxClockDrift          = "max-clock-drift"
+	flagUnbondingPeriod        = "unbonding-period"
+	flagTrustLevel             = "trust-level"
+	flagLegacy                 = "legacy"
+	flagLegacyTransition       = "legacy-transition"
+	flagCheckProposer          = "check-proposer"
+	flagMaxCommitRound         = "max-commit-round"
+	flagStrictParts            = "strict-part-set-header"
+	flagCheckContinuity        = "check-continuity"
+	flagExtendedCommit         = "extended-commit"
+	flagExtensionsEnableHeight = "vote-extensions-enable-height"
+	flagNow                    = "now"
+	flagInputFormat            = "input-format"
+	flagExplain                = "explain"
+
+	inputFormatJSON  = "json"
+	inputFormatProto = "proto"
+)
+
+func LightCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:                        "light",
+		Short:                      "Light client subcommands",
+		DisableFlagParsing:         true,
+		SuggestionsMinimumDistance: 2,
+		RunE:                       client.ValidateCmd,
+	}
+
+	cmd.AddCommand(
+		LightVerifyCmd(),
+		LightVerifyEVMUpdateCmd(),
+		LightFollowCmd(),
+		LightServeCmd(),
+		LightBenchCmd(),
+		LightCanonicalJSONCmd(),
+		LightVectorsCmd(),
+		LightCheckStoreCmd(),
+		LightProviderRecordCmd(),
+		LightProviderScoresCmd(),
+		LightExportStateCmd(),
+		LightExportBootstrapCmd(),
+		LightRecoverCmd(),
+		LightReplicaCmd(),
+	)
+
+	return cmd
+}
+
+// The outcome of an offline header verification, printed as JSON so that it
+// can be consumed by scripts.
+type lightVerificationReport struct {
+	Verified        bool      `json:"verified"`
+	Adjacent        bool      `json:"adjacent"`
+	Legacy          bool      `json:"legacy"`
+	ChainID         string    `json:"chain_id"`
+	TrustedHeight   int64     `json:"trusted_height"`
+	TrustedHash     string    `json:"trusted_hash"`
+	UntrustedHeight int64     `json:"untrusted_height"`
+	UntrustedHash   string    `json:"untrusted_hash"`
+	TrustLevel      string    `json:"trust_level"`
+	TrustingPeriod  string    `json:"trusting_period"`
+	MaxClockDrift   string    `json:"max_clock_drift"`
+	Now             time.Time `json:"now"`
+	Error           string    `json:"error,omitempty"`
+	// Every violated condition, only filled with --explain.
+	Violations []verifier.Violation `json:"violations,omitempty"`
+}
+
+func LightVerifyCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "verify [trusted-light-block] [untrusted-light-block]",
+		Short: "Verify an untrusted header against a trusted one, offline",
+		Long: `Verify an untrusted light block (signed header and validator set) against a trusted one without contacting any node.
+Both files must contain a light block, either as JSON (as served by the light client provider) or as a binary protobuf encoded tendermint.types.LightBlock.
+A JSON report is printed and the command exits with an error if the verification failed.
+With --explain, the report lists every violated condition with its expected and actual values instead of stopping at the first one.
+With --chain-id, light blocks of other chains are rejected before verifying, except for later revisions of a chain id in the {name}-{revision} format.
+With --extended-commit, the extended commit of the untrusted block (same encoding, tendermint.types.ExtendedCommit for protobuf) must also be signed by its validator set, along with a vote extension of every precommit from --vote-extensions-enable-height.`,
+		Args: cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			format, err := cmd.Flags().GetString(flagInputFormat)
+			if err != nil {
+				return err
+			}
+			trustingPeriod, err := cmd.Flags().GetDuration(flagTrustingPeriod)
+			if err != nil {
+				return err
+			}
+			maxClockDrift, err := cmd.Flags().GetDuration(flagMaxClockDrift)
+			if err != nil {
+				return err
+			}
+			unbondingPeriod, err := cmd.Flags().GetDuration(flagUnbondingPeriod)
+			if err != nil {
+				return err
+			}
+			if err := verifier.ValidateBounds(trustingPeriod, maxClockDrift, unbondingPeriod); err != nil {
+				return err
+			}
+			legacy, err := cmd.Flags().GetBool(flagLegacy)
+			if err != nil {
+				return err
+			}
+			explain, err := cmd.Flags().GetBool(flagExplain)
+			if err != nil {
+				return err
+			}
+			checkProposer, err := cmd.Flags().GetBool(flagCheckProposer)
+			if err != nil {
+				return err
+			}
+			strict, err := lightStrict(cmd)
+			if err != nil {
+				return err
+			}
+			checkContinuity, err := cmd.Flags().GetBool(flagCheckContinuity)
+			if err != nil {
+				return err
+			}
+			extendedCommitPath, err := cmd.Flags().GetString(flagExtendedCommit)
+			if err != nil {
+				return err
+			}
+			extensionsEnableHeight, err := cmd.Flags().GetInt64(flagExtensionsEnableHeight)
+			if err != nil {
+				return err
+			}
+			if extensionsEnableHeight < 0 {
+				return fmt.Errorf("--%s can't be negative", flagExtensionsEnableHeight)
+			}
+			rawTrustLevel, err := cmd.Flags().GetString(flagTrustLevel)
+			if err != nil {
+				return err
+			}
+			trustLevel, err := cmtmath.ParseFraction(rawTrustLevel)
+			if err != nil {
+				return err
+			}
+			if trustLevel, err = verifier.ValidateTrustLevel(trustLevel); err != nil {
+				return err
+			}
+			chainID, err := cmd.Flags().GetString(flags.FlagChainID)
+			if err != nil {
+				return err
+			}
+			now := time.Now()
+			rawNow, err := cmd.Flags().GetString(flagNow)
+			if err != nil {
+				return err
+			}
+			if rawNow != "" {
+				now, err = time.Parse(time.RFC3339, rawNow)
+				if err != nil {
+					return fmt.Errorf("invalid --%s: %w", flagNow, err)
+				}
+			}
+
+			transition, err := lightTransition(cmd)
+			if err != nil {
+				return err
+			}
+
+			trusted, trustedLegacy, err := readLightBlock(args[0], format, transition)
+			if err != nil {
+				return err
+			}
+			untrusted, untrustedLegacy, err := readLightBlock(args[1], format, transition)
+			if err != nil {
+				return err
+			}
+			detectedLegacy, err := verifier.LegacyMode(trustedLegacy, untrustedLegacy)
+			if err != nil {
+				return err
+			}
+			if cmd.Flags().Changed(flagLegacy) && legacy != detectedLegacy {
+				return fmt.Errorf("--%s=%t, but %s uses the %s hashes", flagLegacy, legacy, args[1], verifier.SchemeName(detectedLegacy))
+			}
+			legacy = detectedLegacy
+			if chainID != "" {
+				for _, lightBlock := range []*cmttypes.LightBlock{trusted, untrusted} {
+					if err := verifier.CheckChainID(chainID, lightBlock.ChainID); err != nil {
+						return err
+					}
+				}
+			}
+
+			verify := light.Verify
+			if legacy {
+				verify = light.VerifyLegacy
+			}
+			if untrusted.Height != trusted.Height+1 {
+				if err := verifier.CheckTrustMath(trusted.ValidatorSet, trustLevel); err != nil {
+					return fmt.Errorf("%s: %w", args[0], err)
+				}
+			}
+			verifyErr := verify(
+				trusted.SignedHeader,
+				trusted.ValidatorSet,
+				untrusted.SignedHeader,
+				untrusted.ValidatorSet,
+				trustingPeriod,
+				now,
+				maxClockDrift,
+				trustLevel,
+			)
+			if verifyErr == nil && strict.Enabled() {
+				verifyErr = verifier.CheckStrict(untrusted, strict)
+			}
+			if verifyErr == nil && checkContinuity && untrusted.Height == trusted.Height+1 {
+				verifyErr = verifier.CheckContinuity(trusted, untrusted, trustedLegacy)
+			}
+			if verifyErr == nil && checkProposer {
+				verifyErr = verifier.CheckProposer(trusted, untrusted)
+			}
+			if verifyErr == nil && extendedCommitPath != "" {
+				extendedCommit, err := readExtendedCommit(extendedCommitPath, format)
+				if err != nil {
+					return err
+				}
+				verifyErr = verifier.VerifyExtendedCommit(untrusted, extendedCommit, extensionsEnableHeight, verifier.CanonicalSignBytes(legacy))
+			}
+
+			report := lightVerificationReport{
+				Verified:        verifyErr == nil,
+				Adjacent:        untrusted.Height == trusted.Height+1,
+				Legacy:          legacy,
+				ChainID:         trusted.ChainID,
+				TrustedHeight:   trusted.Height,
+				TrustedHash:     cmtbytes.HexBytes(verifier.LightBlockHash(trusted, trustedLegacy)).String(),
+				UntrustedHeight: untrusted.Height,
+				UntrustedHash:   cmtbytes.HexBytes(verifier.LightBlockHash(untrusted, untrustedLegacy)).String(),
+				TrustLevel:      trustLevel.String(),
+				TrustingPeriod:  trustingPeriod.String(),
+				MaxClockDrift:   maxClockDrift.String(),
+				Now:             now.UTC(),
+			}
+			if verifyErr != nil {
+				report.Error = verifyErr.Error()
+			}
+			if explain {
+				report.Violations = verifier.Explain(trusted, untrusted, trustingPeriod, now, maxClockDrift, trustLevel, legacy)
+			}
+			reportJson, err := json.MarshalIndent(&report, "", "  ")
+			if err != nil {
+				return err
+			}
+			fmt.Fprintln(cmd.OutOrStdout(), string(reportJson))
+
+			if verifyErr != nil {
+				cmd.SilenceUsage = true
+				return fmt.Errorf("verification failed: %w", verifyErr)
+			}
+			return nil
+		},
+	}
+	cmd.Flags().String(flagInputFormat, inputFormatJSON, "Encoding of the light block files (json|proto)")
+	cmd.Flags().Duration(flagTrustingPeriod, 168*time.Hour, "Period during which the trusted header can be used to verify new headers")
+	cmd.Flags().Duration(flagMaxClockDrift, 10*time.Second, "Maximum allowed drift between the untrusted header time and now")
+	cmd.Flags().Duration(flagUnbondingPeriod, verifier.DefaultUnbondingPeriod, "Unbonding period of the chain, the trusting period must be shorter")
+	cmd.Flags().String(flagTrustLevel, light.DefaultTrustLevel.String(), "Fraction of the trusted validator set that must have signed a non adjacent header")
+	cmd.Flags().Bool(flagLegacy, false, "Require the legacy (pre cometbls) hashes and vote sign bytes, detected from the light blocks if unset")
+	cmd.Flags().String(flagLegacyTransition, "", legacyTransitionUsage)
+	cmd.Flags().String(flagNow, "", "Verification time as RFC3339, defaults to the current time")
+	cmd.Flags().String(flags.FlagChainID, "", "Chain the light blocks must belong to, or a later revision of it, any chain if unset")
+	cmd.Flags().Bool(flagCheckProposer, false, "Also check that the proposer of the untrusted header is elected by the proposer rotation")
+	cmd.Flags().Int32(flagMaxCommitRound, 0, "Reject an untrusted header committed after that round, no bound if zero")
+	cmd.Flags().Bool(flagStrictParts, false, "Reject an untrusted header whose block ids don't reference a complete part set")
+	cmd.Flags().Bool(flagCheckContinuity, false, "Reject an adjacent untrusted header that doesn't build on the block committed by the trusted one")
+	cmd.Flags().String(flagExtendedCommit, "", "Also verify the extended commit of the untrusted block in this file, with the vote extensions it carries")
+	cmd.Flags().Int64(flagExtensionsEnableHeight, 0, "Height from which the chain enables vote extensions, never if zero")
+	cmd.Flags().Bool(flagExplain, false, "Run every check even after a failure and report all the violated conditions")
+	return cmd
+}
+
+func LightCanonicalJSONCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "canonical-json [light-block]",
+		Short: "Print the canonical JSON encoding of a light block",
+		Long: `Print the canonical JSON encoding of a light block (sorted keys, no whitespace, fixed number and string encodings), to be signed or hashed reproducibly by any implementation.
+The file must contain a light block, either as JSON or as a binary protobuf encoded tendermint.types.LightBlock.`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			format, err := cmd.Flags().GetString(flagInputFormat)
+			if err != nil {
+				return err
+			}
+			lightBlock, _, err := readLightBlock(args[0], format, verifier.AnyHeight)
+			if err != nil {
+				return err
+			}
+			bz, err := verifier.CanonicalJSON(lightBlock)
+			if err != nil {
+				return err
+			}
+			fmt.Fprintln(cmd.OutOrStdout(), string(bz))
+			return nil
+		},
+	}
+	cmd.Flags().String(flagInputFormat, inputFormatJSON, "Encoding of the light block file (json|proto)")
+	return cmd
+}
+
+// readLightBlock reads a light block file and validates it under the
+// hashing scheme it is consistent with within the transition, returned along
+// with the light block.
+func readLightBlock(path string, format string, transition verifier.Transition) (*cmttypes.LightBlock, bool, error) {
+	bz, err := os.ReadFile(path)
+	if err != nil {
+		return nil, false, err
+	}
+	var lightBlock *cmttypes.LightBlock
+	switch format {
+	case inputFormatJSON:
+		lightBlock = &cmttypes.LightBlock{}
+		if err := cmtjson.Unmarshal(bytes.TrimSpace(bz), lightBlock); err != nil {
+			return nil, false, fmt.Errorf("%s: %w", path, err)
+		}
+	case inputFormatProto:
+		var pb cmtproto.LightBlock
+		if err := pb.Unmarshal(bz); err != nil {
+			return nil, false, fmt.Errorf("%s: %w", path, err)
+		}
+		lightBlock, err = cmttypes.LightBlockFromProto(&pb)
+		if err != nil {
+			return nil, false, fmt.Errorf("%s: %w", path, err)
+		}
+	default:
+		return nil, false, fmt.Errorf("unknown input format %q, expected %s or %s", format, inputFormatJSON, inputFormatProto)
+	}
+	if lightBlock.SignedHeader == nil || lightBlock.ValidatorSet == nil {
+		return nil, false, fmt.Errorf("%s: light block must contain both a signed header and a validator set", path)
+	}
+	legacy, err := verifier.DetectLegacy(lightBlock, transition)
+	if err != nil {
+		return nil, false, fmt.Errorf("%s: %w", path, err)
+	}
+	return lightBlock, legacy, nil
+}
+
+// readExtendedCommit reads an extended commit file.
+func readExtendedCommit(path string, format string) (*cmttypes.ExtendedCommit, error) {
+	bz, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	switch format {
+	case inputFormatJSON:
+		extendedCommit := &cmttypes.ExtendedCommit{}
+		if err := cmtjson.Unmarshal(bytes.TrimSpace(bz), extendedCommit); err != nil {
+			return nil, fmt.Errorf("%s: %w", path, err)
+		}
+		return extendedCommit, nil
+	case inputFormatProto:
+		var pb cmtproto.ExtendedCommit
+		if err := pb.Unmarshal(bz); err != nil {
+			return nil, fmt.Errorf("%s: %w", path, err)
+		}
+		extendedCommit, err := cmttypes.ExtendedCommitFromProto(&pb)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", path, err)
+		}
+		return extendedCommit, nil
+	default:
+		return nil, fmt.Errorf("unknown input format %q, expected %s or %s", format, inputFormatJSON, inputFormatProto)
+	}
+}
+
+// lightStrict returns the strict checks configured by the flags.
+// legacyTransitionUsage is the usage of the flag of the commands detecting the
+// hashing scheme of light blocks.
+const legacyTransitionUsage = "Heights <from>-<to> within which the chain moved from the legacy hashes to the current ones, the light blocks below being legacy and the ones above current, every height if unset"
+
+// lightTransition returns the transition of the chain given by the flags.
+func lightTransition(cmd *cobra.Command) (verifier.Transition, error) {
+	rawTransition, err := cmd.Flags().GetString(flagLegacyTransition)
+	if err != nil {
+		return verifier.Transition{}, err
+	}
+	transition, err := verifier.ParseTransition(rawTransition)
+	if err != nil {
+		return verifier.Transition{}, fmt.Errorf("--%s: %w", flagLegacyTransition, err)
+	}
+	return transition, nil
+}
+
+func lightStrict(cmd *cobra.Command) (verifier.Strict, error) {
+	maxCommitRound, err := cmd.Flags().GetInt32(flagMaxCommitRound)
+	if err != nil {
+		return verifier.Strict{}, err
+	}
+	if maxCommitRound < 0 {
+		return verifier.Strict{}, fmt.Errorf("--%s can't be negative", flagMaxCommitRound)
+	}
+	partSetHeader, err := cmd.Flags().GetBool(flagStrictParts)
+	if err != nil {
+		return verifier.Strict{}, err
+	}
+	return verifier.Strict{MaxCommitRound: maxCommitRound, PartSetHeader: partSetHeader}, nil
+}
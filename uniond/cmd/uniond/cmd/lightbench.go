@@ -0,0 +1,77 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"slices"
+
+	"github.com/spf13/cobra"
+
+	"union/verifier/lightbench"
+)
+
+const (
+	flagValidators = "validators"
+	flagScenarios  = "scenarios"
+	flagSeed       = "seed"
+)
+
+func LightBenchCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "bench",
+		Short: "Benchmark the header verification on generated validator sets",
+		Long: `Benchmark the light client header verification on generated chains of the given validator set sizes and print one JSON line per scenario and size.
+The scenarios are adjacent, non-adjacent (skipping heights, checked against the trusted validator set) and legacy (adjacent, pre cometbls sign bytes).
+Keys and voting powers are derived from --seed, so that runs of different releases on the same machine are comparable.`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			sizes, err := cmd.Flags().GetIntSlice(flagValidators)
+			if err != nil {
+				return err
+			}
+			rawScenarios, err := cmd.Flags().GetStringSlice(flagScenarios)
+			if err != nil {
+				return err
+			}
+			seed, err := cmd.Flags().GetInt64(flagSeed)
+			if err != nil {
+				return err
+			}
+
+			scenarios := make([]lightbench.Scenario, len(rawScenarios))
+			for i, raw := range rawScenarios {
+				scenarios[i] = lightbench.Scenario(raw)
+				if !slices.Contains(lightbench.Scenarios, scenarios[i]) {
+					return fmt.Errorf("unknown scenario %q, expected one of %v", raw, lightbench.Scenarios)
+				}
+			}
+			for _, size := range sizes {
+				if size <= 0 {
+					return fmt.Errorf("validator set sizes must be positive, got %d", size)
+				}
+			}
+			for _, scenario := range scenarios {
+				for _, size := range sizes {
+					res, err := lightbench.Run(scenario, size, seed)
+					if err != nil {
+						return fmt.Errorf("%s with %d validators: %w", scenario, size, err)
+					}
+					resJson, err := json.Marshal(&res)
+					if err != nil {
+						return err
+					}
+					fmt.Fprintln(cmd.OutOrStdout(), string(resJson))
+				}
+			}
+			return nil
+		},
+	}
+	defaultScenarios := make([]string, len(lightbench.Scenarios))
+	for i, scenario := range lightbench.Scenarios {
+		defaultScenarios[i] = string(scenario)
+	}
+	cmd.Flags().IntSlice(flagValidators, []int{4, 32, 128}, "Validator set sizes to benchmark")
+	cmd.Flags().StringSlice(flagScenarios, defaultScenarios, "Scenarios to benchmark (adjacent|non-adjacent|legacy)")
+	cmd.Flags().Int64(flagSeed, 1, "Seed of the generated keys and voting powers")
+	return cmd
+}
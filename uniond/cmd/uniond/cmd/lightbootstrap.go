@@ -0,0 +1,196 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strconv"
+	"time"
+
+	cmtbytes "github.com/cometbft/cometbft/libs/bytes"
+	cmtjson "github.com/cometbft/cometbft/libs/json"
+	cmttypes "github.com/cometbft/cometbft/types"
+	"github.com/cosmos/cosmos-sdk/client/flags"
+	"github.com/spf13/cobra"
+
+	"union/verifier"
+	"union/verifier/transport"
+)
+
+// The root of trust of a light client at a height, along with everything
+// needed to start a client from it, written as a single JSON file.
+type lightBootstrap struct {
+	ChainID string `json:"chain_id"`
+	Height  int64  `json:"height"`
+	// Hash of the header under its hashing scheme, the trusted hash of a
+	// light client bootstrapped from the height.
+	Hash   cmtbytes.HexBytes `json:"hash"`
+	Time   time.Time         `json:"time"`
+	Legacy bool              `json:"legacy"`
+	// The CometBFT RPC JSON encodings.
+	SignedHeader     json.RawMessage `json:"signed_header"`
+	ValidatorSet     json.RawMessage `json:"validator_set"`
+	NextValidatorSet json.RawMessage `json:"next_validator_set"`
+	ConsensusParams  json.RawMessage `json:"consensus_params"`
+	// The initial states of the EVM cometbls client.
+	EVM lightBootstrapEVM `json:"evm"`
+}
+
+// The client and consensus states of the EVM cometbls client, the durations
+// and timestamp in nanoseconds as decimal strings since they exceed the
+// integers JSON numbers hold exactly.
+type lightBootstrapEVM struct {
+	ClientState struct {
+		ChainID        string `json:"chain_id"`
+		TrustingPeriod string `json:"trusting_period"`
+		MaxClockDrift  string `json:"max_clock_drift"`
+		FrozenHeight   string `json:"frozen_height"`
+		LatestHeight   string `json:"latest_height"`
+	} `json:"client_state"`
+	ConsensusState struct {
+		Timestamp          string `json:"timestamp"`
+		AppHash            string `json:"app_hash"`
+		NextValidatorsHash string `json:"next_validators_hash"`
+	} `json:"consensus_state"`
+}
+
+func LightExportBootstrapCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "export-bootstrap [file]",
+		Short: "Export a bootstrap bundle of a light client at a height",
+		Long: `Write the header, commit, validator set, next validator set and consensus parameters of the node at --height (the latest if zero) to a single JSON file, so that light clients are bootstrapped from it on other machines.
+The bundle holds the height and hash to trust, e.g. for light follow --trusted-height and --trusted-hash, and the initial client and consensus states of the EVM cometbls client for its deployment scripts.
+The commit is checked to be signed by more than 2/3 of the validator set and the header to commit to both validator sets, but the node is trusted: the hash must be checked out of band, e.g. with a block explorer or other operators.`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			node, err := cmd.Flags().GetString(flags.FlagNode)
+			if err != nil {
+				return err
+			}
+			height, err := cmd.Flags().GetInt64(flagHeight)
+			if err != nil {
+				return err
+			}
+			if height < 0 {
+				return fmt.Errorf("--%s can't be negative", flagHeight)
+			}
+			trustingPeriod, err := cmd.Flags().GetDuration(flagTrustingPeriod)
+			if err != nil {
+				return err
+			}
+			maxClockDrift, err := cmd.Flags().GetDuration(flagMaxClockDrift)
+			if err != nil {
+				return err
+			}
+			unbondingPeriod, err := cmd.Flags().GetDuration(flagUnbondingPeriod)
+			if err != nil {
+				return err
+			}
+			if err := verifier.ValidateBounds(trustingPeriod, maxClockDrift, unbondingPeriod); err != nil {
+				return err
+			}
+			maxResponseBytes, err := cmd.Flags().GetInt64(flagMaxResponseBytes)
+			if err != nil {
+				return err
+			}
+			transition, err := lightTransition(cmd)
+			if err != nil {
+				return err
+			}
+
+			client, err := transport.NewClient(node, &transport.Transport{MaxDecodedBytes: maxResponseBytes})
+			if err != nil {
+				return err
+			}
+			var requested *int64
+			if height > 0 {
+				requested = &height
+			}
+			commit, err := client.Commit(cmd.Context(), requested)
+			if err != nil {
+				return err
+			}
+			// Pinned to the height of the commit when the latest is requested.
+			height = commit.Height
+			_, valSet, err := verifier.FetchValidatorSet(cmd.Context(), client, &height)
+			if err != nil {
+				return fmt.Errorf("validator set at height %d: %w", height, err)
+			}
+			nextHeight := height + 1
+			_, nextValSet, err := verifier.FetchValidatorSet(cmd.Context(), client, &nextHeight)
+			if err != nil {
+				return fmt.Errorf("validator set at height %d: %w", nextHeight, err)
+			}
+			params, err := client.ConsensusParams(cmd.Context(), &height)
+			if err != nil {
+				return err
+			}
+
+			lightBlock := &cmttypes.LightBlock{SignedHeader: &commit.SignedHeader, ValidatorSet: valSet}
+			legacy, err := verifier.DetectLegacy(lightBlock, transition)
+			if err != nil {
+				return fmt.Errorf("light block %d: %w", height, err)
+			}
+			if err := verifier.VerifyCommitLight(lightBlock.ChainID, valSet, lightBlock.Commit.BlockID, height, lightBlock.Commit, verifier.CanonicalSignBytes(legacy)); err != nil {
+				return fmt.Errorf("commit %d: %w", height, err)
+			}
+			nextValsHash := nextValSet.Hash()
+			if legacy {
+				nextValsHash = nextValSet.HashSha256()
+			}
+			if !bytes.Equal(nextValsHash, lightBlock.NextValidatorsHash) {
+				return fmt.Errorf("the header %d commits to the next validators hash %s, the validator set at height %d hashes to %X", height, lightBlock.NextValidatorsHash, nextHeight, nextValsHash)
+			}
+
+			bootstrap := lightBootstrap{
+				ChainID: lightBlock.ChainID,
+				Height:  height,
+				Hash:    verifier.LightBlockHash(lightBlock, legacy),
+				Time:    lightBlock.Time,
+				Legacy:  legacy,
+			}
+			for _, field := range []struct {
+				raw *json.RawMessage
+				v   any
+			}{
+				{&bootstrap.SignedHeader, lightBlock.SignedHeader},
+				{&bootstrap.ValidatorSet, valSet},
+				{&bootstrap.NextValidatorSet, nextValSet},
+				{&bootstrap.ConsensusParams, params.ConsensusParams},
+			} {
+				if *field.raw, err = cmtjson.Marshal(field.v); err != nil {
+					return err
+				}
+			}
+			revisionHeight := verifier.HeightOf(lightBlock.ChainID, height).Number
+			evm := &bootstrap.EVM
+			evm.ClientState.ChainID = lightBlock.ChainID
+			evm.ClientState.TrustingPeriod = strconv.FormatInt(trustingPeriod.Nanoseconds(), 10)
+			evm.ClientState.MaxClockDrift = strconv.FormatInt(maxClockDrift.Nanoseconds(), 10)
+			evm.ClientState.FrozenHeight = "0"
+			evm.ClientState.LatestHeight = strconv.FormatUint(revisionHeight, 10)
+			evm.ConsensusState.Timestamp = strconv.FormatInt(lightBlock.Time.UnixNano(), 10)
+			evm.ConsensusState.AppHash = "0x" + lightBlock.AppHash.String()
+			evm.ConsensusState.NextValidatorsHash = "0x" + lightBlock.NextValidatorsHash.String()
+
+			bz, err := json.MarshalIndent(&bootstrap, "", "  ")
+			if err != nil {
+				return err
+			}
+			if err := os.WriteFile(args[0], append(bz, '\n'), 0o644); err != nil {
+				return err
+			}
+			fmt.Fprintf(cmd.ErrOrStderr(), "exported the bootstrap bundle at height %d, hash %s\n", height, bootstrap.Hash)
+			return nil
+		},
+	}
+	cmd.Flags().String(flags.FlagNode, "tcp://localhost:26657", "<host>:<port> to CometBFT RPC interface for this chain")
+	cmd.Flags().Int64(flagHeight, 0, "Height of the bundle, the latest if zero")
+	cmd.Flags().Duration(flagTrustingPeriod, 168*time.Hour, "Trusting period of the EVM client state")
+	cmd.Flags().Duration(flagMaxClockDrift, 10*time.Second, "Maximum clock drift of the EVM client state")
+	cmd.Flags().Duration(flagUnbondingPeriod, verifier.DefaultUnbondingPeriod, "Unbonding period of the chain, the trusting period must be shorter")
+	cmd.Flags().Int64(flagMaxResponseBytes, transport.DefaultMaxDecodedBytes, "Maximum size of a node response once decompressed")
+	cmd.Flags().String(flagLegacyTransition, "", legacyTransitionUsage)
+	return cmd
+}
@@ -0,0 +1,86 @@
+package cmd
+
+import (
+	"context"
+	"crypto/ed25519"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	cmttypes "github.com/cometbft/cometbft/types"
+	"github.com/spf13/cobra"
+
+	"union/verifier/checkpoint"
+)
+
+const (
+	flagCheckpointAnchor   = "checkpoint-anchor"
+	flagCheckpointKeyFile  = "checkpoint-key-file"
+	flagCheckpointInterval = "checkpoint-interval"
+)
+
+// checkpointPublisher publishes the latest trusted header of light follow to
+// the anchors, at most once per interval and per height.
+type checkpointPublisher struct {
+	anchor    checkpoint.Anchor
+	key       ed25519.PrivateKey
+	interval  time.Duration
+	published int64
+}
+
+// lightCheckpointPublisher returns the publisher configured with the flags,
+// nil if there is no anchor.
+func lightCheckpointPublisher(cmd *cobra.Command) (*checkpointPublisher, error) {
+	locations, err := cmd.Flags().GetStringSlice(flagCheckpointAnchor)
+	if err != nil {
+		return nil, err
+	}
+	if len(locations) == 0 {
+		return nil, nil
+	}
+	keyFile, err := cmd.Flags().GetString(flagCheckpointKeyFile)
+	if err != nil {
+		return nil, err
+	}
+	if keyFile == "" {
+		return nil, fmt.Errorf("--%s requires the signing key in --%s", flagCheckpointAnchor, flagCheckpointKeyFile)
+	}
+	key, err := readEd25519Key(keyFile)
+	if err != nil {
+		return nil, err
+	}
+	interval, err := cmd.Flags().GetDuration(flagCheckpointInterval)
+	if err != nil {
+		return nil, err
+	}
+	if interval <= 0 {
+		return nil, fmt.Errorf("--%s must be positive", flagCheckpointInterval)
+	}
+	var anchors checkpoint.Multi
+	httpClient := &http.Client{Timeout: 30 * time.Second}
+	for _, location := range locations {
+		if strings.HasPrefix(location, "http://") || strings.HasPrefix(location, "https://") {
+			anchors = append(anchors, checkpoint.HTTP{URL: location, Client: httpClient})
+		} else {
+			anchors = append(anchors, checkpoint.Dir(location))
+		}
+	}
+	return &checkpointPublisher{anchor: anchors, key: key, interval: interval}, nil
+}
+
+// publish signs and publishes the light block unless it already was, a
+// failure being retried at the next interval.
+func (p *checkpointPublisher) publish(cmd *cobra.Command, lightBlock *cmttypes.LightBlock) {
+	if lightBlock.Height <= p.published {
+		return
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+	signed := checkpoint.Sign(checkpoint.FromLightBlock(lightBlock), p.key)
+	if err := p.anchor.Publish(ctx, signed); err != nil {
+		fmt.Fprintf(cmd.ErrOrStderr(), "can't publish the checkpoint at height %d: %s\n", lightBlock.Height, err)
+		return
+	}
+	p.published = lightBlock.Height
+}
@@ -0,0 +1,66 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"path/filepath"
+	"time"
+
+	dbm "github.com/cometbft/cometbft-db"
+	lightdb "github.com/cometbft/cometbft/light/store/db"
+	"github.com/cosmos/cosmos-sdk/client/flags"
+	"github.com/spf13/cobra"
+
+	"union/verifier"
+)
+
+func LightCheckStoreCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "check-store [chain-id]",
+		Short: "Check the invariants of the light client trusted store",
+		Long: `Scan the trusted store of light follow for violations of the invariants the light client relies on: light blocks of another chain, malformed or not signed by their validator set, validator set hashes not matching the stored validator sets, consecutive heights not linked by the next validators hash, decreasing times, an expired latest light block and a size not matching the content.
+A JSON report is printed and the command exits with an error if any invariant is violated. The store can't be opened while light follow runs, use its --check-store-interval instead.`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			chainID := args[0]
+			trustingPeriod, err := cmd.Flags().GetDuration(flagTrustingPeriod)
+			if err != nil {
+				return err
+			}
+			dbDir, err := cmd.Flags().GetString(flagDBDir)
+			if err != nil {
+				return err
+			}
+			if dbDir == "" {
+				home, err := cmd.Flags().GetString(flags.FlagHome)
+				if err != nil {
+					return err
+				}
+				dbDir = filepath.Join(home, "data")
+			}
+
+			db, err := dbm.NewGoLevelDB(lightDBName, dbDir)
+			if err != nil {
+				return fmt.Errorf("can't open light client store: %w", err)
+			}
+			defer db.Close()
+			report, err := verifier.CheckStore(lightdb.New(db, chainID), chainID, trustingPeriod, time.Now())
+			if err != nil {
+				return err
+			}
+			reportJson, err := json.MarshalIndent(report, "", "  ")
+			if err != nil {
+				return err
+			}
+			fmt.Fprintln(cmd.OutOrStdout(), string(reportJson))
+			if len(report.Violations) > 0 {
+				cmd.SilenceUsage = true
+				return fmt.Errorf("%d invariant violations in the trusted store", len(report.Violations))
+			}
+			return nil
+		},
+	}
+	cmd.Flags().Duration(flagTrustingPeriod, 168*time.Hour, "Period during which a trusted header can be used to verify new headers")
+	cmd.Flags().String(flagDBDir, "", "Directory of the light client store, defaults to <home>/data")
+	return cmd
+}
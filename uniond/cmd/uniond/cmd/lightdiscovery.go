@@ -0,0 +1,130 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"crypto/ed25519"
+	"encoding/hex"
+	"fmt"
+	"net"
+	"os"
+	"slices"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"union/verifier/discovery"
+)
+
+const (
+	flagDiscover     = "discover"
+	flagDiscoverKeys = "discover-keys"
+	flagKeyFile      = "key-file"
+	flagExpiresIn    = "expires-in"
+)
+
+func LightProviderRecordCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "provider-record [chain-id] [url]",
+		Short: "Sign a DNS TXT record announcing an RPC provider of a chain",
+		Long: `Print a TXT record announcing an RPC provider of a chain, signed with the ed25519 publisher key of --key-file (its hex encoded 32 bytes seed), and the hex encoded public key to give to light follow --discover-keys.
+Publish the record under the name given to light follow --discover, records expire after --expires-in and must be signed again.`,
+		Args: cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			keyFile, err := cmd.Flags().GetString(flagKeyFile)
+			if err != nil {
+				return err
+			}
+			if keyFile == "" {
+				return fmt.Errorf("--%s is required", flagKeyFile)
+			}
+			expiresIn, err := cmd.Flags().GetDuration(flagExpiresIn)
+			if err != nil {
+				return err
+			}
+			if expiresIn <= 0 {
+				return fmt.Errorf("--%s must be positive", flagExpiresIn)
+			}
+			key, err := readEd25519Key(keyFile)
+			if err != nil {
+				return err
+			}
+
+			record := discovery.Record{ChainID: args[0], URL: args[1], Expires: time.Now().Add(expiresIn)}
+			txt, err := record.Sign(key)
+			if err != nil {
+				return err
+			}
+			fmt.Fprintln(cmd.OutOrStdout(), txt)
+			fmt.Fprintf(cmd.ErrOrStderr(), "publisher key: %X\n", key.Public())
+			return nil
+		},
+	}
+	cmd.Flags().String(flagKeyFile, "", "File holding the hex encoded ed25519 seed of the publisher")
+	cmd.Flags().Duration(flagExpiresIn, 30*24*time.Hour, "Duration after which the record expires")
+	return cmd
+}
+
+// lightDiscoveredWitnesses adds the providers announced under the
+// --discover name to the witnesses, if any.
+func lightDiscoveredWitnesses(cmd *cobra.Command, chainID, primary string, witnesses []string) ([]string, error) {
+	name, err := cmd.Flags().GetString(flagDiscover)
+	if err != nil {
+		return nil, err
+	}
+	if name == "" {
+		return witnesses, nil
+	}
+	rawKeys, err := cmd.Flags().GetStringSlice(flagDiscoverKeys)
+	if err != nil {
+		return nil, err
+	}
+	if len(rawKeys) == 0 {
+		return nil, fmt.Errorf("--%s requires the publisher keys in --%s", flagDiscover, flagDiscoverKeys)
+	}
+	keys, err := parseEd25519PublicKeys(flagDiscoverKeys, rawKeys)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx, cancel := context.WithTimeout(cmd.Context(), 10*time.Second)
+	defer cancel()
+	urls, err := discovery.Discover(ctx, net.DefaultResolver, name, chainID, keys, time.Now())
+	if err != nil {
+		return nil, err
+	}
+	for _, url := range urls {
+		if url != primary && !slices.Contains(witnesses, url) {
+			witnesses = append(witnesses, url)
+		}
+	}
+	return witnesses, nil
+}
+
+// readEd25519Key reads an ed25519 private key from a file holding its hex
+// encoded seed.
+func readEd25519Key(path string) (ed25519.PrivateKey, error) {
+	bz, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	seed, err := hex.DecodeString(string(bytes.TrimSpace(bz)))
+	if err != nil || len(seed) != ed25519.SeedSize {
+		return nil, fmt.Errorf("%s must contain a hex encoded %d bytes ed25519 seed", path, ed25519.SeedSize)
+	}
+	return ed25519.NewKeyFromSeed(seed), nil
+}
+
+// parseEd25519PublicKeys parses the hex encoded ed25519 public keys of a
+// flag.
+func parseEd25519PublicKeys(flag string, rawKeys []string) ([]ed25519.PublicKey, error) {
+	keys := make([]ed25519.PublicKey, 0, len(rawKeys))
+	for _, rawKey := range rawKeys {
+		key, err := hex.DecodeString(rawKey)
+		if err != nil || len(key) != ed25519.PublicKeySize {
+			return nil, fmt.Errorf("invalid --%s %q, expected a hex encoded ed25519 public key", flag, rawKey)
+		}
+		keys = append(keys, key)
+	}
+	return keys, nil
+}
@@ -0,0 +1,806 @@
+package cmd
+
+import (
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"strings"
+	"syscall"
+	"time"
+
+	dbm "github.com/cometbft/cometbft-db"
+	cmtlog "github.com/cometbft/cometbft/libs/log"
+	cmtmath "github.com/cometbft/cometbft/libs/math"
+	"github.com/cometbft/cometbft/light"
+	"github.com/cometbft/cometbft/light/provider"
+	lightdb "github.com/cometbft/cometbft/light/store/db"
+	cmttypes "github.com/cometbft/cometbft/types"
+	"github.com/cosmos/cosmos-sdk/client/flags"
+	"github.com/spf13/cobra"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+
+	"union/app/archive"
+	"union/app/valsetcache"
+	"union/verifier"
+	"union/verifier/alert"
+	"union/verifier/leader"
+	"union/verifier/replay"
+	"union/verifier/reputation"
+	"union/verifier/transport"
+)
+
+const (
+	flagPrimary       = "primary"
+	flagWitnesses     = "witnesses"
+	flagTrustedHeight = "trusted-height"
+	flagTrustedHash   = "trusted-hash"
+	flagSequential    = "sequential"
+	flagAdaptive      = "adaptive"
+	flagInterval      = "interval"
+	flagDBDir         = "db-dir"
+
+	flagAlertWebhook         = "alert-webhook"
+	flagAlertSlackWebhook    = "alert-slack-webhook"
+	flagAlertPagerDutyKey    = "alert-pagerduty-routing-key"
+	flagAlertExpiryThreshold = "alert-expiry-threshold"
+	flagCheckStoreInterval   = "check-store-interval"
+	flagMinProviderScore     = "min-provider-score"
+	flagMaxResponseBytes     = "max-response-bytes"
+	flagDeepValidation       = "deep-validation"
+	flagValsetCache          = "valset-cache"
+	flagArchiveProviders     = "archive-providers"
+	flagCatchUpSpan          = "catch-up-span"
+	flagLeaderLock           = "leader-lock"
+	flagExportState          = "export-state"
+	flagFinalityDepth        = "finality-depth"
+	flagPrefetch             = "prefetch"
+	flagRecordProviders      = "record-providers"
+	flagReplayProviders      = "replay-providers"
+
+	lightDBName = "light-client-db"
+)
+
+// A newly trusted height, printed as a single JSON line.
+type lightFollowEvent struct {
+	Height         int64     `json:"height"`
+	Revision       uint64    `json:"revision"`
+	Hash           string    `json:"hash"`
+	Time           time.Time `json:"time"`
+	ValidatorsHash string    `json:"validators_hash"`
+}
+
+func LightFollowCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "follow [chain-id]",
+		Short: "Follow a chain, verifying every new header with the light client",
+		Long: `Run the light client against a primary RPC endpoint, cross-checking it with the witnesses, and print every newly trusted height as a JSON line.
+The trusted state is persisted under --db-dir, the first run must be given a root of trust with --trusted-height and --trusted-hash, or a trusted state file with --trusted-state.
+Subsequent runs resume from the latest trusted light block in the store, or from the pivots of an interrupted bisection.
+Providers are scored from their latency, errors and divergences, the scores being persisted along with the trusted state: witnesses are ranked by score and, with --min-provider-score, bad endpoints are replaced or dropped.
+Responses are requested compressed with zstd or gzip and rejected past --max-response-bytes once decompressed.
+With --discover, the witnesses announced by the TXT records of a DNS name and signed by one of the --discover-keys are added to --witnesses.
+With --archive-providers, the heights the primary or a witness reports as pruned are fetched from archive nodes instead, failing with a height pruned error when none serves them.
+With --valset-cache, the validator sets are first looked up by hash in the validator set cache of a node, such as the one the light client runs alongside, and only fetched from the providers when it misses them.
+With --catch-up-span, a light client far behind catches up in steps of at most that many heights, each persisted to the store, bounding the light blocks held in memory.
+With --leader-lock, replicas sharing the store wait as warm standbys until they hold the lock on the given file, only the leader advancing the store.
+With --export-state, every newly trusted light block is written to the given file as a trusted state, which light replica follows.
+With --record-providers, every request of the providers is recorded to the given file, which --replay-providers replays deterministically, timing included, to reproduce an incident.
+With --finality-depth, only the headers at least that many heights behind the tip reported by the providers are trusted, for chains with a history of halts or rollbacks.
+With --prefetch, the heights following the one requested from the primary are fetched and checked concurrently ahead of the light client once it requests consecutive heights, as when verifying sequentially or catching up. It has no effect along --record-providers or --replay-providers, whose requests are recorded and replayed in order.
+With --adaptive, the validator set churn between the trusted and the latest heights is estimated before every update, and the headers are verified sequentially when it is too high for skipping to pay off, skipping otherwise.
+With --deep-validation, the block of every newly trusted header is fetched from the primary and its contents checked against the data, last commit and evidence hashes of the header.`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			chainID := args[0]
+
+			primary, err := cmd.Flags().GetString(flagPrimary)
+			if err != nil {
+				return err
+			}
+			witnesses, err := cmd.Flags().GetStringSlice(flagWitnesses)
+			if err != nil {
+				return err
+			}
+			witnesses, err = lightDiscoveredWitnesses(cmd, chainID, primary, witnesses)
+			if err != nil {
+				return err
+			}
+			if len(witnesses) == 0 {
+				return fmt.Errorf("at least one witness must be given with --%s or --%s", flagWitnesses, flagDiscover)
+			}
+			rawTrustedHeight, err := cmd.Flags().GetString(flagTrustedHeight)
+			if err != nil {
+				return err
+			}
+			var trustedHeight verifier.Height
+			if rawTrustedHeight != "" {
+				trustedHeight, err = verifier.ParseHeight(chainID, rawTrustedHeight)
+				if err != nil {
+					return fmt.Errorf("invalid --%s: %w", flagTrustedHeight, err)
+				}
+				// The light client can't verify across revisions, a root of
+				// trust of another revision can't be used for this chain id.
+				if revision := verifier.HeightOf(chainID, 0).Revision; trustedHeight.Revision != revision {
+					return fmt.Errorf("--%s %s belongs to revision %d, %s is revision %d", flagTrustedHeight, trustedHeight, trustedHeight.Revision, chainID, revision)
+				}
+			}
+			rawTrustedHash, err := cmd.Flags().GetString(flagTrustedHash)
+			if err != nil {
+				return err
+			}
+			trustedState, err := cmd.Flags().GetString(flagTrustedState)
+			if err != nil {
+				return err
+			}
+			trustingPeriod, err := cmd.Flags().GetDuration(flagTrustingPeriod)
+			if err != nil {
+				return err
+			}
+			maxClockDrift, err := cmd.Flags().GetDuration(flagMaxClockDrift)
+			if err != nil {
+				return err
+			}
+			unbondingPeriod, err := cmd.Flags().GetDuration(flagUnbondingPeriod)
+			if err != nil {
+				return err
+			}
+			if err := verifier.ValidateBounds(trustingPeriod, maxClockDrift, unbondingPeriod); err != nil {
+				return err
+			}
+			rawTrustLevel, err := cmd.Flags().GetString(flagTrustLevel)
+			if err != nil {
+				return err
+			}
+			trustLevel, err := cmtmath.ParseFraction(rawTrustLevel)
+			if err != nil {
+				return err
+			}
+			if trustLevel, err = verifier.ValidateTrustLevel(trustLevel); err != nil {
+				return err
+			}
+			sequential, err := cmd.Flags().GetBool(flagSequential)
+			if err != nil {
+				return err
+			}
+			adaptive, err := cmd.Flags().GetBool(flagAdaptive)
+			if err != nil {
+				return err
+			}
+			if sequential && adaptive {
+				return fmt.Errorf("--%s and --%s are mutually exclusive", flagSequential, flagAdaptive)
+			}
+			interval, err := cmd.Flags().GetDuration(flagInterval)
+			if err != nil {
+				return err
+			}
+			if interval <= 0 {
+				return fmt.Errorf("--%s must be positive", flagInterval)
+			}
+			dbDir, err := cmd.Flags().GetString(flagDBDir)
+			if err != nil {
+				return err
+			}
+			if dbDir == "" {
+				home, err := cmd.Flags().GetString(flags.FlagHome)
+				if err != nil {
+					return err
+				}
+				dbDir = filepath.Join(home, "data")
+			}
+
+			alerter, err := lightFollowAlerter(cmd)
+			if err != nil {
+				return err
+			}
+			expiryThreshold, err := cmd.Flags().GetDuration(flagAlertExpiryThreshold)
+			if err != nil {
+				return err
+			}
+			checkStoreInterval, err := cmd.Flags().GetDuration(flagCheckStoreInterval)
+			if err != nil {
+				return err
+			}
+			minProviderScore, err := cmd.Flags().GetFloat64(flagMinProviderScore)
+			if err != nil {
+				return err
+			}
+			maxResponseBytes, err := cmd.Flags().GetInt64(flagMaxResponseBytes)
+			if err != nil {
+				return err
+			}
+			if maxResponseBytes <= 0 {
+				return fmt.Errorf("--%s must be positive", flagMaxResponseBytes)
+			}
+			publisher, err := lightCheckpointPublisher(cmd)
+			if err != nil {
+				return err
+			}
+			deepValidation, err := cmd.Flags().GetBool(flagDeepValidation)
+			if err != nil {
+				return err
+			}
+			valsetCache, err := cmd.Flags().GetString(flagValsetCache)
+			if err != nil {
+				return err
+			}
+			catchUpSpan, err := cmd.Flags().GetInt64(flagCatchUpSpan)
+			if err != nil {
+				return err
+			}
+			if catchUpSpan < 0 {
+				return fmt.Errorf("--%s can't be negative", flagCatchUpSpan)
+			}
+			leaderLock, err := cmd.Flags().GetString(flagLeaderLock)
+			if err != nil {
+				return err
+			}
+			finalityDepth, err := cmd.Flags().GetInt64(flagFinalityDepth)
+			if err != nil {
+				return err
+			}
+			if finalityDepth < 0 {
+				return fmt.Errorf("--%s can't be negative", flagFinalityDepth)
+			}
+			prefetch, err := cmd.Flags().GetInt(flagPrefetch)
+			if err != nil {
+				return err
+			}
+			if prefetch < 0 {
+				return fmt.Errorf("--%s can't be negative", flagPrefetch)
+			}
+			exportState, err := cmd.Flags().GetString(flagExportState)
+			if err != nil {
+				return err
+			}
+			recordProviders, err := cmd.Flags().GetString(flagRecordProviders)
+			if err != nil {
+				return err
+			}
+			replayProviders, err := cmd.Flags().GetString(flagReplayProviders)
+			if err != nil {
+				return err
+			}
+			if recordProviders != "" && replayProviders != "" {
+				return fmt.Errorf("--%s and --%s are mutually exclusive", flagRecordProviders, flagReplayProviders)
+			}
+
+			ctx, cancel := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+			defer cancel()
+
+			// The store is only opened once leading the replicas, the other
+			// ones waiting with their flags checked. A lost leadership
+			// cancels the context, stopping the verification in flight.
+			var lease leader.Lease
+			stopped := func() error {
+				if lease == nil {
+					return nil
+				}
+				return lease.Err()
+			}
+			if leaderLock != "" {
+				fmt.Fprintf(cmd.ErrOrStderr(), "waiting for the leadership of %s\n", leaderLock)
+				lease, err = leader.FileLock{Path: leaderLock}.Acquire(ctx)
+				if errors.Is(err, context.Canceled) {
+					return nil
+				}
+				if err != nil {
+					return fmt.Errorf("can't acquire the leadership: %w", err)
+				}
+				defer lease.Release()
+				fmt.Fprintf(cmd.ErrOrStderr(), "acquired the leadership of %s\n", leaderLock)
+				go func() {
+					select {
+					case <-lease.Lost():
+						cancel()
+					case <-ctx.Done():
+					}
+				}()
+			}
+
+			db, err := dbm.NewGoLevelDB(lightDBName, dbDir)
+			if err != nil {
+				return fmt.Errorf("can't open light client store: %w", err)
+			}
+			defer db.Close()
+			store := lightdb.New(db, chainID)
+			if trustedState != "" {
+				if trustedHeight.Number > 0 {
+					return fmt.Errorf("--%s and --%s are exclusive", flagTrustedState, flagTrustedHeight)
+				}
+				statePeriod, err := importTrustedState(trustedState, chainID, store)
+				if err != nil {
+					return err
+				}
+				if !cmd.Flags().Changed(flagTrustingPeriod) {
+					trustingPeriod = statePeriod
+					if err := verifier.ValidateBounds(trustingPeriod, maxClockDrift, unbondingPeriod); err != nil {
+						return fmt.Errorf("trusting period of %s: %w", trustedState, err)
+					}
+				}
+			}
+
+			tracker, err := reputation.NewTracker(db, chainID)
+			if err != nil {
+				return err
+			}
+			defer flushProviderScores(cmd, tracker)
+			journal := verifier.NewPivotJournal(db, chainID)
+			selectedPrimary, witnesses := tracker.Select(primary, witnesses, minProviderScore)
+			if selectedPrimary != primary {
+				fmt.Fprintf(cmd.ErrOrStderr(), "primary %s scores under --%s, using %s instead\n", primary, flagMinProviderScore, selectedPrimary)
+				primary = selectedPrimary
+			}
+			var cache transport.ValidatorSetCache
+			if valsetCache != "" {
+				conn, err := grpc.Dial(valsetCache, grpc.WithTransportCredentials(insecure.NewCredentials()))
+				if err != nil {
+					return fmt.Errorf("invalid --%s: %w", flagValsetCache, err)
+				}
+				defer conn.Close()
+				cache = valsetcache.NewClient(conn)
+			}
+			archives, closeArchives, err := lightArchiveProviders(cmd, chainID)
+			if err != nil {
+				return err
+			}
+			defer closeArchives()
+			// The verifications run at the recorded times when replaying.
+			clock := time.Now
+			var session *replay.Session
+			if replayProviders != "" {
+				if session, err = replay.Open(replayProviders); err != nil {
+					return err
+				}
+				clock = session.Now
+			}
+			var recorder *replay.Recorder
+			if recordProviders != "" {
+				f, err := os.OpenFile(recordProviders, os.O_WRONLY|os.O_CREATE|os.O_APPEND, 0o644)
+				if err != nil {
+					return err
+				}
+				defer f.Close()
+				recorder = replay.NewRecorder(f)
+				defer func() {
+					if err := recorder.Err(); err != nil {
+						fmt.Fprintf(cmd.ErrOrStderr(), "can't record the providers to %s: %s\n", recordProviders, err)
+					}
+				}()
+			}
+			providers := make([]provider.Provider, 0, len(witnesses)+1)
+			// The primary without the journal and the scores, the churn
+			// estimates fetching light blocks off the verification.
+			var estimator provider.Provider
+			for _, address := range append([]string{primary}, witnesses...) {
+				address := address
+				var p provider.Provider
+				if session != nil {
+					p = session.Provider(chainID, address)
+				} else {
+					cached, err := transport.NewCachedProvider(chainID, address, &transport.Transport{
+						MaxDecodedBytes: maxResponseBytes,
+						Observe: func(wireBytes, decodedBytes int64) {
+							tracker.RecordTransfer(address, wireBytes, decodedBytes)
+						},
+					}, cache)
+					if err != nil {
+						return err
+					}
+					p = verifier.NewArchiveFallback(cached, archives...)
+					if recorder != nil {
+						p = recorder.Wrap(address, p)
+					} else if address == primary {
+						p = verifier.NewPrefetcher(p, prefetch)
+					}
+				}
+				p = verifier.NewFinalityDepth(p, finalityDepth)
+				if estimator == nil {
+					estimator = p
+				}
+				providers = append(providers, journal.Wrap(tracker.Wrap(address, p)))
+			}
+
+			verificationOptions := func(sequential bool) []light.Option {
+				options := []light.Option{
+					light.Logger(cmtlog.NewTMLogger(cmtlog.NewSyncWriter(cmd.ErrOrStderr()))),
+					light.MaxClockDrift(maxClockDrift),
+				}
+				if sequential {
+					return append(options, light.SequentialVerification())
+				}
+				return append(options, light.SkippingVerification(trustLevel))
+			}
+			options := verificationOptions(sequential)
+
+			var client *light.Client
+			if trustedHeight.Number > 0 {
+				trustedHash, err := hex.DecodeString(rawTrustedHash)
+				if err != nil {
+					return fmt.Errorf("invalid --%s: %w", flagTrustedHash, err)
+				}
+				client, err = light.NewClient(
+					ctx,
+					chainID,
+					light.TrustOptions{
+						Period: trustingPeriod,
+						Height: int64(trustedHeight.Number),
+						Hash:   trustedHash,
+					},
+					providers[0],
+					providers[1:],
+					store,
+					options...,
+				)
+				if err != nil {
+					return verifier.HeightPrunedError(int64(trustedHeight.Number), err)
+				}
+			} else {
+				if store.Size() == 0 {
+					return fmt.Errorf("no trusted state in %s, --%s and --%s or --%s are required", dbDir, flagTrustedHeight, flagTrustedHash, flagTrustedState)
+				}
+				client, err = light.NewClientFromTrustedStore(
+					chainID,
+					trustingPeriod,
+					providers[0],
+					providers[1:],
+					store,
+					options...,
+				)
+				if err != nil {
+					return err
+				}
+			}
+
+			lastTrustedHeight, err := client.LastTrustedHeight()
+			if err != nil {
+				return err
+			}
+			lastTrusted, err := client.TrustedLightBlock(lastTrustedHeight)
+			if err != nil {
+				return err
+			}
+			if err := printLightFollowEvent(cmd, lastTrusted); err != nil {
+				return err
+			}
+
+			sendAlert := func(a alert.Alert) {
+				if alerter == nil {
+					return
+				}
+				alertCtx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+				defer cancel()
+				if err := alerter.Alert(alertCtx, a); err != nil {
+					fmt.Fprintf(cmd.ErrOrStderr(), "can't deliver the %s alert: %s\n", a.Kind, err)
+				}
+			}
+			// Height for which the near expiry alert was sent, so that it is
+			// raised once per trusted header.
+			var expiryAlertedHeight int64
+
+			validateBlock := func(lightBlock *cmttypes.LightBlock) {}
+			if deepValidation {
+				rpcClient, err := transport.NewClient(primary, &transport.Transport{MaxDecodedBytes: maxResponseBytes})
+				if err != nil {
+					return err
+				}
+				validateBlock = func(lightBlock *cmttypes.LightBlock) {
+					res, err := rpcClient.Block(ctx, &lightBlock.Height)
+					if err != nil {
+						fmt.Fprintf(cmd.ErrOrStderr(), "can't fetch the block %d to validate: %s\n", lightBlock.Height, err)
+						return
+					}
+					if err := verifier.CheckBlock(lightBlock, res.Block); err != nil {
+						fmt.Fprintf(cmd.ErrOrStderr(), "%s\n", err)
+						sendAlert(alert.Alert{
+							Kind:    alert.KindBlockMismatch,
+							ChainID: chainID,
+							Height:  lightBlock.Height,
+							Message: err.Error(),
+							Time:    clock(),
+						})
+					}
+				}
+			}
+			validateBlock(lastTrusted)
+
+			// A failed export is retried with the next trusted light block.
+			exportTrustedState := func(lightBlock *cmttypes.LightBlock) {
+				if exportState == "" {
+					return
+				}
+				state := verifier.TrustedState{LightBlock: lightBlock, TrustingPeriod: trustingPeriod}
+				if err := verifier.WriteTrustedState(exportState, &state); err != nil {
+					fmt.Fprintf(cmd.ErrOrStderr(), "can't export the trusted state at height %d: %s\n", lightBlock.Height, err)
+				}
+			}
+			exportTrustedState(lastTrusted)
+
+			// Never ticks unless the store is checked periodically.
+			var checkStore <-chan time.Time
+			if checkStoreInterval > 0 {
+				checkStoreTicker := time.NewTicker(checkStoreInterval)
+				defer checkStoreTicker.Stop()
+				checkStore = checkStoreTicker.C
+			}
+
+			// Never ticks unless checkpoints are published.
+			var publishCheckpoint <-chan time.Time
+			if publisher != nil {
+				publisher.publish(cmd, lastTrusted)
+				checkpointTicker := time.NewTicker(publisher.interval)
+				defer checkpointTicker.Stop()
+				publishCheckpoint = checkpointTicker.C
+			}
+
+			ticker := time.NewTicker(interval)
+			defer ticker.Stop()
+			for {
+				select {
+				case <-ctx.Done():
+					return stopped()
+				case <-publishCheckpoint:
+					publisher.publish(cmd, lastTrusted)
+				case <-checkStore:
+					now := clock()
+					report, err := verifier.CheckStore(store, chainID, trustingPeriod, now)
+					if err != nil {
+						return fmt.Errorf("failed to check the trusted store: %w", err)
+					}
+					for _, violation := range report.Violations {
+						violationJson, err := json.Marshal(&violation)
+						if err != nil {
+							return err
+						}
+						fmt.Fprintf(cmd.ErrOrStderr(), "trusted store invariant violated: %s\n", violationJson)
+						sendAlert(alert.Alert{
+							Kind:    alert.KindStoreInvariant,
+							ChainID: chainID,
+							Height:  violation.Height,
+							Message: string(violationJson),
+							Time:    now,
+						})
+					}
+				case <-ticker.C:
+					now := clock()
+					if adaptive {
+						// The light client verifies in a single mode, switching
+						// reloads it from the store it shares with the previous
+						// one.
+						estimate, err := lightEstimateSkipping(ctx, estimator, lastTrusted.Height, trustLevel)
+						if err != nil {
+							fmt.Fprintf(cmd.ErrOrStderr(), "can't estimate the validator set churn: %s\n", err)
+						} else if estimate != nil && estimate.PreferSequential() != sequential {
+							sequential = !sequential
+							if client, err = light.NewClientFromTrustedStore(chainID, trustingPeriod, providers[0], providers[1:], store, verificationOptions(sequential)...); err != nil {
+								return err
+							}
+							mode := "skipping"
+							if sequential {
+								mode = "sequential"
+							}
+							fmt.Fprintf(cmd.ErrOrStderr(), "switching to %s verification: %d heights verifiable at once from %d, %d behind the latest height\n", mode, estimate.Distance, estimate.TrustedHeight, estimate.LatestHeight-estimate.TrustedHeight)
+						}
+					}
+					_, err := verifier.CatchUp(ctx, client, catchUpSpan, journal, clock, func(lightBlock *cmttypes.LightBlock) error {
+						lastTrusted = lightBlock
+						if err := printLightFollowEvent(cmd, lightBlock); err != nil {
+							return err
+						}
+						validateBlock(lightBlock)
+						exportTrustedState(lightBlock)
+						return nil
+					})
+					flushProviderScores(cmd, tracker)
+					if err != nil {
+						if errors.Is(err, context.Canceled) {
+							return stopped()
+						}
+						sendAlert(alert.Alert{
+							Kind:    updateErrorKind(err),
+							ChainID: chainID,
+							Height:  lastTrusted.Height,
+							Message: err.Error(),
+							Time:    now,
+						})
+						if errors.As(err, &light.ErrOldHeaderExpired{}) {
+							return fmt.Errorf("failed to advance the light client: %w; the trusted state must be re-bootstrapped with light recover", err)
+						}
+						return fmt.Errorf("failed to advance the light client: %w", err)
+					}
+					expiresAt := lastTrusted.Time.Add(trustingPeriod)
+					if expiresAt.Sub(now) < expiryThreshold && expiryAlertedHeight != lastTrusted.Height {
+						expiryAlertedHeight = lastTrusted.Height
+						sendAlert(alert.Alert{
+							Kind:    alert.KindNearExpiry,
+							ChainID: chainID,
+							Height:  lastTrusted.Height,
+							Message: fmt.Sprintf("latest trusted header %d expires at %s", lastTrusted.Height, expiresAt.UTC().Format(time.RFC3339)),
+							Time:    now,
+						})
+					}
+					if session != nil && session.Exhausted() {
+						fmt.Fprintf(cmd.ErrOrStderr(), "replayed every request recorded in %s\n", replayProviders)
+						return nil
+					}
+				}
+			}
+		},
+	}
+	cmd.Flags().String(flagPrimary, "tcp://localhost:26657", "RPC address of the primary provider")
+	cmd.Flags().StringSlice(flagWitnesses, nil, "Comma separated RPC addresses of the witnesses, at least one is required")
+	cmd.Flags().String(flagDiscover, "", "DNS name whose signed TXT records announce additional witnesses")
+	cmd.Flags().StringSlice(flagDiscoverKeys, nil, "Comma separated hex encoded ed25519 keys of the publishers trusted to sign the --discover records")
+	cmd.Flags().String(flagTrustedHeight, "", "Height of the header to trust on first run, as {number} or {revision}-{number}")
+	cmd.Flags().String(flagTrustedHash, "", "Hex encoded hash of the header to trust on first run")
+	cmd.Flags().String(flagTrustedState, "", "Trusted state file, as written by light export-state, to trust on first run instead of --trusted-height and --trusted-hash")
+	cmd.Flags().Duration(flagTrustingPeriod, 168*time.Hour, "Period during which a trusted header can be used to verify new headers")
+	cmd.Flags().Duration(flagMaxClockDrift, 10*time.Second, "Maximum allowed drift between a new header time and now")
+	cmd.Flags().Duration(flagUnbondingPeriod, verifier.DefaultUnbondingPeriod, "Unbonding period of the chain, the trusting period must be shorter")
+	cmd.Flags().String(flagTrustLevel, light.DefaultTrustLevel.String(), "Fraction of the trusted validator set that must have signed a non adjacent header")
+	cmd.Flags().Bool(flagSequential, false, "Verify every intermediate header instead of skipping")
+	cmd.Flags().Bool(flagAdaptive, false, "Choose between sequential and skipping verification before every update from the validator set churn")
+	cmd.Flags().Duration(flagInterval, 5*time.Second, "Interval between two update attempts")
+	cmd.Flags().Int64(flagCatchUpSpan, 0, "Maximum number of heights verified at once when catching up, bounding the light blocks held in memory, unbounded if zero")
+	cmd.Flags().String(flagDBDir, "", "Directory of the light client store, defaults to <home>/data")
+	cmd.Flags().StringSlice(flagAlertWebhook, nil, "URLs the alerts are posted to as JSON")
+	cmd.Flags().String(flagAlertSlackWebhook, "", "Slack incoming webhook URL the alerts are posted to")
+	cmd.Flags().String(flagAlertPagerDutyKey, "", "PagerDuty Events API v2 routing key triggering incidents on alerts")
+	cmd.Flags().Duration(flagAlertExpiryThreshold, 24*time.Hour, "Alert when the latest trusted header expires in less than this duration")
+	cmd.Flags().Duration(flagCheckStoreInterval, 0, "Interval between two checks of the trusted store invariants, disabled if zero")
+	cmd.Flags().Float64(flagMinProviderScore, 0, "Replace a primary and drop witnesses scoring under this value (between 0 and 1), disabled if zero")
+	cmd.Flags().Int64(flagMaxResponseBytes, transport.DefaultMaxDecodedBytes, "Maximum size of a provider response once decompressed")
+	cmd.Flags().StringSlice(flagCheckpointAnchor, nil, "Directories or http(s) URLs the latest trusted header is published to as a signed checkpoint")
+	cmd.Flags().String(flagCheckpointKeyFile, "", "File holding the hex encoded ed25519 seed signing the checkpoints")
+	cmd.Flags().Duration(flagCheckpointInterval, time.Hour, "Interval between two checkpoint publications")
+	cmd.Flags().StringSlice(flagArchiveProviders, nil, "Comma separated RPC addresses of archive nodes, or grpc:// addresses of nodes serving their header archive, serving the heights pruned by the providers")
+	cmd.Flags().String(flagValsetCache, "", "gRPC address of a node whose validator set cache is queried before fetching the validator sets from the providers")
+	cmd.Flags().String(flagExportState, "", "File every newly trusted light block is written to as a trusted state, e.g. for light replica")
+	cmd.Flags().String(flagRecordProviders, "", "File every request of the providers is appended to, along with its response and latency, for --replay-providers")
+	cmd.Flags().String(flagReplayProviders, "", "File recorded with --record-providers whose responses are served instead of contacting the providers, at the recorded times")
+	cmd.Flags().Int64(flagFinalityDepth, 0, "Number of heights the trusted headers must be behind the tip reported by the providers, trusting the tip if zero")
+	cmd.Flags().Int(flagPrefetch, 0, "Number of heights fetched from the primary ahead of the light client when it requests consecutive heights, disabled if zero")
+	cmd.Flags().String(flagLeaderLock, "", "File locked by the leader of the replicas sharing the store, the others waiting to take over, disabled if empty")
+	cmd.Flags().Bool(flagDeepValidation, false, "Fetch the block of every newly trusted header from the primary and check it against the hashes of the header")
+	return cmd
+}
+
+// lightArchiveProviders returns the providers of --archive-providers, RPC
+// endpoints of archive nodes or, with the grpc:// scheme, gRPC endpoints of
+// nodes serving their header archive, along with a function closing them.
+func lightArchiveProviders(cmd *cobra.Command, chainID string) ([]provider.Provider, func(), error) {
+	addresses, err := cmd.Flags().GetStringSlice(flagArchiveProviders)
+	if err != nil {
+		return nil, nil, err
+	}
+	var (
+		archives []provider.Provider
+		conns    []*grpc.ClientConn
+	)
+	closeAll := func() {
+		for _, conn := range conns {
+			conn.Close()
+		}
+	}
+	for _, address := range addresses {
+		if target, ok := strings.CutPrefix(address, "grpc://"); ok {
+			conn, err := grpc.Dial(target, grpc.WithTransportCredentials(insecure.NewCredentials()))
+			if err != nil {
+				closeAll()
+				return nil, nil, fmt.Errorf("invalid --%s %s: %w", flagArchiveProviders, address, err)
+			}
+			conns = append(conns, conn)
+			archives = append(archives, archive.NewProvider(chainID, address, conn))
+			continue
+		}
+		p, err := transport.NewProvider(chainID, address, &transport.Transport{})
+		if err != nil {
+			closeAll()
+			return nil, nil, fmt.Errorf("invalid --%s %s: %w", flagArchiveProviders, address, err)
+		}
+		archives = append(archives, p)
+	}
+	return archives, closeAll, nil
+}
+
+// lightEstimateSkipping estimates the skipping distance from a trusted height
+// to the latest height of a provider, from the validator sets of its light
+// blocks. It returns nil when the latest height is adjacent, which both modes
+// verify alike.
+func lightEstimateSkipping(ctx context.Context, p provider.Provider, trustedHeight int64, trustLevel cmtmath.Fraction) (*verifier.SkippingEstimate, error) {
+	latest, err := p.LightBlock(ctx, 0)
+	if err != nil {
+		return nil, err
+	}
+	if latest.Height <= trustedHeight+1 {
+		return nil, nil
+	}
+	valsets := func(ctx context.Context, height int64) (*cmttypes.ValidatorSet, error) {
+		if height == latest.Height {
+			return latest.ValidatorSet, nil
+		}
+		lightBlock, err := p.LightBlock(ctx, height)
+		if err != nil {
+			return nil, err
+		}
+		return lightBlock.ValidatorSet, nil
+	}
+	return verifier.EstimateSkippingDistance(ctx, valsets, trustedHeight, latest.Height, trustLevel)
+}
+
+// flushProviderScores persists the provider scores, failing to do so only
+// loses the latest records.
+func flushProviderScores(cmd *cobra.Command, tracker *reputation.Tracker) {
+	if err := tracker.Flush(); err != nil {
+		fmt.Fprintf(cmd.ErrOrStderr(), "can't persist the provider scores: %s\n", err)
+	}
+}
+
+// lightFollowAlerter returns the alerters configured with the flags, nil if
+// there is none.
+func lightFollowAlerter(cmd *cobra.Command) (alert.Alerter, error) {
+	webhooks, err := cmd.Flags().GetStringSlice(flagAlertWebhook)
+	if err != nil {
+		return nil, err
+	}
+	slackWebhook, err := cmd.Flags().GetString(flagAlertSlackWebhook)
+	if err != nil {
+		return nil, err
+	}
+	pagerDutyKey, err := cmd.Flags().GetString(flagAlertPagerDutyKey)
+	if err != nil {
+		return nil, err
+	}
+
+	var alerters alert.Multi
+	for _, url := range webhooks {
+		alerters = append(alerters, alert.Webhook{URL: url})
+	}
+	if slackWebhook != "" {
+		alerters = append(alerters, alert.Slack{WebhookURL: slackWebhook})
+	}
+	if pagerDutyKey != "" {
+		alerters = append(alerters, alert.PagerDuty{RoutingKey: pagerDutyKey})
+	}
+	if len(alerters) == 0 {
+		return nil, nil
+	}
+	return alerters, nil
+}
+
+// updateErrorKind classifies the error preventing the light client from
+// advancing.
+func updateErrorKind(err error) alert.Kind {
+	switch {
+	case errors.Is(err, light.ErrLightClientAttack):
+		return alert.KindWitnessDivergence
+	case errors.As(err, &light.ErrOldHeaderExpired{}):
+		return alert.KindExpired
+	case errors.As(err, &light.ErrVerificationFailed{}),
+		errors.As(err, &light.ErrNewValSetCantBeTrusted{}),
+		errors.As(err, &light.ErrInvalidHeader{}):
+		return alert.KindVerificationFailed
+	default:
+		return alert.KindProviderFailure
+	}
+}
+
+func printLightFollowEvent(cmd *cobra.Command, lightBlock *cmttypes.LightBlock) error {
+	eventJson, err := json.Marshal(&lightFollowEvent{
+		Height:         lightBlock.Height,
+		Revision:       verifier.HeightOf(lightBlock.ChainID, lightBlock.Height).Revision,
+		Hash:           lightBlock.Hash().String(),
+		Time:           lightBlock.Time,
+		ValidatorsHash: lightBlock.ValidatorsHash.String(),
+	})
+	if err != nil {
+		return err
+	}
+	fmt.Fprintln(cmd.OutOrStdout(), string(eventJson))
+	return nil
+}
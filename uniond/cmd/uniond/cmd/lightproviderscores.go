@@ -0,0 +1,71 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"path/filepath"
+
+	dbm "github.com/cometbft/cometbft-db"
+	"github.com/cosmos/cosmos-sdk/client/flags"
+	"github.com/spf13/cobra"
+
+	"union/verifier"
+	"union/verifier/reputation"
+)
+
+const (
+	flagRedact        = "redact"
+	flagRedactKeyFile = "redact-key-file"
+)
+
+func LightProviderScoresCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "provider-scores [chain-id]",
+		Short: "Print the scores of the providers used by light follow",
+		Long: `Print, best first, the scores light follow persisted for its providers: requests, errors, divergences in which a provider served the conflicting light block, average latency and the resulting value between 0 and 1.
+With --redact, the provider addresses are hashed or dropped so that the scores can be shared without disclosing the infrastructure of the operator.
+The store can't be opened while light follow runs.`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			dbDir, err := cmd.Flags().GetString(flagDBDir)
+			if err != nil {
+				return err
+			}
+			if dbDir == "" {
+				home, err := cmd.Flags().GetString(flags.FlagHome)
+				if err != nil {
+					return err
+				}
+				dbDir = filepath.Join(home, "data")
+			}
+
+			db, err := dbm.NewGoLevelDB(lightDBName, dbDir)
+			if err != nil {
+				return fmt.Errorf("can't open light client store: %w", err)
+			}
+			defer db.Close()
+			tracker, err := reputation.NewTracker(db, args[0])
+			if err != nil {
+				return err
+			}
+			redactor, err := lightRedactor(cmd, flagRedact, flagRedactKeyFile)
+			if err != nil {
+				return err
+			}
+			status := tracker.Status()
+			for i := range status {
+				status[i].Address = redactor.Redact(status[i].Address)
+			}
+			statusJson, err := json.MarshalIndent(status, "", "  ")
+			if err != nil {
+				return err
+			}
+			fmt.Fprintln(cmd.OutOrStdout(), string(statusJson))
+			return nil
+		},
+	}
+	cmd.Flags().String(flagDBDir, "", "Directory of the light client store, defaults to <home>/data")
+	cmd.Flags().String(flagRedact, string(verifier.RedactionNone), "How the provider addresses are written: none, hash (keyed HMAC-SHA256) or drop")
+	cmd.Flags().String(flagRedactKeyFile, "", "File holding the hex encoded key of the hashes, random per run if unset")
+	return cmd
+}
@@ -0,0 +1,253 @@
+package cmd
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"strings"
+	"syscall"
+	"time"
+
+	dbm "github.com/cometbft/cometbft-db"
+	cmtlog "github.com/cometbft/cometbft/libs/log"
+	"github.com/cometbft/cometbft/light"
+	"github.com/cometbft/cometbft/light/provider"
+	lightdb "github.com/cometbft/cometbft/light/store/db"
+	"github.com/cosmos/cosmos-sdk/client/flags"
+	"github.com/spf13/cobra"
+
+	"union/verifier"
+	"union/verifier/checkpoint"
+	"union/verifier/transport"
+)
+
+const (
+	flagCheckpoint     = "checkpoint"
+	flagCheckpointKeys = "checkpoint-keys"
+)
+
+func LightRecoverCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "recover [chain-id]",
+		Short: "Re-bootstrap the light follow store once its trusted state expired",
+		Long: `Once the latest trusted header of the light follow store left the trusting period, no new header can be verified from it and the light client has to be re-bootstrapped from a new root of trust, trusted subjectively.
+The new root is either the signed checkpoint of --checkpoint (a file or an http(s) URL, as published by light follow --checkpoint-anchor) verified against the publisher keys of --checkpoint-keys, or the header of the primary at --height (the latest if zero), which the operator confirms after checking its hash out of band, e.g. with a block explorer or other operators.
+The header is cross-checked with the witnesses, then replaces the expired trusted state, and the lineage break is recorded in --audit-log.
+The store can't be opened while light follow runs.`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			chainID := args[0]
+			primary, err := cmd.Flags().GetString(flagPrimary)
+			if err != nil {
+				return err
+			}
+			witnesses, err := cmd.Flags().GetStringSlice(flagWitnesses)
+			if err != nil {
+				return err
+			}
+			if len(witnesses) == 0 {
+				return fmt.Errorf("at least one witness must be given with --%s", flagWitnesses)
+			}
+			height, err := cmd.Flags().GetInt64(flagHeight)
+			if err != nil {
+				return err
+			}
+			trustingPeriod, err := cmd.Flags().GetDuration(flagTrustingPeriod)
+			if err != nil {
+				return err
+			}
+			location, err := cmd.Flags().GetString(flagCheckpoint)
+			if err != nil {
+				return err
+			}
+			rawKeys, err := cmd.Flags().GetStringSlice(flagCheckpointKeys)
+			if err != nil {
+				return err
+			}
+			skipConfirmation, err := cmd.Flags().GetBool(flags.FlagSkipConfirmation)
+			if err != nil {
+				return err
+			}
+			auditLogPath, err := cmd.Flags().GetString(flagAuditLog)
+			if err != nil {
+				return err
+			}
+			dbDir, err := cmd.Flags().GetString(flagDBDir)
+			if err != nil {
+				return err
+			}
+			if dbDir == "" {
+				home, err := cmd.Flags().GetString(flags.FlagHome)
+				if err != nil {
+					return err
+				}
+				dbDir = filepath.Join(home, "data")
+			}
+
+			ctx, cancel := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+			defer cancel()
+
+			db, err := dbm.NewGoLevelDB(lightDBName, dbDir)
+			if err != nil {
+				return fmt.Errorf("can't open light client store: %w", err)
+			}
+			defer db.Close()
+			store := lightdb.New(db, chainID)
+			lastHeight, err := store.LastLightBlockHeight()
+			if err != nil {
+				return err
+			}
+			if lastHeight <= 0 {
+				return fmt.Errorf("no trusted state in %s, bootstrap light follow with --%s and --%s instead", dbDir, flagTrustedHeight, flagTrustedHash)
+			}
+			expired, err := store.LightBlock(lastHeight)
+			if err != nil {
+				return err
+			}
+			now := time.Now()
+			if expiresAt := expired.Time.Add(trustingPeriod); expiresAt.After(now) {
+				return fmt.Errorf("the trusted state at height %d doesn't expire before %s, there is nothing to recover", lastHeight, expiresAt.UTC().Format(time.RFC3339))
+			}
+
+			archives, closeArchives, err := lightArchiveProviders(cmd, chainID)
+			if err != nil {
+				return err
+			}
+			defer closeArchives()
+			providers := make([]provider.Provider, 0, len(witnesses)+1)
+			for _, address := range append([]string{primary}, witnesses...) {
+				p, err := transport.NewProvider(chainID, address, &transport.Transport{})
+				if err != nil {
+					return err
+				}
+				providers = append(providers, verifier.NewArchiveFallback(p, archives...))
+			}
+
+			var source string
+			var root checkpoint.Checkpoint
+			if location != "" {
+				if height != 0 {
+					return fmt.Errorf("--%s and --%s are exclusive", flagCheckpoint, flagHeight)
+				}
+				keys, err := parseEd25519PublicKeys(flagCheckpointKeys, rawKeys)
+				if err != nil {
+					return err
+				}
+				if len(keys) == 0 {
+					return fmt.Errorf("--%s requires the publisher keys in --%s", flagCheckpoint, flagCheckpointKeys)
+				}
+				signed, err := checkpoint.Fetch(ctx, &http.Client{Timeout: 30 * time.Second}, location, chainID, keys)
+				if err != nil {
+					return err
+				}
+				source, root = location, signed.Checkpoint
+			} else {
+				lightBlock, err := providers[0].LightBlock(ctx, height)
+				if err != nil {
+					return fmt.Errorf("can't fetch the light block of the primary: %w", verifier.HeightPrunedError(height, err))
+				}
+				source, root = "operator", checkpoint.FromLightBlock(lightBlock)
+			}
+			if root.Height <= expired.Height {
+				return fmt.Errorf("the new root of trust at height %d isn't after the expired one at height %d", root.Height, expired.Height)
+			}
+			if !root.Time.Add(trustingPeriod).After(now) {
+				return fmt.Errorf("the new root of trust at height %d is expired too", root.Height)
+			}
+
+			rootJson, err := json.MarshalIndent(&root, "", "  ")
+			if err != nil {
+				return err
+			}
+			fmt.Fprintf(cmd.ErrOrStderr(), "the trusted state at height %d expired, new root of trust from %s:\n%s\n", expired.Height, source, rootJson)
+			if source == "operator" && !skipConfirmation {
+				fmt.Fprint(cmd.ErrOrStderr(), "check the hash out of band, trust this header? [y/N] ")
+				answer, err := bufio.NewReader(cmd.InOrStdin()).ReadString('\n')
+				if err != nil {
+					return fmt.Errorf("no confirmation: %w", err)
+				}
+				if answer = strings.ToLower(strings.TrimSpace(answer)); answer != "y" && answer != "yes" {
+					return fmt.Errorf("recovery aborted")
+				}
+			}
+
+			var auditLog *verifier.AuditLog
+			if auditLogPath != "" {
+				auditLog, err = verifier.NewAuditLog(auditLogPath, 100<<20, 10)
+				if err != nil {
+					return fmt.Errorf("can't open the audit log: %w", err)
+				}
+				defer auditLog.Close()
+			}
+
+			// The light client bootstraps from the new root in memory,
+			// cross-checking it with the witnesses, so that the expired
+			// trusted state is only replaced once the new one is trusted.
+			client, err := light.NewClient(
+				ctx,
+				chainID,
+				light.TrustOptions{Period: trustingPeriod, Height: root.Height, Hash: root.Hash},
+				providers[0],
+				providers[1:],
+				lightdb.New(dbm.NewMemDB(), chainID),
+				light.Logger(cmtlog.NewTMLogger(cmtlog.NewSyncWriter(cmd.ErrOrStderr()))),
+			)
+			if err != nil {
+				return fmt.Errorf("can't bootstrap from the new root of trust: %w", verifier.HeightPrunedError(root.Height, err))
+			}
+			recovered, err := client.TrustedLightBlock(root.Height)
+			if err != nil {
+				return err
+			}
+			if err := store.Prune(0); err != nil {
+				return err
+			}
+			if err := store.SaveLightBlock(recovered); err != nil {
+				return err
+			}
+
+			entry := &verifier.AuditEntry{
+				Time:                    time.Now().UTC(),
+				Method:                  "Recover",
+				ChainID:                 chainID,
+				TrustedHeight:           expired.Height,
+				TrustedHash:             expired.Hash(),
+				TrustedValidatorsHash:   expired.ValidatorsHash,
+				UntrustedHeight:         recovered.Height,
+				UntrustedHash:           recovered.Hash(),
+				UntrustedValidatorsHash: recovered.ValidatorsHash,
+				TrustingPeriod:          trustingPeriod.String(),
+				Now:                     now.UTC(),
+				LineageBreak:            true,
+				RecoverySource:          source,
+			}
+			if auditLog != nil {
+				if err := auditLog.Record(entry); err != nil {
+					return fmt.Errorf("recovered, but can't record the lineage break: %w", err)
+				}
+			}
+			entryJson, err := json.Marshal(entry)
+			if err != nil {
+				return err
+			}
+			fmt.Fprintln(cmd.OutOrStdout(), string(entryJson))
+			return nil
+		},
+	}
+	cmd.Flags().String(flagPrimary, "tcp://localhost:26657", "RPC address of the primary provider")
+	cmd.Flags().StringSlice(flagWitnesses, nil, "Comma separated RPC addresses of the witnesses, at least one is required")
+	cmd.Flags().Int64(flagHeight, 0, "Height of the header of the primary to trust, the latest if zero")
+	cmd.Flags().String(flagCheckpoint, "", "File or http(s) URL of a signed checkpoint to trust instead of a header confirmed by the operator")
+	cmd.Flags().StringSlice(flagCheckpointKeys, nil, "Comma separated hex encoded ed25519 keys of the publishers trusted to sign the checkpoint")
+	cmd.Flags().Duration(flagTrustingPeriod, 168*time.Hour, "Period during which a trusted header can be used to verify new headers")
+	cmd.Flags().Bool(flags.FlagSkipConfirmation, false, "Trust the header of the primary without asking for confirmation")
+	cmd.Flags().String(flagAuditLog, "", "JSON lines audit log the lineage break is appended to")
+	cmd.Flags().StringSlice(flagArchiveProviders, nil, "Comma separated RPC addresses of archive nodes, or grpc:// addresses of nodes serving their header archive, serving the heights pruned by the providers")
+	cmd.Flags().String(flagDBDir, "", "Directory of the light client store, defaults to <home>/data")
+	return cmd
+}
@@ -0,0 +1,46 @@
+package cmd
+
+import (
+	"encoding/hex"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"union/verifier"
+)
+
+// lightRedactor builds the redactor of an export from its mode and key file
+// flags, nil if nothing is redacted.
+func lightRedactor(cmd *cobra.Command, modeFlag, keyFileFlag string) (*verifier.Redactor, error) {
+	modeStr, err := cmd.Flags().GetString(modeFlag)
+	if err != nil {
+		return nil, err
+	}
+	mode, err := verifier.ParseRedaction(modeStr)
+	if err != nil {
+		return nil, fmt.Errorf("--%s: %w", modeFlag, err)
+	}
+	keyFile, err := cmd.Flags().GetString(keyFileFlag)
+	if err != nil {
+		return nil, err
+	}
+	if mode == verifier.RedactionNone {
+		return nil, nil
+	}
+	var key []byte
+	if keyFile != "" {
+		bz, err := os.ReadFile(keyFile)
+		if err != nil {
+			return nil, err
+		}
+		if key, err = hex.DecodeString(strings.TrimSpace(string(bz))); err != nil {
+			return nil, fmt.Errorf("%s: %w", keyFile, err)
+		}
+		if len(key) == 0 {
+			return nil, fmt.Errorf("%s: empty key", keyFile)
+		}
+	}
+	return verifier.NewRedactor(mode, key)
+}
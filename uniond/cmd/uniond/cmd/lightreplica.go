@@ -0,0 +1,176 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	storetypes "cosmossdk.io/store/types"
+	cmtlog "github.com/cometbft/cometbft/libs/log"
+	cmtmath "github.com/cometbft/cometbft/libs/math"
+	"github.com/cometbft/cometbft/light"
+	"github.com/cometbft/cometbft/light/provider"
+	lightproxy "github.com/cometbft/cometbft/light/proxy"
+	lightrpc "github.com/cometbft/cometbft/light/rpc"
+	rpcserver "github.com/cometbft/cometbft/rpc/jsonrpc/server"
+	cmttypes "github.com/cometbft/cometbft/types"
+	"github.com/spf13/cobra"
+
+	"union/verifier"
+	"union/verifier/transport"
+)
+
+const (
+	flagLaddr         = "laddr"
+	flagWatchInterval = "watch-interval"
+)
+
+func LightReplicaCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "replica [chain-id]",
+		Short: "Serve verified RPC queries from the trusted state written by light follow",
+		Long: `Run a read-only light client following the trusted state file written by light follow --export-state, reloaded every --watch-interval, and serve the CometBFT RPC on --laddr, verifying the results of the primary against it.
+The heights the trusted state doesn't cover are verified from it in memory, cross-checked with the witnesses, the file and the store of light follow never being written, so that any number of replicas scale a verifying RPC proxy out.
+Every newly loaded trusted state is printed as a JSON line.`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			chainID := args[0]
+
+			statePath, err := cmd.Flags().GetString(flagTrustedState)
+			if err != nil {
+				return err
+			}
+			if statePath == "" {
+				return fmt.Errorf("--%s is required", flagTrustedState)
+			}
+			primary, err := cmd.Flags().GetString(flagPrimary)
+			if err != nil {
+				return err
+			}
+			witnesses, err := cmd.Flags().GetStringSlice(flagWitnesses)
+			if err != nil {
+				return err
+			}
+			if len(witnesses) == 0 {
+				return fmt.Errorf("at least one witness must be given with --%s", flagWitnesses)
+			}
+			laddr, err := cmd.Flags().GetString(flagLaddr)
+			if err != nil {
+				return err
+			}
+			watchInterval, err := cmd.Flags().GetDuration(flagWatchInterval)
+			if err != nil {
+				return err
+			}
+			if watchInterval <= 0 {
+				return fmt.Errorf("--%s must be positive", flagWatchInterval)
+			}
+			maxClockDrift, err := cmd.Flags().GetDuration(flagMaxClockDrift)
+			if err != nil {
+				return err
+			}
+			unbondingPeriod, err := cmd.Flags().GetDuration(flagUnbondingPeriod)
+			if err != nil {
+				return err
+			}
+			rawTrustLevel, err := cmd.Flags().GetString(flagTrustLevel)
+			if err != nil {
+				return err
+			}
+			trustLevel, err := cmtmath.ParseFraction(rawTrustLevel)
+			if err != nil {
+				return err
+			}
+			if trustLevel, err = verifier.ValidateTrustLevel(trustLevel); err != nil {
+				return err
+			}
+			maxResponseBytes, err := cmd.Flags().GetInt64(flagMaxResponseBytes)
+			if err != nil {
+				return err
+			}
+			if maxResponseBytes <= 0 {
+				return fmt.Errorf("--%s must be positive", flagMaxResponseBytes)
+			}
+
+			t := &transport.Transport{MaxDecodedBytes: maxResponseBytes}
+			providers := make([]provider.Provider, 0, len(witnesses)+1)
+			for _, address := range append([]string{primary}, witnesses...) {
+				p, err := transport.NewProvider(chainID, address, t)
+				if err != nil {
+					return err
+				}
+				providers = append(providers, p)
+			}
+			logger := cmtlog.NewTMLogger(cmtlog.NewSyncWriter(cmd.ErrOrStderr()))
+			replica, err := verifier.NewReplica(
+				statePath,
+				chainID,
+				providers[0],
+				providers[1:],
+				light.Logger(logger),
+				light.MaxClockDrift(maxClockDrift),
+				light.SkippingVerification(trustLevel),
+			)
+			if err != nil {
+				return err
+			}
+			state := replica.State()
+			if err := verifier.ValidateBounds(state.TrustingPeriod, maxClockDrift, unbondingPeriod); err != nil {
+				return fmt.Errorf("trusting period of %s: %w", statePath, err)
+			}
+			if err := printLightFollowEvent(cmd, state.LightBlock); err != nil {
+				return err
+			}
+
+			rpcClient, err := transport.NewClient(primary, t)
+			if err != nil {
+				return err
+			}
+			client := lightrpc.NewClient(rpcClient, replica, lightrpc.KeyPathFn(lightrpc.DefaultMerkleKeyPathFn()))
+			// The Cosmos SDK stores are proven with ICS-23 commitment proofs.
+			client.RegisterOpDecoder(storetypes.ProofOpIAVLCommitment, storetypes.CommitmentOpDecoder)
+			client.RegisterOpDecoder(storetypes.ProofOpSimpleMerkleCommitment, storetypes.CommitmentOpDecoder)
+			proxy := &lightproxy.Proxy{
+				Addr:   laddr,
+				Config: rpcserver.DefaultConfig(),
+				Client: client,
+				Logger: logger,
+			}
+
+			ctx, cancel := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+			defer cancel()
+			go replica.Watch(ctx, watchInterval, func(lightBlock *cmttypes.LightBlock, err error) {
+				if err != nil {
+					fmt.Fprintf(cmd.ErrOrStderr(), "can't reload the trusted state, keeping height %d: %s\n", replica.State().LightBlock.Height, err)
+					return
+				}
+				if err := printLightFollowEvent(cmd, lightBlock); err != nil {
+					fmt.Fprintf(cmd.ErrOrStderr(), "%s\n", err)
+				}
+			})
+			errc := make(chan error, 1)
+			go func() {
+				errc <- proxy.ListenAndServe()
+			}()
+			select {
+			case <-ctx.Done():
+				return nil
+			case err := <-errc:
+				return err
+			}
+		},
+	}
+	cmd.Flags().String(flagTrustedState, "", "Trusted state file written by light follow --export-state")
+	cmd.Flags().String(flagPrimary, "tcp://localhost:26657", "RPC address of the primary provider, whose results are verified")
+	cmd.Flags().StringSlice(flagWitnesses, nil, "Comma separated RPC addresses of the witnesses, at least one is required")
+	cmd.Flags().String(flagLaddr, "tcp://localhost:8888", "Address the verified RPC is served on")
+	cmd.Flags().Duration(flagWatchInterval, time.Second, "Interval between two checks of the trusted state file")
+	cmd.Flags().Duration(flagMaxClockDrift, 10*time.Second, "Maximum allowed drift between a new header time and now")
+	cmd.Flags().Duration(flagUnbondingPeriod, verifier.DefaultUnbondingPeriod, "Unbonding period of the chain, the trusting period of the state must be shorter")
+	cmd.Flags().String(flagTrustLevel, light.DefaultTrustLevel.String(), "Fraction of the trusted validator set that must have signed a non adjacent header")
+	cmd.Flags().Int64(flagMaxResponseBytes, transport.DefaultMaxDecodedBytes, "Maximum size of a provider response once decompressed")
+	return cmd
+}
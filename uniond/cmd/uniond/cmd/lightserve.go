@@ -0,0 +1,465 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net"
+	"net/http"
+	"os"
+	"os/signal"
+	"strings"
+	"syscall"
+	"time"
+
+	cmtmath "github.com/cometbft/cometbft/libs/math"
+	"github.com/cometbft/cometbft/light"
+	"github.com/cosmos/cosmos-sdk/client/flags"
+	"github.com/spf13/cobra"
+	"golang.org/x/sync/errgroup"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+
+	"union/verifier"
+)
+
+const (
+	flagGRPCAddress    = "grpc-address"
+	flagRESTAddress    = "rest-address"
+	flagAuthTokensFile = "auth-tokens-file"
+	flagRateLimit      = "rate-limit"
+	flagRateLimitBurst = "rate-limit-burst"
+	flagMaxBatchSize   = "max-batch-size"
+	flagMaxMessageSize = "max-message-size"
+	flagMaxSignatures  = "max-signatures"
+	flagMaxDecoded     = "max-decoded-bytes"
+	flagRequestTimeout = "request-timeout"
+	flagTLSCert        = "tls-cert"
+	flagTLSKey         = "tls-key"
+	flagAuditLog       = "audit-log"
+	flagAuditLogSize   = "audit-log-max-size"
+	flagAuditLogFiles  = "audit-log-max-files"
+	flagAuditLogRedact = "audit-log-redact"
+	flagAuditLogKey    = "audit-log-redact-key-file"
+	flagDenyProposers  = "proposer-denylist"
+	flagDenyAppHashes  = "app-hash-denylist"
+	flagPolicyWebhook  = "policy-webhook"
+	flagReportWebhooks = "report-webhook"
+	flagReportEvents   = "report-webhook-events"
+	flagDetectLegacy   = "detect-legacy"
+)
+
+func LightServeCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "serve",
+		Short: "Serve the light client verification over gRPC and REST",
+		Long: `Run a daemon exposing the light client header verification as the union.verifier.v1.Verifier gRPC service (Verify, VerifyNonAdjacent and VerifyBatch), so that it can be reused from any language.
+If --rest-address is given, the same verification is served as a JSON API under /v1, described by the OpenAPI specification served on /openapi.yaml.
+The verification flags are the defaults applied to the requests not overriding them.
+With --chain-id, light blocks of other chains are rejected, except for later revisions of a chain id in the {name}-{revision} format.
+Clients are authenticated with an "authorization: Bearer <token>" header if --auth-tokens-file is given, and rate limited per token, or per address when unauthenticated.`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			chainID, err := cmd.Flags().GetString(flags.FlagChainID)
+			if err != nil {
+				return err
+			}
+			address, err := cmd.Flags().GetString(flagGRPCAddress)
+			if err != nil {
+				return err
+			}
+			restAddress, err := cmd.Flags().GetString(flagRESTAddress)
+			if err != nil {
+				return err
+			}
+			tokensFile, err := cmd.Flags().GetString(flagAuthTokensFile)
+			if err != nil {
+				return err
+			}
+			rateLimit, err := cmd.Flags().GetFloat64(flagRateLimit)
+			if err != nil {
+				return err
+			}
+			burst, err := cmd.Flags().GetInt(flagRateLimitBurst)
+			if err != nil {
+				return err
+			}
+			maxBatchSize, err := cmd.Flags().GetInt(flagMaxBatchSize)
+			if err != nil {
+				return err
+			}
+			maxMessageSize, err := cmd.Flags().GetInt(flagMaxMessageSize)
+			if err != nil {
+				return err
+			}
+			maxSignatures, err := cmd.Flags().GetInt(flagMaxSignatures)
+			if err != nil {
+				return err
+			}
+			maxDecoded, err := cmd.Flags().GetInt(flagMaxDecoded)
+			if err != nil {
+				return err
+			}
+			requestTimeout, err := cmd.Flags().GetDuration(flagRequestTimeout)
+			if err != nil {
+				return err
+			}
+			tlsCert, err := cmd.Flags().GetString(flagTLSCert)
+			if err != nil {
+				return err
+			}
+			tlsKey, err := cmd.Flags().GetString(flagTLSKey)
+			if err != nil {
+				return err
+			}
+			trustingPeriod, err := cmd.Flags().GetDuration(flagTrustingPeriod)
+			if err != nil {
+				return err
+			}
+			maxClockDrift, err := cmd.Flags().GetDuration(flagMaxClockDrift)
+			if err != nil {
+				return err
+			}
+			unbondingPeriod, err := cmd.Flags().GetDuration(flagUnbondingPeriod)
+			if err != nil {
+				return err
+			}
+			rawTrustLevel, err := cmd.Flags().GetString(flagTrustLevel)
+			if err != nil {
+				return err
+			}
+			trustLevel, err := cmtmath.ParseFraction(rawTrustLevel)
+			if err != nil {
+				return err
+			}
+			if trustLevel, err = verifier.ValidateTrustLevel(trustLevel); err != nil {
+				return err
+			}
+
+			logger, err := verifierLogger(cmd)
+			if err != nil {
+				return err
+			}
+			auditLogPath, err := cmd.Flags().GetString(flagAuditLog)
+			if err != nil {
+				return err
+			}
+			auditLogSize, err := cmd.Flags().GetInt64(flagAuditLogSize)
+			if err != nil {
+				return err
+			}
+			auditLogFiles, err := cmd.Flags().GetInt(flagAuditLogFiles)
+			if err != nil {
+				return err
+			}
+			var auditLog *verifier.AuditLog
+			if auditLogPath != "" {
+				auditLog, err = verifier.NewAuditLog(auditLogPath, auditLogSize, auditLogFiles)
+				if err != nil {
+					return fmt.Errorf("can't open the audit log: %w", err)
+				}
+				defer auditLog.Close()
+				if auditLog.Redactor, err = lightRedactor(cmd, flagAuditLogRedact, flagAuditLogKey); err != nil {
+					return err
+				}
+			}
+
+			policies, err := lightServePolicies(cmd)
+			if err != nil {
+				return err
+			}
+			detectLegacy, err := cmd.Flags().GetBool(flagDetectLegacy)
+			if err != nil {
+				return err
+			}
+			transition, err := lightTransition(cmd)
+			if err != nil {
+				return err
+			}
+			checkProposer, err := cmd.Flags().GetBool(flagCheckProposer)
+			if err != nil {
+				return err
+			}
+			strict, err := lightStrict(cmd)
+			if err != nil {
+				return err
+			}
+			checkContinuity, err := cmd.Flags().GetBool(flagCheckContinuity)
+			if err != nil {
+				return err
+			}
+			reportWebhooks, err := lightServeReportWebhooks(cmd)
+			if err != nil {
+				return err
+			}
+			reportHooks := make([]verifier.ReportHook, len(reportWebhooks))
+			for i, webhook := range reportWebhooks {
+				reportHooks[i] = webhook
+			}
+
+			server, err := verifier.NewServer(verifier.Config{
+				ChainID:         chainID,
+				TrustingPeriod:  trustingPeriod,
+				MaxClockDrift:   maxClockDrift,
+				UnbondingPeriod: unbondingPeriod,
+				TrustLevel:      trustLevel,
+				MaxBatchSize:    maxBatchSize,
+				Logger:          logger,
+				AuditLog:        auditLog,
+				ReportHooks:     reportHooks,
+				Policies:        policies,
+				DetectLegacy:    detectLegacy,
+				Transition:      transition,
+				CheckProposer:   checkProposer,
+				Strict:          strict,
+				CheckContinuity: checkContinuity,
+				Budget: verifier.Budget{
+					MaxSignatures:   maxSignatures,
+					MaxDecodedBytes: maxDecoded,
+					MaxDuration:     requestTimeout,
+				},
+			})
+			if err != nil {
+				return err
+			}
+
+			var tokens []string
+			if tokensFile != "" {
+				tokens, err = readAuthTokens(tokensFile)
+				if err != nil {
+					return err
+				}
+			}
+			authenticator := verifier.NewAuthenticator(tokens)
+			rateLimiter := verifier.NewRateLimiter(rateLimit, burst)
+
+			interceptors := []grpc.UnaryServerInterceptor{authenticator.UnaryInterceptor()}
+			if rateLimit > 0 {
+				interceptors = append(interceptors, rateLimiter.UnaryInterceptor())
+			}
+			options := []grpc.ServerOption{
+				grpc.ChainUnaryInterceptor(interceptors...),
+				grpc.MaxRecvMsgSize(maxMessageSize),
+			}
+			useTLS := tlsCert != "" || tlsKey != ""
+			if useTLS {
+				creds, err := credentials.NewServerTLSFromFile(tlsCert, tlsKey)
+				if err != nil {
+					return fmt.Errorf("can't load the TLS certificate: %w", err)
+				}
+				options = append(options, grpc.Creds(creds))
+			}
+
+			grpcServer := grpc.NewServer(options...)
+			verifier.RegisterVerifierServer(grpcServer, server)
+
+			listener, err := net.Listen("tcp", address)
+			if err != nil {
+				return err
+			}
+
+			var restServer *http.Server
+			if restAddress != "" {
+				middlewares := []func(http.Handler) http.Handler{authenticator.Middleware}
+				if rateLimit > 0 {
+					middlewares = append(middlewares, rateLimiter.Middleware)
+				}
+				restServer = &http.Server{
+					Addr:              restAddress,
+					Handler:           verifier.NewRESTHandler(server, int64(maxMessageSize), middlewares...),
+					ReadHeaderTimeout: 10 * time.Second,
+				}
+			}
+
+			ctx, cancel := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+			defer cancel()
+
+			if len(tokens) == 0 {
+				fmt.Fprintln(cmd.ErrOrStderr(), "warning: authentication is disabled, every client is allowed")
+			}
+
+			g, ctx := errgroup.WithContext(ctx)
+			g.Go(func() error {
+				fmt.Fprintf(cmd.ErrOrStderr(), "serving the gRPC verifier on %s\n", listener.Addr())
+				return grpcServer.Serve(listener)
+			})
+			if restServer != nil {
+				g.Go(func() error {
+					fmt.Fprintf(cmd.ErrOrStderr(), "serving the REST verifier on %s\n", restServer.Addr)
+					var err error
+					if useTLS {
+						err = restServer.ListenAndServeTLS(tlsCert, tlsKey)
+					} else {
+						err = restServer.ListenAndServe()
+					}
+					if errors.Is(err, http.ErrServerClosed) {
+						return nil
+					}
+					return err
+				})
+			}
+			g.Go(func() error {
+				<-ctx.Done()
+				grpcServer.GracefulStop()
+				if restServer != nil {
+					return restServer.Shutdown(context.Background())
+				}
+				return nil
+			})
+			err = g.Wait()
+			// Deliver the queued reports before exiting, for a bounded time.
+			closeCtx, closeCancel := context.WithTimeout(context.Background(), 10*time.Second)
+			defer closeCancel()
+			for _, webhook := range reportWebhooks {
+				if closeErr := webhook.Close(closeCtx); closeErr != nil {
+					fmt.Fprintf(cmd.ErrOrStderr(), "dropping the undelivered reports of %s: %s\n", webhook.URL, closeErr)
+				}
+			}
+			return err
+		},
+	}
+	cmd.Flags().String(flags.FlagChainID, "", "Chain the verifier is bound to, light blocks of any chain are accepted if unset")
+	cmd.Flags().String(flagGRPCAddress, "localhost:9190", "Address the gRPC server listens on")
+	cmd.Flags().String(flagRESTAddress, "", "Address the REST server listens on, the REST API is disabled if unset")
+	cmd.Flags().String(flagAuthTokensFile, "", "File containing the accepted bearer tokens, one per line; authentication is disabled if unset")
+	cmd.Flags().Float64(flagRateLimit, 10, "Maximum number of requests per second and per client, 0 to disable")
+	cmd.Flags().Int(flagRateLimitBurst, 20, "Maximum burst of requests per client")
+	cmd.Flags().Int(flagMaxBatchSize, 100, "Maximum number of light blocks of a batch verification")
+	cmd.Flags().Int(flagMaxMessageSize, 16<<20, "Maximum size of a request in bytes")
+	cmd.Flags().Int(flagMaxSignatures, 20_000, "Maximum number of commit signatures verified by a request, 0 to disable")
+	cmd.Flags().Int(flagMaxDecoded, 0, "Maximum protobuf size in bytes of the light blocks of a request, 0 to only enforce --max-message-size")
+	cmd.Flags().Duration(flagRequestTimeout, 30*time.Second, "Maximum time spent on a request, 0 to disable")
+	cmd.Flags().String(flagTLSCert, "", "TLS certificate file, the server is plaintext if unset")
+	cmd.Flags().String(flagTLSKey, "", "TLS private key file")
+	cmd.Flags().String(flagAuditLog, "", "JSON lines file recording every verified transition, disabled if unset")
+	cmd.Flags().Int64(flagAuditLogSize, 100<<20, "Size in bytes after which the audit log is rotated")
+	cmd.Flags().Int(flagAuditLogFiles, 10, "Number of rotated audit logs kept")
+	cmd.Flags().String(flagAuditLogRedact, string(verifier.RedactionNone), "How the clients are written in the audit log: none, hash (keyed HMAC-SHA256) or drop")
+	cmd.Flags().String(flagAuditLogKey, "", "File holding the hex encoded key of the audit log hashes, random per process if unset")
+	cmd.Flags().String(flagDenyProposers, "", "File of hex encoded validator addresses, one per line, whose proposed headers are rejected")
+	cmd.Flags().String(flagDenyAppHashes, "", "File of hex encoded app hashes, one per line, whose headers are rejected")
+	cmd.Flags().Bool(flagCheckProposer, false, "Reject the headers whose proposer isn't elected by the proposer rotation")
+	cmd.Flags().Int32(flagMaxCommitRound, 0, "Reject the headers committed after that round, no bound if zero")
+	cmd.Flags().Bool(flagStrictParts, false, "Reject the headers whose block ids don't reference a complete part set")
+	cmd.Flags().Bool(flagCheckContinuity, false, "Reject the adjacent headers, e.g. of the batches, that don't build on the block committed by their trusted header")
+	cmd.Flags().Bool(flagDetectLegacy, false, "Verify every transition in the mode of the hashing scheme of its light blocks, ignoring the legacy option of the requests")
+	cmd.Flags().String(flagLegacyTransition, "", legacyTransitionUsage)
+	cmd.Flags().String(flagPolicyWebhook, "", "URL asked to accept every verified header, which is rejected unless it answers with a 2xx status")
+	cmd.Flags().StringSlice(flagReportWebhooks, nil, "Comma separated URLs the verification report of every verified header, accepted or rejected, is posted to as JSON")
+	cmd.Flags().String(flagReportEvents, string(verifier.ReportAll), "Reports posted to the report webhooks: all, accepted or rejected")
+	cmd.Flags().Duration(flagTrustingPeriod, 168*time.Hour, "Default period during which a trusted header can be used to verify new headers")
+	cmd.Flags().Duration(flagMaxClockDrift, 10*time.Second, "Default maximum allowed drift between a new header time and now")
+	cmd.Flags().Duration(flagUnbondingPeriod, verifier.DefaultUnbondingPeriod, "Unbonding period of the verified chains, the trusting periods must be shorter")
+	cmd.Flags().String(flagTrustLevel, light.DefaultTrustLevel.String(), "Default fraction of the trusted validator set that must have signed a non adjacent header")
+	return cmd
+}
+
+// verifierLogger logs the verifications to stderr following the --log_format
+// and --log_level flags.
+func verifierLogger(cmd *cobra.Command) (*slog.Logger, error) {
+	format, err := cmd.Flags().GetString(flags.FlagLogFormat)
+	if err != nil {
+		return nil, err
+	}
+	rawLevel, err := cmd.Flags().GetString(flags.FlagLogLevel)
+	if err != nil {
+		return nil, err
+	}
+	// slog has no trace level, debug is the closest.
+	if rawLevel == "trace" {
+		rawLevel = "debug"
+	}
+	var level slog.Level
+	if err := level.UnmarshalText([]byte(rawLevel)); err != nil {
+		return nil, fmt.Errorf("invalid --%s: %w", flags.FlagLogLevel, err)
+	}
+	options := &slog.HandlerOptions{Level: level}
+	if format == flags.OutputFormatJSON {
+		return slog.New(slog.NewJSONHandler(cmd.ErrOrStderr(), options)), nil
+	}
+	return slog.New(slog.NewTextHandler(cmd.ErrOrStderr(), options)), nil
+}
+
+func readAuthTokens(path string) ([]string, error) {
+	bz, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var tokens []string
+	for _, line := range strings.Split(string(bz), "\n") {
+		if token := strings.TrimSpace(line); token != "" && !strings.HasPrefix(token, "#") {
+			tokens = append(tokens, token)
+		}
+	}
+	if len(tokens) == 0 {
+		return nil, fmt.Errorf("%s: no token found", path)
+	}
+	return tokens, nil
+}
+
+// lightServePolicies builds the policies run on the verified headers from the
+// flags, denylists first so that the webhook only sees the headers they
+// accept.
+func lightServePolicies(cmd *cobra.Command) ([]verifier.Policy, error) {
+	var policies []verifier.Policy
+	proposersFile, err := cmd.Flags().GetString(flagDenyProposers)
+	if err != nil {
+		return nil, err
+	}
+	if proposersFile != "" {
+		proposers, err := verifier.ReadHexList(proposersFile)
+		if err != nil {
+			return nil, err
+		}
+		policies = append(policies, verifier.ProposerDenylist(proposers))
+	}
+	appHashesFile, err := cmd.Flags().GetString(flagDenyAppHashes)
+	if err != nil {
+		return nil, err
+	}
+	if appHashesFile != "" {
+		appHashes, err := verifier.ReadHexList(appHashesFile)
+		if err != nil {
+			return nil, err
+		}
+		policies = append(policies, verifier.AppHashDenylist(appHashes))
+	}
+	webhook, err := cmd.Flags().GetString(flagPolicyWebhook)
+	if err != nil {
+		return nil, err
+	}
+	if webhook != "" {
+		policies = append(policies, verifier.PolicyWebhook{URL: webhook, Client: &http.Client{Timeout: 10 * time.Second}})
+	}
+	return policies, nil
+}
+
+// reportWebhookQueue bounds the reports queued per report webhook, the
+// following ones being dropped until it catches up.
+const reportWebhookQueue = 1024
+
+// lightServeReportWebhooks starts the report webhooks given by the flags.
+func lightServeReportWebhooks(cmd *cobra.Command) ([]*verifier.ReportWebhook, error) {
+	urls, err := cmd.Flags().GetStringSlice(flagReportWebhooks)
+	if err != nil {
+		return nil, err
+	}
+	rawEvents, err := cmd.Flags().GetString(flagReportEvents)
+	if err != nil {
+		return nil, err
+	}
+	events, err := verifier.ParseReportEvents(rawEvents)
+	if err != nil {
+		return nil, err
+	}
+	webhooks := make([]*verifier.ReportWebhook, 0, len(urls))
+	for _, url := range urls {
+		webhook, err := verifier.NewReportWebhook(url, events, reportWebhookQueue)
+		if err != nil {
+			return nil, err
+		}
+		webhooks = append(webhooks, webhook)
+	}
+	return webhooks, nil
+}
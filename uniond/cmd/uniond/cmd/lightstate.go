@@ -0,0 +1,112 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	dbm "github.com/cometbft/cometbft-db"
+	lightstore "github.com/cometbft/cometbft/light/store"
+	lightdb "github.com/cometbft/cometbft/light/store/db"
+	"github.com/cosmos/cosmos-sdk/client/flags"
+	"github.com/spf13/cobra"
+
+	"union/verifier"
+)
+
+const (
+	flagHeight       = "height"
+	flagTrustedState = "trusted-state"
+)
+
+func LightExportStateCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "export-state [chain-id] [file]",
+		Short: "Export a trusted light block of light follow as a trusted state",
+		Long: `Write the latest (or --height) trusted light block of the light follow store to a file, along with the trusting period, in the versioned and checksummed trusted state encoding.
+The file can be handed to light follow --trusted-state or to any program decoding it with verifier.UnmarshalTrustedState, which trust it without verifying it again.
+The store can't be opened while light follow runs.`,
+		Args: cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			chainID := args[0]
+			height, err := cmd.Flags().GetInt64(flagHeight)
+			if err != nil {
+				return err
+			}
+			trustingPeriod, err := cmd.Flags().GetDuration(flagTrustingPeriod)
+			if err != nil {
+				return err
+			}
+			dbDir, err := cmd.Flags().GetString(flagDBDir)
+			if err != nil {
+				return err
+			}
+			if dbDir == "" {
+				home, err := cmd.Flags().GetString(flags.FlagHome)
+				if err != nil {
+					return err
+				}
+				dbDir = filepath.Join(home, "data")
+			}
+
+			db, err := dbm.NewGoLevelDB(lightDBName, dbDir)
+			if err != nil {
+				return fmt.Errorf("can't open light client store: %w", err)
+			}
+			defer db.Close()
+			store := lightdb.New(db, chainID)
+			if height == 0 {
+				if height, err = store.LastLightBlockHeight(); err != nil {
+					return err
+				}
+				if height <= 0 {
+					return fmt.Errorf("no trusted state in %s", dbDir)
+				}
+			}
+			lightBlock, err := store.LightBlock(height)
+			if err != nil {
+				return fmt.Errorf("light block %d: %w", height, err)
+			}
+			if expiresAt := lightBlock.Time.Add(trustingPeriod); !expiresAt.After(time.Now()) {
+				return fmt.Errorf("light block %d expired at %s", height, expiresAt.UTC().Format(time.RFC3339))
+			}
+			state := verifier.TrustedState{LightBlock: lightBlock, TrustingPeriod: trustingPeriod}
+			if err := verifier.WriteTrustedState(args[1], &state); err != nil {
+				return err
+			}
+			fmt.Fprintf(cmd.ErrOrStderr(), "exported the trusted state at height %d, hash %s\n", lightBlock.Height, lightBlock.Hash())
+			return nil
+		},
+	}
+	cmd.Flags().Int64(flagHeight, 0, "Height of the trusted light block to export, the latest if zero")
+	cmd.Flags().Duration(flagTrustingPeriod, 168*time.Hour, "Period during which the exported light block can be used to verify new headers")
+	cmd.Flags().String(flagDBDir, "", "Directory of the light client store, defaults to <home>/data")
+	return cmd
+}
+
+// importTrustedState seeds an empty store with the light block of a trusted
+// state file, returning the trusting period of the state.
+func importTrustedState(path, chainID string, store lightstore.Store) (time.Duration, error) {
+	bz, err := os.ReadFile(path)
+	if err != nil {
+		return 0, err
+	}
+	state, err := verifier.UnmarshalTrustedState(bz)
+	if err != nil {
+		return 0, fmt.Errorf("%s: %w", path, err)
+	}
+	if state.Legacy {
+		return 0, fmt.Errorf("%s: the light client doesn't support legacy light blocks", path)
+	}
+	if state.LightBlock.ChainID != chainID {
+		return 0, fmt.Errorf("%s: trusted state of chain %s, following %s", path, state.LightBlock.ChainID, chainID)
+	}
+	if store.Size() != 0 {
+		return 0, fmt.Errorf("can't import %s, the store already holds a trusted state", path)
+	}
+	if err := store.SaveLightBlock(state.LightBlock); err != nil {
+		return 0, err
+	}
+	return state.TrustingPeriod, nil
+}
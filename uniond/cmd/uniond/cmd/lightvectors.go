@@ -0,0 +1,115 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"union/verifier"
+)
+
+func LightVectorsCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "vectors",
+		Short: "Export and run cross implementation conformance vectors",
+	}
+	cmd.AddCommand(
+		LightVectorsExportCmd(),
+		LightVectorsRunCmd(),
+	)
+	return cmd
+}
+
+func LightVectorsExportCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "export [file]",
+		Short: "Generate the conformance vectors and write them as JSON",
+		Long: `Generate verification inputs (light blocks, options and verification time) along with the verdict of this verifier and write them as JSON, to be run by the other light client implementations.
+Light blocks are 0x prefixed hex protobuf tendermint.types.LightBlock, durations are in seconds. The vectors are derived from --seed, the same seed and validator count always produce the same file.`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			validators, err := cmd.Flags().GetInt(flagValidators)
+			if err != nil {
+				return err
+			}
+			seed, err := cmd.Flags().GetInt64(flagSeed)
+			if err != nil {
+				return err
+			}
+			vectors, err := verifier.GenerateVectors(validators, seed)
+			if err != nil {
+				return err
+			}
+			bz, err := json.MarshalIndent(vectors, "", "  ")
+			if err != nil {
+				return err
+			}
+			return os.WriteFile(args[0], append(bz, '\n'), 0o644)
+		},
+	}
+	cmd.Flags().Int(flagValidators, 4, "Number of validators of the generated chains")
+	cmd.Flags().Int64(flagSeed, 1, "Seed of the generated keys and voting powers")
+	return cmd
+}
+
+// The outcome of a conformance vector, printed as a JSON line.
+type lightVectorResult struct {
+	Name     string                 `json:"name"`
+	Passed   bool                   `json:"passed"`
+	Expected verifier.VectorVerdict `json:"expected"`
+	Actual   verifier.VectorVerdict `json:"actual"`
+	Error    string                 `json:"error,omitempty"`
+}
+
+func LightVectorsRunCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "run [file]",
+		Short: "Run conformance vectors against this verifier",
+		Long: `Run conformance vectors, exported by this or another light client implementation, and print one JSON line per vector.
+The command exits with an error if any verdict differs from the expected one.`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			bz, err := os.ReadFile(args[0])
+			if err != nil {
+				return err
+			}
+			var vectors verifier.Vectors
+			if err := json.Unmarshal(bz, &vectors); err != nil {
+				return fmt.Errorf("%s: %w", args[0], err)
+			}
+			if vectors.Version != verifier.VectorsVersion {
+				return fmt.Errorf("unsupported vectors version %d, expected %d", vectors.Version, verifier.VectorsVersion)
+			}
+			failed := 0
+			for i := range vectors.Vectors {
+				vector := &vectors.Vectors[i]
+				verdict, verifyErr := vector.Run()
+				res := lightVectorResult{
+					Name:     vector.Name,
+					Passed:   verdict == vector.Expected,
+					Expected: vector.Expected,
+					Actual:   verdict,
+				}
+				if verifyErr != nil {
+					res.Error = verifyErr.Error()
+				}
+				if !res.Passed {
+					failed++
+				}
+				resJson, err := json.Marshal(&res)
+				if err != nil {
+					return err
+				}
+				fmt.Fprintln(cmd.OutOrStdout(), string(resJson))
+			}
+			if failed > 0 {
+				cmd.SilenceUsage = true
+				return fmt.Errorf("%d out of %d vectors failed", failed, len(vectors.Vectors))
+			}
+			return nil
+		},
+	}
+	return cmd
+}
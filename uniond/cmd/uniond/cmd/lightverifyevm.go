@@ -0,0 +1,354 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math"
+	"net/http"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	cmtbytes "github.com/cometbft/cometbft/libs/bytes"
+	cmtmath "github.com/cometbft/cometbft/libs/math"
+	"github.com/cometbft/cometbft/light"
+	rpchttp "github.com/cometbft/cometbft/rpc/client/http"
+	cmttypes "github.com/cometbft/cometbft/types"
+	"github.com/cosmos/cosmos-sdk/client/flags"
+	"github.com/spf13/cobra"
+
+	"union/verifier"
+	"union/verifier/transport"
+)
+
+const flagEthRPC = "eth-rpc"
+
+var ethTxHash = regexp.MustCompile(`^0x[0-9a-fA-F]{64}$`)
+
+// The verdict on an update of the EVM cometbls client, printed as JSON.
+type evmUpdateVerdict struct {
+	Verified      bool      `json:"verified"`
+	TxHash        string    `json:"tx_hash"`
+	TxReverted    bool      `json:"tx_reverted"`
+	BlockNumber   uint64    `json:"block_number"`
+	BlockTime     time.Time `json:"block_time"`
+	ClientID      uint32    `json:"client_id"`
+	Relayer       string    `json:"relayer"`
+	ChainID       string    `json:"chain_id"`
+	Legacy        bool      `json:"legacy"`
+	TrustedHeight int64     `json:"trusted_height"`
+	Height        int64     `json:"height"`
+	Adjacent      bool      `json:"adjacent"`
+	// Every violated condition: the header of the calldata differing from the
+	// one of the chain ("calldata_*"), the ones of light verify --explain and
+	// the zero knowledge proof ("zkp").
+	Violations []verifier.Violation `json:"violations"`
+}
+
+func LightVerifyEVMUpdateCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "verify-evm-update [tx-hash]",
+		Short: "Verify an update of the EVM cometbls client by its transaction",
+		Long: `Fetch an updateClient transaction of the IBC handler from the EVM chain of --eth-rpc, decode the cometbls header of its calldata and re-verify the transition as the EVM client does, against the headers of the chain served by --node, printing a JSON verdict.
+The header of the calldata must be the one of the chain at its height, the transition from the trusted height must verify at the time of the EVM block (as light verify --explain), and the zero knowledge proof must prove it from the next validators hash of the trusted header.
+Every violated condition is listed and the command exits with an error if any, whether the transaction succeeded or reverted.`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if !ethTxHash.MatchString(args[0]) {
+				return fmt.Errorf("invalid transaction hash %q, expected 0x followed by 64 hex digits", args[0])
+			}
+			ethRPC, err := cmd.Flags().GetString(flagEthRPC)
+			if err != nil {
+				return err
+			}
+			if ethRPC == "" {
+				return fmt.Errorf("--%s is required", flagEthRPC)
+			}
+			node, err := cmd.Flags().GetString(flags.FlagNode)
+			if err != nil {
+				return err
+			}
+			trustingPeriod, err := cmd.Flags().GetDuration(flagTrustingPeriod)
+			if err != nil {
+				return err
+			}
+			maxClockDrift, err := cmd.Flags().GetDuration(flagMaxClockDrift)
+			if err != nil {
+				return err
+			}
+			unbondingPeriod, err := cmd.Flags().GetDuration(flagUnbondingPeriod)
+			if err != nil {
+				return err
+			}
+			if err := verifier.ValidateBounds(trustingPeriod, maxClockDrift, unbondingPeriod); err != nil {
+				return err
+			}
+			rawTrustLevel, err := cmd.Flags().GetString(flagTrustLevel)
+			if err != nil {
+				return err
+			}
+			trustLevel, err := cmtmath.ParseFraction(rawTrustLevel)
+			if err != nil {
+				return err
+			}
+			if trustLevel, err = verifier.ValidateTrustLevel(trustLevel); err != nil {
+				return err
+			}
+			maxResponseBytes, err := cmd.Flags().GetInt64(flagMaxResponseBytes)
+			if err != nil {
+				return err
+			}
+			transition, err := lightTransition(cmd)
+			if err != nil {
+				return err
+			}
+
+			eth := &ethClient{url: ethRPC, client: &http.Client{Timeout: 30 * time.Second}}
+			tx, err := eth.transaction(cmd.Context(), args[0])
+			if err != nil {
+				return err
+			}
+			update, err := verifier.DecodeEVMUpdateClient(tx.input)
+			if err != nil {
+				return fmt.Errorf("transaction %s: %w", args[0], err)
+			}
+			if update.TrustedHeight == 0 || update.TrustedHeight > uint64(math.MaxInt64) || update.Header.Height > uint64(math.MaxInt64) {
+				return fmt.Errorf("transaction %s: heights %d and %d out of range", args[0], update.TrustedHeight, update.Header.Height)
+			}
+
+			client, err := transport.NewClient(node, &transport.Transport{MaxDecodedBytes: maxResponseBytes})
+			if err != nil {
+				return err
+			}
+			trusted, trustedLegacy, err := fetchLightBlock(cmd.Context(), client, int64(update.TrustedHeight), transition)
+			if err != nil {
+				return err
+			}
+			untrusted, untrustedLegacy, err := fetchLightBlock(cmd.Context(), client, int64(update.Header.Height), transition)
+			if err != nil {
+				return err
+			}
+			legacy, err := verifier.LegacyMode(trustedLegacy, untrustedLegacy)
+			if err != nil {
+				return err
+			}
+
+			verdict := evmUpdateVerdict{
+				TxHash:        strings.ToLower(args[0]),
+				TxReverted:    tx.reverted,
+				BlockNumber:   tx.blockNumber,
+				BlockTime:     tx.blockTime.UTC(),
+				ClientID:      update.ClientID,
+				Relayer:       "0x" + hex.EncodeToString(update.Relayer[:]),
+				ChainID:       untrusted.ChainID,
+				Legacy:        legacy,
+				TrustedHeight: trusted.Height,
+				Height:        untrusted.Height,
+				Adjacent:      untrusted.Height == trusted.Height+1,
+				Violations:    []verifier.Violation{},
+			}
+			verdict.Violations = append(verdict.Violations, evmHeaderViolations(update.Header, untrusted)...)
+			verdict.Violations = append(verdict.Violations, verifier.Explain(trusted, untrusted, trustingPeriod, tx.blockTime, maxClockDrift, trustLevel, legacy)...)
+			var trustedValidatorsHash [32]byte
+			copy(trustedValidatorsHash[:], trusted.NextValidatorsHash)
+			if err := verifier.VerifyZKP(untrusted.ChainID, trustedValidatorsHash, update.Header, update.ZKP); err != nil {
+				verdict.Violations = append(verdict.Violations, verifier.Violation{Check: "zkp", Error: err.Error()})
+			}
+			verdict.Verified = len(verdict.Violations) == 0
+
+			bz, err := json.MarshalIndent(&verdict, "", "  ")
+			if err != nil {
+				return err
+			}
+			fmt.Fprintln(cmd.OutOrStdout(), string(bz))
+			if !verdict.Verified {
+				cmd.SilenceUsage = true
+				return fmt.Errorf("the update of transaction %s doesn't verify: %d violations", args[0], len(verdict.Violations))
+			}
+			return nil
+		},
+	}
+	cmd.Flags().String(flagEthRPC, "", "JSON-RPC endpoint of the EVM chain of the transaction")
+	cmd.Flags().String(flags.FlagNode, "tcp://localhost:26657", "<host>:<port> to CometBFT RPC interface for this chain")
+	cmd.Flags().Duration(flagTrustingPeriod, 168*time.Hour, "Trusting period of the EVM client state")
+	cmd.Flags().Duration(flagMaxClockDrift, 10*time.Second, "Maximum clock drift of the EVM client state")
+	cmd.Flags().Duration(flagUnbondingPeriod, verifier.DefaultUnbondingPeriod, "Unbonding period of the chain, the trusting period must be shorter")
+	cmd.Flags().String(flagTrustLevel, light.DefaultTrustLevel.String(), "Fraction of the trusted validator set that must have signed a non adjacent header")
+	cmd.Flags().Int64(flagMaxResponseBytes, transport.DefaultMaxDecodedBytes, "Maximum size of a node response once decompressed")
+	cmd.Flags().String(flagLegacyTransition, "", legacyTransitionUsage)
+	return cmd
+}
+
+// evmHeaderViolations compares the header of an update calldata to the one of
+// the chain at its height.
+func evmHeaderViolations(header verifier.ZKPHeader, lightBlock *cmttypes.LightBlock) []verifier.Violation {
+	var violations []verifier.Violation
+	secs, nanos := lightBlock.Time.Unix(), lightBlock.Time.Nanosecond()
+	if secs < 0 || header.Secs != uint64(secs) || header.Nanos != uint64(nanos) {
+		violations = append(violations, verifier.Violation{
+			Check:    "calldata_time",
+			Expected: fmt.Sprintf("%d.%09d", secs, nanos),
+			Actual:   fmt.Sprintf("%d.%09d", header.Secs, header.Nanos),
+		})
+	}
+	for _, field := range []struct {
+		check    string
+		expected cmtbytes.HexBytes
+		actual   [32]byte
+	}{
+		{"calldata_validators_hash", lightBlock.ValidatorsHash, header.ValidatorsHash},
+		{"calldata_next_validators_hash", lightBlock.NextValidatorsHash, header.NextValidatorsHash},
+		{"calldata_app_hash", lightBlock.AppHash, header.AppHash},
+	} {
+		if !bytes.Equal(field.expected, field.actual[:]) {
+			violations = append(violations, verifier.Violation{
+				Check:    field.check,
+				Expected: field.expected.String(),
+				Actual:   cmtbytes.HexBytes(field.actual[:]).String(),
+			})
+		}
+	}
+	return violations
+}
+
+// fetchLightBlock fetches the light block at a height, along with whether it
+// uses the legacy hashes, its commit being checked by the light client.
+func fetchLightBlock(ctx context.Context, client *rpchttp.HTTP, height int64, transition verifier.Transition) (*cmttypes.LightBlock, bool, error) {
+	commit, err := client.Commit(ctx, &height)
+	if err != nil {
+		return nil, false, fmt.Errorf("commit %d: %w", height, err)
+	}
+	_, valSet, err := verifier.FetchValidatorSet(ctx, client, &height)
+	if err != nil {
+		return nil, false, fmt.Errorf("validator set at height %d: %w", height, err)
+	}
+	lightBlock := &cmttypes.LightBlock{SignedHeader: &commit.SignedHeader, ValidatorSet: valSet}
+	legacy, err := verifier.DetectLegacy(lightBlock, transition)
+	if err != nil {
+		return nil, false, fmt.Errorf("light block %d: %w", height, err)
+	}
+	return lightBlock, legacy, nil
+}
+
+// ethClient is a minimal Ethereum JSON-RPC client, fetching the transactions
+// to verify.
+type ethClient struct {
+	url    string
+	client *http.Client
+}
+
+// An EVM transaction along with its outcome and the block it was included in.
+type ethTransaction struct {
+	input       []byte
+	reverted    bool
+	blockNumber uint64
+	blockTime   time.Time
+}
+
+func (c *ethClient) call(ctx context.Context, method string, result any, params ...any) error {
+	body, err := json.Marshal(map[string]any{"jsonrpc": "2.0", "id": 1, "method": method, "params": params})
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("%s: %w", method, err)
+	}
+	defer resp.Body.Close()
+	bz, err := io.ReadAll(io.LimitReader(resp.Body, 16<<20))
+	if err != nil {
+		return fmt.Errorf("%s: %w", method, err)
+	}
+	if resp.StatusCode/100 != 2 {
+		return fmt.Errorf("%s: unexpected status %s", method, resp.Status)
+	}
+	var response struct {
+		Result json.RawMessage `json:"result"`
+		Error  *struct {
+			Code    int    `json:"code"`
+			Message string `json:"message"`
+		} `json:"error"`
+	}
+	if err := json.Unmarshal(bz, &response); err != nil {
+		return fmt.Errorf("%s: %w", method, err)
+	}
+	if response.Error != nil {
+		return fmt.Errorf("%s: error %d: %s", method, response.Error.Code, response.Error.Message)
+	}
+	if err := json.Unmarshal(response.Result, result); err != nil {
+		return fmt.Errorf("%s: %w", method, err)
+	}
+	return nil
+}
+
+func parseQuantity(s string) (uint64, error) {
+	if !strings.HasPrefix(s, "0x") {
+		return 0, fmt.Errorf("invalid quantity %q", s)
+	}
+	return strconv.ParseUint(s[2:], 16, 64)
+}
+
+// transaction fetches a mined transaction, its receipt and the time of its
+// block.
+func (c *ethClient) transaction(ctx context.Context, hash string) (*ethTransaction, error) {
+	var tx *struct {
+		Input     string  `json:"input"`
+		BlockHash *string `json:"blockHash"`
+	}
+	if err := c.call(ctx, "eth_getTransactionByHash", &tx, hash); err != nil {
+		return nil, err
+	}
+	if tx == nil {
+		return nil, fmt.Errorf("transaction %s not found", hash)
+	}
+	if tx.BlockHash == nil {
+		return nil, fmt.Errorf("transaction %s is pending", hash)
+	}
+	input, err := hex.DecodeString(strings.TrimPrefix(tx.Input, "0x"))
+	if err != nil {
+		return nil, fmt.Errorf("transaction %s input: %w", hash, err)
+	}
+	var receipt *struct {
+		Status string `json:"status"`
+	}
+	if err := c.call(ctx, "eth_getTransactionReceipt", &receipt, hash); err != nil {
+		return nil, err
+	}
+	if receipt == nil {
+		return nil, fmt.Errorf("transaction %s has no receipt", hash)
+	}
+	var block *struct {
+		Number    string `json:"number"`
+		Timestamp string `json:"timestamp"`
+	}
+	if err := c.call(ctx, "eth_getBlockByHash", &block, *tx.BlockHash, false); err != nil {
+		return nil, err
+	}
+	if block == nil {
+		return nil, fmt.Errorf("block %s of transaction %s not found", *tx.BlockHash, hash)
+	}
+	number, err := parseQuantity(block.Number)
+	if err != nil {
+		return nil, fmt.Errorf("block %s number: %w", *tx.BlockHash, err)
+	}
+	timestamp, err := parseQuantity(block.Timestamp)
+	if err != nil || timestamp > uint64(math.MaxInt64) {
+		return nil, fmt.Errorf("block %s timestamp %q invalid", *tx.BlockHash, block.Timestamp)
+	}
+	return &ethTransaction{
+		input:       input,
+		reverted:    receipt.Status != "0x1",
+		blockNumber: number,
+		blockTime:   time.Unix(int64(timestamp), 0),
+	}, nil
+}
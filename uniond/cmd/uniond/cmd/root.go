@@ -45,7 +45,9 @@ import (
 	// this line is used by starport scaffolding # root/moduleImport
 
 	"union/app"
+	"union/app/archive"
 	appparams "union/app/params"
+	"union/app/valsetcache"
 	"union/x/staking"
 )
 
@@ -243,6 +245,9 @@ func queryCommand() *cobra.Command {
 
 	cmd.AddCommand(
 		rpc.ValidatorCommand(),
+		QueryValsetCmd(),
+		QueryValsetDiffCmd(),
+		QuerySkipDistanceCmd(),
 		rpc.QueryEventForTxCmd(),
 		server.QueryBlocksCmd(),
 		server.QueryBlockResultsCmd(),
@@ -371,7 +376,9 @@ func initAppConfig() (string, interface{}) {
 	type CustomAppConfig struct {
 		serverconfig.Config
 
-		WASM WASMConfig `mapstructure:"wasm"`
+		WASM        WASMConfig         `mapstructure:"wasm"`
+		Archive     archive.Config     `mapstructure:"archive"`
+		ValsetCache valsetcache.Config `mapstructure:"valset-cache"`
 	}
 
 	// Optionally allow the chain developer to overwrite the SDK's default
@@ -398,6 +405,8 @@ func initAppConfig() (string, interface{}) {
 			LruSize:       1,
 			QueryGasLimit: 300000,
 		},
+		Archive:     archive.DefaultConfig(),
+		ValsetCache: valsetcache.DefaultConfig(),
 	}
 
 	customAppTemplate := serverconfig.DefaultConfigTemplate + `
@@ -406,7 +415,8 @@ func initAppConfig() (string, interface{}) {
 query_gas_limit = 300000
 # This is the number of wasm vm instances we keep cached in memory for speed-up
 # Warning: this is currently unstable and may lead to crashes, best to keep for 0 unless testing locally
-lru_size = 0`
+lru_size = 0
+` + archive.ConfigTemplate + valsetcache.ConfigTemplate
 
 	return customAppTemplate, customAppConfig
 }
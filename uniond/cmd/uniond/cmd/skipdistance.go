@@ -0,0 +1,87 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	cmtmath "github.com/cometbft/cometbft/libs/math"
+	"github.com/cometbft/cometbft/light"
+	cmttypes "github.com/cometbft/cometbft/types"
+	"github.com/cosmos/cosmos-sdk/client"
+	"github.com/cosmos/cosmos-sdk/client/flags"
+	"github.com/spf13/cobra"
+
+	"union/verifier"
+)
+
+const flagLatestHeight = "latest-height"
+
+func QuerySkipDistanceCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "skip-distance",
+		Short: "Estimate the furthest height verifiable from a trusted height",
+		Long: `Estimate from the churn of the validator sets the furthest height, up to --latest-height or the latest one, verifiable non-adjacently from --trusted-height under --trust-level, i.e. whose validator set overlaps the trusted one by more than the trust level, and print it as JSON, so that relayers can schedule their updates.
+The heights are probed at doubling distances then bisected, assuming the overlap shrinks as the validators churn, so that only a logarithmic number of validator sets is fetched.`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			clientCtx, err := client.GetClientQueryContext(cmd)
+			if err != nil {
+				return err
+			}
+			trustedHeight, err := cmd.Flags().GetInt64(flagTrustedHeight)
+			if err != nil {
+				return err
+			}
+			if trustedHeight <= 0 {
+				return fmt.Errorf("--%s must be positive", flagTrustedHeight)
+			}
+			latestHeight, err := cmd.Flags().GetInt64(flagLatestHeight)
+			if err != nil {
+				return err
+			}
+			rawTrustLevel, err := cmd.Flags().GetString(flagTrustLevel)
+			if err != nil {
+				return err
+			}
+			trustLevel, err := cmtmath.ParseFraction(rawTrustLevel)
+			if err != nil {
+				return err
+			}
+			if trustLevel, err = verifier.ValidateTrustLevel(trustLevel); err != nil {
+				return err
+			}
+
+			node, err := clientCtx.GetNode()
+			if err != nil {
+				return err
+			}
+			if latestHeight <= 0 {
+				status, err := node.Status(cmd.Context())
+				if err != nil {
+					return err
+				}
+				latestHeight = status.SyncInfo.LatestBlockHeight
+			}
+			valsets := func(ctx context.Context, height int64) (*cmttypes.ValidatorSet, error) {
+				_, valSet, err := verifier.FetchValidatorSet(ctx, node, &height)
+				return valSet, err
+			}
+			estimate, err := verifier.EstimateSkippingDistance(cmd.Context(), valsets, trustedHeight, latestHeight, trustLevel)
+			if err != nil {
+				return err
+			}
+			bz, err := json.MarshalIndent(estimate, "", "  ")
+			if err != nil {
+				return err
+			}
+			fmt.Fprintln(cmd.OutOrStdout(), string(bz))
+			return nil
+		},
+	}
+	cmd.Flags().String(flags.FlagNode, "tcp://localhost:26657", "<host>:<port> to CometBFT RPC interface for this chain")
+	cmd.Flags().Int64(flagTrustedHeight, 0, "Trusted height the estimated height is verified from")
+	cmd.Flags().Int64(flagLatestHeight, 0, "Furthest height considered, the latest height of the node if zero")
+	cmd.Flags().String(flagTrustLevel, light.DefaultTrustLevel.String(), "Fraction of the trusted validator set that must have signed a non adjacent header")
+	return cmd
+}
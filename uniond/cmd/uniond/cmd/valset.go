@@ -0,0 +1,212 @@
+package cmd
+
+import (
+	"encoding/binary"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"strconv"
+
+	cometbn254 "github.com/cometbft/cometbft/crypto/bn254"
+	cmtjson "github.com/cometbft/cometbft/libs/json"
+	cmttypes "github.com/cometbft/cometbft/types"
+	"github.com/consensys/gnark-crypto/ecc/bn254"
+	"github.com/cosmos/cosmos-sdk/client"
+	"github.com/cosmos/cosmos-sdk/client/flags"
+	"github.com/spf13/cobra"
+
+	"union/verifier"
+)
+
+const (
+	flagFormat = "format"
+
+	valsetFormatJSON    = "json"
+	valsetFormatProto   = "proto"
+	valsetFormatEVM     = "evm"
+	valsetFormatCircuit = "circuit"
+)
+
+// The validator set as consumed by the prover, every leaf being the field
+// elements hashed into the validators merkle root.
+type circuitValidatorSet struct {
+	Height     int64              `json:"height"`
+	Root       string             `json:"root"`
+	Validators []circuitValidator `json:"validators"`
+}
+
+type circuitValidator struct {
+	ShiftedX    string `json:"shifted_x"`
+	ShiftedY    string `json:"shifted_y"`
+	MsbX        uint8  `json:"msb_x"`
+	MsbY        uint8  `json:"msb_y"`
+	VotingPower int64  `json:"voting_power"`
+	LeafHash    string `json:"leaf_hash"`
+}
+
+func QueryValsetCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "valset [height]",
+		Short: "Export the validator set at a given height",
+		Long: `Export the full validator set at the given height (latest if omitted) in one of the following formats:
+  json:    the validators as returned by the RPC
+  proto:   hex encoded tendermint.types.ValidatorSet
+  evm:     hex encoded abi.encode(bytes32 validatorsHash, (uint256 x, uint256 y, uint64 power)[] validators)
+  circuit: the merkle leaves field elements and root as consumed by the prover`,
+		Args: cobra.RangeArgs(0, 1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			clientCtx, err := client.GetClientQueryContext(cmd)
+			if err != nil {
+				return err
+			}
+
+			format, err := cmd.Flags().GetString(flagFormat)
+			if err != nil {
+				return err
+			}
+
+			var height *int64
+			if len(args) > 0 {
+				h, err := strconv.ParseInt(args[0], 10, 64)
+				if err != nil {
+					return err
+				}
+				if h <= 0 {
+					return fmt.Errorf("height must be positive, got %d", h)
+				}
+				height = &h
+			}
+
+			blockHeight, valSet, err := queryValidatorSet(cmd, clientCtx, height)
+			if err != nil {
+				return err
+			}
+
+			var out string
+			switch format {
+			case valsetFormatJSON:
+				bz, err := cmtjson.MarshalIndent(valSet.Validators, "", "  ")
+				if err != nil {
+					return err
+				}
+				out = string(bz)
+			case valsetFormatProto:
+				pb, err := valSet.ToProto()
+				if err != nil {
+					return err
+				}
+				bz, err := pb.Marshal()
+				if err != nil {
+					return err
+				}
+				out = hex.EncodeToString(bz)
+			case valsetFormatEVM:
+				bz, err := encodeValidatorSetEVM(valSet)
+				if err != nil {
+					return err
+				}
+				out = "0x" + hex.EncodeToString(bz)
+			case valsetFormatCircuit:
+				circuitValSet, err := encodeValidatorSetCircuit(valSet)
+				if err != nil {
+					return err
+				}
+				circuitValSet.Height = blockHeight
+				bz, err := json.MarshalIndent(circuitValSet, "", "  ")
+				if err != nil {
+					return err
+				}
+				out = string(bz)
+			default:
+				return fmt.Errorf("unknown format %q, expected one of %s, %s, %s or %s", format, valsetFormatJSON, valsetFormatProto, valsetFormatEVM, valsetFormatCircuit)
+			}
+			fmt.Fprintln(cmd.OutOrStdout(), out)
+			return nil
+		},
+	}
+	cmd.Flags().String(flags.FlagNode, "tcp://localhost:26657", "<host>:<port> to CometBFT RPC interface for this chain")
+	cmd.Flags().String(flagFormat, valsetFormatJSON, "Export format (json|proto|evm|circuit)")
+	return cmd
+}
+
+// Fetch every page of the validator set at the given height (latest if nil).
+func queryValidatorSet(cmd *cobra.Command, clientCtx client.Context, height *int64) (int64, *cmttypes.ValidatorSet, error) {
+	node, err := clientCtx.GetNode()
+	if err != nil {
+		return 0, nil, err
+	}
+	return verifier.FetchValidatorSet(cmd.Context(), node, height)
+}
+
+// encodeValidatorSetEVM ABI encodes the validator set hash along with the
+// uncompressed bn254 public key and voting power of every validator.
+func encodeValidatorSetEVM(valSet *cmttypes.ValidatorSet) ([]byte, error) {
+	word := func(v *big.Int) []byte {
+		var padded [32]byte
+		v.FillBytes(padded[:])
+		return padded[:]
+	}
+	var validators []byte
+	for _, val := range valSet.Validators {
+		pubKey, err := validatorG1(val)
+		if err != nil {
+			return nil, err
+		}
+		validators = append(validators, word(pubKey.X.BigInt(new(big.Int)))...)
+		validators = append(validators, word(pubKey.Y.BigInt(new(big.Int)))...)
+		var power [32]byte
+		binary.BigEndian.PutUint64(power[24:], uint64(val.VotingPower))
+		validators = append(validators, power[:]...)
+	}
+	var out []byte
+	out = append(out, valSet.Hash()...)
+	// Offset of the dynamic array, right after the two head words.
+	out = append(out, word(big.NewInt(64))...)
+	out = append(out, word(big.NewInt(int64(len(valSet.Validators))))...)
+	out = append(out, validators...)
+	return out, nil
+}
+
+// encodeValidatorSetCircuit computes the merkle leaves of the validator set,
+// mirroring the encoding used by the prover.
+func encodeValidatorSetCircuit(valSet *cmttypes.ValidatorSet) (*circuitValidatorSet, error) {
+	validators := make([]circuitValidator, len(valSet.Validators))
+	for i, val := range valSet.Validators {
+		pubKey, err := validatorG1(val)
+		if err != nil {
+			return nil, err
+		}
+		leaf, err := cometbn254.NewMerkleLeaf(pubKey, val.VotingPower)
+		if err != nil {
+			return nil, fmt.Errorf("validator %s: could not create merkle leaf: %w", val.Address, err)
+		}
+		leafHash, err := leaf.Hash()
+		if err != nil {
+			return nil, fmt.Errorf("validator %s: could not hash merkle leaf: %w", val.Address, err)
+		}
+		validators[i] = circuitValidator{
+			ShiftedX:    leaf.ShiftedX.String(),
+			ShiftedY:    leaf.ShiftedY.String(),
+			MsbX:        leaf.MsbX,
+			MsbY:        leaf.MsbY,
+			VotingPower: leaf.VotingPower,
+			LeafHash:    "0x" + hex.EncodeToString(leafHash),
+		}
+	}
+	return &circuitValidatorSet{
+		Root:       "0x" + hex.EncodeToString(valSet.Hash()),
+		Validators: validators,
+	}, nil
+}
+
+func validatorG1(val *cmttypes.Validator) (bn254.G1Affine, error) {
+	var pubKey bn254.G1Affine
+	if _, ok := val.PubKey.(cometbn254.PubKey); !ok {
+		return pubKey, fmt.Errorf("validator %s: expected a %s public key, got %s", val.Address, cometbn254.KeyType, val.PubKey.Type())
+	}
+	if _, err := pubKey.SetBytes(val.PubKey.Bytes()); err != nil {
+		return pubKey, fmt.Errorf("validator %s: could not deserialize bn254 public key: %w", val.Address, err)
+	}
+	return pubKey, nil
+}
@@ -0,0 +1,94 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+
+	cmtmath "github.com/cometbft/cometbft/libs/math"
+	"github.com/cometbft/cometbft/light"
+	"github.com/cosmos/cosmos-sdk/client"
+	"github.com/cosmos/cosmos-sdk/client/flags"
+	"github.com/spf13/cobra"
+
+	"union/verifier"
+)
+
+const (
+	flagHeightA = "height-a"
+	flagHeightB = "height-b"
+)
+
+// The diff of the validator sets at two heights, printed as JSON.
+type valsetDiff struct {
+	HeightA    int64  `json:"height_a"`
+	HeightB    int64  `json:"height_b"`
+	TrustLevel string `json:"trust_level"`
+	*verifier.ValidatorSetDiff
+}
+
+func QueryValsetDiffCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "valset-diff",
+		Short: "Diff the validator sets at two heights",
+		Long: `Diff the validator set at --height-a, the trusted one, with the one at --height-b, printing the added and removed validators, the voting power changes and the overlap of the sets as JSON.
+The overlap is the voting power of the validators of the set at --height-a still in the set at --height-b, as a fraction of its total voting power: a header at --height-b can only be verified from --height-a, skipping, if the overlap exceeds --trust-level, which "skippable" tells.`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			clientCtx, err := client.GetClientQueryContext(cmd)
+			if err != nil {
+				return err
+			}
+			heightA, err := cmd.Flags().GetInt64(flagHeightA)
+			if err != nil {
+				return err
+			}
+			heightB, err := cmd.Flags().GetInt64(flagHeightB)
+			if err != nil {
+				return err
+			}
+			if heightA <= 0 || heightB <= 0 {
+				return fmt.Errorf("--%s and --%s must be positive", flagHeightA, flagHeightB)
+			}
+			rawTrustLevel, err := cmd.Flags().GetString(flagTrustLevel)
+			if err != nil {
+				return err
+			}
+			trustLevel, err := cmtmath.ParseFraction(rawTrustLevel)
+			if err != nil {
+				return err
+			}
+			if trustLevel, err = verifier.ValidateTrustLevel(trustLevel); err != nil {
+				return err
+			}
+
+			_, valSetA, err := queryValidatorSet(cmd, clientCtx, &heightA)
+			if err != nil {
+				return fmt.Errorf("validator set at height %d: %w", heightA, err)
+			}
+			_, valSetB, err := queryValidatorSet(cmd, clientCtx, &heightB)
+			if err != nil {
+				return fmt.Errorf("validator set at height %d: %w", heightB, err)
+			}
+			diff, err := verifier.DiffValidatorSets(valSetA, valSetB, trustLevel)
+			if err != nil {
+				return err
+			}
+			bz, err := json.MarshalIndent(valsetDiff{
+				HeightA:          heightA,
+				HeightB:          heightB,
+				TrustLevel:       trustLevel.String(),
+				ValidatorSetDiff: diff,
+			}, "", "  ")
+			if err != nil {
+				return err
+			}
+			fmt.Fprintln(cmd.OutOrStdout(), string(bz))
+			return nil
+		},
+	}
+	cmd.Flags().String(flags.FlagNode, "tcp://localhost:26657", "<host>:<port> to CometBFT RPC interface for this chain")
+	cmd.Flags().Int64(flagHeightA, 0, "Height of the trusted validator set")
+	cmd.Flags().Int64(flagHeightB, 0, "Height of the validator set compared to it")
+	cmd.Flags().String(flagTrustLevel, light.DefaultTrustLevel.String(), "Fraction of the validator set at --height-a that must overlap for the header at --height-b to be skippable")
+	return cmd
+}
@@ -0,0 +1,322 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/cosmos/cosmos-sdk/client"
+	"github.com/cosmos/cosmos-sdk/client/flags"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/types/query"
+	govv1 "github.com/cosmos/cosmos-sdk/x/gov/types/v1"
+	ibcwasmtypes "github.com/cosmos/ibc-go/modules/light-clients/08-wasm/types"
+	clienttypes "github.com/cosmos/ibc-go/v8/modules/core/02-client/types"
+	"github.com/spf13/cobra"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	flagProposalID = "proposal-id"
+	flagFlake      = "flake"
+	flagExpected   = "expected"
+)
+
+// The checksum of a local light client bytecode compared with the one being
+// approved or already stored on chain.
+type wasmChecksumReport struct {
+	File       string   `json:"file"`
+	Checksum   string   `json:"checksum"`
+	ProposalID uint64   `json:"proposal_id,omitempty"`
+	Proposed   []string `json:"proposed,omitempty"`
+	Stored     bool     `json:"stored"`
+	Match      bool     `json:"match"`
+}
+
+// A stored light client checksum along with the clients running it.
+type wasmChecksumUsage struct {
+	Checksum string   `json:"checksum"`
+	Clients  []string `json:"clients"`
+}
+
+func WasmClientCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:                        "wasm-client",
+		Short:                      "08-wasm light client checksum subcommands",
+		DisableFlagParsing:         true,
+		SuggestionsMinimumDistance: 2,
+		RunE:                       client.ValidateCmd,
+	}
+
+	cmd.AddCommand(
+		WasmClientChecksumCmd(),
+		WasmClientReproduceCmd(),
+		WasmClientVerifyCmd(),
+		WasmClientListCmd(),
+	)
+
+	return cmd
+}
+
+func WasmClientChecksumCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "checksum [wasm-file]",
+		Short: "Compute the checksum of a light client bytecode, offline",
+		Long: `Compute the checksum the 08-wasm module assigns to a light client bytecode when it is stored.
+Gzipped bytecode is uncompressed first, as done by the module, so both the .wasm and the .wasm.gz build outputs give the same checksum.`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			checksum, err := wasmFileChecksum(args[0])
+			if err != nil {
+				return err
+			}
+			fmt.Fprintln(cmd.OutOrStdout(), hex.EncodeToString(checksum))
+			return nil
+		},
+	}
+	return cmd
+}
+
+func WasmClientReproduceCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "reproduce [package]",
+		Short: "Build a light client from source with nix and compute its checksum",
+		Long: `Build a light client package (e.g. cometbls-light-client) of the given flake with nix and print the checksum of the resulting bytecode.
+The build is reproducible: given the same revision, the checksum matches the one of the bytecode proposed by the maintainers.
+Use --expected to exit with an error if the checksums differ.`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			flake, err := cmd.Flags().GetString(flagFlake)
+			if err != nil {
+				return err
+			}
+			expected, err := cmd.Flags().GetString(flagExpected)
+			if err != nil {
+				return err
+			}
+
+			build := exec.CommandContext(cmd.Context(), "nix", "build", fmt.Sprintf("%s#%s", flake, args[0]), "--no-link", "--print-out-paths")
+			build.Stderr = cmd.ErrOrStderr()
+			out, err := build.Output()
+			if err != nil {
+				return fmt.Errorf("nix build failed: %w", err)
+			}
+
+			var wasmFiles []string
+			for _, outPath := range strings.Fields(string(out)) {
+				files, err := filepath.Glob(filepath.Join(outPath, "lib", "*.wasm"))
+				if err != nil {
+					return err
+				}
+				wasmFiles = append(wasmFiles, files...)
+			}
+			if len(wasmFiles) != 1 {
+				return fmt.Errorf("expected a single bytecode in the build output of %s, found %d", args[0], len(wasmFiles))
+			}
+
+			checksum, err := wasmFileChecksum(wasmFiles[0])
+			if err != nil {
+				return err
+			}
+			fmt.Fprintln(cmd.ErrOrStderr(), wasmFiles[0])
+			fmt.Fprintln(cmd.OutOrStdout(), hex.EncodeToString(checksum))
+
+			if expected != "" && !strings.EqualFold(strings.TrimPrefix(expected, "0x"), hex.EncodeToString(checksum)) {
+				cmd.SilenceUsage = true
+				return fmt.Errorf("checksum mismatch: expected %s, built %x", expected, checksum)
+			}
+			return nil
+		},
+	}
+	cmd.Flags().String(flagFlake, ".", "Flake reference of the union repository at the revision to reproduce")
+	cmd.Flags().String(flagExpected, "", "Hex encoded checksum the build must match")
+	return cmd
+}
+
+func WasmClientVerifyCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "verify [wasm-file]",
+		Short: "Verify that a local light client bytecode is the one proposed or stored on chain",
+		Long: `Compare the checksum of a local light client bytecode, typically reproduced from source, with the bytecode proposed in a governance proposal (--proposal-id) or, without it, with the bytecode stored by the 08-wasm module.
+A JSON report is printed and the command exits with an error if the checksums don't match.`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			clientCtx, err := client.GetClientQueryContext(cmd)
+			if err != nil {
+				return err
+			}
+			proposalID, err := cmd.Flags().GetUint64(flagProposalID)
+			if err != nil {
+				return err
+			}
+
+			checksum, err := wasmFileChecksum(args[0])
+			if err != nil {
+				return err
+			}
+			report := wasmChecksumReport{
+				File:       args[0],
+				Checksum:   hex.EncodeToString(checksum),
+				ProposalID: proposalID,
+			}
+
+			if proposalID != 0 {
+				res, err := govv1.NewQueryClient(clientCtx).Proposal(cmd.Context(), &govv1.QueryProposalRequest{ProposalId: proposalID})
+				if err != nil {
+					return err
+				}
+				storeCodeURL := sdk.MsgTypeURL(&ibcwasmtypes.MsgStoreCode{})
+				for _, msg := range res.Proposal.Messages {
+					if msg.TypeUrl != storeCodeURL {
+						continue
+					}
+					var storeCode ibcwasmtypes.MsgStoreCode
+					if err := storeCode.Unmarshal(msg.Value); err != nil {
+						return err
+					}
+					proposed, err := wasmChecksum(storeCode.WasmByteCode)
+					if err != nil {
+						return fmt.Errorf("proposal %d: %w", proposalID, err)
+					}
+					report.Proposed = append(report.Proposed, hex.EncodeToString(proposed))
+					report.Match = report.Match || bytes.Equal(proposed, checksum)
+				}
+				if len(report.Proposed) == 0 {
+					return fmt.Errorf("proposal %d doesn't store any light client bytecode", proposalID)
+				}
+			}
+
+			res, err := ibcwasmtypes.NewQueryClient(clientCtx).Code(cmd.Context(), &ibcwasmtypes.QueryCodeRequest{Checksum: report.Checksum})
+			switch {
+			case status.Code(err) == codes.NotFound:
+			case err != nil:
+				return err
+			default:
+				stored, err := wasmChecksum(res.Data)
+				if err != nil {
+					return err
+				}
+				report.Stored = bytes.Equal(stored, checksum)
+			}
+			if proposalID == 0 {
+				report.Match = report.Stored
+			}
+
+			reportJson, err := json.MarshalIndent(&report, "", "  ")
+			if err != nil {
+				return err
+			}
+			fmt.Fprintln(cmd.OutOrStdout(), string(reportJson))
+
+			if !report.Match {
+				cmd.SilenceUsage = true
+				if proposalID != 0 {
+					return fmt.Errorf("%s doesn't match the bytecode of proposal %d", args[0], proposalID)
+				}
+				return fmt.Errorf("%s isn't stored on chain", args[0])
+			}
+			return nil
+		},
+	}
+	flags.AddQueryFlagsToCmd(cmd)
+	cmd.Flags().Uint64(flagProposalID, 0, "Governance proposal storing the bytecode to compare with")
+	return cmd
+}
+
+func WasmClientListCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "list",
+		Short: "List the stored light client checksums along with the clients running them",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			clientCtx, err := client.GetClientQueryContext(cmd)
+			if err != nil {
+				return err
+			}
+
+			checksums, err := ibcwasmtypes.NewQueryClient(clientCtx).Checksums(cmd.Context(), &ibcwasmtypes.QueryChecksumsRequest{})
+			if err != nil {
+				return err
+			}
+			clients := make(map[string][]string, len(checksums.Checksums))
+			for _, checksum := range checksums.Checksums {
+				clients[checksum] = []string{}
+			}
+
+			clientQueryClient := clienttypes.NewQueryClient(clientCtx)
+			wasmClientStateURL := sdk.MsgTypeURL(&ibcwasmtypes.ClientState{})
+			pageReq := &query.PageRequest{}
+			for {
+				res, err := clientQueryClient.ClientStates(cmd.Context(), &clienttypes.QueryClientStatesRequest{Pagination: pageReq})
+				if err != nil {
+					return err
+				}
+				for _, identified := range res.ClientStates {
+					if identified.ClientState == nil || identified.ClientState.TypeUrl != wasmClientStateURL {
+						continue
+					}
+					var clientState ibcwasmtypes.ClientState
+					if err := clientState.Unmarshal(identified.ClientState.Value); err != nil {
+						return fmt.Errorf("%s: %w", identified.ClientId, err)
+					}
+					checksum := hex.EncodeToString(clientState.Checksum)
+					clients[checksum] = append(clients[checksum], identified.ClientId)
+				}
+				if res.Pagination == nil || len(res.Pagination.NextKey) == 0 {
+					break
+				}
+				pageReq = &query.PageRequest{Key: res.Pagination.NextKey}
+			}
+
+			usages := make([]wasmChecksumUsage, 0, len(clients))
+			for checksum, clientIDs := range clients {
+				usages = append(usages, wasmChecksumUsage{Checksum: checksum, Clients: clientIDs})
+			}
+			sort.Slice(usages, func(i, j int) bool { return usages[i].Checksum < usages[j].Checksum })
+
+			usagesJson, err := json.MarshalIndent(usages, "", "  ")
+			if err != nil {
+				return err
+			}
+			fmt.Fprintln(cmd.OutOrStdout(), string(usagesJson))
+			return nil
+		},
+	}
+	flags.AddQueryFlagsToCmd(cmd)
+	return cmd
+}
+
+func wasmFileChecksum(path string) ([]byte, error) {
+	code, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	checksum, err := wasmChecksum(code)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	return checksum, nil
+}
+
+// wasmChecksum computes the checksum of a light client bytecode the same way
+// the 08-wasm module does when storing it.
+func wasmChecksum(code []byte) ([]byte, error) {
+	var err error
+	if ibcwasmtypes.IsGzip(code) {
+		code, err = ibcwasmtypes.Uncompress(code, ibcwasmtypes.MaxWasmByteSize())
+		if err != nil {
+			return nil, err
+		}
+	}
+	if err := ibcwasmtypes.ValidateWasmCode(code); err != nil {
+		return nil, err
+	}
+	return ibcwasmtypes.CreateChecksum(code)
+}
@@ -15,6 +15,8 @@ func main() {
 	rootCmd.AddCommand(cmd.GenBn254())
 	rootCmd.AddCommand(cmd.ProofOfPossession())
 	rootCmd.AddCommand(cmd.GenStateProof())
+	rootCmd.AddCommand(cmd.LightCmd())
+	rootCmd.AddCommand(cmd.WasmClientCmd())
 	if err := svrcmd.Execute(rootCmd, "", app.DefaultNodeHome); err != nil {
 		log.NewLogger(rootCmd.OutOrStderr()).Error("failure when running app", "err", err)
 		os.Exit(1)
@@ -193,8 +193,12 @@ _: {
                 cp --no-preserve=mode -RL ${generate-uniond-proto}/openapi_combined.yaml ./docs/static/openapi.yml
                 cp --no-preserve=mode -RL ${generate-uniond-proto}/union/x/* ./x/
                 cp --no-preserve=mode -RL ${generate-uniond-proto}/union/staking/* ./x/staking
+                cp --no-preserve=mode -RL ${generate-uniond-proto}/union/app/archive/* ./app/archive
+                cp --no-preserve=mode -RL ${generate-uniond-proto}/union/app/lightblock/* ./app/lightblock
+                cp --no-preserve=mode -RL ${generate-uniond-proto}/union/app/valsetcache/* ./app/valsetcache
+                cp --no-preserve=mode -RL ${generate-uniond-proto}/union/verifier/* ./verifier
 
-                echo "Done! Generated .pb.go files are added to ./uniond/x"
+                echo "Done! Generated .pb.go files are added to ./uniond/x, ./uniond/app and ./uniond/verifier"
               '';
             }
           );
@@ -0,0 +1,157 @@
+// Package alert notifies operators of security relevant light client events,
+// such as a witness diverging from the primary or the trusted state being
+// about to expire, through webhooks, Slack or PagerDuty.
+package alert
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+)
+
+// Kind is the class of a security relevant event.
+type Kind string
+
+const (
+	// KindWitnessDivergence is raised when a witness serves a header
+	// conflicting with the one of the primary, i.e. a light client attack.
+	KindWitnessDivergence Kind = "witness_divergence"
+	// KindVerificationFailed is raised when a header fails verification,
+	// e.g. the trust level isn't reached.
+	KindVerificationFailed Kind = "verification_failed"
+	// KindNearExpiry is raised when the latest trusted header is about to
+	// leave the trusting period.
+	KindNearExpiry Kind = "near_expiry"
+	// KindExpired is raised when the latest trusted header left the
+	// trusting period, the client has to be reset subjectively.
+	KindExpired Kind = "expired"
+	// KindProviderFailure is raised when the providers can't be cross
+	// checked anymore, which may be a sign of compromised providers.
+	KindProviderFailure Kind = "provider_failure"
+	// KindStoreInvariant is raised when the trusted store violates an
+	// invariant the light client relies on, e.g. after a corruption.
+	KindStoreInvariant Kind = "store_invariant"
+	// KindBlockMismatch is raised by the deep validation when the block
+	// served for a trusted header doesn't match the hashes it commits to.
+	KindBlockMismatch Kind = "block_mismatch"
+)
+
+// Alert is a security relevant event of a light client.
+type Alert struct {
+	Kind    Kind      `json:"kind"`
+	ChainID string    `json:"chain_id"`
+	Height  int64     `json:"height,omitempty"`
+	Message string    `json:"message"`
+	Time    time.Time `json:"time"`
+}
+
+func (a Alert) String() string {
+	return fmt.Sprintf("[%s] %s: %s", a.ChainID, a.Kind, a.Message)
+}
+
+// Alerter delivers alerts to operators.
+type Alerter interface {
+	Alert(ctx context.Context, alert Alert) error
+}
+
+// Multi delivers alerts to every alerter, reporting all the failures.
+type Multi []Alerter
+
+func (m Multi) Alert(ctx context.Context, alert Alert) error {
+	var errs []error
+	for _, alerter := range m {
+		if err := alerter.Alert(ctx, alert); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// Webhook posts the alerts as JSON to an URL.
+type Webhook struct {
+	URL    string
+	Client *http.Client
+}
+
+func (w Webhook) Alert(ctx context.Context, alert Alert) error {
+	return postJSON(ctx, w.Client, w.URL, alert)
+}
+
+// Slack posts the alerts to a Slack incoming webhook.
+type Slack struct {
+	WebhookURL string
+	Client     *http.Client
+}
+
+func (s Slack) Alert(ctx context.Context, alert Alert) error {
+	return postJSON(ctx, s.Client, s.WebhookURL, map[string]string{"text": alert.String()})
+}
+
+// DefaultPagerDutyURL is the PagerDuty Events API v2 endpoint.
+const DefaultPagerDutyURL = "https://events.pagerduty.com/v2/enqueue"
+
+// PagerDuty triggers PagerDuty incidents through the Events API v2.
+// Successive alerts of the same kind and chain are deduplicated into the
+// same incident.
+type PagerDuty struct {
+	RoutingKey string
+	// URL defaults to DefaultPagerDutyURL.
+	URL    string
+	Client *http.Client
+}
+
+func (p PagerDuty) Alert(ctx context.Context, alert Alert) error {
+	url := p.URL
+	if url == "" {
+		url = DefaultPagerDutyURL
+	}
+	severity := "critical"
+	if alert.Kind == KindNearExpiry {
+		severity = "warning"
+	}
+	return postJSON(ctx, p.Client, url, map[string]any{
+		"routing_key":  p.RoutingKey,
+		"event_action": "trigger",
+		"dedup_key":    fmt.Sprintf("%s/%s", alert.ChainID, alert.Kind),
+		"payload": map[string]any{
+			"summary":   alert.String(),
+			"source":    alert.ChainID,
+			"severity":  severity,
+			"timestamp": alert.Time.UTC().Format(time.RFC3339),
+			"custom_details": map[string]any{
+				"kind":   alert.Kind,
+				"height": alert.Height,
+			},
+		},
+	})
+}
+
+func postJSON(ctx context.Context, client *http.Client, url string, body any) error {
+	if client == nil {
+		client = http.DefaultClient
+	}
+	bz, err := json.Marshal(body)
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(bz))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	res, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+	if res.StatusCode < 200 || res.StatusCode >= 300 {
+		msg, _ := io.ReadAll(io.LimitReader(res.Body, 512))
+		return fmt.Errorf("%s responded %s: %s", req.URL.Host, res.Status, bytes.TrimSpace(msg))
+	}
+	return nil
+}
@@ -0,0 +1,154 @@
+package verifier
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"sync"
+	"time"
+
+	cmtbytes "github.com/cometbft/cometbft/libs/bytes"
+)
+
+// AuditEntry records a verified transition along with everything needed to
+// reproduce the verdict, as a single JSON line of the audit log.
+type AuditEntry struct {
+	Time                    time.Time         `json:"time"`
+	Method                  string            `json:"method"`
+	ChainID                 string            `json:"chain_id"`
+	TrustedHeight           int64             `json:"trusted_height"`
+	TrustedHash             cmtbytes.HexBytes `json:"trusted_hash"`
+	TrustedValidatorsHash   cmtbytes.HexBytes `json:"trusted_validators_hash"`
+	UntrustedHeight         int64             `json:"untrusted_height"`
+	UntrustedHash           cmtbytes.HexBytes `json:"untrusted_hash"`
+	UntrustedValidatorsHash cmtbytes.HexBytes `json:"untrusted_validators_hash"`
+	TrustingPeriod          string            `json:"trusting_period"`
+	MaxClockDrift           string            `json:"max_clock_drift"`
+	TrustLevel              string            `json:"trust_level"`
+	Legacy                  bool              `json:"legacy"`
+	Now                     time.Time         `json:"now"`
+	Verified                bool              `json:"verified"`
+	Adjacent                bool              `json:"adjacent"`
+	Error                   string            `json:"error,omitempty"`
+	// The client that requested the verification, identified by its address
+	// or by a fingerprint of its bearer token.
+	Client string `json:"client,omitempty"`
+	// LineageBreak is set when an expired trusted state is replaced by a new
+	// root of trust, trusted subjectively from RecoverySource rather than
+	// verified from the trusted light block.
+	LineageBreak   bool   `json:"lineage_break,omitempty"`
+	RecoverySource string `json:"recovery_source,omitempty"`
+}
+
+// AuditLog is an append only JSON lines file of every accepted and rejected
+// header. Once the file reaches its maximum size it is rotated: file.1 is the
+// previous one, file.2 the one before, up to the number of kept files.
+type AuditLog struct {
+	// Redactor, if set before the first record, redacts the client of the
+	// entries.
+	Redactor *Redactor
+
+	mu       sync.Mutex
+	path     string
+	maxSize  int64
+	maxFiles int
+	file     *os.File
+	size     int64
+}
+
+// NewAuditLog opens or creates the audit log at path, appending to it.
+func NewAuditLog(path string, maxSize int64, maxFiles int) (*AuditLog, error) {
+	if maxSize <= 0 {
+		return nil, fmt.Errorf("audit log max size must be positive, got %d", maxSize)
+	}
+	if maxFiles < 0 {
+		return nil, fmt.Errorf("number of rotated audit logs can't be negative, got %d", maxFiles)
+	}
+	l := &AuditLog{path: path, maxSize: maxSize, maxFiles: maxFiles}
+	if err := l.open(); err != nil {
+		return nil, err
+	}
+	return l, nil
+}
+
+func (l *AuditLog) open() error {
+	file, err := os.OpenFile(l.path, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0o600)
+	if err != nil {
+		return err
+	}
+	info, err := file.Stat()
+	if err != nil {
+		file.Close()
+		return err
+	}
+	l.file, l.size = file, info.Size()
+	return nil
+}
+
+// Record appends an entry to the log, synced to disk before returning.
+func (l *AuditLog) Record(entry *AuditEntry) error {
+	if l.Redactor != nil {
+		redacted := *entry
+		redacted.Client = l.Redactor.Redact(entry.Client)
+		entry = &redacted
+	}
+	line, err := json.Marshal(entry)
+	if err != nil {
+		return err
+	}
+	line = append(line, '\n')
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if l.file == nil {
+		return fmt.Errorf("audit log %s is closed", l.path)
+	}
+	if l.size > 0 && l.size+int64(len(line)) > l.maxSize {
+		if err := l.rotate(); err != nil {
+			return fmt.Errorf("can't rotate audit log %s: %w", l.path, err)
+		}
+	}
+	n, err := l.file.Write(line)
+	l.size += int64(n)
+	if err != nil {
+		return err
+	}
+	return l.file.Sync()
+}
+
+func (l *AuditLog) rotate() error {
+	if err := l.file.Close(); err != nil {
+		return err
+	}
+	l.file = nil
+	if l.maxFiles == 0 {
+		if err := os.Remove(l.path); err != nil {
+			return err
+		}
+		return l.open()
+	}
+	if err := os.Remove(fmt.Sprintf("%s.%d", l.path, l.maxFiles)); err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	for i := l.maxFiles - 1; i >= 1; i-- {
+		if err := os.Rename(fmt.Sprintf("%s.%d", l.path, i), fmt.Sprintf("%s.%d", l.path, i+1)); err != nil && !os.IsNotExist(err) {
+			return err
+		}
+	}
+	if err := os.Rename(l.path, l.path+".1"); err != nil {
+		return err
+	}
+	return l.open()
+}
+
+// Close closes the underlying file, subsequent records fail.
+func (l *AuditLog) Close() error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if l.file == nil {
+		return nil
+	}
+	err := l.file.Close()
+	l.file = nil
+	return err
+}
@@ -0,0 +1,43 @@
+package verifier
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	cmttypes "github.com/cometbft/cometbft/types"
+)
+
+// ErrBlockMismatch is returned when the contents of a block don't match the
+// hashes committed to by its verified header.
+var ErrBlockMismatch = errors.New("block mismatch")
+
+// CheckBlock checks the contents of a block, as served by an untrusted node,
+// against the hashes committed to by the verified light block of its height:
+// its transactions against the data hash, its last commit against the last
+// commit hash and its evidence against the evidence hash. The light client
+// only verifies headers, so this is the deep validation of auditors who want
+// the block behind a header to be the one the validators signed. Every
+// mismatching hash is reported.
+func CheckBlock(lightBlock *cmttypes.LightBlock, block *cmttypes.Block) error {
+	if block == nil {
+		return fmt.Errorf("%w: no block at height %d", ErrBlockMismatch, lightBlock.Height)
+	}
+	if block.Height != lightBlock.Height {
+		return fmt.Errorf("%w: block of height %d, expected %d", ErrBlockMismatch, block.Height, lightBlock.Height)
+	}
+	var mismatches []string
+	if hash := block.Data.Hash(); !digestsEqual(hash, lightBlock.DataHash) {
+		mismatches = append(mismatches, fmt.Sprintf("data hash %X, committed %X", hash, lightBlock.DataHash))
+	}
+	if hash := block.LastCommit.Hash(); !digestsEqual(hash, lightBlock.LastCommitHash) {
+		mismatches = append(mismatches, fmt.Sprintf("last commit hash %X, committed %X", hash, lightBlock.LastCommitHash))
+	}
+	if hash := block.Evidence.Hash(); !digestsEqual(hash, lightBlock.EvidenceHash) {
+		mismatches = append(mismatches, fmt.Sprintf("evidence hash %X, committed %X", hash, lightBlock.EvidenceHash))
+	}
+	if len(mismatches) > 0 {
+		return fmt.Errorf("%w at height %d: %s", ErrBlockMismatch, lightBlock.Height, strings.Join(mismatches, ", "))
+	}
+	return nil
+}
@@ -0,0 +1,43 @@
+package verifier
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+const (
+	// MaxClockDriftBound is the largest accepted clock drift. A larger drift
+	// lets a header from the future be trusted early, e.g. to extend the
+	// trusting period of a compromised validator set.
+	MaxClockDriftBound = time.Minute
+	// DefaultUnbondingPeriod is the unbonding period of the Cosmos SDK
+	// staking module, used as the trusting period bound unless the chain's
+	// one is known.
+	DefaultUnbondingPeriod = 21 * 24 * time.Hour
+)
+
+// ErrInvalidConfig is returned for verification options breaking the light
+// client security model.
+var ErrInvalidConfig = errors.New("invalid light client configuration")
+
+// ValidateBounds checks the trusting period and the clock drift against the
+// bounds of the light client security model: validators stay accountable
+// for a header only until they unbond, so the trusting period must be shorter
+// than the unbonding period, and the clock drift can't exceed
+// MaxClockDriftBound.
+func ValidateBounds(trustingPeriod, maxClockDrift, unbondingPeriod time.Duration) error {
+	if trustingPeriod <= 0 {
+		return fmt.Errorf("%w: trusting period must be positive, got %s", ErrInvalidConfig, trustingPeriod)
+	}
+	if trustingPeriod >= unbondingPeriod {
+		return fmt.Errorf("%w: trusting period %s must be shorter than the unbonding period %s", ErrInvalidConfig, trustingPeriod, unbondingPeriod)
+	}
+	if maxClockDrift < 0 {
+		return fmt.Errorf("%w: max clock drift can't be negative, got %s", ErrInvalidConfig, maxClockDrift)
+	}
+	if maxClockDrift > MaxClockDriftBound {
+		return fmt.Errorf("%w: max clock drift %s exceeds %s", ErrInvalidConfig, maxClockDrift, MaxClockDriftBound)
+	}
+	return nil
+}
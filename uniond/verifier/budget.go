@@ -0,0 +1,63 @@
+package verifier
+
+import (
+	"context"
+	"time"
+
+	cmtproto "github.com/cometbft/cometbft/proto/tendermint/types"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// Budget bounds the resources a single request can consume, so that one
+// pathological request can't exhaust the server. A zero field is unbounded.
+type Budget struct {
+	// MaxSignatures bounds the commit signatures of the untrusted light
+	// blocks of a request, each one costing a pairing check.
+	MaxSignatures int
+	// MaxDecodedBytes bounds the protobuf size of the light blocks of a
+	// request.
+	MaxDecodedBytes int
+	// MaxDuration bounds the time spent on a request, which fails with
+	// DeadlineExceeded once elapsed. The verification of a single transition
+	// isn't interrupted, MaxSignatures bounds it.
+	MaxDuration time.Duration
+}
+
+// check rejects the requests exceeding the budget, or already past their
+// deadline, before anything is decoded.
+func (b Budget) check(ctx context.Context, trusted *cmtproto.LightBlock, untrusted ...*cmtproto.LightBlock) error {
+	if err := spent(ctx); err != nil {
+		return err
+	}
+	size, signatures := trusted.Size(), 0
+	for _, pb := range untrusted {
+		size += pb.Size()
+		if commit := pb.GetSignedHeader().GetCommit(); commit != nil {
+			signatures += len(commit.Signatures)
+		}
+	}
+	if b.MaxDecodedBytes > 0 && size > b.MaxDecodedBytes {
+		return status.Errorf(codes.ResourceExhausted, "light blocks of %d bytes exceed the budget of %d", size, b.MaxDecodedBytes)
+	}
+	if b.MaxSignatures > 0 && signatures > b.MaxSignatures {
+		return status.Errorf(codes.ResourceExhausted, "%d signatures to verify exceed the budget of %d", signatures, b.MaxSignatures)
+	}
+	return nil
+}
+
+// withDeadline bounds the context of a request by MaxDuration.
+func (b Budget) withDeadline(ctx context.Context) (context.Context, context.CancelFunc) {
+	if b.MaxDuration <= 0 {
+		return context.WithCancel(ctx)
+	}
+	return context.WithTimeout(ctx, b.MaxDuration)
+}
+
+// spent fails once the request is canceled or its deadline exceeded.
+func spent(ctx context.Context) error {
+	if err := ctx.Err(); err != nil {
+		return status.FromContextError(err).Err()
+	}
+	return nil
+}
@@ -0,0 +1,137 @@
+package verifier
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	cmtjson "github.com/cometbft/cometbft/libs/json"
+	cmttypes "github.com/cometbft/cometbft/types"
+)
+
+// CanonicalJSON encodes a light block as canonical JSON, so that signatures
+// and attestations over JSON exports can be reproduced byte for byte by any
+// implementation. The document follows the CometBFT JSON conventions (64 bits
+// integers as decimal strings, hashes and addresses as upper case hex,
+// signatures and keys as base64, times as RFC3339 UTC with trailing zero
+// nanoseconds trimmed, keys as {"type", "value"} objects) with:
+//   - object keys sorted by their UTF-8 bytes,
+//   - no insignificant whitespace,
+//   - integers without sign, leading zeros, fraction or exponent,
+//   - strings escaping only '"', '\\' and control characters, the latter as
+//     \uXXXX except for \b, \f, \n, \r and \t.
+func CanonicalJSON(lightBlock *cmttypes.LightBlock) ([]byte, error) {
+	bz, err := cmtjson.Marshal(lightBlock)
+	if err != nil {
+		return nil, err
+	}
+	return canonicalizeJSON(bz)
+}
+
+// canonicalizeJSON rewrites an arbitrary JSON document in the canonical form
+// of CanonicalJSON. Non integer numbers are rejected.
+func canonicalizeJSON(bz []byte) ([]byte, error) {
+	decoder := json.NewDecoder(bytes.NewReader(bz))
+	decoder.UseNumber()
+	var value any
+	if err := decoder.Decode(&value); err != nil {
+		return nil, err
+	}
+	var buf bytes.Buffer
+	if err := writeCanonicalJSON(&buf, value); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+func writeCanonicalJSON(buf *bytes.Buffer, value any) error {
+	switch value := value.(type) {
+	case nil:
+		buf.WriteString("null")
+	case bool:
+		if value {
+			buf.WriteString("true")
+		} else {
+			buf.WriteString("false")
+		}
+	case json.Number:
+		n := value.String()
+		digits := strings.TrimPrefix(n, "-")
+		if digits == "" || strings.Trim(digits, "0123456789") != "" || (len(digits) > 1 && digits[0] == '0') {
+			return fmt.Errorf("non integer number %s can't be canonically encoded", n)
+		}
+		if digits == "0" {
+			n = digits
+		}
+		buf.WriteString(n)
+	case string:
+		writeCanonicalString(buf, value)
+	case []any:
+		buf.WriteByte('[')
+		for i, elem := range value {
+			if i > 0 {
+				buf.WriteByte(',')
+			}
+			if err := writeCanonicalJSON(buf, elem); err != nil {
+				return err
+			}
+		}
+		buf.WriteByte(']')
+	case map[string]any:
+		keys := make([]string, 0, len(value))
+		for key := range value {
+			keys = append(keys, key)
+		}
+		// Go compares strings bytewise, which is the UTF-8 order.
+		sort.Strings(keys)
+		buf.WriteByte('{')
+		for i, key := range keys {
+			if i > 0 {
+				buf.WriteByte(',')
+			}
+			writeCanonicalString(buf, key)
+			buf.WriteByte(':')
+			if err := writeCanonicalJSON(buf, value[key]); err != nil {
+				return err
+			}
+		}
+		buf.WriteByte('}')
+	default:
+		return fmt.Errorf("unexpected JSON value of type %T", value)
+	}
+	return nil
+}
+
+func writeCanonicalString(buf *bytes.Buffer, s string) {
+	const hex = "0123456789abcdef"
+	buf.WriteByte('"')
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		switch c {
+		case '"', '\\':
+			buf.WriteByte('\\')
+			buf.WriteByte(c)
+		case '\b':
+			buf.WriteString(`\b`)
+		case '\f':
+			buf.WriteString(`\f`)
+		case '\n':
+			buf.WriteString(`\n`)
+		case '\r':
+			buf.WriteString(`\r`)
+		case '\t':
+			buf.WriteString(`\t`)
+		default:
+			if c < 0x20 {
+				buf.WriteString(`\u00`)
+				buf.WriteByte(hex[c>>4])
+				buf.WriteByte(hex[c&0xf])
+			} else {
+				buf.WriteByte(c)
+			}
+		}
+	}
+	buf.WriteByte('"')
+}
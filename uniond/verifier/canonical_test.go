@@ -0,0 +1,96 @@
+package verifier_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"flag"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	cmtjson "github.com/cometbft/cometbft/libs/json"
+	cmttypes "github.com/cometbft/cometbft/types"
+	"github.com/stretchr/testify/require"
+
+	"union/verifier"
+	"union/verifier/lighttest"
+)
+
+var updateGolden = flag.Bool("update", false, "Rewrite the golden files of the canonical JSON encoding")
+
+func TestCanonicalJSON(t *testing.T) {
+	chain, err := lighttest.NewChain("canonical-1", 2, 1)
+	require.NoError(t, err)
+	lightBlock := func(t *testing.T, mutate func(*cmttypes.LightBlock)) *cmttypes.LightBlock {
+		lightBlock, err := chain.LightBlock(2, false)
+		require.NoError(t, err)
+		mutate(lightBlock)
+		return lightBlock
+	}
+
+	for _, tc := range []struct {
+		name   string
+		mutate func(*cmttypes.LightBlock)
+	}{
+		{
+			// The nested objects of a whole light block, keys sorted.
+			name:   "light_block",
+			mutate: func(*cmttypes.LightBlock) {},
+		},
+		{
+			// Plain numbers next to the 64 bits integers encoded as
+			// strings, zero and negative ones included.
+			name: "numbers",
+			mutate: func(lightBlock *cmttypes.LightBlock) {
+				lightBlock.Commit.Round = 0
+				lightBlock.Commit.BlockID.PartSetHeader.Total = 1_000_000
+				lightBlock.ValidatorSet.Validators[0].ProposerPriority = -42
+				lightBlock.ValidatorSet.Validators[1].ProposerPriority = 0
+			},
+		},
+		{
+			// Only quotes, backslashes and control characters are escaped,
+			// the HTML characters and non ASCII ones are kept.
+			name: "escaping",
+			mutate: func(lightBlock *cmttypes.LightBlock) {
+				lightBlock.ChainID = "union \"1\"\\\b\f\n\r\t\x01\x1f<&> é"
+			},
+		},
+		{
+			// Times are converted to UTC and trailing zero nanoseconds
+			// trimmed.
+			name: "time",
+			mutate: func(lightBlock *cmttypes.LightBlock) {
+				lightBlock.Time = time.Date(2024, 1, 1, 2, 3, 4, 120_000_000, time.FixedZone("", 2*60*60))
+				lightBlock.Commit.Signatures[0].Timestamp = time.Date(2024, 1, 1, 0, 3, 4, 0, time.UTC)
+				lightBlock.Commit.Signatures[1].Timestamp = time.Date(2024, 1, 1, 0, 3, 4, 1, time.UTC)
+			},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			lightBlock := lightBlock(t, tc.mutate)
+			bz, err := verifier.CanonicalJSON(lightBlock)
+			require.NoError(t, err)
+
+			golden := filepath.Join("testdata", "canonical", tc.name+".json")
+			if *updateGolden {
+				require.NoError(t, os.WriteFile(golden, append(bz, '\n'), 0o644))
+			}
+			expected, err := os.ReadFile(golden)
+			require.NoError(t, err)
+			require.Equal(t, string(bytes.TrimSuffix(expected, []byte("\n"))), string(bz))
+
+			// The encoding is a fixed point, without whitespace, of the
+			// same light block.
+			var compact bytes.Buffer
+			require.NoError(t, json.Compact(&compact, bz))
+			require.Equal(t, compact.Bytes(), bz)
+			var decoded cmttypes.LightBlock
+			require.NoError(t, cmtjson.Unmarshal(bz, &decoded))
+			again, err := verifier.CanonicalJSON(&decoded)
+			require.NoError(t, err)
+			require.Equal(t, bz, again)
+		})
+	}
+}
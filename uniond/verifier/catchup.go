@@ -0,0 +1,112 @@
+package verifier
+
+import (
+	"context"
+	"time"
+
+	"github.com/cometbft/cometbft/light"
+	cmttypes "github.com/cometbft/cometbft/types"
+)
+
+// CatchUp advances a light client to the latest height of its primary in
+// steps of at most span heights, calling trusted with the light block of
+// every step. It returns the latest light block, or nil if the client was
+// already up to date, as light.Client.Update does when span isn't positive.
+//
+// The light client holds the whole trace of a verification in memory until
+// the witnesses cross-checked its target: every intermediate light block
+// with sequential verification, and every bisection pivot with skipping
+// verification. Catching up on months of heights in a single verification
+// can then exhaust the memory of small machines. Each step is instead
+// cross-checked and persisted to the trusted store on its own, its trace
+// being released, so that at most span light blocks are held at once
+// whatever the distance to the latest height.
+//
+// With a journal recording the pivots fetched by the providers of the
+// client, the pivots above the last trusted height, left by a catch-up
+// interrupted before its target was trusted, are verified first, in order,
+// and every trusted height is pruned from the journal.
+//
+// The steps are fetched from the primary, which the light client replaces by
+// a witness when it fails, as for light.Client.Update.
+func CatchUp(ctx context.Context, client *light.Client, span int64, journal *PivotJournal, now func() time.Time, trusted func(*cmttypes.LightBlock) error) (*cmttypes.LightBlock, error) {
+	var lightBlock *cmttypes.LightBlock
+	advance := func(next *cmttypes.LightBlock) error {
+		lightBlock = next
+		if err := trusted(next); err != nil {
+			return err
+		}
+		if journal != nil {
+			return journal.Prune(next.Height)
+		}
+		return nil
+	}
+
+	lastTrustedHeight, err := client.LastTrustedHeight()
+	if err != nil {
+		return nil, err
+	}
+	if journal != nil {
+		pivots, err := journal.Pivots()
+		if err != nil {
+			return nil, err
+		}
+		for _, pivot := range pivots {
+			if pivot <= lastTrustedHeight {
+				continue
+			}
+			next, err := client.VerifyLightBlockAtHeight(ctx, pivot, now())
+			if err != nil {
+				return nil, err
+			}
+			if err := advance(next); err != nil {
+				return nil, err
+			}
+			lastTrustedHeight = pivot
+		}
+	}
+
+	if span <= 0 {
+		next, err := client.Update(ctx, now())
+		if err != nil {
+			return nil, err
+		}
+		if next != nil {
+			if err := advance(next); err != nil {
+				return nil, err
+			}
+		}
+		return lightBlock, nil
+	}
+	latestHeight, err := primaryLatestHeight(ctx, client)
+	if err != nil {
+		return nil, err
+	}
+	for height := lastTrustedHeight; height < latestHeight; {
+		height = min(height+span, latestHeight)
+		next, err := client.VerifyLightBlockAtHeight(ctx, height, now())
+		if err != nil {
+			return nil, err
+		}
+		if err := advance(next); err != nil {
+			return nil, err
+		}
+	}
+	return lightBlock, nil
+}
+
+// primaryLatestHeight returns the latest height of the primary of a light
+// client, or of the first witness serving it when the primary fails, the
+// light client replacing the primary once the steps are fetched.
+func primaryLatestHeight(ctx context.Context, client *light.Client) (int64, error) {
+	latest, err := client.Primary().LightBlock(ctx, 0)
+	if err == nil {
+		return latest.Height, nil
+	}
+	for _, witness := range client.Witnesses() {
+		if latest, witnessErr := witness.LightBlock(ctx, 0); witnessErr == nil {
+			return latest.Height, nil
+		}
+	}
+	return 0, err
+}
@@ -0,0 +1,94 @@
+package verifier_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	dbm "github.com/cometbft/cometbft-db"
+	cmtlog "github.com/cometbft/cometbft/libs/log"
+	"github.com/cometbft/cometbft/light"
+	"github.com/cometbft/cometbft/light/provider"
+	lightdb "github.com/cometbft/cometbft/light/store/db"
+	cmttypes "github.com/cometbft/cometbft/types"
+	"github.com/stretchr/testify/require"
+
+	"union/verifier"
+	"union/verifier/lighttest"
+)
+
+func TestCatchUpResumesFromPivots(t *testing.T) {
+	const (
+		span           = 40
+		trustingPeriod = 24 * time.Hour
+	)
+	// The validators churn too fast to skip far, the light client bisects.
+	chain, err := lighttest.NewChain("catchup-1", 6, 1, lighttest.WithChurn(1))
+	require.NoError(t, err)
+	root, err := chain.LightBlock(1, false)
+	require.NoError(t, err)
+	clock := lighttest.NewClock(chain.Time(60))
+
+	db := dbm.NewMemDB()
+	store := lightdb.New(db, chain.ChainID)
+	journal := verifier.NewPivotJournal(db, chain.ChainID)
+	providers := func(witness *lighttest.Provider, latest int64) (provider.Provider, []provider.Provider) {
+		return journal.Wrap(lighttest.NewProvider("primary", chain, latest, false)), []provider.Provider{journal.Wrap(witness)}
+	}
+
+	// The witness stalls on the target of the catch-up, which is interrupted
+	// before it is trusted, once its pivots are verified.
+	witness := lighttest.NewProvider("witness", chain, 41, false)
+	witness.Script(41, lighttest.Response{Delay: time.Hour})
+	primary, witnesses := providers(witness, 41)
+	client, err := light.NewClient(
+		context.Background(),
+		chain.ChainID,
+		light.TrustOptions{Period: trustingPeriod, Height: 1, Hash: root.Hash()},
+		primary,
+		witnesses,
+		store,
+		light.Logger(cmtlog.NewNopLogger()),
+	)
+	require.NoError(t, err)
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	_, err = verifier.CatchUp(ctx, client, span, journal, clock.Now, func(*cmttypes.LightBlock) error { return nil })
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+
+	lastTrustedHeight, err := store.LastLightBlockHeight()
+	require.NoError(t, err)
+	require.Equal(t, int64(1), lastTrustedHeight)
+	pivots, err := journal.Pivots()
+	require.NoError(t, err)
+	require.Greater(t, len(pivots), 2, "the light client didn't bisect")
+	require.Equal(t, int64(41), pivots[len(pivots)-1])
+
+	// Restarted, the light client verifies the pivots in order before the
+	// steps of the catch-up to the new latest height.
+	primary, witnesses = providers(lighttest.NewProvider("witness", chain, 60, false), 60)
+	client, err = light.NewClientFromTrustedStore(chain.ChainID, trustingPeriod, primary, witnesses, store, light.Logger(cmtlog.NewNopLogger()))
+	require.NoError(t, err)
+	var trusted []int64
+	latest, err := verifier.CatchUp(context.Background(), client, span, journal, clock.Now, func(lightBlock *cmttypes.LightBlock) error {
+		// The previous step is pruned before the next one is verified.
+		if len(trusted) > 0 {
+			remaining, err := journal.Pivots()
+			require.NoError(t, err)
+			require.NotEmpty(t, remaining)
+			require.Greater(t, remaining[0], trusted[len(trusted)-1])
+		}
+		trusted = append(trusted, lightBlock.Height)
+		return nil
+	})
+	require.NoError(t, err)
+	require.Equal(t, int64(60), latest.Height)
+	// The root of trust is journaled as well.
+	require.Equal(t, int64(1), pivots[0])
+	require.Equal(t, append(pivots[1:], 60), trusted)
+
+	// Every pivot is pruned once trusted.
+	pivots, err = journal.Pivots()
+	require.NoError(t, err)
+	require.Empty(t, pivots)
+}
@@ -0,0 +1,34 @@
+package verifier
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	clienttypes "github.com/cosmos/ibc-go/v8/modules/core/02-client/types"
+)
+
+// ErrChainIDMismatch is returned when a light block belongs to another chain
+// than the one the verifier is bound to.
+var ErrChainIDMismatch = errors.New("chain id mismatch")
+
+// CheckChainID checks that a chain id is the bound one or, for chain ids in
+// the {name}-{revision} format, a later revision of the same chain, e.g.
+// union-2 once union-1 upgraded.
+func CheckChainID(bound, chainID string) error {
+	if chainID == bound {
+		return nil
+	}
+	if clienttypes.IsRevisionFormat(bound) &&
+		clienttypes.IsRevisionFormat(chainID) &&
+		chainName(bound) == chainName(chainID) &&
+		clienttypes.ParseChainID(chainID) > clienttypes.ParseChainID(bound) {
+		return nil
+	}
+	return fmt.Errorf("%w: bound to %s, got %s", ErrChainIDMismatch, bound, chainID)
+}
+
+// chainName strips the revision number of a chain id in revision format.
+func chainName(chainID string) string {
+	return chainID[:strings.LastIndex(chainID, "-")]
+}
@@ -0,0 +1,44 @@
+package verifier_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"union/verifier"
+)
+
+func TestCheckChainID(t *testing.T) {
+	for _, tc := range []struct {
+		name     string
+		bound    string
+		chainID  string
+		accepted bool
+	}{
+		{name: "same chain id", bound: "union-1", chainID: "union-1", accepted: true},
+		{name: "same chain id without revision", bound: "union", chainID: "union", accepted: true},
+		{name: "later revision", bound: "union-1", chainID: "union-2", accepted: true},
+		{name: "later revision compared as a number", bound: "union-9", chainID: "union-10", accepted: true},
+		{name: "later revision of a name with dashes", bound: "union-testnet-8", chainID: "union-testnet-9", accepted: true},
+		{name: "lower revision", bound: "union-2", chainID: "union-1"},
+		{name: "lower revision compared as a number", bound: "union-10", chainID: "union-9"},
+		{name: "bound without revision", bound: "union", chainID: "union-1"},
+		{name: "chain id without revision", bound: "union-1", chainID: "union"},
+		{name: "revision zero", bound: "union-0", chainID: "union-1"},
+		{name: "back to revision zero", bound: "union-1", chainID: "union-0"},
+		{name: "revision with a leading zero", bound: "union-1", chainID: "union-02"},
+		{name: "mismatched name", bound: "union-1", chainID: "other-2"},
+		{name: "name prefix", bound: "union-1", chainID: "union-testnet-2"},
+		{name: "name extending the bound one", bound: "union-testnet-1", chainID: "union-2"},
+		{name: "empty chain id", bound: "union-1", chainID: ""},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			err := verifier.CheckChainID(tc.bound, tc.chainID)
+			if tc.accepted {
+				require.NoError(t, err)
+			} else {
+				require.ErrorIs(t, err, verifier.ErrChainIDMismatch)
+			}
+		})
+	}
+}
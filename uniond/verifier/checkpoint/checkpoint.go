@@ -0,0 +1,237 @@
+// Package checkpoint publishes the latest trusted header of a light client
+// to external anchors, such as an object store or a directory replicated
+// off-site, so that the trust root can be recovered after a disaster
+// without relying on the providers. Checkpoints are signed by the
+// publishing operator: an anchor isn't trusted, it only stores them.
+package checkpoint
+
+import (
+	"bytes"
+	"context"
+	"crypto/ed25519"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	cmtbytes "github.com/cometbft/cometbft/libs/bytes"
+	cmttypes "github.com/cometbft/cometbft/types"
+)
+
+// Version is the domain separator of the signed bytes.
+const Version = "union-checkpoint1"
+
+// maxSize bounds the size of a fetched checkpoint.
+const maxSize = 64 << 10
+
+// ErrInvalidCheckpoint is returned for a checkpoint that can't be decoded or
+// whose signature doesn't verify.
+var ErrInvalidCheckpoint = errors.New("invalid checkpoint")
+
+// Checkpoint is a trusted header of a chain.
+type Checkpoint struct {
+	ChainID        string            `json:"chain_id"`
+	Height         int64             `json:"height"`
+	Hash           cmtbytes.HexBytes `json:"hash"`
+	ValidatorsHash cmtbytes.HexBytes `json:"validators_hash"`
+	Time           time.Time         `json:"time"`
+}
+
+// FromLightBlock returns the checkpoint of a trusted light block.
+func FromLightBlock(lightBlock *cmttypes.LightBlock) Checkpoint {
+	return Checkpoint{
+		ChainID:        lightBlock.ChainID,
+		Height:         lightBlock.Height,
+		Hash:           lightBlock.Hash(),
+		ValidatorsHash: lightBlock.ValidatorsHash,
+		Time:           lightBlock.Time.UTC(),
+	}
+}
+
+// SignBytes are the bytes signed by the publisher, binding every field of
+// the checkpoint.
+func (c Checkpoint) SignBytes() []byte {
+	return []byte(strings.Join([]string{
+		Version,
+		c.ChainID,
+		strconv.FormatInt(c.Height, 10),
+		c.Hash.String(),
+		c.ValidatorsHash.String(),
+		strconv.FormatInt(c.Time.UnixNano(), 10),
+	}, "\n"))
+}
+
+// Signed is a checkpoint signed by a publisher, as stored by the anchors.
+type Signed struct {
+	Checkpoint
+	PublicKey cmtbytes.HexBytes `json:"public_key"`
+	Signature cmtbytes.HexBytes `json:"signature"`
+}
+
+// Sign signs a checkpoint with the publisher key.
+func Sign(checkpoint Checkpoint, key ed25519.PrivateKey) *Signed {
+	return &Signed{
+		Checkpoint: checkpoint,
+		PublicKey:  cmtbytes.HexBytes(key.Public().(ed25519.PublicKey)),
+		Signature:  ed25519.Sign(key, checkpoint.SignBytes()),
+	}
+}
+
+// Verify checks that the checkpoint is of the chain and signed by one of
+// the publisher keys.
+func (s *Signed) Verify(chainID string, keys []ed25519.PublicKey) error {
+	if s.ChainID != chainID {
+		return fmt.Errorf("%w: checkpoint of chain %s, expected %s", ErrInvalidCheckpoint, s.ChainID, chainID)
+	}
+	if s.Height <= 0 || len(s.Hash) == 0 {
+		return fmt.Errorf("%w: missing height or hash", ErrInvalidCheckpoint)
+	}
+	for _, key := range keys {
+		if bytes.Equal(key, s.PublicKey) && ed25519.Verify(key, s.SignBytes(), s.Signature) {
+			return nil
+		}
+	}
+	return fmt.Errorf("%w: not signed by a trusted publisher", ErrInvalidCheckpoint)
+}
+
+// Anchor stores the published checkpoints.
+type Anchor interface {
+	Publish(ctx context.Context, checkpoint *Signed) error
+}
+
+// Multi publishes to every anchor, reporting all the failures.
+type Multi []Anchor
+
+func (m Multi) Publish(ctx context.Context, checkpoint *Signed) error {
+	var errs []error
+	for _, anchor := range m {
+		if err := anchor.Publish(ctx, checkpoint); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// Dir writes the checkpoints to <dir>/<chain-id>/<height>.json and the
+// latest one to <dir>/<chain-id>/latest.json, e.g. in a directory synced to
+// a bucket.
+type Dir string
+
+func (d Dir) Publish(_ context.Context, checkpoint *Signed) error {
+	bz, err := json.MarshalIndent(checkpoint, "", "  ")
+	if err != nil {
+		return err
+	}
+	dir := filepath.Join(string(d), checkpoint.ChainID)
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return err
+	}
+	for _, name := range []string{fmt.Sprintf("%d.json", checkpoint.Height), "latest.json"} {
+		if err := writeFileAtomic(filepath.Join(dir, name), bz); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// writeFileAtomic writes a file through a renamed temporary file, so that a
+// reader never sees a partial checkpoint.
+func writeFileAtomic(path string, bz []byte) error {
+	tmp, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".tmp*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+	if _, err := tmp.Write(bz); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Sync(); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), path)
+}
+
+// HTTP puts the latest checkpoint as JSON to an URL, e.g. a pre-signed
+// object store URL or any service anchoring it elsewhere, such as in a
+// contract of another chain.
+type HTTP struct {
+	URL string
+	// Client puts the checkpoints, http.DefaultClient if nil.
+	Client *http.Client
+}
+
+func (h HTTP) Publish(ctx context.Context, checkpoint *Signed) error {
+	bz, err := json.Marshal(checkpoint)
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPut, h.URL, bytes.NewReader(bz))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := client(h.Client).Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	io.Copy(io.Discard, io.LimitReader(resp.Body, 4096))
+	if resp.StatusCode/100 != 2 {
+		return fmt.Errorf("%s answered %s", h.URL, resp.Status)
+	}
+	return nil
+}
+
+// Fetch reads a checkpoint published by an anchor, from an http(s) URL or a
+// file path, and verifies it.
+func Fetch(ctx context.Context, httpClient *http.Client, location, chainID string, keys []ed25519.PublicKey) (*Signed, error) {
+	var bz []byte
+	if strings.HasPrefix(location, "http://") || strings.HasPrefix(location, "https://") {
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, location, nil)
+		if err != nil {
+			return nil, err
+		}
+		resp, err := client(httpClient).Do(req)
+		if err != nil {
+			return nil, err
+		}
+		defer resp.Body.Close()
+		if resp.StatusCode != http.StatusOK {
+			return nil, fmt.Errorf("%s answered %s", location, resp.Status)
+		}
+		if bz, err = io.ReadAll(io.LimitReader(resp.Body, maxSize)); err != nil {
+			return nil, err
+		}
+	} else {
+		var err error
+		if bz, err = os.ReadFile(location); err != nil {
+			return nil, err
+		}
+	}
+	var checkpoint Signed
+	if err := json.Unmarshal(bz, &checkpoint); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidCheckpoint, err)
+	}
+	if err := checkpoint.Verify(chainID, keys); err != nil {
+		return nil, err
+	}
+	return &checkpoint, nil
+}
+
+func client(c *http.Client) *http.Client {
+	if c == nil {
+		return http.DefaultClient
+	}
+	return c
+}
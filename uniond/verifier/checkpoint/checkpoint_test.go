@@ -0,0 +1,66 @@
+package checkpoint_test
+
+import (
+	"context"
+	"crypto/ed25519"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"union/verifier/checkpoint"
+)
+
+// key derives a publisher key from a single seed character.
+func key(seed string) (ed25519.PrivateKey, []ed25519.PublicKey) {
+	privKey := ed25519.NewKeyFromSeed([]byte(strings.Repeat(seed, ed25519.SeedSize)))
+	return privKey, []ed25519.PublicKey{privKey.Public().(ed25519.PublicKey)}
+}
+
+func TestCheckpointSignatureRoundTrip(t *testing.T) {
+	publisher, publisherKeys := key("p")
+	other, otherKeys := key("o")
+	trusted := checkpoint.Checkpoint{
+		ChainID:        "union-1",
+		Height:         42,
+		Hash:           []byte(strings.Repeat("h", 32)),
+		ValidatorsHash: []byte(strings.Repeat("v", 32)),
+		Time:           time.Unix(1_700_000_000, 0).UTC(),
+	}
+	dir := t.TempDir()
+	require.NoError(t, checkpoint.Dir(dir).Publish(context.Background(), checkpoint.Sign(trusted, publisher)))
+
+	latest := filepath.Join(dir, "union-1", "latest.json")
+	fetched, err := checkpoint.Fetch(context.Background(), nil, latest, "union-1", publisherKeys)
+	require.NoError(t, err)
+	require.Equal(t, trusted.Height, fetched.Height)
+	require.Equal(t, trusted.Hash, fetched.Hash)
+	require.Equal(t, trusted.ValidatorsHash, fetched.ValidatorsHash)
+	require.True(t, trusted.Time.Equal(fetched.Time))
+	_, err = checkpoint.Fetch(context.Background(), nil, filepath.Join(dir, "union-1", "42.json"), "union-1", publisherKeys)
+	require.NoError(t, err)
+
+	// Signed by another key than the trusted publisher.
+	_, err = checkpoint.Fetch(context.Background(), nil, latest, "union-1", otherKeys)
+	require.ErrorIs(t, err, checkpoint.ErrInvalidCheckpoint)
+	forged := checkpoint.Sign(trusted, other)
+	require.ErrorIs(t, forged.Verify("union-1", publisherKeys), checkpoint.ErrInvalidCheckpoint)
+	// Claiming the trusted public key doesn't help.
+	forged.PublicKey = []byte(publisherKeys[0])
+	require.ErrorIs(t, forged.Verify("union-1", publisherKeys), checkpoint.ErrInvalidCheckpoint)
+
+	// Any field changed invalidates the signature.
+	bz, err := os.ReadFile(latest)
+	require.NoError(t, err)
+	require.Contains(t, string(bz), `"height": 42`)
+	require.NoError(t, os.WriteFile(latest, []byte(strings.Replace(string(bz), `"height": 42`, `"height": 43`, 1)), 0o644))
+	_, err = checkpoint.Fetch(context.Background(), nil, latest, "union-1", publisherKeys)
+	require.ErrorIs(t, err, checkpoint.ErrInvalidCheckpoint)
+
+	// A checkpoint of another chain is rejected, even if correctly signed.
+	_, err = checkpoint.Fetch(context.Background(), nil, filepath.Join(dir, "union-1", "42.json"), "union-2", publisherKeys)
+	require.ErrorIs(t, err, checkpoint.ErrInvalidCheckpoint)
+}
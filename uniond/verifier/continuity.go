@@ -0,0 +1,69 @@
+package verifier
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/cometbft/cometbft/light"
+	cmttypes "github.com/cometbft/cometbft/types"
+)
+
+// ErrDiscontinuity is returned for an adjacent header that doesn't build on
+// the previous one.
+var ErrDiscontinuity = errors.New("discontinuity")
+
+// CheckContinuity checks that the header following a light block builds on
+// it: its last block id must be the block id committed to by the commit of
+// the previous light block, hash and part set header included.
+//
+// The app hash and last results hash of a header are the outcome of
+// executing the previous block, which a light client can't recompute. The
+// light client only checks that enough validators signed each header, so a
+// provider serving a header of another block at the right height, e.g. from
+// a corrupted store, goes unnoticed, while the execution lineage of the app
+// hashes is broken. The last block id binds each header to the block it
+// executed, catching such providers at the first broken link.
+func CheckContinuity(previous, next *cmttypes.LightBlock, previousLegacy bool) error {
+	if next.Height != previous.Height+1 {
+		return fmt.Errorf("%w: height %d doesn't follow height %d", ErrDiscontinuity, next.Height, previous.Height)
+	}
+	if hash := LightBlockHash(previous, previousLegacy); !digestsEqual(next.LastBlockID.Hash, hash) {
+		return fmt.Errorf("%w: height %d builds on block %X, expected %X", ErrDiscontinuity, next.Height, next.LastBlockID.Hash, hash)
+	}
+	if psh := previous.Commit.BlockID.PartSetHeader; !next.LastBlockID.PartSetHeader.Equals(psh) {
+		return fmt.Errorf("%w: height %d builds on the part set %v, expected the committed %v", ErrDiscontinuity, next.Height, next.LastBlockID.PartSetHeader, psh)
+	}
+	return nil
+}
+
+// VerifyBackwards verifies a header older than a trusted one, as
+// light.VerifyBackwards: the trusted header must build on it. The header is
+// hashed under the given scheme, and the hashes are compared in constant
+// time. A header of the other scheme can make the hashing panic, which fails
+// as an invalid header.
+func VerifyBackwards(untrustedHeader, trustedHeader *cmttypes.Header, legacy bool) (err error) {
+	if err := untrustedHeader.ValidateBasic(); err != nil {
+		return light.ErrInvalidHeader{Reason: err}
+	}
+	if untrustedHeader.ChainID != trustedHeader.ChainID {
+		return light.ErrInvalidHeader{Reason: errors.New("header belongs to another chain")}
+	}
+	if !untrustedHeader.Time.Before(trustedHeader.Time) {
+		return light.ErrInvalidHeader{Reason: fmt.Errorf("expected older header time %v to be before new header time %v", untrustedHeader.Time, trustedHeader.Time)}
+	}
+	defer func() {
+		if r := recover(); r != nil {
+			err = light.ErrInvalidHeader{Reason: fmt.Errorf("can't hash the older header: %v", r)}
+		}
+	}()
+	var hash []byte
+	if legacy {
+		hash = untrustedHeader.HashSha256()
+	} else {
+		hash = untrustedHeader.Hash()
+	}
+	if !digestsEqual(hash, trustedHeader.LastBlockID.Hash) {
+		return light.ErrInvalidHeader{Reason: fmt.Errorf("older header hash %X does not match trusted header's last block %X", hash, trustedHeader.LastBlockID.Hash)}
+	}
+	return nil
+}
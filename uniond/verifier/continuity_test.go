@@ -0,0 +1,117 @@
+package verifier_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/cometbft/cometbft/light"
+	cmttypes "github.com/cometbft/cometbft/types"
+	"github.com/stretchr/testify/require"
+
+	"union/verifier"
+	"union/verifier/lighttest"
+)
+
+func TestCheckContinuity(t *testing.T) {
+	linked, err := lighttest.NewChain("continuity-1", 4, 1, lighttest.WithLinkedHeaders())
+	require.NoError(t, err)
+	unlinked, err := lighttest.NewChain("continuity-1", 4, 1)
+	require.NoError(t, err)
+
+	for _, legacy := range []bool{false, true} {
+		lightBlocks, err := linked.LightBlocks(1, 6, legacy)
+		require.NoError(t, err)
+		for i := 1; i < len(lightBlocks); i++ {
+			require.NoError(t, verifier.CheckContinuity(lightBlocks[i-1], lightBlocks[i], legacy))
+		}
+
+		previous, next := lightBlocks[2], lightBlocks[3]
+		other, err := linked.LightBlock(2, legacy)
+		require.NoError(t, err)
+		withLastBlockID := func(lastBlockID cmttypes.BlockID) *cmttypes.LightBlock {
+			header := *next.Header
+			header.LastBlockID = lastBlockID
+			return &cmttypes.LightBlock{
+				SignedHeader: &cmttypes.SignedHeader{Header: &header, Commit: next.Commit},
+				ValidatorSet: next.ValidatorSet,
+			}
+		}
+		unlinkedNext, err := unlinked.LightBlock(next.Height, legacy)
+		require.NoError(t, err)
+
+		for _, tc := range []struct {
+			name     string
+			previous *cmttypes.LightBlock
+			next     *cmttypes.LightBlock
+		}{
+			{
+				name:     "swapped hash",
+				previous: previous,
+				next: withLastBlockID(cmttypes.BlockID{
+					Hash:          other.Commit.BlockID.Hash,
+					PartSetHeader: next.LastBlockID.PartSetHeader,
+				}),
+			},
+			{
+				name:     "swapped part set header",
+				previous: previous,
+				next: withLastBlockID(cmttypes.BlockID{
+					Hash:          next.LastBlockID.Hash,
+					PartSetHeader: other.Commit.BlockID.PartSetHeader,
+				}),
+			},
+			{
+				name:     "unlinked header",
+				previous: previous,
+				next:     unlinkedNext,
+			},
+			{
+				name:     "non adjacent",
+				previous: lightBlocks[1],
+				next:     next,
+			},
+		} {
+			t.Run(tc.name, func(t *testing.T) {
+				require.ErrorIs(t, verifier.CheckContinuity(tc.previous, tc.next, legacy), verifier.ErrDiscontinuity)
+			})
+		}
+	}
+}
+
+func TestVerifyBackwards(t *testing.T) {
+	chain, err := lighttest.NewChain("continuity-1", 4, 1, lighttest.WithLinkedHeaders())
+	require.NoError(t, err)
+
+	for _, legacy := range []bool{false, true} {
+		older, err := chain.Header(3, legacy)
+		require.NoError(t, err)
+		trusted, err := chain.Header(4, legacy)
+		require.NoError(t, err)
+		with := func(mutate func(*cmttypes.Header)) *cmttypes.Header {
+			header := *older
+			mutate(&header)
+			return &header
+		}
+
+		require.NoError(t, verifier.VerifyBackwards(older, trusted, legacy))
+		if !legacy {
+			require.NoError(t, light.VerifyBackwards(older, trusted))
+		}
+		for _, tc := range []struct {
+			name        string
+			header      *cmttypes.Header
+			otherScheme bool
+		}{
+			{name: "other scheme", header: older, otherScheme: true},
+			{name: "other chain", header: with(func(header *cmttypes.Header) { header.ChainID = "continuity-2" })},
+			{name: "not older", header: with(func(header *cmttypes.Header) { header.Time = trusted.Time })},
+			{name: "tampered header", header: with(func(header *cmttypes.Header) { header.AppHash = lighttest.FieldHash("tampered") })},
+			{name: "invalid header", header: with(func(header *cmttypes.Header) { header.ValidatorsHash = []byte("short") })},
+		} {
+			t.Run(fmt.Sprintf("%s %s", verifier.SchemeName(legacy), tc.name), func(t *testing.T) {
+				err := verifier.VerifyBackwards(tc.header, trusted, legacy != tc.otherScheme)
+				require.ErrorAs(t, err, &light.ErrInvalidHeader{})
+			})
+		}
+	}
+}
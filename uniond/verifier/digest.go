@@ -0,0 +1,10 @@
+package verifier
+
+import "crypto/subtle"
+
+// digestsEqual compares security relevant digests, such as header and
+// validator set hashes, in constant time so that a caller of the verifier
+// can't learn how much of a forged digest matches by timing the responses.
+func digestsEqual(a, b []byte) bool {
+	return subtle.ConstantTimeCompare(a, b) == 1
+}
@@ -0,0 +1,165 @@
+// Package discovery discovers the RPC providers of a chain from signed DNS
+// TXT records, so that light client configurations don't have to hardcode
+// endpoints. Records are only used once signed by a trusted publisher key:
+// the DNS isn't trusted, and a discovered provider is still cross-checked by
+// the light client like any other.
+package discovery
+
+import (
+	"context"
+	"crypto/ed25519"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Version prefixes every record, TXT records of other formats are ignored.
+const Version = "union-rpc1"
+
+var (
+	// ErrInvalidRecord is returned for a record that can't be parsed or
+	// whose signature doesn't verify.
+	ErrInvalidRecord = errors.New("invalid provider record")
+	// ErrNoProvider is returned when no valid record is found for a chain.
+	ErrNoProvider = errors.New("no provider discovered")
+)
+
+// Record announces an RPC provider of a chain until it expires. It is
+// published as a single TXT record:
+//
+//	v=union-rpc1 chain=<chain-id> url=<url> expires=<unix> sig=<base64>
+type Record struct {
+	ChainID string
+	URL     string
+	Expires time.Time
+}
+
+// SignBytes are the bytes signed by the publisher, binding every field of
+// the record.
+func (r Record) SignBytes() []byte {
+	return []byte(strings.Join([]string{Version, r.ChainID, r.URL, strconv.FormatInt(r.Expires.Unix(), 10)}, "\n"))
+}
+
+// Sign returns the TXT record signed with the publisher key.
+func (r Record) Sign(key ed25519.PrivateKey) (string, error) {
+	if err := r.validate(); err != nil {
+		return "", err
+	}
+	sig := ed25519.Sign(key, r.SignBytes())
+	return fmt.Sprintf("v=%s chain=%s url=%s expires=%d sig=%s", Version, r.ChainID, r.URL, r.Expires.Unix(), base64.StdEncoding.EncodeToString(sig)), nil
+}
+
+// Verify checks that the record is signed by one of the publisher keys and
+// not expired.
+func (r Record) Verify(sig []byte, keys []ed25519.PublicKey, now time.Time) error {
+	if !now.Before(r.Expires) {
+		return fmt.Errorf("%w: expired at %s", ErrInvalidRecord, r.Expires.UTC().Format(time.RFC3339))
+	}
+	for _, key := range keys {
+		if ed25519.Verify(key, r.SignBytes(), sig) {
+			return nil
+		}
+	}
+	return fmt.Errorf("%w: not signed by a trusted publisher", ErrInvalidRecord)
+}
+
+func (r Record) validate() error {
+	if r.ChainID == "" || strings.ContainsAny(r.ChainID, " \t\n") {
+		return fmt.Errorf("%w: invalid chain id %q", ErrInvalidRecord, r.ChainID)
+	}
+	if strings.ContainsAny(r.URL, " \t\n") {
+		return fmt.Errorf("%w: invalid url %q", ErrInvalidRecord, r.URL)
+	}
+	u, err := url.Parse(r.URL)
+	if err != nil || u.Scheme == "" || u.Host == "" {
+		return fmt.Errorf("%w: invalid url %q", ErrInvalidRecord, r.URL)
+	}
+	return nil
+}
+
+// ParseRecord parses a TXT record, returning the record and its signature.
+func ParseRecord(txt string) (Record, []byte, error) {
+	fields := map[string]string{}
+	for _, field := range strings.Fields(txt) {
+		key, value, ok := strings.Cut(field, "=")
+		if !ok {
+			return Record{}, nil, fmt.Errorf("%w: malformed field %q", ErrInvalidRecord, field)
+		}
+		if _, dup := fields[key]; dup {
+			return Record{}, nil, fmt.Errorf("%w: duplicate field %q", ErrInvalidRecord, key)
+		}
+		fields[key] = value
+	}
+	if fields["v"] != Version {
+		return Record{}, nil, fmt.Errorf("%w: version %q, expected %s", ErrInvalidRecord, fields["v"], Version)
+	}
+	expires, err := strconv.ParseInt(fields["expires"], 10, 64)
+	if err != nil {
+		return Record{}, nil, fmt.Errorf("%w: invalid expiry: %s", ErrInvalidRecord, err)
+	}
+	sig, err := base64.StdEncoding.DecodeString(fields["sig"])
+	if err != nil {
+		return Record{}, nil, fmt.Errorf("%w: invalid signature: %s", ErrInvalidRecord, err)
+	}
+	record := Record{ChainID: fields["chain"], URL: fields["url"], Expires: time.Unix(expires, 0)}
+	if err := record.validate(); err != nil {
+		return Record{}, nil, err
+	}
+	return record, sig, nil
+}
+
+// Resolver looks up TXT records, e.g. a *net.Resolver.
+type Resolver interface {
+	LookupTXT(ctx context.Context, name string) ([]string, error)
+}
+
+// Discover returns the URLs of the providers of a chain announced under a
+// DNS name, in the order of the records. Records of other formats or chains
+// are ignored, and records that are expired or not signed by one of the
+// publisher keys are skipped.
+func Discover(ctx context.Context, resolver Resolver, name string, chainID string, keys []ed25519.PublicKey, now time.Time) ([]string, error) {
+	if len(keys) == 0 {
+		return nil, errors.New("at least one publisher key is required")
+	}
+	txts, err := resolver.LookupTXT(ctx, name)
+	if err != nil {
+		return nil, fmt.Errorf("can't look up %s: %w", name, err)
+	}
+	var (
+		urls    []string
+		seen    = map[string]bool{}
+		skipped []error
+	)
+	for _, txt := range txts {
+		if !strings.HasPrefix(txt, "v="+Version+" ") {
+			continue
+		}
+		record, sig, err := ParseRecord(txt)
+		if err == nil && record.ChainID != chainID {
+			continue
+		}
+		if err == nil {
+			err = record.Verify(sig, keys, now)
+		}
+		if err != nil {
+			skipped = append(skipped, err)
+			continue
+		}
+		if !seen[record.URL] {
+			seen[record.URL] = true
+			urls = append(urls, record.URL)
+		}
+	}
+	if len(urls) == 0 {
+		err := fmt.Errorf("%w for %s under %s", ErrNoProvider, chainID, name)
+		if len(skipped) > 0 {
+			err = fmt.Errorf("%w: %w", err, errors.Join(skipped...))
+		}
+		return nil, err
+	}
+	return urls, nil
+}
@@ -0,0 +1,61 @@
+package discovery_test
+
+import (
+	"context"
+	"crypto/ed25519"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"union/verifier/discovery"
+)
+
+// resolver serves fixed TXT records.
+type resolver []string
+
+func (r resolver) LookupTXT(context.Context, string) ([]string, error) {
+	return r, nil
+}
+
+// key derives a publisher key from a single seed character.
+func key(seed string) (ed25519.PrivateKey, []ed25519.PublicKey) {
+	privKey := ed25519.NewKeyFromSeed([]byte(strings.Repeat(seed, ed25519.SeedSize)))
+	return privKey, []ed25519.PublicKey{privKey.Public().(ed25519.PublicKey)}
+}
+
+func TestRecordSignatureRoundTrip(t *testing.T) {
+	now := time.Unix(1_700_000_000, 0)
+	publisher, publisherKeys := key("p")
+	other, otherKeys := key("o")
+	record := discovery.Record{ChainID: "union-1", URL: "https://rpc.union.build", Expires: now.Add(time.Hour)}
+	txt, err := record.Sign(publisher)
+	require.NoError(t, err)
+
+	parsed, sig, err := discovery.ParseRecord(txt)
+	require.NoError(t, err)
+	require.Equal(t, record.ChainID, parsed.ChainID)
+	require.Equal(t, record.URL, parsed.URL)
+	require.True(t, record.Expires.Equal(parsed.Expires))
+	require.NoError(t, parsed.Verify(sig, publisherKeys, now))
+
+	// Signed by another key than the trusted publisher.
+	err = parsed.Verify(sig, otherKeys, now)
+	require.ErrorIs(t, err, discovery.ErrInvalidRecord)
+	// Any field changed invalidates the signature.
+	tampered := strings.Replace(txt, "url=https://rpc.union.build", "url=https://rpc.attacker.example", 1)
+	parsed, sig, err = discovery.ParseRecord(tampered)
+	require.NoError(t, err)
+	require.ErrorIs(t, parsed.Verify(sig, publisherKeys, now), discovery.ErrInvalidRecord)
+
+	// Discovery only keeps the records of the trusted publishers.
+	forged, err := discovery.Record{ChainID: "union-1", URL: "https://rpc.attacker.example", Expires: now.Add(time.Hour)}.Sign(other)
+	require.NoError(t, err)
+	urls, err := discovery.Discover(context.Background(), resolver{forged, txt}, "_rpc.union.build", "union-1", publisherKeys, now)
+	require.NoError(t, err)
+	require.Equal(t, []string{"https://rpc.union.build"}, urls)
+	_, err = discovery.Discover(context.Background(), resolver{forged}, "_rpc.union.build", "union-1", publisherKeys, now)
+	require.ErrorIs(t, err, discovery.ErrNoProvider)
+	require.ErrorIs(t, err, discovery.ErrInvalidRecord)
+}
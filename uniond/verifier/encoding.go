@@ -0,0 +1,134 @@
+package verifier
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+
+	"github.com/cometbft/cometbft/crypto/bn254"
+	cmttypes "github.com/cometbft/cometbft/types"
+	gnarkbn254 "github.com/consensys/gnark-crypto/ecc/bn254"
+	"github.com/consensys/gnark-crypto/ecc/bn254/fr"
+)
+
+var (
+	// ErrInvalidPubKey is returned for a validator public key that isn't the
+	// canonical encoding of a bn254 G1 point.
+	ErrInvalidPubKey = errors.New("invalid bn254 public key")
+	// ErrInvalidSignature is returned for a commit signature that isn't the
+	// canonical encoding of a bn254 G2 point.
+	ErrInvalidSignature = errors.New("invalid bn254 signature")
+	// ErrInvalidFieldElement is returned for a header field that can't be
+	// hashed with MiMC as it isn't a canonical bn254 scalar.
+	ErrInvalidFieldElement = errors.New("invalid bn254 scalar field element")
+)
+
+// CheckPubKey checks that a public key is the compressed encoding of a bn254
+// G1 point of the prime order subgroup other than the identity, with a
+// canonical coordinate and no trailing bytes, so that a key has a single
+// encoding and address.
+func CheckPubKey(pubKey []byte) error {
+	if len(pubKey) != gnarkbn254.SizeOfG1AffineCompressed {
+		return fmt.Errorf("%w: expected %d bytes, got %d", ErrInvalidPubKey, gnarkbn254.SizeOfG1AffineCompressed, len(pubKey))
+	}
+	var point gnarkbn254.G1Affine
+	if _, err := point.SetBytes(pubKey); err != nil {
+		return fmt.Errorf("%w: %s", ErrInvalidPubKey, err)
+	}
+	if point.IsInfinity() {
+		return fmt.Errorf("%w: point at infinity", ErrInvalidPubKey)
+	}
+	if encoded := point.Bytes(); !bytes.Equal(encoded[:], pubKey) {
+		return fmt.Errorf("%w: non canonical encoding", ErrInvalidPubKey)
+	}
+	return nil
+}
+
+// CheckSignature checks that a signature is the compressed encoding of a
+// bn254 G2 point of the prime order subgroup other than the identity, with
+// canonical coordinates and no trailing bytes, so that it can't be malleated.
+func CheckSignature(signature []byte) error {
+	if len(signature) != gnarkbn254.SizeOfG2AffineCompressed {
+		return fmt.Errorf("%w: expected %d bytes, got %d", ErrInvalidSignature, gnarkbn254.SizeOfG2AffineCompressed, len(signature))
+	}
+	var point gnarkbn254.G2Affine
+	if _, err := point.SetBytes(signature); err != nil {
+		return fmt.Errorf("%w: %s", ErrInvalidSignature, err)
+	}
+	if point.IsInfinity() {
+		return fmt.Errorf("%w: point at infinity", ErrInvalidSignature)
+	}
+	if encoded := point.Bytes(); !bytes.Equal(encoded[:], signature) {
+		return fmt.Errorf("%w: non canonical encoding", ErrInvalidSignature)
+	}
+	return nil
+}
+
+// CheckEncoding checks the keys, voting powers and signatures of a light block
+// and, unless legacy, that its header can be hashed with MiMC. It must run before the
+// light block is hashed or verified, which assume well formed data and panic
+// otherwise.
+func CheckEncoding(lightBlock *cmttypes.LightBlock, legacy bool) error {
+	if lightBlock.SignedHeader == nil || lightBlock.Header == nil || lightBlock.Commit == nil || lightBlock.ValidatorSet == nil {
+		return errors.New("light block must contain a header, a commit and a validator set")
+	}
+	for i, val := range lightBlock.ValidatorSet.Validators {
+		if val == nil || val.PubKey == nil {
+			return fmt.Errorf("validator #%d: %w: missing", i, ErrInvalidPubKey)
+		}
+		if _, ok := val.PubKey.(bn254.PubKey); !ok {
+			return fmt.Errorf("validator #%d: %w: unsupported %s key", i, ErrInvalidPubKey, val.PubKey.Type())
+		}
+		if err := CheckPubKey(val.PubKey.Bytes()); err != nil {
+			return fmt.Errorf("validator #%d: %w", i, err)
+		}
+	}
+	if err := CheckValidatorSetPowers(lightBlock.ValidatorSet); err != nil {
+		return err
+	}
+	for i, sig := range lightBlock.Commit.Signatures {
+		if sig.BlockIDFlag == cmttypes.BlockIDFlagAbsent {
+			continue
+		}
+		if err := CheckSignature(sig.Signature); err != nil {
+			return fmt.Errorf("commit signature #%d: %w", i, err)
+		}
+	}
+	if legacy {
+		return nil
+	}
+
+	header := lightBlock.Header
+	// The chain id and the byte strings split in a head byte and a tail are
+	// hashed as single scalars.
+	if len(header.ChainID) >= fr.Bytes {
+		return fmt.Errorf("%w: chain id of %d bytes", ErrInvalidFieldElement, len(header.ChainID))
+	}
+	if len(header.AppHash) > fr.Bytes {
+		return fmt.Errorf("%w: app hash of %d bytes", ErrInvalidFieldElement, len(header.AppHash))
+	}
+	// MiMC hashes are hashed as is.
+	fields := []struct {
+		name     string
+		hash     []byte
+		optional bool
+	}{
+		{"validators hash", header.ValidatorsHash, false},
+		{"next validators hash", header.NextValidatorsHash, false},
+		// The first block has no last block id.
+		{"last block id hash", header.LastBlockID.Hash, true},
+	}
+	for _, field := range fields {
+		if len(field.hash) == 0 && field.optional {
+			continue
+		}
+		if len(field.hash) != fr.Bytes {
+			return fmt.Errorf("%w: %s of %d bytes", ErrInvalidFieldElement, field.name, len(field.hash))
+		}
+		var element fr.Element
+		if err := element.SetBytesCanonical(field.hash); err != nil {
+			return fmt.Errorf("%w: %s: %s", ErrInvalidFieldElement, field.name, err)
+		}
+	}
+	return nil
+}
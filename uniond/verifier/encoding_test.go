@@ -0,0 +1,223 @@
+package verifier_test
+
+import (
+	"encoding/base64"
+	"os"
+	"path/filepath"
+	"testing"
+
+	cmtjson "github.com/cometbft/cometbft/libs/json"
+	cmttypes "github.com/cometbft/cometbft/types"
+	gnarkbn254 "github.com/consensys/gnark-crypto/ecc/bn254"
+	"github.com/consensys/gnark-crypto/ecc/bn254/fp"
+	"github.com/stretchr/testify/require"
+
+	"union/verifier"
+)
+
+const (
+	compressedSmallest = 0b10 << 6
+	compressedInfinity = 0b01 << 6
+)
+
+// devnetLightBlock reads a light block served by a single validator devnet.
+func devnetLightBlock(t *testing.T) *cmttypes.LightBlock {
+	bz, err := os.ReadFile(filepath.Join("testdata", "encoding", "light_block.json"))
+	require.NoError(t, err)
+	var lightBlock cmttypes.LightBlock
+	require.NoError(t, cmtjson.Unmarshal(bz, &lightBlock))
+	return &lightBlock
+}
+
+func decodeBase64(t *testing.T, s string) []byte {
+	bz, err := base64.StdEncoding.DecodeString(s)
+	require.NoError(t, err)
+	return bz
+}
+
+// modulus returns the base field modulus, the smallest non canonical
+// coordinate, with the given flags.
+func modulus(flags byte) []byte {
+	bz := make([]byte, fp.Bytes)
+	fp.Modulus().FillBytes(bz)
+	bz[0] |= flags
+	return bz
+}
+
+// offCurveG1 returns the smallest x coordinate of no G1 point.
+func offCurveG1() []byte {
+	var x, rhs, three fp.Element
+	three.SetUint64(3)
+	for i := uint64(1); ; i++ {
+		x.SetUint64(i)
+		rhs.Square(&x).Mul(&rhs, &x).Add(&rhs, &three)
+		if rhs.Legendre() == -1 {
+			bz := x.Bytes()
+			bz[0] |= compressedSmallest
+			return bz[:]
+		}
+	}
+}
+
+// g2Points hands the points of x coordinate (i, 0), for i from 1, to solve
+// until it returns true, along with whether they are on the curve. The y
+// coordinate of the points off the curve is left to zero.
+func g2Points(solve func(point *gnarkbn254.G2Affine, onCurve bool) bool) {
+	_, _, _, g2 := gnarkbn254.Generators()
+	// b' = y² - x³ of the generator.
+	b := g2.Y
+	b.Square(&b)
+	x3 := g2.X
+	x3.Square(&x3).Mul(&x3, &g2.X)
+	b.Sub(&b, &x3)
+	for i := uint64(1); ; i++ {
+		var point gnarkbn254.G2Affine
+		point.X.A0.SetUint64(i)
+		rhs := point.X
+		rhs.Square(&rhs).Mul(&rhs, &point.X).Add(&rhs, &b)
+		onCurve := rhs.Legendre() == 1
+		if onCurve {
+			point.Y.Sqrt(&rhs)
+		}
+		if solve(&point, onCurve) {
+			return
+		}
+	}
+}
+
+func TestCheckPubKey(t *testing.T) {
+	lightBlock := devnetLightBlock(t)
+	infinity := make([]byte, gnarkbn254.SizeOfG1AffineCompressed)
+	infinity[0] = compressedInfinity
+	_, _, generator, _ := gnarkbn254.Generators()
+	uncompressed := generator.RawBytes()
+
+	for _, tc := range []struct {
+		name   string
+		pubKey []byte
+		valid  bool
+		reason string
+	}{
+		{name: "devnet validator", pubKey: lightBlock.ValidatorSet.Validators[0].PubKey.Bytes(), valid: true},
+		{name: "union-testnet-8 genesis validator", pubKey: decodeBase64(t, "qjLfwpmv7J8EXAnAt7ULSIi3lMbBJ84U6qnm8893L9U="), valid: true},
+		{name: "point at infinity", pubKey: infinity, reason: "point at infinity"},
+		{name: "coordinate equal to the modulus", pubKey: modulus(compressedSmallest), reason: "invalid fp.Element encoding"},
+		{name: "off the curve", pubKey: offCurveG1(), reason: "square root doesn't exist"},
+		{name: "uncompressed flag", pubKey: uncompressed[:gnarkbn254.SizeOfG1AffineCompressed], reason: "short buffer"},
+		{name: "uncompressed", pubKey: uncompressed[:], reason: "bytes, got"},
+		{name: "too short", pubKey: lightBlock.ValidatorSet.Validators[0].PubKey.Bytes()[1:], reason: "bytes, got"},
+		{name: "trailing byte", pubKey: append(append([]byte(nil), lightBlock.ValidatorSet.Validators[0].PubKey.Bytes()...), 0), reason: "bytes, got"},
+		{name: "empty", pubKey: nil, reason: "bytes, got"},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			err := verifier.CheckPubKey(tc.pubKey)
+			if tc.valid {
+				require.NoError(t, err)
+			} else {
+				require.ErrorIs(t, err, verifier.ErrInvalidPubKey)
+				require.ErrorContains(t, err, tc.reason)
+			}
+		})
+	}
+}
+
+func TestCheckSignature(t *testing.T) {
+	lightBlock := devnetLightBlock(t)
+	signature := lightBlock.Commit.Signatures[0].Signature
+	infinity := make([]byte, gnarkbn254.SizeOfG2AffineCompressed)
+	infinity[0] = compressedInfinity
+	// The first coordinate encoded is the imaginary part of x.
+	coordinate := append(modulus(compressedSmallest), make([]byte, fp.Bytes)...)
+	var offCurve, outsideSubgroup []byte
+	g2Points(func(point *gnarkbn254.G2Affine, onCurve bool) bool {
+		if !onCurve && offCurve == nil {
+			bz := point.Bytes()
+			// Encoded as is, the y coordinate being recomputed.
+			offCurve = bz[:]
+		}
+		if onCurve && outsideSubgroup == nil && !point.IsInSubGroup() {
+			require.True(t, point.IsOnCurve())
+			bz := point.Bytes()
+			outsideSubgroup = bz[:]
+		}
+		return offCurve != nil && outsideSubgroup != nil
+	})
+
+	for _, tc := range []struct {
+		name      string
+		signature []byte
+		valid     bool
+		reason    string
+	}{
+		{name: "devnet commit signature", signature: signature, valid: true},
+		{name: "point at infinity", signature: infinity, reason: "point at infinity"},
+		{name: "coordinate equal to the modulus", signature: coordinate, reason: "invalid fp.Element encoding"},
+		{name: "off the curve", signature: offCurve, reason: "square root doesn't exist"},
+		{name: "outside the subgroup", signature: outsideSubgroup, reason: "subgroup check failed"},
+		{name: "too short", signature: signature[1:], reason: "bytes, got"},
+		{name: "trailing byte", signature: append(append([]byte(nil), signature...), 0), reason: "bytes, got"},
+		{name: "empty", signature: nil, reason: "bytes, got"},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			err := verifier.CheckSignature(tc.signature)
+			if tc.valid {
+				require.NoError(t, err)
+			} else {
+				require.ErrorIs(t, err, verifier.ErrInvalidSignature)
+				require.ErrorContains(t, err, tc.reason)
+			}
+		})
+	}
+}
+
+func TestCheckEncoding(t *testing.T) {
+	lightBlock := devnetLightBlock(t)
+	require.NoError(t, verifier.CheckEncoding(lightBlock, false))
+	require.NoError(t, verifier.ValidateLightBlock(lightBlock, false))
+	require.NoError(t, lightBlock.ValidatorSet.VerifyCommitLight(lightBlock.ChainID, lightBlock.Commit.BlockID, lightBlock.Height, lightBlock.Commit))
+	legacy, err := verifier.DetectLegacy(lightBlock, verifier.AnyHeight)
+	require.NoError(t, err)
+	require.False(t, legacy)
+
+	for _, tc := range []struct {
+		name   string
+		mutate func(*cmttypes.LightBlock)
+		err    error
+	}{
+		{
+			name: "signature at infinity",
+			mutate: func(lightBlock *cmttypes.LightBlock) {
+				lightBlock.Commit.Signatures[0].Signature = make([]byte, gnarkbn254.SizeOfG2AffineCompressed)
+				lightBlock.Commit.Signatures[0].Signature[0] = compressedInfinity
+			},
+			err: verifier.ErrInvalidSignature,
+		},
+		{
+			name: "truncated signature",
+			mutate: func(lightBlock *cmttypes.LightBlock) {
+				lightBlock.Commit.Signatures[0].Signature = lightBlock.Commit.Signatures[0].Signature[1:]
+			},
+			err: verifier.ErrInvalidSignature,
+		},
+		{
+			name: "validators hash above the scalar field",
+			mutate: func(lightBlock *cmttypes.LightBlock) {
+				lightBlock.ValidatorsHash = modulus(0xff)
+			},
+			err: verifier.ErrInvalidFieldElement,
+		},
+		{
+			name: "short next validators hash",
+			mutate: func(lightBlock *cmttypes.LightBlock) {
+				lightBlock.NextValidatorsHash = lightBlock.NextValidatorsHash[1:]
+			},
+			err: verifier.ErrInvalidFieldElement,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			lightBlock := devnetLightBlock(t)
+			tc.mutate(lightBlock)
+			require.ErrorIs(t, verifier.CheckEncoding(lightBlock, false), tc.err)
+		})
+	}
+}
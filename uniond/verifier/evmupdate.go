@@ -0,0 +1,158 @@
+package verifier
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"math/big"
+
+	"golang.org/x/crypto/sha3"
+)
+
+// ErrNotUpdateClient is returned for the calldata of an EVM transaction that
+// isn't an updateClient call of the IBC handler.
+var ErrNotUpdateClient = errors.New("not an updateClient call")
+
+// updateClientSelector selects IBCHandler.updateClient(MsgUpdateClient).
+var updateClientSelector = func() []byte {
+	h := sha3.NewLegacyKeccak256()
+	h.Write([]byte("updateClient((uint32,bytes,address))"))
+	return h.Sum(nil)[:4]
+}()
+
+// EVMUpdateClient is an update of a cometbls client by the IBC handler of an
+// EVM chain, as decoded from the calldata of its transaction.
+type EVMUpdateClient struct {
+	ClientID      uint32
+	Relayer       [20]byte
+	Header        ZKPHeader
+	TrustedHeight uint64
+	// ZKP is the zero knowledge proof of the transition from TrustedHeight to
+	// the header, see VerifyZKP.
+	ZKP []byte
+}
+
+// abiWords reads the ABI encoded words of calldata, strictly: offsets and
+// lengths must stay in bounds and integers must not have dirty high bits.
+type abiWords []byte
+
+func (w abiWords) word(offset uint64) ([]byte, error) {
+	if offset > uint64(len(w)) || uint64(len(w))-offset < 32 {
+		return nil, fmt.Errorf("word at %d out of the %d bytes", offset, len(w))
+	}
+	return w[offset : offset+32], nil
+}
+
+func (w abiWords) uint(offset uint64, bits int) (uint64, error) {
+	word, err := w.word(offset)
+	if err != nil {
+		return 0, err
+	}
+	n := new(big.Int).SetBytes(word)
+	if n.BitLen() > bits {
+		return 0, fmt.Errorf("word at %d overflows uint%d", offset, bits)
+	}
+	return n.Uint64(), nil
+}
+
+func (w abiWords) address(offset uint64) (out [20]byte, err error) {
+	word, err := w.word(offset)
+	if err != nil {
+		return out, err
+	}
+	if new(big.Int).SetBytes(word).BitLen() > 160 {
+		return out, fmt.Errorf("word at %d overflows an address", offset)
+	}
+	copy(out[:], word[12:])
+	return out, nil
+}
+
+func (w abiWords) bytes32(offset uint64) (out [32]byte, err error) {
+	word, err := w.word(offset)
+	if err != nil {
+		return out, err
+	}
+	copy(out[:], word)
+	return out, nil
+}
+
+// dynamicBytes reads the bytes encoded at the offset stored at offset,
+// relative to base.
+func (w abiWords) dynamicBytes(base, offset uint64) ([]byte, error) {
+	relative, err := w.uint(offset, 64)
+	if err != nil {
+		return nil, err
+	}
+	if relative > uint64(len(w))-base {
+		return nil, fmt.Errorf("offset %d out of the %d bytes", relative, len(w))
+	}
+	start := base + relative
+	length, err := w.uint(start, 64)
+	if err != nil {
+		return nil, err
+	}
+	start += 32
+	if length > uint64(len(w))-start {
+		return nil, fmt.Errorf("%d bytes at %d out of the %d bytes", length, start, len(w))
+	}
+	return w[start : start+length], nil
+}
+
+// DecodeEVMUpdateClient decodes the calldata of an updateClient call of the
+// IBC handler whose client message is a cometbls header, i.e. a MsgUpdateClient
+// (clientId, clientMessage, relayer) whose clientMessage is the ABI encoding
+// of (signedHeader, trustedHeight, zeroKnowledgeProof) as decoded by the
+// EVM client.
+func DecodeEVMUpdateClient(calldata []byte) (*EVMUpdateClient, error) {
+	if len(calldata) < 4 || !bytes.Equal(calldata[:4], updateClientSelector) {
+		return nil, ErrNotUpdateClient
+	}
+	args := abiWords(calldata[4:])
+	msg, err := args.uint(0, 64)
+	if err != nil {
+		return nil, fmt.Errorf("MsgUpdateClient: %w", err)
+	}
+	update := &EVMUpdateClient{}
+	clientID, err := args.uint(msg, 32)
+	if err != nil {
+		return nil, fmt.Errorf("clientId: %w", err)
+	}
+	update.ClientID = uint32(clientID)
+	clientMessage, err := args.dynamicBytes(msg, msg+32)
+	if err != nil {
+		return nil, fmt.Errorf("clientMessage: %w", err)
+	}
+	if update.Relayer, err = args.address(msg + 64); err != nil {
+		return nil, fmt.Errorf("relayer: %w", err)
+	}
+
+	header := abiWords(clientMessage)
+	fields := []struct {
+		name string
+		n    *uint64
+		hash *[32]byte
+	}{
+		{name: "height", n: &update.Header.Height},
+		{name: "secs", n: &update.Header.Secs},
+		{name: "nanos", n: &update.Header.Nanos},
+		{name: "validatorsHash", hash: &update.Header.ValidatorsHash},
+		{name: "nextValidatorsHash", hash: &update.Header.NextValidatorsHash},
+		{name: "appHash", hash: &update.Header.AppHash},
+		{name: "trustedHeight", n: &update.TrustedHeight},
+	}
+	for i, field := range fields {
+		offset := uint64(32 * i)
+		if field.n != nil {
+			*field.n, err = header.uint(offset, 64)
+		} else {
+			*field.hash, err = header.bytes32(offset)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("header %s: %w", field.name, err)
+		}
+	}
+	if update.ZKP, err = header.dynamicBytes(0, uint64(32*len(fields))); err != nil {
+		return nil, fmt.Errorf("header zeroKnowledgeProof: %w", err)
+	}
+	return update, nil
+}
@@ -0,0 +1,190 @@
+package verifier_test
+
+import (
+	"encoding/binary"
+	"encoding/hex"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/sha3"
+
+	"union/verifier"
+)
+
+// The transition from height 969001 to 969002 of union-testnet-8, proven
+// for the EVM client (see lib/cometbls-groth16-verifier).
+const (
+	evmChainID               = "union-testnet-8"
+	evmTrustedValidatorsHash = "01a84dca649aa2df8de2f65a84c9092bbd5296b4bc54d818f844b28573d8e0be"
+	evmAppHash               = "333f81c038816f109413eac1dc1cb8cef8facca1e9a49f21763f5dc84a375e14"
+	evmZKP                   = "02344d05cbb4f42548eadc621c46a3ae37f2ce23c12df83d1b490414bc20749a1fd5d4bd3b62a5b2cfae9f29686bfe1bc7a7c4bde72df168bdc1c1b0a3da1deb2a3f92896f5c37b4e3269aa84b47a67cad8b072350f794a15bac37608a5d549315e3850f18ddfa58ff9cfd5b2d133c3ac08d9f76e64611e6df4b6ba3d752e6f9054ec040028d1fd50d0f39eb60cb16326ba8876f5a47eea0c8b9c61461612bd518532a44ed88602a6e81177d08018fefadb2fedeac17ec26dae578532efb8a7905e1aca9429d9b8bfd7fb04e419c034258bc2d367e1c1a63936c67aca6767d5c1ba16ebb1dfccd919fa28d12255e6f9fcb98964682ca733bc591a25bd5a7993226daae60fea7d697b714916f9a6093f40a7a0e2a2a40b41b8741a98d5337b91f21a20866c16d94855c50593175e6d61481d56d08569ca55f8aa9f73277b3782a179b1bb01a269ae4eeacf273379099c641503f20830d6ef399867024b4f3c191120c8f0c1091387705c314ee6c5d8d23bf200649fe7b8dc2857db55f7bc5968c"
+)
+
+func mustHex32(t *testing.T, s string) (out [32]byte) {
+	bz, err := hex.DecodeString(s)
+	require.NoError(t, err)
+	require.Len(t, bz, 32)
+	copy(out[:], bz)
+	return out
+}
+
+func evmHeader(t *testing.T) verifier.ZKPHeader {
+	headerTime, err := time.Parse(time.RFC3339Nano, "2024-06-18T13:21:02.868708953Z")
+	require.NoError(t, err)
+	return verifier.ZKPHeader{
+		Height:             969002,
+		Secs:               uint64(headerTime.Unix()),
+		Nanos:              uint64(headerTime.Nanosecond()),
+		ValidatorsHash:     mustHex32(t, evmTrustedValidatorsHash),
+		NextValidatorsHash: mustHex32(t, evmTrustedValidatorsHash),
+		AppHash:            mustHex32(t, evmAppHash),
+	}
+}
+
+func abiUint(n uint64) []byte {
+	return binary.BigEndian.AppendUint64(make([]byte, 24), n)
+}
+
+// abiBytes encodes dynamic bytes: their length, then the bytes padded to a
+// multiple of 32 bytes.
+func abiBytes(bz []byte) []byte {
+	encoded := append(abiUint(uint64(len(bz))), bz...)
+	return append(encoded, make([]byte, (32-len(bz)%32)%32)...)
+}
+
+// updateClientCalldata encodes an updateClient call of the IBC handler as the
+// EVM cometbls client decodes it: the client message is the tuple
+// (signedHeader, trustedHeight, zeroKnowledgeProof), without a leading
+// offset.
+func updateClientCalldata(clientID uint32, relayer [20]byte, header verifier.ZKPHeader, trustedHeight uint64, zkp []byte) []byte {
+	var clientMessage []byte
+	for _, n := range []uint64{header.Height, header.Secs, header.Nanos} {
+		clientMessage = append(clientMessage, abiUint(n)...)
+	}
+	clientMessage = append(clientMessage, header.ValidatorsHash[:]...)
+	clientMessage = append(clientMessage, header.NextValidatorsHash[:]...)
+	clientMessage = append(clientMessage, header.AppHash[:]...)
+	clientMessage = append(clientMessage, abiUint(trustedHeight)...)
+	clientMessage = append(clientMessage, abiUint(8*32)...)
+	clientMessage = append(clientMessage, abiBytes(zkp)...)
+
+	h := sha3.NewLegacyKeccak256()
+	h.Write([]byte("updateClient((uint32,bytes,address))"))
+	calldata := h.Sum(nil)[:4]
+	// The offset of the MsgUpdateClient tuple, which is dynamic.
+	calldata = append(calldata, abiUint(32)...)
+	calldata = append(calldata, abiUint(uint64(clientID))...)
+	calldata = append(calldata, abiUint(3*32)...)
+	calldata = append(calldata, make([]byte, 12)...)
+	calldata = append(calldata, relayer[:]...)
+	return append(calldata, abiBytes(clientMessage)...)
+}
+
+func TestDecodeEVMUpdateClient(t *testing.T) {
+	zkp, err := hex.DecodeString(evmZKP)
+	require.NoError(t, err)
+	relayer := [20]byte{0xde, 0xad, 0xbe, 0xef}
+	header := evmHeader(t)
+	calldata := updateClientCalldata(3, relayer, header, 969001, zkp)
+
+	update, err := verifier.DecodeEVMUpdateClient(calldata)
+	require.NoError(t, err)
+	require.Equal(t, &verifier.EVMUpdateClient{
+		ClientID:      3,
+		Relayer:       relayer,
+		Header:        header,
+		TrustedHeight: 969001,
+		ZKP:           zkp,
+	}, update)
+	require.NoError(t, verifier.VerifyZKP(evmChainID, mustHex32(t, evmTrustedValidatorsHash), update.Header, update.ZKP))
+
+	// Offsets of the calldata, after the selector.
+	const (
+		msgOffset           = 4
+		clientID            = msgOffset + 32
+		clientMessageOffset = clientID + 32
+		relayerWord         = clientMessageOffset + 32
+		clientMessage       = relayerWord + 32 + 32
+		height              = clientMessage
+		zkpOffset           = clientMessage + 7*32
+	)
+	setWord := func(calldata []byte, offset int, n *big.Int) []byte {
+		modified := append([]byte(nil), calldata...)
+		n.FillBytes(modified[offset : offset+32])
+		return modified
+	}
+	big2 := func(exp uint) *big.Int {
+		return new(big.Int).Lsh(big.NewInt(1), exp)
+	}
+	for _, tc := range []struct {
+		name     string
+		calldata []byte
+		err      error
+	}{
+		{name: "empty", calldata: nil, err: verifier.ErrNotUpdateClient},
+		{name: "other selector", calldata: append([]byte{0, 0, 0, 0}, calldata[4:]...), err: verifier.ErrNotUpdateClient},
+		{name: "truncated", calldata: calldata[:len(calldata)-64]},
+		{name: "message offset out of bounds", calldata: setWord(calldata, msgOffset, big.NewInt(int64(len(calldata))))},
+		{name: "message offset wrapping", calldata: setWord(calldata, msgOffset, new(big.Int).SetUint64(^uint64(0)-31))},
+		{name: "client message offset out of bounds", calldata: setWord(calldata, clientMessageOffset, big.NewInt(int64(len(calldata))))},
+		{name: "client message offset wrapping", calldata: setWord(calldata, clientMessageOffset, new(big.Int).SetUint64(^uint64(0)))},
+		{name: "client message length out of bounds", calldata: setWord(calldata, clientMessage-32, big.NewInt(int64(len(calldata))))},
+		{name: "proof offset out of bounds", calldata: setWord(calldata, zkpOffset, big.NewInt(int64(len(calldata))))},
+		{name: "proof length out of bounds", calldata: setWord(calldata, zkpOffset+32, big.NewInt(verifier.ZKPSize+32))},
+		{name: "dirty client id", calldata: setWord(calldata, clientID, new(big.Int).Add(big2(32), big.NewInt(3)))},
+		{name: "dirty relayer", calldata: setWord(calldata, relayerWord, new(big.Int).Add(big2(160), new(big.Int).SetBytes(relayer[:])))},
+		{name: "dirty height", calldata: setWord(calldata, height, new(big.Int).Add(big2(64), big.NewInt(969002)))},
+		{name: "dirty trusted height", calldata: setWord(calldata, zkpOffset-32, new(big.Int).Add(big2(255), big.NewInt(969001)))},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := verifier.DecodeEVMUpdateClient(tc.calldata)
+			if tc.err != nil {
+				require.ErrorIs(t, err, tc.err)
+			} else {
+				require.Error(t, err)
+			}
+		})
+	}
+}
+
+func TestVerifyZKP(t *testing.T) {
+	zkp, err := hex.DecodeString(evmZKP)
+	require.NoError(t, err)
+	trusted := mustHex32(t, evmTrustedValidatorsHash)
+	header := evmHeader(t)
+	require.NoError(t, verifier.VerifyZKP(evmChainID, trusted, header, zkp))
+
+	// A truncated proof is decoded from the calldata as is.
+	update, err := verifier.DecodeEVMUpdateClient(updateClientCalldata(3, [20]byte{}, header, 969001, zkp[:len(zkp)-1]))
+	require.NoError(t, err)
+	require.ErrorIs(t, verifier.VerifyZKP(evmChainID, trusted, update.Header, update.ZKP), verifier.ErrInvalidZKP)
+
+	tampered := header
+	tampered.AppHash[31] ^= 1
+	laterTime := header
+	laterTime.Nanos++
+	notOnCurve := append([]byte(nil), zkp...)
+	notOnCurve[63] ^= 1
+	for _, tc := range []struct {
+		name    string
+		chainID string
+		trusted [32]byte
+		header  verifier.ZKPHeader
+		zkp     []byte
+		err     error
+	}{
+		{name: "tampered app hash", chainID: evmChainID, trusted: trusted, header: tampered, zkp: zkp, err: verifier.ErrInvalidProof},
+		{name: "other time", chainID: evmChainID, trusted: trusted, header: laterTime, zkp: zkp, err: verifier.ErrInvalidProof},
+		{name: "other chain", chainID: "union-testnet-9", trusted: trusted, header: header, zkp: zkp, err: verifier.ErrInvalidProof},
+		{name: "other trusted validators", chainID: evmChainID, trusted: header.AppHash, header: header, zkp: zkp, err: verifier.ErrInvalidProof},
+		{name: "truncated", chainID: evmChainID, trusted: trusted, header: header, zkp: zkp[:verifier.ZKPSize-32], err: verifier.ErrInvalidZKP},
+		{name: "extended", chainID: evmChainID, trusted: trusted, header: header, zkp: append(append([]byte(nil), zkp...), 0), err: verifier.ErrInvalidZKP},
+		{name: "point off the curve", chainID: evmChainID, trusted: trusted, header: header, zkp: notOnCurve, err: verifier.ErrInvalidZKP},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			require.ErrorIs(t, verifier.VerifyZKP(tc.chainID, tc.trusted, tc.header, tc.zkp), tc.err)
+		})
+	}
+}
@@ -0,0 +1,160 @@
+package verifier
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+
+	cmtbytes "github.com/cometbft/cometbft/libs/bytes"
+	cmtmath "github.com/cometbft/cometbft/libs/math"
+	cmttypes "github.com/cometbft/cometbft/types"
+)
+
+// Violation is a verification condition that doesn't hold, along with the
+// expected and actual values when they can be told apart.
+type Violation struct {
+	Check    string `json:"check"`
+	Expected string `json:"expected,omitempty"`
+	Actual   string `json:"actual,omitempty"`
+	Error    string `json:"error,omitempty"`
+}
+
+// Explain runs every condition checked by light.Verify (or light.VerifyLegacy)
+// on a transition, without stopping at the first failure, and returns the
+// ones that don't hold. No violation means that the transition verifies.
+func Explain(
+	trusted *cmttypes.LightBlock,
+	untrusted *cmttypes.LightBlock,
+	trustingPeriod time.Duration,
+	now time.Time,
+	maxClockDrift time.Duration,
+	trustLevel cmtmath.Fraction,
+	legacy bool,
+) []Violation {
+	var violations []Violation
+	check := func(name string, fn func() *Violation) {
+		runCheck(&violations, name, fn)
+	}
+	chainID := trusted.ChainID
+
+	check("chain_id", func() *Violation {
+		if untrusted.ChainID == chainID {
+			return nil
+		}
+		return &Violation{Expected: chainID, Actual: untrusted.ChainID}
+	})
+	check("header", func() *Violation {
+		var err error
+		if legacy {
+			err = untrusted.SignedHeader.ValidateBasicLegacy(untrusted.ChainID)
+		} else {
+			err = untrusted.SignedHeader.ValidateBasic(untrusted.ChainID)
+		}
+		if err == nil {
+			return nil
+		}
+		return &Violation{Error: err.Error()}
+	})
+	check("height", func() *Violation {
+		if untrusted.Height > trusted.Height {
+			return nil
+		}
+		return &Violation{Expected: "above " + strconv.FormatInt(trusted.Height, 10), Actual: strconv.FormatInt(untrusted.Height, 10)}
+	})
+	check("time", func() *Violation {
+		if untrusted.Time.After(trusted.Time) {
+			return nil
+		}
+		return &Violation{Expected: "after " + formatTime(trusted.Time), Actual: formatTime(untrusted.Time)}
+	})
+	check("trusting_period", func() *Violation {
+		expiresAt := trusted.Time.Add(trustingPeriod)
+		if expiresAt.After(now) {
+			return nil
+		}
+		return &Violation{Expected: "trusted header expiring after " + formatTime(now), Actual: "expired at " + formatTime(expiresAt)}
+	})
+	check("clock_drift", func() *Violation {
+		if untrusted.Time.Before(now.Add(maxClockDrift)) {
+			return nil
+		}
+		return &Violation{Expected: "before " + formatTime(now.Add(maxClockDrift)), Actual: formatTime(untrusted.Time)}
+	})
+	check("validators_hash", func() *Violation {
+		var valsHash cmtbytes.HexBytes
+		if legacy {
+			valsHash = untrusted.ValidatorSet.HashSha256()
+		} else {
+			valsHash = untrusted.ValidatorSet.Hash()
+		}
+		if digestsEqual(untrusted.ValidatorsHash, valsHash) {
+			return nil
+		}
+		return &Violation{Expected: valsHash.String(), Actual: untrusted.ValidatorsHash.String()}
+	})
+
+	if untrusted.Height == trusted.Height+1 {
+		check("next_validators_hash", func() *Violation {
+			if digestsEqual(untrusted.ValidatorsHash, trusted.NextValidatorsHash) {
+				return nil
+			}
+			return &Violation{Expected: trusted.NextValidatorsHash.String(), Actual: untrusted.ValidatorsHash.String()}
+		})
+	} else {
+		check("trust_level", func() *Violation {
+			var err error
+			if legacy {
+				err = trusted.ValidatorSet.VerifyCommitLightTrustingLegacy(chainID, untrusted.Commit, trustLevel)
+			} else {
+				err = trusted.ValidatorSet.VerifyCommitLightTrusting(chainID, untrusted.Commit, trustLevel)
+			}
+			return votingPowerViolation(err)
+		})
+	}
+
+	check("commit", func() *Violation {
+		var err error
+		if legacy {
+			err = untrusted.ValidatorSet.VerifyCommitLightLegacy(chainID, untrusted.Commit.BlockID, untrusted.Height, untrusted.Commit)
+		} else {
+			err = untrusted.ValidatorSet.VerifyCommitLight(chainID, untrusted.Commit.BlockID, untrusted.Height, untrusted.Commit)
+		}
+		return votingPowerViolation(err)
+	})
+
+	return violations
+}
+
+// runCheck appends the violation returned by fn, if any, under the given
+// name. Malformed light blocks can make the hashing panic, which is a
+// violation by itself.
+func runCheck(violations *[]Violation, name string, fn func() *Violation) {
+	defer func() {
+		if r := recover(); r != nil {
+			*violations = append(*violations, Violation{Check: name, Error: fmt.Sprintf("%v", r)})
+		}
+	}()
+	if v := fn(); v != nil {
+		v.Check = name
+		*violations = append(*violations, *v)
+	}
+}
+
+func votingPowerViolation(err error) *Violation {
+	if err == nil {
+		return nil
+	}
+	var notEnough cmttypes.ErrNotEnoughVotingPowerSigned
+	if errors.As(err, &notEnough) {
+		return &Violation{
+			Expected: fmt.Sprintf("voting power above %d", notEnough.Needed),
+			Actual:   fmt.Sprintf("voting power %d", notEnough.Got),
+		}
+	}
+	return &Violation{Error: err.Error()}
+}
+
+func formatTime(t time.Time) string {
+	return t.UTC().Format(time.RFC3339Nano)
+}
@@ -0,0 +1,68 @@
+package verifier
+
+import (
+	"errors"
+	"fmt"
+
+	cmttypes "github.com/cometbft/cometbft/types"
+)
+
+// ErrInvalidExtendedCommit is returned for an extended commit that doesn't
+// commit to a light block, or whose vote extensions aren't signed by their
+// validators.
+var ErrInvalidExtendedCommit = errors.New("invalid extended commit")
+
+// VerifyExtendedCommit verifies the extended commit of the block of a
+// verified light block, as stored by the nodes of a chain enabling vote
+// extensions from extensionsEnableHeight (never if zero), e.g. to trust the
+// vote extensions an application acts upon.
+//
+// The vote extensions aren't part of the signed precommits, the light
+// blocks of a chain enabling them are verified as any other. The extended
+// commit must be of the height and block id of the commit of the light
+// block, signed by more than 2/3 of its validator set over the given sign
+// bytes, CanonicalSignBytes if nil. Once vote extensions are enabled, every
+// precommit for the block must carry an extension signed by its validator,
+// and none otherwise, as checked by the consensus.
+func VerifyExtendedCommit(lightBlock *cmttypes.LightBlock, extendedCommit *cmttypes.ExtendedCommit, extensionsEnableHeight int64, signBytes SignBytes) error {
+	if extendedCommit == nil {
+		return fmt.Errorf("%w: nil extended commit", ErrInvalidExtendedCommit)
+	}
+	if err := extendedCommit.ValidateBasic(); err != nil {
+		return fmt.Errorf("%w: %w", ErrInvalidExtendedCommit, err)
+	}
+	if extendedCommit.Height != lightBlock.Height {
+		return fmt.Errorf("%w: height %d, expected %d", ErrInvalidExtendedCommit, extendedCommit.Height, lightBlock.Height)
+	}
+	if !extendedCommit.BlockID.Equals(lightBlock.Commit.BlockID) {
+		return fmt.Errorf("%w: block id %v at height %d, expected the committed %v", ErrInvalidExtendedCommit, extendedCommit.BlockID, extendedCommit.Height, lightBlock.Commit.BlockID)
+	}
+	enabled := extensionsEnableHeight > 0 && extendedCommit.Height >= extensionsEnableHeight
+	if err := extendedCommit.EnsureExtensions(enabled); err != nil {
+		return fmt.Errorf("%w: height %d: %w", ErrInvalidExtendedCommit, extendedCommit.Height, err)
+	}
+
+	if signBytes == nil {
+		signBytes = CanonicalSignBytes(false)
+	}
+	vals := lightBlock.ValidatorSet
+	commit := extendedCommit.ToCommit()
+	if err := VerifyCommitLight(lightBlock.ChainID, vals, commit.BlockID, commit.Height, commit, signBytes); err != nil {
+		return fmt.Errorf("%w: %w", ErrInvalidExtendedCommit, err)
+	}
+	if !enabled {
+		return nil
+	}
+	// The precommits were verified up to 2/3 of the voting power, the
+	// extensions must all be.
+	for i, sig := range extendedCommit.ExtendedSignatures {
+		if sig.BlockIDFlag != cmttypes.BlockIDFlagCommit {
+			continue
+		}
+		vote := extendedCommit.GetExtendedVote(int32(i))
+		if err := vote.VerifyExtension(lightBlock.ChainID, vals.Validators[i].PubKey); err != nil {
+			return fmt.Errorf("%w: vote extension #%d of %X: %w", ErrInvalidExtendedCommit, i, sig.ValidatorAddress, err)
+		}
+	}
+	return nil
+}
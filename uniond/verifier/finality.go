@@ -0,0 +1,46 @@
+package verifier
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/cometbft/cometbft/light/provider"
+	cmttypes "github.com/cometbft/cometbft/types"
+)
+
+// NewFinalityDepth wraps a provider so that its latest light block is the one
+// depth heights behind the tip it reports, such that a light client only
+// trusts the headers buried under depth others, e.g. for integrators wanting
+// a settlement assurance beyond the instant finality of CometBFT on chains
+// with a history of halts and rollbacks.
+//
+// The light blocks requested at a given height are served unchanged, so that
+// the witnesses cross-check the lagging target at its height and a light
+// client still verifies any explicit height. While the chain is shorter than
+// depth, the latest light block is the first one.
+func NewFinalityDepth(p provider.Provider, depth int64) provider.Provider {
+	if depth <= 0 {
+		return p
+	}
+	return &finalityDepth{Provider: p, depth: depth}
+}
+
+type finalityDepth struct {
+	provider.Provider
+	depth int64
+}
+
+func (p *finalityDepth) LightBlock(ctx context.Context, height int64) (*cmttypes.LightBlock, error) {
+	if height != 0 {
+		return p.Provider.LightBlock(ctx, height)
+	}
+	tip, err := p.Provider.LightBlock(ctx, 0)
+	if err != nil {
+		return nil, err
+	}
+	return p.Provider.LightBlock(ctx, max(tip.Height-p.depth, 1))
+}
+
+func (p *finalityDepth) String() string {
+	return fmt.Sprint(p.Provider)
+}
@@ -0,0 +1,63 @@
+package verifier
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	clienttypes "github.com/cosmos/ibc-go/v8/modules/core/02-client/types"
+)
+
+// Height is a block height qualified by the revision of the chain, the
+// revision being the suffix of a chain id in the {name}-{revision} format and
+// 0 otherwise. Chains restarting after a halt increment their revision and
+// usually restart their heights, so heights are only comparable once qualified.
+type Height struct {
+	Revision uint64 `json:"revision"`
+	Number   uint64 `json:"number"`
+}
+
+// HeightOf qualifies a height of a chain with the revision of its chain id.
+func HeightOf(chainID string, height int64) Height {
+	return Height{Revision: clienttypes.ParseChainID(chainID), Number: uint64(height)}
+}
+
+// ParseHeight parses a height given either as {number}, the revision being
+// the one of the chain id, or as {revision}-{number}.
+func ParseHeight(chainID, s string) (Height, error) {
+	revision, number, qualified := strings.Cut(s, "-")
+	if !qualified {
+		number, revision = revision, strconv.FormatUint(clienttypes.ParseChainID(chainID), 10)
+	}
+	r, err := strconv.ParseUint(revision, 10, 64)
+	if err != nil {
+		return Height{}, fmt.Errorf("invalid revision in height %q: %w", s, err)
+	}
+	n, err := strconv.ParseUint(number, 10, 63)
+	if err != nil {
+		return Height{}, fmt.Errorf("invalid number in height %q: %w", s, err)
+	}
+	return Height{Revision: r, Number: n}, nil
+}
+
+// Compare returns -1, 0 or 1 if the height is lower, equal or greater than
+// the other one, revisions being compared first.
+func (h Height) Compare(other Height) int {
+	switch {
+	case h.Revision < other.Revision:
+		return -1
+	case h.Revision > other.Revision:
+		return 1
+	case h.Number < other.Number:
+		return -1
+	case h.Number > other.Number:
+		return 1
+	default:
+		return 0
+	}
+}
+
+// String formats the height as {revision}-{number}.
+func (h Height) String() string {
+	return fmt.Sprintf("%d-%d", h.Revision, h.Number)
+}
@@ -0,0 +1,190 @@
+package verifier
+
+import (
+	"context"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+	"net"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"golang.org/x/time/rate"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+)
+
+const authorizationHeader = "authorization"
+
+// Authenticator only lets through the requests carrying one of its tokens as
+// an `authorization: Bearer <token>` header. No authentication is performed if
+// it has no token.
+type Authenticator struct {
+	tokens []string
+}
+
+func NewAuthenticator(tokens []string) *Authenticator {
+	return &Authenticator{tokens: tokens}
+}
+
+// authenticate returns the client identifier, the token if authenticated.
+func (a *Authenticator) authenticate(authorization []string) (string, error) {
+	if len(a.tokens) == 0 {
+		return "", nil
+	}
+	for _, value := range authorization {
+		token, found := strings.CutPrefix(value, "Bearer ")
+		if !found {
+			continue
+		}
+		for _, allowed := range a.tokens {
+			if subtle.ConstantTimeCompare([]byte(token), []byte(allowed)) == 1 {
+				return "token:" + token, nil
+			}
+		}
+		return "", status.Error(codes.Unauthenticated, "invalid bearer token")
+	}
+	return "", status.Error(codes.Unauthenticated, "missing bearer token")
+}
+
+// UnaryInterceptor authenticates the gRPC requests.
+func (a *Authenticator) UnaryInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		md, _ := metadata.FromIncomingContext(ctx)
+		id, err := a.authenticate(md.Get(authorizationHeader))
+		if err != nil {
+			return nil, err
+		}
+		if id != "" {
+			ctx = context.WithValue(ctx, clientIDKey{}, id)
+		}
+		return handler(ctx, req)
+	}
+}
+
+// Middleware authenticates the HTTP requests.
+func (a *Authenticator) Middleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		id, err := a.authenticate(r.Header.Values(authorizationHeader))
+		if err != nil {
+			writeError(w, err)
+			return
+		}
+		if id != "" {
+			r = r.WithContext(context.WithValue(r.Context(), clientIDKey{}, id))
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+type clientIDKey struct{}
+
+// Clients idle for that long are forgotten.
+const limiterTTL = 10 * time.Minute
+
+// RateLimiter limits every client to a number of requests per second, shared
+// between the gRPC and HTTP servers. Authenticated clients are identified by
+// their token, the others by their address.
+type RateLimiter struct {
+	mu        sync.Mutex
+	limit     rate.Limit
+	burst     int
+	limiters  map[string]*clientLimiter
+	lastSweep time.Time
+}
+
+type clientLimiter struct {
+	limiter  *rate.Limiter
+	lastSeen time.Time
+}
+
+func NewRateLimiter(requestsPerSecond float64, burst int) *RateLimiter {
+	return &RateLimiter{
+		limit:    rate.Limit(requestsPerSecond),
+		burst:    burst,
+		limiters: make(map[string]*clientLimiter),
+	}
+}
+
+// UnaryInterceptor rate limits the gRPC requests, it must run after the
+// authentication.
+func (l *RateLimiter) UnaryInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		var addr string
+		if p, ok := peer.FromContext(ctx); ok {
+			addr = p.Addr.String()
+		}
+		if !l.allow(clientID(ctx, addr)) {
+			return nil, status.Error(codes.ResourceExhausted, "rate limit exceeded")
+		}
+		return handler(ctx, req)
+	}
+}
+
+// Middleware rate limits the HTTP requests, it must run after the
+// authentication.
+func (l *RateLimiter) Middleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !l.allow(clientID(r.Context(), r.RemoteAddr)) {
+			writeError(w, status.Error(codes.ResourceExhausted, "rate limit exceeded"))
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+func clientID(ctx context.Context, addr string) string {
+	if id, ok := ctx.Value(clientIDKey{}).(string); ok {
+		return id
+	}
+	// Ignore the port, a client may open several connections.
+	if host, _, err := net.SplitHostPort(addr); err == nil {
+		addr = host
+	}
+	return "addr:" + addr
+}
+
+type remoteAddrKey struct{}
+
+// auditClient identifies the client of a request in the audit log, by a
+// fingerprint of its token rather than the token itself.
+func auditClient(ctx context.Context) string {
+	var addr string
+	if p, ok := peer.FromContext(ctx); ok {
+		addr = p.Addr.String()
+	} else if remoteAddr, ok := ctx.Value(remoteAddrKey{}).(string); ok {
+		addr = remoteAddr
+	}
+	id := clientID(ctx, addr)
+	if token, ok := strings.CutPrefix(id, "token:"); ok {
+		fingerprint := sha256.Sum256([]byte(token))
+		return "token:" + hex.EncodeToString(fingerprint[:8])
+	}
+	return id
+}
+
+func (l *RateLimiter) allow(id string) bool {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	now := time.Now()
+	if now.Sub(l.lastSweep) > limiterTTL {
+		for id, limiter := range l.limiters {
+			if now.Sub(limiter.lastSeen) > limiterTTL {
+				delete(l.limiters, id)
+			}
+		}
+		l.lastSweep = now
+	}
+	limiter, ok := l.limiters[id]
+	if !ok {
+		limiter = &clientLimiter{limiter: rate.NewLimiter(l.limit, l.burst)}
+		l.limiters[id] = limiter
+	}
+	limiter.lastSeen = now
+	return limiter.limiter.AllowN(now, 1)
+}
@@ -0,0 +1,151 @@
+// Package leader elects a single leader among the replicas of a light client
+// sharing a trusted store, such as relayers deployed for high availability,
+// so that only the leader advances the store and acts on it while the other
+// replicas wait as warm standbys.
+package leader
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"sync"
+	"time"
+)
+
+// ErrLeadershipLost is returned once a lease can't be guaranteed anymore, at
+// which point the replica must stop acting as the leader.
+var ErrLeadershipLost = errors.New("leadership lost")
+
+// Elector elects the leader among replicas.
+type Elector interface {
+	// Acquire blocks until the replica is elected or the context is done.
+	Acquire(ctx context.Context) (Lease, error)
+}
+
+// Lease is the leadership held by a replica.
+type Lease interface {
+	// Lost is closed once the lease is lost, see Err.
+	Lost() <-chan struct{}
+	// Err returns why the lease was lost, wrapping ErrLeadershipLost.
+	Err() error
+	// Release gives the leadership up to the next replica.
+	Release() error
+}
+
+// FileLock elects as the leader the replica holding an exclusive lock on a
+// file, e.g. next to a trusted store on a volume shared by the replicas. The
+// lock is released by the operating system when the leader exits, however it
+// dies, and the next replica to retry takes over.
+type FileLock struct {
+	Path string
+	// RetryInterval is the interval between two attempts to lock the file,
+	// and to check that the locked file is still the one at the path.
+	RetryInterval time.Duration
+}
+
+var _ Elector = FileLock{}
+
+func (l FileLock) Acquire(ctx context.Context) (Lease, error) {
+	interval := l.RetryInterval
+	if interval <= 0 {
+		interval = time.Second
+	}
+	for {
+		file, err := os.OpenFile(l.Path, os.O_RDWR|os.O_CREATE, 0o644)
+		if err != nil {
+			return nil, err
+		}
+		locked, err := tryLock(file)
+		if err != nil {
+			file.Close()
+			return nil, fmt.Errorf("can't lock %s: %w", l.Path, err)
+		}
+		// A lock on a file replaced since it was opened doesn't exclude the
+		// replicas opening the new one.
+		if locked && samePath(file, l.Path) {
+			lease := &fileLease{file: file, path: l.Path, lost: make(chan struct{}), done: make(chan struct{})}
+			go lease.watch(interval)
+			return lease, nil
+		}
+		file.Close()
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-time.After(interval):
+		}
+	}
+}
+
+type fileLease struct {
+	file *os.File
+	path string
+
+	once sync.Once
+	lost chan struct{}
+	err  error
+	done chan struct{}
+}
+
+// watch loses the lease once the locked file is removed or replaced, since
+// another replica could then lock the new file.
+func (l *fileLease) watch(interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-l.done:
+			return
+		case <-ticker.C:
+			if !samePath(l.file, l.path) {
+				l.lose(fmt.Errorf("%w: %s was removed or replaced", ErrLeadershipLost, l.path))
+				return
+			}
+		}
+	}
+}
+
+func (l *fileLease) lose(err error) {
+	l.once.Do(func() {
+		l.err = err
+		close(l.lost)
+	})
+}
+
+func (l *fileLease) Lost() <-chan struct{} {
+	return l.lost
+}
+
+func (l *fileLease) Err() error {
+	select {
+	case <-l.lost:
+		return l.err
+	default:
+		return nil
+	}
+}
+
+func (l *fileLease) Release() error {
+	l.lose(fmt.Errorf("%w: released", ErrLeadershipLost))
+	select {
+	case <-l.done:
+		return nil
+	default:
+		close(l.done)
+	}
+	// Closing the file releases the lock.
+	return l.file.Close()
+}
+
+// samePath checks that an open file is still the file at a path.
+func samePath(file *os.File, path string) bool {
+	opened, err := file.Stat()
+	if err != nil {
+		return false
+	}
+	current, err := os.Stat(path)
+	if err != nil {
+		return false
+	}
+	return os.SameFile(opened, current)
+}
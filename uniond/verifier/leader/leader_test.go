@@ -0,0 +1,64 @@
+//go:build linux || darwin || freebsd || netbsd || openbsd || dragonfly
+
+package leader_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"union/verifier/leader"
+)
+
+func TestFileLock(t *testing.T) {
+	elector := leader.FileLock{Path: filepath.Join(t.TempDir(), "leader.lock"), RetryInterval: 10 * time.Millisecond}
+	first, err := elector.Acquire(context.Background())
+	require.NoError(t, err)
+
+	// A second holder stays blocked while the first one holds the lease.
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+	_, err = elector.Acquire(ctx)
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+
+	acquired := make(chan leader.Lease)
+	go func() {
+		second, err := elector.Acquire(context.Background())
+		if err == nil {
+			acquired <- second
+		}
+		close(acquired)
+	}()
+	select {
+	case <-acquired:
+		t.Fatal("the second holder acquired a held lease")
+	case <-time.After(100 * time.Millisecond):
+	}
+	require.NoError(t, first.Err())
+
+	// Released, the lease goes to the second holder.
+	require.NoError(t, first.Release())
+	require.ErrorIs(t, first.Err(), leader.ErrLeadershipLost)
+	var second leader.Lease
+	select {
+	case second = <-acquired:
+		require.NotNil(t, second)
+	case <-time.After(5 * time.Second):
+		t.Fatal("the second holder wasn't elected once the lease was released")
+	}
+
+	// The lease is lost once the locked file is removed, as a third holder
+	// could then lock a new one.
+	require.NoError(t, os.Remove(elector.Path))
+	select {
+	case <-second.Lost():
+		require.ErrorIs(t, second.Err(), leader.ErrLeadershipLost)
+	case <-time.After(5 * time.Second):
+		t.Fatal("the lease wasn't lost once the locked file was removed")
+	}
+	require.NoError(t, second.Release())
+}
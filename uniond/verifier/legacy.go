@@ -0,0 +1,134 @@
+package verifier
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+
+	cmttypes "github.com/cometbft/cometbft/types"
+)
+
+// ErrUnknownHashScheme is returned for a light block consistent with neither
+// the legacy nor the current hashing scheme.
+var ErrUnknownHashScheme = errors.New("unknown hashing scheme")
+
+// ErrOutsideTransition is returned for a light block using a hashing scheme
+// its chain no longer, or not yet, uses at its height.
+var ErrOutsideTransition = errors.New("hashing scheme outside of the transition")
+
+// Transition is the window of heights within which a chain upgraded from the
+// legacy hashing scheme to the current one. The zero value is the one of a
+// chain that always used the current scheme.
+type Transition struct {
+	// From is the first height which may use the current hashes, the light
+	// blocks below it being legacy.
+	From int64
+	// To is the last height which may use the legacy hashes, the light
+	// blocks above it being current.
+	To int64
+}
+
+// AnyHeight detects the scheme of the light blocks of every height, for the
+// callers not knowing the transition of their chain.
+var AnyHeight = Transition{From: 1, To: math.MaxInt64}
+
+// ParseTransition parses a transition given as <from>-<to>, such as the
+// flags of the light commands, the empty string being AnyHeight.
+func ParseTransition(s string) (Transition, error) {
+	if s == "" {
+		return AnyHeight, nil
+	}
+	from, to, ok := strings.Cut(s, "-")
+	if !ok {
+		return Transition{}, fmt.Errorf("invalid transition %q, expected <from>-<to>", s)
+	}
+	var transition Transition
+	var err error
+	if transition.From, err = strconv.ParseInt(from, 10, 64); err != nil {
+		return Transition{}, fmt.Errorf("invalid transition %q: %w", s, err)
+	}
+	if transition.To, err = strconv.ParseInt(to, 10, 64); err != nil {
+		return Transition{}, fmt.Errorf("invalid transition %q: %w", s, err)
+	}
+	if transition.From < 1 || transition.To < transition.From-1 {
+		return Transition{}, fmt.Errorf("invalid transition %q, expected 1 <= from <= to + 1", s)
+	}
+	return transition, nil
+}
+
+func (t Transition) String() string {
+	return fmt.Sprintf("%d-%d", t.From, t.To)
+}
+
+// DetectLegacy tells whether a light block uses the legacy (pre cometbls)
+// SHA-256 hashes or the current MiMC ones, by checking under which scheme
+// its commit signs its header and its header commits to its validator set.
+// Only the light blocks within the transition are checked under both
+// schemes, a light block consistent with neither being rejected with the
+// reasons of both. Outside of it, a light block must use the scheme of its
+// side of the transition, and is rejected with ErrOutsideTransition if it
+// uses the other one.
+func DetectLegacy(lightBlock *cmttypes.LightBlock, transition Transition) (bool, error) {
+	if err := CheckEncoding(lightBlock, true); err != nil {
+		return false, err
+	}
+	switch height := lightBlock.Height; {
+	case height > transition.To:
+		return false, requireScheme(lightBlock, false, "above", transition)
+	case height < transition.From:
+		return true, requireScheme(lightBlock, true, "below", transition)
+	}
+	currentErr := ValidateLightBlock(lightBlock, false)
+	if currentErr == nil {
+		return false, nil
+	}
+	legacyErr := ValidateLightBlock(lightBlock, true)
+	if legacyErr == nil {
+		return true, nil
+	}
+	return false, fmt.Errorf("%w: %w (current), %w (legacy)", ErrUnknownHashScheme, currentErr, legacyErr)
+}
+
+// requireScheme validates a light block outside of the transition under the
+// scheme of its side, telling a light block using the other scheme apart
+// from an invalid one.
+func requireScheme(lightBlock *cmttypes.LightBlock, legacy bool, side string, transition Transition) error {
+	err := ValidateLightBlock(lightBlock, legacy)
+	if err == nil {
+		return nil
+	}
+	if ValidateLightBlock(lightBlock, !legacy) == nil {
+		return fmt.Errorf("%w: %s light block at height %d, %s the transition %s", ErrOutsideTransition, SchemeName(!legacy), lightBlock.Height, side, transition)
+	}
+	return fmt.Errorf("%s light block: %w", SchemeName(legacy), err)
+}
+
+// SchemeName names the legacy or current hashing scheme.
+func SchemeName(legacy bool) string {
+	if legacy {
+		return "legacy"
+	}
+	return "current"
+}
+
+// LightBlockHash returns the hash of the header of a light block under its
+// scheme.
+func LightBlockHash(lightBlock *cmttypes.LightBlock, legacy bool) []byte {
+	if legacy {
+		return lightBlock.Header.HashSha256()
+	}
+	return lightBlock.Hash()
+}
+
+// LegacyMode returns the mode verifying a transition, which is the scheme of
+// the untrusted light block: a legacy trusted light block may be followed by
+// a current one, which is how a chain upgrades to cometbls, but not the
+// other way around.
+func LegacyMode(trustedLegacy, untrustedLegacy bool) (bool, error) {
+	if !trustedLegacy && untrustedLegacy {
+		return false, errors.New("a legacy light block can't follow a current one")
+	}
+	return untrustedLegacy, nil
+}
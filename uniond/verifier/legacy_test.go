@@ -0,0 +1,112 @@
+package verifier_test
+
+import (
+	"math"
+	"testing"
+
+	cmttypes "github.com/cometbft/cometbft/types"
+	"github.com/stretchr/testify/require"
+
+	"union/verifier"
+	"union/verifier/lighttest"
+)
+
+func TestDetectLegacy(t *testing.T) {
+	chain, err := lighttest.NewChain("legacy-1", 4, 1)
+	require.NoError(t, err)
+	window := verifier.Transition{From: 5, To: 10}
+
+	for _, tc := range []struct {
+		name       string
+		transition verifier.Transition
+		height     int64
+		legacy     bool
+		mutate     func(*cmttypes.LightBlock)
+		err        error
+	}{
+		{name: "legacy below the window", transition: window, height: 4, legacy: true},
+		{name: "current below the window", transition: window, height: 4, err: verifier.ErrOutsideTransition},
+		{name: "current at the start of the window", transition: window, height: 5},
+		{name: "legacy at the start of the window", transition: window, height: 5, legacy: true},
+		{name: "current within the window", transition: window, height: 7},
+		{name: "legacy within the window", transition: window, height: 7, legacy: true},
+		{name: "current at the end of the window", transition: window, height: 10},
+		{name: "legacy at the end of the window", transition: window, height: 10, legacy: true},
+		{name: "current above the window", transition: window, height: 11},
+		{name: "legacy above the window", transition: window, height: 11, legacy: true, err: verifier.ErrOutsideTransition},
+		{name: "legacy of a chain without transition", height: 1, legacy: true, err: verifier.ErrOutsideTransition},
+		{name: "legacy at any height", transition: verifier.AnyHeight, height: 100, legacy: true},
+		{
+			// Neither scheme, so not reported as being the other one.
+			name:       "invalid above the window",
+			transition: window,
+			height:     11,
+			mutate: func(lightBlock *cmttypes.LightBlock) {
+				lightBlock.AppHash = make([]byte, 32)
+			},
+		},
+		{
+			// Signed under the current scheme but committing to its validator
+			// set under the legacy one.
+			name:       "ambiguous within the window",
+			transition: window,
+			height:     7,
+			mutate: func(lightBlock *cmttypes.LightBlock) {
+				lightBlock.ValidatorsHash = lightBlock.ValidatorSet.HashSha256()
+			},
+			err: verifier.ErrUnknownHashScheme,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			lightBlock, err := chain.LightBlock(tc.height, tc.legacy)
+			require.NoError(t, err)
+			if tc.mutate != nil {
+				tc.mutate(lightBlock)
+				legacy, err := verifier.DetectLegacy(lightBlock, tc.transition)
+				require.Error(t, err)
+				require.False(t, legacy)
+				require.NotErrorIs(t, err, verifier.ErrOutsideTransition)
+				if tc.err != nil {
+					require.ErrorIs(t, err, tc.err)
+				}
+				return
+			}
+			legacy, err := verifier.DetectLegacy(lightBlock, tc.transition)
+			if tc.err != nil {
+				require.ErrorIs(t, err, tc.err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tc.legacy, legacy)
+		})
+	}
+}
+
+func TestParseTransition(t *testing.T) {
+	for _, tc := range []struct {
+		s          string
+		transition verifier.Transition
+		valid      bool
+	}{
+		{s: "", transition: verifier.Transition{From: 1, To: math.MaxInt64}, valid: true},
+		{s: "5-10", transition: verifier.Transition{From: 5, To: 10}, valid: true},
+		{s: "5-5", transition: verifier.Transition{From: 5, To: 5}, valid: true},
+		// No height left using both schemes, from 1 on the current one.
+		{s: "1-0", transition: verifier.Transition{From: 1, To: 0}, valid: true},
+		{s: "10-5"},
+		{s: "0-5"},
+		{s: "5"},
+		{s: "a-5"},
+		{s: "5-b"},
+	} {
+		t.Run(tc.s, func(t *testing.T) {
+			transition, err := verifier.ParseTransition(tc.s)
+			if !tc.valid {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tc.transition, transition)
+		})
+	}
+}
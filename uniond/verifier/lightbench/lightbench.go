@@ -0,0 +1,131 @@
+// Package lightbench benchmarks the light client header verification against
+// light blocks of a configurable validator set size generated by lighttest,
+// so that the verification cost can be compared across releases on the same
+// machine.
+package lightbench
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	cmtmath "github.com/cometbft/cometbft/libs/math"
+	"github.com/cometbft/cometbft/light"
+	cmttypes "github.com/cometbft/cometbft/types"
+
+	"union/verifier/lighttest"
+)
+
+// Scenario is a kind of header transition being verified.
+type Scenario string
+
+const (
+	// ScenarioAdjacent verifies a header against the one right before it.
+	ScenarioAdjacent Scenario = "adjacent"
+	// ScenarioNonAdjacent skips heights, the trusted validator set has to
+	// have signed the untrusted header.
+	ScenarioNonAdjacent Scenario = "non-adjacent"
+	// ScenarioLegacy verifies an adjacent header signed with the legacy
+	// (pre cometbls) vote sign bytes.
+	ScenarioLegacy Scenario = "legacy"
+)
+
+// Scenarios lists every supported scenario.
+var Scenarios = []Scenario{ScenarioAdjacent, ScenarioNonAdjacent, ScenarioLegacy}
+
+// Heights skipped by the non adjacent scenario.
+const nonAdjacentDistance = 100
+
+// transition returns the trusted and untrusted light blocks of a scenario.
+func transition(c *lighttest.Chain, scenario Scenario) (trusted, untrusted *cmttypes.LightBlock, err error) {
+	distance := int64(1)
+	if scenario == ScenarioNonAdjacent {
+		distance = nonAdjacentDistance
+	}
+	legacy := scenario == ScenarioLegacy
+	trusted, err = c.LightBlock(1, legacy)
+	if err != nil {
+		return nil, nil, err
+	}
+	untrusted, err = c.LightBlock(1+distance, legacy)
+	if err != nil {
+		return nil, nil, err
+	}
+	return trusted, untrusted, nil
+}
+
+// Result is the outcome of the benchmark of a scenario.
+type Result struct {
+	Scenario    Scenario `json:"scenario"`
+	Validators  int      `json:"validators"`
+	Iterations  int      `json:"iterations"`
+	NsPerOp     int64    `json:"ns_per_op"`
+	AllocsPerOp int64    `json:"allocs_per_op"`
+	BytesPerOp  int64    `json:"bytes_per_op"`
+}
+
+// Run benchmarks the verification of a scenario on a chain of the given
+// number of validators. The transition is checked to verify before being
+// measured.
+func Run(scenario Scenario, validators int, seed int64) (Result, error) {
+	var verify func(*cmttypes.SignedHeader, *cmttypes.ValidatorSet, *cmttypes.SignedHeader, *cmttypes.ValidatorSet, time.Duration, time.Time, time.Duration, cmtmath.Fraction) error
+	switch scenario {
+	case ScenarioAdjacent, ScenarioNonAdjacent:
+		verify = light.Verify
+	case ScenarioLegacy:
+		verify = light.VerifyLegacy
+	default:
+		return Result{}, fmt.Errorf("unknown scenario %q", scenario)
+	}
+
+	chain, err := lighttest.NewChain("lightbench-1", validators, seed)
+	if err != nil {
+		return Result{}, err
+	}
+	trusted, untrusted, err := transition(chain, scenario)
+	if err != nil {
+		return Result{}, err
+	}
+	var (
+		trustingPeriod = 168 * time.Hour
+		now            = untrusted.Time.Add(time.Second)
+		maxClockDrift  = 10 * time.Second
+	)
+	run := func() error {
+		return verify(
+			trusted.SignedHeader,
+			trusted.ValidatorSet,
+			untrusted.SignedHeader,
+			untrusted.ValidatorSet,
+			trustingPeriod,
+			now,
+			maxClockDrift,
+			light.DefaultTrustLevel,
+		)
+	}
+	if err := run(); err != nil {
+		return Result{}, fmt.Errorf("%s transition doesn't verify: %w", scenario, err)
+	}
+
+	var runErr error
+	res := testing.Benchmark(func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			if err := run(); err != nil {
+				runErr = err
+				b.FailNow()
+			}
+		}
+	})
+	if runErr != nil {
+		return Result{}, runErr
+	}
+	return Result{
+		Scenario:    scenario,
+		Validators:  validators,
+		Iterations:  res.N,
+		NsPerOp:     res.NsPerOp(),
+		AllocsPerOp: res.AllocsPerOp(),
+		BytesPerOp:  res.AllocedBytesPerOp(),
+	}, nil
+}
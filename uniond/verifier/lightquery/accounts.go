@@ -0,0 +1,69 @@
+package lightquery
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"cosmossdk.io/collections"
+	"github.com/cosmos/cosmos-sdk/codec"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	authtypes "github.com/cosmos/cosmos-sdk/x/auth/types"
+	banktypes "github.com/cosmos/cosmos-sdk/x/bank/types"
+)
+
+// ErrAccountNotFound is returned when the account is proven not to exist.
+var ErrAccountNotFound = errors.New("account not found")
+
+// The keys of the balances, as laid out by the x/bank keeper.
+var balancesKeyCodec = collections.PairKeyCodec(sdk.AccAddressKey, collections.StringKey)
+
+func storeKeyPath(storeKey string) string {
+	return "/store/" + storeKey + "/key"
+}
+
+// VerifiedBalance returns the balance of an address in a denom at a height,
+// verified against the trusted app hash. A balance proven absent is zero.
+func (c *Client) VerifiedBalance(ctx context.Context, addr sdk.AccAddress, denom string, height int64) (sdk.Coin, error) {
+	if err := sdk.ValidateDenom(denom); err != nil {
+		return sdk.Coin{}, err
+	}
+	key, err := collections.EncodeKeyWithPrefix(banktypes.BalancesPrefix, balancesKeyCodec, collections.Join(addr, denom))
+	if err != nil {
+		return sdk.Coin{}, err
+	}
+	resp, err := c.VerifiedABCIQuery(ctx, storeKeyPath(banktypes.StoreKey), key, height)
+	if err != nil {
+		return sdk.Coin{}, err
+	}
+	if len(resp.Value) == 0 {
+		return sdk.NewInt64Coin(denom, 0), nil
+	}
+	amount, err := banktypes.BalanceValueCodec.Decode(resp.Value)
+	if err != nil {
+		return sdk.Coin{}, fmt.Errorf("can't decode the balance: %w", err)
+	}
+	return sdk.NewCoin(denom, amount), nil
+}
+
+// VerifiedAccount returns the account of an address at a height, verified
+// against the trusted app hash. The codec must know the account types of the
+// chain, e.g. the one of the app.
+func (c *Client) VerifiedAccount(ctx context.Context, cdc codec.BinaryCodec, addr sdk.AccAddress, height int64) (sdk.AccountI, error) {
+	key, err := collections.EncodeKeyWithPrefix(authtypes.AddressStoreKeyPrefix, sdk.AccAddressKey, addr)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := c.VerifiedABCIQuery(ctx, storeKeyPath(authtypes.StoreKey), key, height)
+	if err != nil {
+		return nil, err
+	}
+	if len(resp.Value) == 0 {
+		return nil, fmt.Errorf("%w: %s at %d", ErrAccountNotFound, addr, resp.Height)
+	}
+	account, err := codec.CollInterfaceValue[sdk.AccountI](cdc).Decode(resp.Value)
+	if err != nil {
+		return nil, fmt.Errorf("can't decode the account: %w", err)
+	}
+	return account, nil
+}
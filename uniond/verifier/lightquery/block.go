@@ -0,0 +1,32 @@
+package lightquery
+
+import (
+	"context"
+	"fmt"
+
+	cmttypes "github.com/cometbft/cometbft/types"
+
+	"union/verifier"
+)
+
+// VerifiedBlock fetches the block at a height and checks that its
+// transactions, last commit and evidence match the hashes committed to by the
+// verified header of the height, failing with verifier.ErrBlockMismatch
+// otherwise.
+func (c *Client) VerifiedBlock(ctx context.Context, height int64) (*cmttypes.Block, error) {
+	if height <= 0 {
+		return nil, fmt.Errorf("height must be positive, got %d", height)
+	}
+	res, err := c.next.Block(ctx, &height)
+	if err != nil {
+		return nil, err
+	}
+	lightBlock, err := c.trustedLightBlock(ctx, height)
+	if err != nil {
+		return nil, err
+	}
+	if err := verifier.CheckBlock(lightBlock, res.Block); err != nil {
+		return nil, err
+	}
+	return res.Block, nil
+}
@@ -0,0 +1,128 @@
+// Package lightquery reads the state and the transactions of a chain through
+// an untrusted RPC node, verifying every result against the headers trusted by
+// a light client before returning it.
+package lightquery
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"cosmossdk.io/store/rootmulti"
+	abci "github.com/cometbft/cometbft/abci/types"
+	"github.com/cometbft/cometbft/crypto/merkle"
+	lightrpc "github.com/cometbft/cometbft/light/rpc"
+	rpcclient "github.com/cometbft/cometbft/rpc/client"
+	cmttypes "github.com/cometbft/cometbft/types"
+)
+
+// ErrInvalidProof is returned when a result doesn't match the proof the node
+// served with it, or the proof doesn't match the trusted headers.
+var ErrInvalidProof = errors.New("invalid proof")
+
+// Client verifies the results of an RPC node. The light client is trusted to
+// verify the headers: the ones it already trusts are read from its store, the
+// others are verified from them, bisecting if needed.
+type Client struct {
+	next    rpcclient.Client
+	light   lightrpc.LightClient
+	prt     *merkle.ProofRuntime
+	keyPath lightrpc.KeyPathFunc
+	now     func() time.Time
+}
+
+// NewClient creates a client verifying the results of next with the light
+// client, e.g. a *light.Client. The headers are verified at the time given by
+// now, the system time if nil.
+func NewClient(next rpcclient.Client, lc lightrpc.LightClient, now func() time.Time) *Client {
+	if now == nil {
+		now = time.Now
+	}
+	return &Client{
+		next:  next,
+		light: lc,
+		now:   now,
+		// The Cosmos SDK stores are proven with ICS-23 commitment proofs.
+		prt:     rootmulti.DefaultProofRuntime(),
+		keyPath: lightrpc.DefaultMerkleKeyPathFn(),
+	}
+}
+
+// trustedLightBlock returns the light block at a height once verified.
+func (c *Client) trustedLightBlock(ctx context.Context, height int64) (*cmttypes.LightBlock, error) {
+	lightBlock, err := c.light.VerifyLightBlockAtHeight(ctx, height, c.now())
+	if err != nil {
+		return nil, fmt.Errorf("can't verify the header at %d: %w", height, err)
+	}
+	return lightBlock, nil
+}
+
+// latestProvableHeight is the latest height whose state and results can be
+// proven, the ones of a height being committed by the header of the next one.
+func (c *Client) latestProvableHeight(ctx context.Context) (int64, error) {
+	status, err := c.next.Status(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("can't get the latest height: %w", err)
+	}
+	if status.SyncInfo.LatestBlockHeight < 2 {
+		return 0, errors.New("no provable height yet")
+	}
+	return status.SyncInfo.LatestBlockHeight - 1, nil
+}
+
+// VerifiedABCIQuery queries a key of a store at a height, the path being
+// /store/<store>/key, and verifies the returned value, or its absence, against
+// the app hash committed by the header of the next height. The latest
+// provable height is queried if the height is 0.
+func (c *Client) VerifiedABCIQuery(ctx context.Context, path string, key []byte, height int64) (*abci.ResponseQuery, error) {
+	if height < 0 {
+		return nil, fmt.Errorf("height must be positive or zero, got %d", height)
+	}
+	if height == 0 {
+		latest, err := c.latestProvableHeight(ctx)
+		if err != nil {
+			return nil, err
+		}
+		height = latest
+	}
+	keyPath, err := c.keyPath(path, key)
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := c.next.ABCIQueryWithOptions(ctx, path, key, rpcclient.ABCIQueryOptions{Height: height, Prove: true})
+	if err != nil {
+		return nil, err
+	}
+	resp := res.Response
+	if resp.IsErr() {
+		return nil, fmt.Errorf("query failed with code %d: %s", resp.Code, resp.Log)
+	}
+	// The node could otherwise prove another key or height than the queried
+	// ones.
+	if !bytes.Equal(resp.Key, key) {
+		return nil, fmt.Errorf("%w: got key %X, queried %X", ErrInvalidProof, resp.Key, key)
+	}
+	if resp.Height != height {
+		return nil, fmt.Errorf("%w: got height %d, queried %d", ErrInvalidProof, resp.Height, height)
+	}
+	if resp.ProofOps == nil || len(resp.ProofOps.Ops) == 0 {
+		return nil, fmt.Errorf("%w: no proof", ErrInvalidProof)
+	}
+
+	lightBlock, err := c.trustedLightBlock(ctx, height+1)
+	if err != nil {
+		return nil, err
+	}
+	if len(resp.Value) > 0 {
+		err = c.prt.VerifyValue(resp.ProofOps, lightBlock.AppHash, keyPath.String(), resp.Value)
+	} else {
+		err = c.prt.VerifyAbsence(resp.ProofOps, lightBlock.AppHash, keyPath.String())
+	}
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", ErrInvalidProof, err)
+	}
+	return &resp, nil
+}
@@ -0,0 +1,49 @@
+package lightquery
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	dbm "github.com/cometbft/cometbft-db"
+	cmtlog "github.com/cometbft/cometbft/libs/log"
+	"github.com/cometbft/cometbft/light"
+	"github.com/cometbft/cometbft/light/provider"
+	lightdb "github.com/cometbft/cometbft/light/store/db"
+	"github.com/stretchr/testify/require"
+
+	"union/verifier/lighttest"
+)
+
+func TestTrustedLightBlockClock(t *testing.T) {
+	const trustingPeriod = 24 * time.Hour
+	chain, err := lighttest.NewChain("clock-1", 4, 1)
+	require.NoError(t, err)
+	root, err := chain.LightBlock(1, false)
+	require.NoError(t, err)
+
+	ctx := context.Background()
+	lc, err := light.NewClient(
+		ctx,
+		chain.ChainID,
+		light.TrustOptions{Period: trustingPeriod, Height: 1, Hash: root.Hash()},
+		lighttest.NewProvider("primary", chain, 100, false),
+		[]provider.Provider{lighttest.NewProvider("witness", chain, 100, false)},
+		lightdb.New(dbm.NewMemDB(), chain.ChainID),
+		light.Logger(cmtlog.NewNopLogger()),
+	)
+	require.NoError(t, err)
+
+	clock := lighttest.NewClock(chain.Time(10))
+	c := NewClient(nil, lc, clock.Now)
+	lightBlock, err := c.trustedLightBlock(ctx, 10)
+	require.NoError(t, err)
+	require.Equal(t, int64(10), lightBlock.Height)
+
+	// Past the trusting period of the latest trusted header, the headers
+	// can't be verified anymore.
+	clock.Advance(trustingPeriod)
+	_, err = c.trustedLightBlock(ctx, 20)
+	require.True(t, errors.As(err, &light.ErrOldHeaderExpired{}), "got %v", err)
+}
@@ -0,0 +1,119 @@
+package lightquery
+
+import (
+	"bytes"
+	"context"
+	"crypto/subtle"
+	"fmt"
+
+	abci "github.com/cometbft/cometbft/abci/types"
+	ctypes "github.com/cometbft/cometbft/rpc/core/types"
+	cmttypes "github.com/cometbft/cometbft/types"
+)
+
+// VerifiedTx fetches a transaction by hash along with its Merkle proof, and
+// verifies its inclusion against the data hash of the header of its height and
+// its result against the results hash committed by the header of the next
+// height. Only the code, data and gas of the result are committed, the events
+// are returned as served by the node.
+func (c *Client) VerifiedTx(ctx context.Context, hash []byte) (*ctypes.ResultTx, error) {
+	res, err := c.next.Tx(ctx, hash, true)
+	if err != nil {
+		return nil, err
+	}
+	if subtle.ConstantTimeCompare(res.Hash, hash) != 1 {
+		return nil, fmt.Errorf("%w: got transaction %X, queried %X", ErrInvalidProof, res.Hash, hash)
+	}
+	results, err := c.verifiedResults(ctx, res.Height)
+	if err != nil {
+		return nil, err
+	}
+	if err := c.verifyTx(ctx, res, results); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+// VerifiedTxSearch searches transactions by events like TxSearch and verifies
+// every returned transaction as VerifiedTx does, failing rather than returning
+// a transaction whose inclusion or result can't be verified. The events are
+// those the node indexed, they belong to verified transactions but aren't
+// committed by the headers, and the node can still omit matching
+// transactions.
+func (c *Client) VerifiedTxSearch(ctx context.Context, query string, page, perPage *int, orderBy string) (*ctypes.ResultTxSearch, error) {
+	res, err := c.next.TxSearch(ctx, query, true, page, perPage, orderBy)
+	if err != nil {
+		return nil, err
+	}
+	// The transactions of a height share the same results.
+	results := make(map[int64][]*abci.ExecTxResult)
+	for _, tx := range res.Txs {
+		heightResults, ok := results[tx.Height]
+		if !ok {
+			heightResults, err = c.verifiedResults(ctx, tx.Height)
+			if err != nil {
+				return nil, fmt.Errorf("transaction %X: %w", tx.Hash, err)
+			}
+			results[tx.Height] = heightResults
+		}
+		if err := c.verifyTx(ctx, tx, heightResults); err != nil {
+			return nil, fmt.Errorf("transaction %X: %w", tx.Hash, err)
+		}
+	}
+	return res, nil
+}
+
+// verifyTx verifies the inclusion of a transaction and its result against the
+// verified results of its height.
+func (c *Client) verifyTx(ctx context.Context, res *ctypes.ResultTx, results []*abci.ExecTxResult) error {
+	// The node could otherwise prove another transaction, or the same one at
+	// another index, than the one it returns.
+	if !bytes.Equal(res.Proof.Data, res.Tx) {
+		return fmt.Errorf("%w: proof of another transaction", ErrInvalidProof)
+	}
+	if subtle.ConstantTimeCompare(res.Tx.Hash(), res.Hash) != 1 {
+		return fmt.Errorf("%w: transaction hash %X doesn't match %X", ErrInvalidProof, res.Tx.Hash(), res.Hash)
+	}
+	if res.Proof.Proof.Index != int64(res.Index) {
+		return fmt.Errorf("%w: proof at index %d, transaction at %d", ErrInvalidProof, res.Proof.Proof.Index, res.Index)
+	}
+
+	lightBlock, err := c.trustedLightBlock(ctx, res.Height)
+	if err != nil {
+		return err
+	}
+	if err := res.Proof.Validate(lightBlock.DataHash); err != nil {
+		return fmt.Errorf("%w: %s", ErrInvalidProof, err)
+	}
+
+	if int(res.Index) >= len(results) {
+		return fmt.Errorf("%w: no result at index %d of %d", ErrInvalidProof, res.Index, res.Height)
+	}
+	// Hashing a single result covers exactly its committed fields.
+	committed := cmttypes.NewResults([]*abci.ExecTxResult{results[res.Index]}).Hash()
+	served := cmttypes.NewResults([]*abci.ExecTxResult{&res.TxResult}).Hash()
+	if subtle.ConstantTimeCompare(committed, served) != 1 {
+		return fmt.Errorf("%w: result of transaction %X doesn't match the committed one", ErrInvalidProof, res.Hash)
+	}
+	return nil
+}
+
+// verifiedResults fetches the transaction results of a height and verifies
+// them against the results hash committed by the header of the next height.
+func (c *Client) verifiedResults(ctx context.Context, height int64) ([]*abci.ExecTxResult, error) {
+	if height <= 0 {
+		return nil, fmt.Errorf("height must be positive, got %d", height)
+	}
+	res, err := c.next.BlockResults(ctx, &height)
+	if err != nil {
+		return nil, err
+	}
+	lightBlock, err := c.trustedLightBlock(ctx, height+1)
+	if err != nil {
+		return nil, err
+	}
+	if resultsHash := cmttypes.NewResults(res.TxsResults).Hash(); subtle.ConstantTimeCompare(resultsHash, lightBlock.LastResultsHash) != 1 {
+		return nil, fmt.Errorf("%w: results hash %X of %d doesn't match the committed %X", ErrInvalidProof, resultsHash, height, lightBlock.LastResultsHash)
+	}
+	return res.TxsResults, nil
+}
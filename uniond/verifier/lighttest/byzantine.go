@@ -0,0 +1,208 @@
+package lighttest
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"time"
+
+	cmtmath "github.com/cometbft/cometbft/libs/math"
+	"github.com/cometbft/cometbft/light"
+	cmttypes "github.com/cometbft/cometbft/types"
+)
+
+// AttackKind is a class of byzantine behavior the light client must resist.
+type AttackKind string
+
+const (
+	// AttackLunatic is a header with forged application state, signed by
+	// validators unknown to the trusted validator set. The verifier rejects
+	// it as not enough trusted voting power signed it.
+	AttackLunatic AttackKind = "lunatic"
+	// AttackEquivocation is a second header at a height, signed by the same
+	// validators as the honest one. It verifies, only cross checking with a
+	// witness detects it, the double signing validators being accountable.
+	AttackEquivocation AttackKind = "equivocation"
+	// AttackForgedValidatorsHash supplies a validator set that isn't the one
+	// the header commits to. The verifier rejects the header as invalid.
+	AttackForgedValidatorsHash AttackKind = "forged_validators_hash"
+	// AttackExpiredTrustAnchor verifies a valid header from a trusted header
+	// that left the trusting period. The verifier rejects it as expired.
+	AttackExpiredTrustAnchor AttackKind = "expired_trust_anchor"
+)
+
+// AttackKinds lists every simulated attack.
+var AttackKinds = []AttackKind{
+	AttackLunatic,
+	AttackEquivocation,
+	AttackForgedValidatorsHash,
+	AttackExpiredTrustAnchor,
+}
+
+// Attack is a byzantine transition along with the verification inputs it is
+// checked with.
+type Attack struct {
+	Kind      AttackKind
+	Trusted   *cmttypes.LightBlock
+	Untrusted *cmttypes.LightBlock
+	// Honest is the light block of the honest chain at the untrusted height,
+	// the one the attack conflicts with.
+	Honest         *cmttypes.LightBlock
+	TrustingPeriod time.Duration
+	MaxClockDrift  time.Duration
+	TrustLevel     cmtmath.Fraction
+	Legacy         bool
+	Now            time.Time
+}
+
+// Attacks returns the attack corpus against a chain of the given number of
+// validators derived from the seed, every attack being an instance of one of
+// AttackKinds.
+func Attacks(validators int, seed int64, legacy bool) ([]Attack, error) {
+	const chainID = "byzantine-1"
+	honest, err := NewChain(chainID, validators, seed)
+	if err != nil {
+		return nil, err
+	}
+	// The byzantine validators never overlap with the honest ones.
+	byzantine, err := NewChain(chainID, validators, seed+1)
+	if err != nil {
+		return nil, err
+	}
+
+	var attacks []Attack
+	newAttack := func(kind AttackKind, trustedHeight, untrustedHeight int64) (Attack, error) {
+		trusted, err := honest.LightBlock(trustedHeight, legacy)
+		if err != nil {
+			return Attack{}, err
+		}
+		honestLightBlock, err := honest.LightBlock(untrustedHeight, legacy)
+		if err != nil {
+			return Attack{}, err
+		}
+		return Attack{
+			Kind:           kind,
+			Trusted:        trusted,
+			Untrusted:      honestLightBlock,
+			Honest:         honestLightBlock,
+			TrustingPeriod: 168 * time.Hour,
+			MaxClockDrift:  10 * time.Second,
+			TrustLevel:     light.DefaultTrustLevel,
+			Legacy:         legacy,
+			Now:            honestLightBlock.Time.Add(time.Minute),
+		}, nil
+	}
+
+	lunatic, err := newAttack(AttackLunatic, 1, 10)
+	if err != nil {
+		return nil, err
+	}
+	header, err := byzantine.Header(10, legacy)
+	if err != nil {
+		return nil, err
+	}
+	header.AppHash = FieldHash("forged app")
+	if lunatic.Untrusted, err = byzantine.Sign(header, legacy); err != nil {
+		return nil, err
+	}
+	attacks = append(attacks, lunatic)
+
+	equivocation, err := newAttack(AttackEquivocation, 1, 10)
+	if err != nil {
+		return nil, err
+	}
+	header, err = honest.Header(10, legacy)
+	if err != nil {
+		return nil, err
+	}
+	header.DataHash = FieldHash("double spend")
+	if equivocation.Untrusted, err = honest.Sign(header, legacy); err != nil {
+		return nil, err
+	}
+	attacks = append(attacks, equivocation)
+
+	forgedValidatorsHash, err := newAttack(AttackForgedValidatorsHash, 1, 2)
+	if err != nil {
+		return nil, err
+	}
+	byzantineVals, err := byzantine.ValidatorSet(2)
+	if err != nil {
+		return nil, err
+	}
+	forgedValidatorsHash.Untrusted = &cmttypes.LightBlock{
+		SignedHeader: forgedValidatorsHash.Honest.SignedHeader,
+		ValidatorSet: byzantineVals,
+	}
+	attacks = append(attacks, forgedValidatorsHash)
+
+	expiredTrustAnchor, err := newAttack(AttackExpiredTrustAnchor, 1, 2)
+	if err != nil {
+		return nil, err
+	}
+	expiredTrustAnchor.Now = expiredTrustAnchor.Trusted.Time.Add(expiredTrustAnchor.TrustingPeriod + time.Second)
+	attacks = append(attacks, expiredTrustAnchor)
+
+	return attacks, nil
+}
+
+// Verify runs the light client verification of the attack.
+func (a Attack) Verify() error {
+	verify := light.Verify
+	if a.Legacy {
+		verify = light.VerifyLegacy
+	}
+	return verify(
+		a.Trusted.SignedHeader,
+		a.Trusted.ValidatorSet,
+		a.Untrusted.SignedHeader,
+		a.Untrusted.ValidatorSet,
+		a.TrustingPeriod,
+		a.Now,
+		a.MaxClockDrift,
+		a.TrustLevel,
+	)
+}
+
+// Check asserts that the attack is rejected by the verifier or, for the
+// attacks the verifier can't tell apart from honest headers, that it is
+// detected as conflicting with the honest chain and attributed to the right
+// validators.
+func (a Attack) Check() error {
+	verifyErr := a.Verify()
+	switch a.Kind {
+	case AttackLunatic:
+		if !errors.As(verifyErr, &light.ErrNewValSetCantBeTrusted{}) {
+			return fmt.Errorf("expected the validator set not to be trusted, got %v", verifyErr)
+		}
+		evidence := &cmttypes.LightClientAttackEvidence{ConflictingBlock: a.Untrusted, CommonHeight: a.Trusted.Height}
+		if !evidence.ConflictingHeaderIsInvalid(a.Honest.Header) {
+			return errors.New("conflicting header isn't classified as lunatic")
+		}
+	case AttackEquivocation:
+		if verifyErr != nil {
+			return fmt.Errorf("equivocating header signed by the trusted validators must verify: %w", verifyErr)
+		}
+		if bytes.Equal(a.Untrusted.Commit.BlockID.Hash, a.Honest.Commit.BlockID.Hash) {
+			return errors.New("equivocating header doesn't conflict with the honest one")
+		}
+		evidence := &cmttypes.LightClientAttackEvidence{ConflictingBlock: a.Untrusted, CommonHeight: a.Honest.Height}
+		if evidence.ConflictingHeaderIsInvalid(a.Honest.Header) {
+			return errors.New("conflicting header isn't classified as an equivocation")
+		}
+		byzantineVals := evidence.GetByzantineValidators(a.Honest.ValidatorSet, a.Honest.SignedHeader)
+		if len(byzantineVals) != a.Honest.ValidatorSet.Size() {
+			return fmt.Errorf("expected every validator to have double signed, got %d out of %d", len(byzantineVals), a.Honest.ValidatorSet.Size())
+		}
+	case AttackForgedValidatorsHash:
+		if !errors.As(verifyErr, &light.ErrInvalidHeader{}) {
+			return fmt.Errorf("expected the header to be invalid, got %v", verifyErr)
+		}
+	case AttackExpiredTrustAnchor:
+		if !errors.As(verifyErr, &light.ErrOldHeaderExpired{}) {
+			return fmt.Errorf("expected the trusted header to be expired, got %v", verifyErr)
+		}
+	default:
+		return fmt.Errorf("unknown attack %q", a.Kind)
+	}
+	return nil
+}
@@ -0,0 +1,64 @@
+package lighttest_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"union/verifier"
+	"union/verifier/lighttest"
+)
+
+func TestAttacks(t *testing.T) {
+	server, err := verifier.NewServer(verifier.DefaultConfig())
+	require.NoError(t, err)
+
+	for _, legacy := range []bool{false, true} {
+		for _, seed := range []int64{1, 2, 3} {
+			attacks, err := lighttest.Attacks(4, seed, legacy)
+			require.NoError(t, err)
+			require.Len(t, attacks, len(lighttest.AttackKinds))
+
+			for _, attack := range attacks {
+				require.NoError(t, attack.Check(), "%s, seed %d, legacy %t", attack.Kind, seed, legacy)
+
+				trusted, err := attack.Trusted.ToProto()
+				require.NoError(t, err)
+				// The forged validator set doesn't hash to the validators
+				// hash, which ToProto doesn't check.
+				untrusted, err := attack.Untrusted.ToProto()
+				require.NoError(t, err)
+				res, err := server.Verify(context.Background(), &verifier.VerifyRequest{
+					Trusted:   trusted,
+					Untrusted: untrusted,
+					Options: &verifier.VerificationOptions{
+						TrustingPeriod: &attack.TrustingPeriod,
+						MaxClockDrift:  &attack.MaxClockDrift,
+						TrustLevel: &verifier.Fraction{
+							Numerator:   attack.TrustLevel.Numerator,
+							Denominator: attack.TrustLevel.Denominator,
+						},
+						Legacy: attack.Legacy,
+						Now:    &attack.Now,
+					},
+				})
+				switch attack.Kind {
+				case lighttest.AttackForgedValidatorsHash:
+					// Rejected when decoding, before being verified.
+					require.Equal(t, codes.InvalidArgument, status.Code(err), "%s, seed %d, legacy %t: %v", attack.Kind, seed, legacy, err)
+				case lighttest.AttackEquivocation:
+					// Only a witness tells the equivocating header apart.
+					require.NoError(t, err)
+					require.True(t, res.Report.Verified, "%s, seed %d, legacy %t", attack.Kind, seed, legacy)
+				default:
+					require.NoError(t, err)
+					require.False(t, res.Report.Verified, "%s, seed %d, legacy %t", attack.Kind, seed, legacy)
+					require.NotEmpty(t, res.Report.Error)
+				}
+			}
+		}
+	}
+}
@@ -0,0 +1,179 @@
+package lighttest
+
+import (
+	"encoding/binary"
+	"fmt"
+	"time"
+
+	cmtproto "github.com/cometbft/cometbft/proto/tendermint/types"
+	cmttypes "github.com/cometbft/cometbft/types"
+)
+
+// Source turns a raw byte stream, e.g. the input of a fuzzing harness, into
+// values. It yields zeros once exhausted so that any input builds a value.
+type Source struct {
+	data []byte
+}
+
+func NewSource(data []byte) *Source {
+	return &Source{data: data}
+}
+
+// Bytes consumes n bytes.
+func (s *Source) Bytes(n int) []byte {
+	bz := make([]byte, n)
+	s.data = s.data[copy(bz, s.data):]
+	return bz
+}
+
+// Uint64 consumes 8 bytes.
+func (s *Source) Uint64() uint64 {
+	return binary.BigEndian.Uint64(s.Bytes(8))
+}
+
+// Intn consumes 8 bytes and returns an integer in [0, n).
+func (s *Source) Intn(n int) int {
+	if n <= 0 {
+		return 0
+	}
+	return int(s.Uint64() % uint64(n))
+}
+
+// Bool consumes a byte.
+func (s *Source) Bool() bool {
+	return s.Bytes(1)[0]&1 == 1
+}
+
+// ArbitraryChain builds a chain of up to maxValidators validators whose
+// keys, voting powers, churn and block time are derived from the source.
+func ArbitraryChain(src *Source, chainID string, maxValidators int) (*Chain, error) {
+	if maxValidators <= 0 {
+		return nil, fmt.Errorf("max validators must be positive, got %d", maxValidators)
+	}
+	validators := 1 + src.Intn(maxValidators)
+	seed := int64(src.Uint64())
+	churn := src.Intn(validators + 1)
+	blockTime := time.Duration(1+src.Intn(60)) * time.Second
+	return NewChain(chainID, validators, seed, WithChurn(churn), WithGenesis(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC), blockTime))
+}
+
+// ArbitraryTransition builds a structurally valid transition from the
+// source: the chain, the trusted height, the distance to the untrusted height
+// and the legacy mode are derived from it. Whether the transition verifies
+// depends on the churn of the chain.
+func ArbitraryTransition(src *Source, maxValidators int) (trusted, untrusted *cmttypes.LightBlock, legacy bool, err error) {
+	chain, err := ArbitraryChain(src, "arbitrary-1", maxValidators)
+	if err != nil {
+		return nil, nil, false, err
+	}
+	trustedHeight := int64(1 + src.Intn(1_000))
+	distance := int64(1 + src.Intn(200))
+	legacy = src.Bool()
+	if trusted, err = chain.LightBlock(trustedHeight, legacy); err != nil {
+		return nil, nil, false, err
+	}
+	if untrusted, err = chain.LightBlock(trustedHeight+distance, legacy); err != nil {
+		return nil, nil, false, err
+	}
+	return trusted, untrusted, legacy, nil
+}
+
+// Mutation flips a single field checked by the verification.
+type Mutation string
+
+// The mutations are named after the flipped field of the header, the commit
+// (its first signature for signature and block_id_flag) or the validator set
+// (its first validator for voting_power).
+const (
+	MutateChainID            Mutation = "chain_id"
+	MutateHeight             Mutation = "height"
+	MutateTime               Mutation = "time"
+	MutateValidatorsHash     Mutation = "validators_hash"
+	MutateNextValidatorsHash Mutation = "next_validators_hash"
+	MutateAppHash            Mutation = "app_hash"
+	MutateCommitHeight       Mutation = "commit_height"
+	MutateCommitBlockID      Mutation = "commit_block_id"
+	MutateSignature          Mutation = "signature"
+	MutateBlockIDFlag        Mutation = "block_id_flag"
+	MutateVotingPower        Mutation = "voting_power"
+)
+
+// Mutations lists every mutation.
+var Mutations = []Mutation{
+	MutateChainID,
+	MutateHeight,
+	MutateTime,
+	MutateValidatorsHash,
+	MutateNextValidatorsHash,
+	MutateAppHash,
+	MutateCommitHeight,
+	MutateCommitBlockID,
+	MutateSignature,
+	MutateBlockIDFlag,
+	MutateVotingPower,
+}
+
+// Mutate returns a copy of the light block with a single field flipped,
+// without signing it again. Hashes have their last bit flipped so
+// that they remain bn254 field elements.
+func Mutate(lightBlock *cmttypes.LightBlock, mutation Mutation) (*cmttypes.LightBlock, error) {
+	mutated, err := copyLightBlock(lightBlock)
+	if err != nil {
+		return nil, err
+	}
+	header, commit := mutated.Header, mutated.Commit
+	switch mutation {
+	case MutateChainID:
+		header.ChainID += "x"
+	case MutateHeight:
+		header.Height++
+	case MutateTime:
+		header.Time = header.Time.Add(time.Second)
+	case MutateValidatorsHash:
+		flipLastBit(header.ValidatorsHash)
+	case MutateNextValidatorsHash:
+		flipLastBit(header.NextValidatorsHash)
+	case MutateAppHash:
+		flipLastBit(header.AppHash)
+	case MutateCommitHeight:
+		commit.Height++
+	case MutateCommitBlockID:
+		flipLastBit(commit.BlockID.Hash)
+	case MutateSignature:
+		flipLastBit(commit.Signatures[0].Signature)
+	case MutateBlockIDFlag:
+		commit.Signatures[0] = cmttypes.NewCommitSigAbsent()
+	case MutateVotingPower:
+		vals := mutated.ValidatorSet.Validators
+		vals[0].VotingPower++
+		if mutated.ValidatorSet, err = cmttypes.ValidatorSetFromExistingValidators(vals); err != nil {
+			return nil, err
+		}
+	default:
+		return nil, fmt.Errorf("unknown mutation %q", mutation)
+	}
+	return mutated, nil
+}
+
+func flipLastBit(bz []byte) {
+	if len(bz) > 0 {
+		bz[len(bz)-1] ^= 1
+	}
+}
+
+// copyLightBlock deep copies a light block through its protobuf encoding.
+func copyLightBlock(lightBlock *cmttypes.LightBlock) (*cmttypes.LightBlock, error) {
+	pb, err := lightBlock.ToProto()
+	if err != nil {
+		return nil, err
+	}
+	bz, err := pb.Marshal()
+	if err != nil {
+		return nil, err
+	}
+	var copied cmtproto.LightBlock
+	if err := copied.Unmarshal(bz); err != nil {
+		return nil, err
+	}
+	return cmttypes.LightBlockFromProto(&copied)
+}
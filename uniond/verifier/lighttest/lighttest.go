@@ -0,0 +1,373 @@
+// Package lighttest deterministically generates chains of signed headers,
+// validator sets and commits from a seed, so that verification tests written
+// against them are reproducible. The same seed and options always produce
+// the same keys, voting powers, validator set changes and signatures.
+package lighttest
+
+import (
+	"fmt"
+	"math/rand"
+	"sync"
+	"time"
+
+	"github.com/cometbft/cometbft/crypto"
+	cometbn254 "github.com/cometbft/cometbft/crypto/bn254"
+	"github.com/cometbft/cometbft/crypto/tmhash"
+	cmtproto "github.com/cometbft/cometbft/proto/tendermint/types"
+	cmtversion "github.com/cometbft/cometbft/proto/tendermint/version"
+	cmttypes "github.com/cometbft/cometbft/types"
+	"github.com/cometbft/cometbft/version"
+)
+
+// Chain is a fake chain able to sign light blocks at any height. Its
+// validator set is the same at every height unless churn is configured, the
+// proposer rotating as on a live chain. It is safe for concurrent use, e.g.
+// by several providers.
+type Chain struct {
+	ChainID   string
+	Genesis   time.Time
+	BlockTime time.Duration
+	churn     int
+	linked    bool
+	signBytes func(chainID string, vote *cmtproto.Vote) []byte
+	mu        sync.Mutex
+	rng       *rand.Rand
+	privKeys  map[string]crypto.PrivKey
+	// Validator sets from height 1, generated on demand as the set at a
+	// height derives from the one before it.
+	valsets []*cmttypes.ValidatorSet
+	// Hashes of the current and legacy headers of a linked chain from
+	// height 1, computed on demand as a header links to the one before it.
+	// Guarded by hashesMu, the headers reading the validator sets with mu
+	// locked.
+	hashesMu sync.Mutex
+	hashes   [2][][]byte
+}
+
+// Option configures a Chain.
+type Option func(*Chain)
+
+// WithChurn replaces the given number of validators, picked at random, by
+// new ones at every height.
+func WithChurn(validators int) Option {
+	return func(c *Chain) {
+		c.churn = validators
+	}
+}
+
+// WithLinkedHeaders links every header to the one before it, its previous
+// block id being the one committed at the height before, as checked by
+// verifier.CheckContinuity. Every header up to the requested height is then
+// hashed, which is slow at high heights, e.g. for fuzzing.
+func WithLinkedHeaders() Option {
+	return func(c *Chain) {
+		c.linked = true
+	}
+}
+
+// WithSignBytes signs the votes over the given sign bytes instead of the
+// canonical ones of the mode, e.g. to test the verification of a fork with
+// verifier.NewVerifyFunc.
+func WithSignBytes(signBytes func(chainID string, vote *cmtproto.Vote) []byte) Option {
+	return func(c *Chain) {
+		c.signBytes = signBytes
+	}
+}
+
+// WithGenesis sets the time of the genesis and the time between blocks,
+// 2024-01-01 and 6 seconds by default.
+func WithGenesis(genesis time.Time, blockTime time.Duration) Option {
+	return func(c *Chain) {
+		c.Genesis = genesis
+		c.BlockTime = blockTime
+	}
+}
+
+// NewChain creates a chain starting with the given number of validators. The
+// keys and the voting powers are derived from the seed, the voting power
+// follows a long tail distribution similar to the one of live networks.
+func NewChain(chainID string, validators int, seed int64, options ...Option) (*Chain, error) {
+	if validators <= 0 {
+		return nil, fmt.Errorf("the validator set can't be empty, got %d validators", validators)
+	}
+	c := &Chain{
+		ChainID:   chainID,
+		Genesis:   time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+		BlockTime: 6 * time.Second,
+		rng:       rand.New(rand.NewSource(seed)),
+		privKeys:  make(map[string]crypto.PrivKey, validators),
+	}
+	for _, option := range options {
+		option(c)
+	}
+	if c.churn < 0 || c.churn > validators {
+		return nil, fmt.Errorf("churn must be between 0 and the %d validators, got %d", validators, c.churn)
+	}
+	vals := make([]*cmttypes.Validator, validators)
+	for i := range vals {
+		vals[i] = c.newValidator(i)
+	}
+	c.valsets = []*cmttypes.ValidatorSet{cmttypes.NewValidatorSet(vals)}
+	return c, nil
+}
+
+// newValidator generates a validator whose voting power decreases with its
+// rank.
+func (c *Chain) newValidator(rank int) *cmttypes.Validator {
+	keySeed := make([]byte, 64)
+	c.rng.Read(keySeed)
+	privKey := cometbn254.GenPrivKeyFromSeed(keySeed)
+	c.privKeys[string(privKey.PubKey().Address())] = privKey
+	power := 1_000_000/int64(rank+1) + c.rng.Int63n(1_000)
+	return cmttypes.NewValidator(privKey.PubKey(), power)
+}
+
+// Time returns the time of the header at the given height.
+func (c *Chain) Time(height int64) time.Time {
+	return c.Genesis.Add(time.Duration(height) * c.BlockTime)
+}
+
+// ValidatorSet returns the validator set signing the header at the given
+// height.
+func (c *Chain) ValidatorSet(height int64) (*cmttypes.ValidatorSet, error) {
+	if height <= 0 {
+		return nil, fmt.Errorf("height must be positive, got %d", height)
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for int64(len(c.valsets)) < height {
+		previous := c.valsets[len(c.valsets)-1]
+		// The set evolves as the one of a CometBFT state: the changes are
+		// applied then the priorities incremented once per height.
+		vals := previous.Copy()
+		if c.churn > 0 {
+			changes := make([]*cmttypes.Validator, 0, 2*c.churn)
+			for _, i := range c.rng.Perm(len(previous.Validators))[:c.churn] {
+				changes = append(changes, cmttypes.NewValidator(previous.Validators[i].PubKey, 0), c.newValidator(i))
+			}
+			if err := vals.UpdateWithChangeSet(changes); err != nil {
+				return nil, err
+			}
+		}
+		vals.IncrementProposerPriority(1)
+		c.valsets = append(c.valsets, vals)
+	}
+	return c.valsets[height-1], nil
+}
+
+// LightBlock returns the light block at the given height, signed by every
+// validator of its set. Legacy light blocks are hashed with SHA-256 and signed
+// with the legacy vote sign bytes.
+func (c *Chain) LightBlock(height int64, legacy bool) (*cmttypes.LightBlock, error) {
+	header, err := c.Header(height, legacy)
+	if err != nil {
+		return nil, err
+	}
+	return c.Sign(header, legacy)
+}
+
+// Header returns the header at the given height. The headers aren't linked
+// together unless WithLinkedHeaders is given: the previous block id and the
+// other hashes not checked by the light client are derived from the height.
+func (c *Chain) Header(height int64, legacy bool) (*cmttypes.Header, error) {
+	if !c.linked {
+		return c.header(height, legacy)
+	}
+	if height <= 0 {
+		return nil, fmt.Errorf("height must be positive, got %d", height)
+	}
+	c.hashesMu.Lock()
+	defer c.hashesMu.Unlock()
+	scheme := hashScheme(legacy)
+	for int64(len(c.hashes[scheme])) < height-1 {
+		header, err := c.header(int64(len(c.hashes[scheme]))+1, legacy)
+		if err != nil {
+			return nil, err
+		}
+		var hash []byte
+		if legacy {
+			hash = header.HashSha256()
+		} else {
+			hash = header.Hash()
+		}
+		c.hashes[scheme] = append(c.hashes[scheme], hash)
+	}
+	return c.header(height, legacy)
+}
+
+// header builds the header at the given height. For a linked chain, the
+// hashes of the headers before it must be computed and hashesMu locked.
+func (c *Chain) header(height int64, legacy bool) (*cmttypes.Header, error) {
+	vals, err := c.ValidatorSet(height)
+	if err != nil {
+		return nil, err
+	}
+	nextVals, err := c.ValidatorSet(height + 1)
+	if err != nil {
+		return nil, err
+	}
+	valsHash, nextValsHash := vals.Hash(), nextVals.Hash()
+	if legacy {
+		valsHash, nextValsHash = vals.HashSha256(), nextVals.HashSha256()
+	}
+	lastBlockID := cmttypes.BlockID{
+		Hash:          FieldHash(fmt.Sprintf("block %d", height-1)),
+		PartSetHeader: partSetHeader(height - 1),
+	}
+	if c.linked {
+		// The first block has no previous one.
+		lastBlockID = cmttypes.BlockID{}
+		if height > 1 {
+			lastBlockID = cmttypes.BlockID{
+				Hash:          c.hashes[hashScheme(legacy)][height-2],
+				PartSetHeader: partSetHeader(height - 1),
+			}
+		}
+	}
+	return &cmttypes.Header{
+		Version:            cmtversion.Consensus{Block: version.BlockProtocol},
+		ChainID:            c.ChainID,
+		Height:             height,
+		Time:               c.Time(height),
+		LastBlockID:        lastBlockID,
+		LastCommitHash:     FieldHash("last commit"),
+		DataHash:           FieldHash("data"),
+		ValidatorsHash:     valsHash,
+		NextValidatorsHash: nextValsHash,
+		ConsensusHash:      FieldHash("consensus"),
+		AppHash:            FieldHash(fmt.Sprintf("app %d", height)),
+		LastResultsHash:    FieldHash("last results"),
+		EvidenceHash:       FieldHash("evidence"),
+		ProposerAddress:    vals.GetProposer().Address,
+	}, nil
+}
+
+// Sign commits to any header, possibly forged, with the validator set of the
+// chain at the header height, every validator signing it.
+func (c *Chain) Sign(header *cmttypes.Header, legacy bool) (*cmttypes.LightBlock, error) {
+	vals, err := c.ValidatorSet(header.Height)
+	if err != nil {
+		return nil, err
+	}
+	var headerHash []byte
+	if legacy {
+		headerHash = header.HashSha256()
+	} else {
+		headerHash = header.Hash()
+	}
+	commit := &cmttypes.Commit{
+		Height: header.Height,
+		BlockID: cmttypes.BlockID{
+			Hash:          headerHash,
+			PartSetHeader: partSetHeader(header.Height),
+		},
+		Signatures: make([]cmttypes.CommitSig, len(vals.Validators)),
+	}
+	for i, val := range vals.Validators {
+		commit.Signatures[i] = cmttypes.CommitSig{
+			BlockIDFlag:      cmttypes.BlockIDFlagCommit,
+			ValidatorAddress: val.Address,
+			Timestamp:        header.Time,
+		}
+	}
+	for i, val := range vals.Validators {
+		var signBytes []byte
+		if c.signBytes != nil {
+			signBytes = c.signBytes(header.ChainID, commit.GetVote(int32(i)).ToProto())
+		} else if legacy {
+			signBytes = commit.VoteSignBytesLegacy(header.ChainID, int32(i))
+		} else {
+			signBytes = commit.VoteSignBytes(header.ChainID, int32(i))
+		}
+		c.mu.Lock()
+		privKey := c.privKeys[string(val.Address)]
+		c.mu.Unlock()
+		signature, err := privKey.Sign(signBytes)
+		if err != nil {
+			return nil, fmt.Errorf("validator %s: %w", val.Address, err)
+		}
+		commit.Signatures[i].Signature = signature
+	}
+
+	signedHeader := &cmttypes.SignedHeader{Header: header, Commit: commit}
+	if legacy {
+		if err := signedHeader.ValidateBasicLegacy(header.ChainID); err != nil {
+			return nil, err
+		}
+	} else if err := signedHeader.ValidateBasic(header.ChainID); err != nil {
+		return nil, err
+	}
+	return &cmttypes.LightBlock{SignedHeader: signedHeader, ValidatorSet: vals}, nil
+}
+
+// LightBlocks returns the light blocks from one height to another, both
+// included.
+func (c *Chain) LightBlocks(from, to int64, legacy bool) ([]*cmttypes.LightBlock, error) {
+	if from > to {
+		return nil, fmt.Errorf("invalid range of heights [%d, %d]", from, to)
+	}
+	lightBlocks := make([]*cmttypes.LightBlock, 0, to-from+1)
+	for height := from; height <= to; height++ {
+		lightBlock, err := c.LightBlock(height, legacy)
+		if err != nil {
+			return nil, err
+		}
+		lightBlocks = append(lightBlocks, lightBlock)
+	}
+	return lightBlocks, nil
+}
+
+// ExtendedCommit returns the extended commit of the block at a height, as
+// stored by the nodes of a chain enabling vote extensions: the commit of its
+// light block whose validators each signed the extension returned for them.
+func (c *Chain) ExtendedCommit(height int64, extension func(val *cmttypes.Validator) []byte) (*cmttypes.ExtendedCommit, error) {
+	lightBlock, err := c.LightBlock(height, false)
+	if err != nil {
+		return nil, err
+	}
+	commit := lightBlock.Commit
+	extendedCommit := &cmttypes.ExtendedCommit{
+		Height:             commit.Height,
+		Round:              commit.Round,
+		BlockID:            commit.BlockID,
+		ExtendedSignatures: make([]cmttypes.ExtendedCommitSig, len(commit.Signatures)),
+	}
+	for i, val := range lightBlock.ValidatorSet.Validators {
+		extendedCommit.ExtendedSignatures[i] = cmttypes.ExtendedCommitSig{
+			CommitSig: commit.Signatures[i],
+			Extension: extension(val),
+		}
+		vote := extendedCommit.GetExtendedVote(int32(i))
+		c.mu.Lock()
+		privKey := c.privKeys[string(val.Address)]
+		c.mu.Unlock()
+		signature, err := privKey.Sign(cmttypes.VoteExtensionSignBytes(lightBlock.ChainID, vote.ToProto()))
+		if err != nil {
+			return nil, fmt.Errorf("validator %s: %w", val.Address, err)
+		}
+		extendedCommit.ExtendedSignatures[i].ExtensionSignature = signature
+	}
+	return extendedCommit, nil
+}
+
+// hashScheme indexes the header hashes of a scheme.
+func hashScheme(legacy bool) int {
+	if legacy {
+		return 1
+	}
+	return 0
+}
+
+// partSetHeader returns the part set header of the block at the given
+// height.
+func partSetHeader(height int64) cmttypes.PartSetHeader {
+	return cmttypes.PartSetHeader{Total: 1, Hash: FieldHash(fmt.Sprintf("parts %d", height))}
+}
+
+// FieldHash returns a 32 bytes hash of s fitting in a bn254 scalar field
+// element, as required for the header fields hashed with MiMC by cometbls.
+func FieldHash(s string) []byte {
+	hash := tmhash.Sum([]byte(s))
+	hash[0] &= 0x0f
+	return hash
+}
@@ -0,0 +1,105 @@
+package lighttest_test
+
+import (
+	"testing"
+	"time"
+
+	cmtmath "github.com/cometbft/cometbft/libs/math"
+	"github.com/cometbft/cometbft/light"
+	cmttypes "github.com/cometbft/cometbft/types"
+	"github.com/stretchr/testify/require"
+
+	"union/verifier/lighttest"
+)
+
+func TestNewChainIsDeterministic(t *testing.T) {
+	for _, legacy := range []bool{false, true} {
+		lightBlocks := func(seed int64) [][]byte {
+			chain, err := lighttest.NewChain("lighttest-1", 5, seed, lighttest.WithChurn(2), lighttest.WithLinkedHeaders())
+			require.NoError(t, err)
+			lbs, err := chain.LightBlocks(1, 6, legacy)
+			require.NoError(t, err)
+			encoded := make([][]byte, len(lbs))
+			for i, lb := range lbs {
+				pb, err := lb.ToProto()
+				require.NoError(t, err)
+				encoded[i], err = pb.Marshal()
+				require.NoError(t, err)
+			}
+			return encoded
+		}
+
+		require.Equal(t, lightBlocks(42), lightBlocks(42))
+		require.NotEqual(t, lightBlocks(42), lightBlocks(43))
+	}
+}
+
+func TestLightBlocksVerify(t *testing.T) {
+	chain, err := lighttest.NewChain("lighttest-1", 4, 1)
+	require.NoError(t, err)
+
+	for _, tc := range []struct {
+		name   string
+		legacy bool
+		verify verifyFunc
+	}{
+		{name: "current", legacy: false, verify: light.Verify},
+		{name: "legacy", legacy: true, verify: light.VerifyLegacy},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			trusted, err := chain.LightBlock(1, tc.legacy)
+			require.NoError(t, err)
+			// Adjacent, then skipping.
+			for _, height := range []int64{2, 10} {
+				untrusted, err := chain.LightBlock(height, tc.legacy)
+				require.NoError(t, err)
+				now := chain.Time(height).Add(time.Minute)
+				require.NoError(t, tc.verify(trusted.SignedHeader, trusted.ValidatorSet, untrusted.SignedHeader, untrusted.ValidatorSet,
+					24*time.Hour, now, 10*time.Second, light.DefaultTrustLevel))
+
+				// The commit doesn't sign a header altered afterwards.
+				header := *untrusted.Header
+				header.AppHash = lighttest.FieldHash("forged")
+				forged := &cmttypes.SignedHeader{Header: &header, Commit: untrusted.Commit}
+				require.Error(t, tc.verify(trusted.SignedHeader, trusted.ValidatorSet, forged, untrusted.ValidatorSet,
+					24*time.Hour, now, 10*time.Second, light.DefaultTrustLevel))
+			}
+		})
+	}
+}
+
+type verifyFunc func(
+	trustedHeader *cmttypes.SignedHeader,
+	trustedVals *cmttypes.ValidatorSet,
+	untrustedHeader *cmttypes.SignedHeader,
+	untrustedVals *cmttypes.ValidatorSet,
+	trustingPeriod time.Duration,
+	now time.Time,
+	maxClockDrift time.Duration,
+	trustLevel cmtmath.Fraction,
+) error
+
+func TestWithChurn(t *testing.T) {
+	for _, churn := range []int{0, 1, 3, 5} {
+		chain, err := lighttest.NewChain("lighttest-1", 5, 7, lighttest.WithChurn(churn))
+		require.NoError(t, err)
+		previous, err := chain.ValidatorSet(1)
+		require.NoError(t, err)
+		for height := int64(2); height <= 8; height++ {
+			vals, err := chain.ValidatorSet(height)
+			require.NoError(t, err)
+			require.Equal(t, previous.Size(), vals.Size())
+			added := 0
+			for _, val := range vals.Validators {
+				if !previous.HasAddress(val.Address) {
+					added++
+				}
+			}
+			require.Equal(t, churn, added, "height %d", height)
+			previous = vals
+		}
+	}
+
+	_, err := lighttest.NewChain("lighttest-1", 5, 7, lighttest.WithChurn(6))
+	require.Error(t, err)
+}
@@ -0,0 +1,127 @@
+package lighttest
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/cometbft/cometbft/light/provider"
+	cmttypes "github.com/cometbft/cometbft/types"
+)
+
+var _ provider.Provider = (*Provider)(nil)
+
+// Response scripts the answer of a Provider at a height.
+type Response struct {
+	// Delay is waited before answering, the request fails with the context
+	// error if it is canceled first.
+	Delay time.Duration
+	// Err is returned instead of the light block, e.g. provider.ErrNoResponse
+	// to have the light client replace the provider.
+	Err error
+	// LightBlock is served instead of the one of the chain, e.g. a header
+	// conflicting with the primary to act as a malicious witness.
+	LightBlock *cmttypes.LightBlock
+}
+
+// Provider is a light client provider serving the light blocks of a Chain up
+// to its latest height, whose responses can be scripted per height so that
+// the light client failover and attack detection can be tested hermetically.
+type Provider struct {
+	name   string
+	chain  *Chain
+	legacy bool
+
+	mu        sync.Mutex
+	latest    int64
+	responses map[int64]Response
+	evidence  []cmttypes.Evidence
+}
+
+// NewProvider creates a provider serving the light blocks of the chain up to
+// the latest height.
+func NewProvider(name string, chain *Chain, latest int64, legacy bool) *Provider {
+	return &Provider{
+		name:      name,
+		chain:     chain,
+		legacy:    legacy,
+		latest:    latest,
+		responses: make(map[int64]Response),
+	}
+}
+
+// Script sets the response of the provider at a height, the height 0 being
+// the latest light block.
+func (p *Provider) Script(height int64, res Response) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.responses[height] = res
+}
+
+// SetLatest makes the chain advance, or roll back, to the given height.
+func (p *Provider) SetLatest(height int64) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.latest = height
+}
+
+// Evidence returns the evidence reported by the light client.
+func (p *Provider) Evidence() []cmttypes.Evidence {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return append([]cmttypes.Evidence(nil), p.evidence...)
+}
+
+func (p *Provider) String() string {
+	return p.name
+}
+
+// ChainID implements provider.Provider.
+func (p *Provider) ChainID() string {
+	return p.chain.ChainID
+}
+
+// LightBlock implements provider.Provider.
+func (p *Provider) LightBlock(ctx context.Context, height int64) (*cmttypes.LightBlock, error) {
+	if height < 0 {
+		return nil, fmt.Errorf("height must be positive or zero, got %d", height)
+	}
+	p.mu.Lock()
+	latest := p.latest
+	res, scripted := p.responses[height]
+	p.mu.Unlock()
+
+	if res.Delay > 0 {
+		timer := time.NewTimer(res.Delay)
+		defer timer.Stop()
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-timer.C:
+		}
+	}
+	if scripted {
+		if res.Err != nil {
+			return nil, res.Err
+		}
+		if res.LightBlock != nil {
+			return res.LightBlock, nil
+		}
+	}
+	if height == 0 {
+		height = latest
+	}
+	if height > latest {
+		return nil, provider.ErrHeightTooHigh
+	}
+	return p.chain.LightBlock(height, p.legacy)
+}
+
+// ReportEvidence implements provider.Provider, the evidence is recorded.
+func (p *Provider) ReportEvidence(ctx context.Context, evidence cmttypes.Evidence) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.evidence = append(p.evidence, evidence)
+	return nil
+}
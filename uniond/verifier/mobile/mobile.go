@@ -0,0 +1,230 @@
+// Package mobile is a facade over the light client and its verified queries
+// that gomobile can bind, so that iOS and Android wallets can embed them:
+//
+//	gomobile bind -target=android union/verifier/mobile
+//
+// Its exported API only uses the types gomobile supports: strings, integers,
+// byte slices, errors and pointers to the structs of this package. Lists are
+// given as comma separated strings, durations as seconds, and the blocking
+// calls are cancelled through a CancelToken instead of a context.
+package mobile
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	dbm "github.com/cometbft/cometbft-db"
+	"github.com/cometbft/cometbft/light"
+	"github.com/cometbft/cometbft/light/provider"
+	lightdb "github.com/cometbft/cometbft/light/store/db"
+	rpchttp "github.com/cometbft/cometbft/rpc/client/http"
+	"github.com/cosmos/cosmos-sdk/types/bech32"
+
+	"union/verifier/lightquery"
+	"union/verifier/transport"
+)
+
+// The database of the trusted store, named as the one of light follow.
+const dbName = "light-client-db"
+
+// CancelToken cancels the calls it is given to, from any thread.
+type CancelToken struct {
+	ctx    context.Context
+	cancel context.CancelFunc
+}
+
+// NewCancelToken returns a token cancelled by Cancel only.
+func NewCancelToken() *CancelToken {
+	ctx, cancel := context.WithCancel(context.Background())
+	return &CancelToken{ctx: ctx, cancel: cancel}
+}
+
+// NewTimeoutToken returns a token cancelled by Cancel or after a timeout in
+// milliseconds.
+func NewTimeoutToken(timeoutMillis int64) *CancelToken {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Duration(timeoutMillis)*time.Millisecond)
+	return &CancelToken{ctx: ctx, cancel: cancel}
+}
+
+// Cancel cancels the calls given the token, it can be called several times.
+func (t *CancelToken) Cancel() {
+	t.cancel()
+}
+
+func (t *CancelToken) context() context.Context {
+	if t == nil {
+		return context.Background()
+	}
+	return t.ctx
+}
+
+// Client is a light client whose trusted state is persisted in a directory,
+// answering verified queries from an untrusted RPC endpoint.
+type Client struct {
+	// Serializes the calls verifying headers, which update the store.
+	mtx   sync.Mutex
+	db    dbm.DB
+	light *light.Client
+	query *lightquery.Client
+	// Gives the verification time, the system time out of tests.
+	now func() time.Time
+}
+
+// NewClient creates a light client of a chain following the primary RPC
+// endpoint, cross-checked with the comma separated witnesses, whose trusted
+// state is persisted in dbDir. The first run must be given a root of trust,
+// the height and hash of a trusted header, later runs resume from the store
+// if the trusted height is 0.
+func NewClient(chainID, primary, witnesses, dbDir string, trustedHeight int64, trustedHash []byte, trustingPeriodSeconds int64, token *CancelToken) (*Client, error) {
+	if trustingPeriodSeconds <= 0 {
+		return nil, errors.New("the trusting period must be positive")
+	}
+	trustingPeriod := time.Duration(trustingPeriodSeconds) * time.Second
+	var witnessAddresses []string
+	for _, witness := range strings.Split(witnesses, ",") {
+		if witness = strings.TrimSpace(witness); witness != "" {
+			witnessAddresses = append(witnessAddresses, witness)
+		}
+	}
+	if len(witnessAddresses) == 0 {
+		return nil, errors.New("at least one witness is required")
+	}
+
+	if !strings.Contains(primary, "://") {
+		primary = "http://" + primary
+	}
+	providers := make([]provider.Provider, 0, len(witnessAddresses)+1)
+	for _, address := range append([]string{primary}, witnessAddresses...) {
+		p, err := transport.NewProvider(chainID, address, &transport.Transport{})
+		if err != nil {
+			return nil, err
+		}
+		providers = append(providers, p)
+	}
+	rpc, err := rpchttp.New(primary, "/websocket")
+	if err != nil {
+		return nil, err
+	}
+
+	db, err := dbm.NewGoLevelDB(dbName, dbDir)
+	if err != nil {
+		return nil, fmt.Errorf("can't open the trusted store: %w", err)
+	}
+	store := lightdb.New(db, chainID)
+	var lc *light.Client
+	if trustedHeight > 0 {
+		lc, err = light.NewClient(
+			token.context(),
+			chainID,
+			light.TrustOptions{Period: trustingPeriod, Height: trustedHeight, Hash: trustedHash},
+			providers[0],
+			providers[1:],
+			store,
+		)
+	} else if store.Size() == 0 {
+		err = errors.New("no trusted state, a trusted height and hash are required")
+	} else {
+		lc, err = light.NewClientFromTrustedStore(chainID, trustingPeriod, providers[0], providers[1:], store)
+	}
+	if err != nil {
+		db.Close()
+		return nil, err
+	}
+	c := &Client{db: db, light: lc, now: time.Now}
+	c.query = lightquery.NewClient(rpc, lc, func() time.Time { return c.now() })
+	return c, nil
+}
+
+// Close closes the trusted store.
+func (c *Client) Close() error {
+	return c.db.Close()
+}
+
+// Update verifies the latest header of the primary and returns the latest
+// trusted height.
+func (c *Client) Update(token *CancelToken) (int64, error) {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+	if _, err := c.light.Update(token.context(), c.now()); err != nil {
+		return 0, err
+	}
+	return c.light.LastTrustedHeight()
+}
+
+// TrustedHeight returns the latest trusted height, -1 if there is none.
+func (c *Client) TrustedHeight() (int64, error) {
+	return c.light.LastTrustedHeight()
+}
+
+// TrustedHash returns the hash of the trusted header at a height.
+func (c *Client) TrustedHash(height int64) ([]byte, error) {
+	lightBlock, err := c.light.TrustedLightBlock(height)
+	if err != nil {
+		return nil, err
+	}
+	return lightBlock.Hash(), nil
+}
+
+// Query returns the value of a key of a store at a height, verified against
+// the trusted headers, nil if the key is proven absent. The latest provable
+// height is queried if the height is 0.
+func (c *Client) Query(storeName string, key []byte, height int64, token *CancelToken) ([]byte, error) {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+	resp, err := c.query.VerifiedABCIQuery(token.context(), "/store/"+storeName+"/key", key, height)
+	if err != nil {
+		return nil, err
+	}
+	return resp.Value, nil
+}
+
+// Balance returns the amount of a denom held by a bech32 address at a
+// height, verified against the trusted headers.
+func (c *Client) Balance(address, denom string, height int64, token *CancelToken) (string, error) {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+	_, addr, err := bech32.DecodeAndConvert(address)
+	if err != nil {
+		return "", fmt.Errorf("invalid address %q: %w", address, err)
+	}
+	coin, err := c.query.VerifiedBalance(token.context(), addr, denom, height)
+	if err != nil {
+		return "", err
+	}
+	return coin.Amount.String(), nil
+}
+
+// TxResult is the verified result of a transaction.
+type TxResult struct {
+	Height    int64
+	Index     int64
+	Code      int64
+	Data      []byte
+	GasWanted int64
+	GasUsed   int64
+	Tx        []byte
+}
+
+// Tx returns a transaction by hash once its inclusion and result are
+// verified against the trusted headers.
+func (c *Client) Tx(hash []byte, token *CancelToken) (*TxResult, error) {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+	res, err := c.query.VerifiedTx(token.context(), hash)
+	if err != nil {
+		return nil, err
+	}
+	return &TxResult{
+		Height:    res.Height,
+		Index:     int64(res.Index),
+		Code:      int64(res.TxResult.Code),
+		Data:      res.TxResult.Data,
+		GasWanted: res.TxResult.GasWanted,
+		GasUsed:   res.TxResult.GasUsed,
+		Tx:        res.Tx,
+	}, nil
+}
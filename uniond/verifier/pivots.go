@@ -0,0 +1,119 @@
+package verifier
+
+import (
+	"context"
+	"encoding/binary"
+	"fmt"
+
+	dbm "github.com/cometbft/cometbft-db"
+	"github.com/cometbft/cometbft/light/provider"
+	cmttypes "github.com/cometbft/cometbft/types"
+)
+
+// PivotJournal persists the heights of the light blocks fetched by a light
+// client while it verifies a height, e.g. in the database of its store, so
+// that a catch-up interrupted by a restart resumes from its pivots instead
+// of bisecting again from the last trusted height.
+//
+// The light client doesn't expose the pivots it verified, only the target of
+// a verification being saved to the trusted store, so the journal records
+// the heights of every light block its providers serve, the latest ones
+// aside as a witness may be ahead of the primary. The pivots are only a hint:
+// CatchUp verifies them again, in order, a pivot verified before the restart
+// being verified directly from the previous one.
+type PivotJournal struct {
+	db      dbm.DB
+	chainID string
+}
+
+// NewPivotJournal creates the pivot journal of a chain in a database.
+func NewPivotJournal(db dbm.DB, chainID string) *PivotJournal {
+	return &PivotJournal{db: db, chainID: chainID}
+}
+
+func (j *PivotJournal) prefix() []byte {
+	return []byte("bisection-pivot/" + j.chainID + "/")
+}
+
+// key orders the pivots by height, which is positive.
+func (j *PivotJournal) key(height int64) []byte {
+	return binary.BigEndian.AppendUint64(j.prefix(), uint64(height))
+}
+
+// Record persists a pivot, synced so that it survives a crash.
+func (j *PivotJournal) Record(height int64) error {
+	if height <= 0 {
+		return fmt.Errorf("invalid pivot height %d", height)
+	}
+	key := j.key(height)
+	if recorded, err := j.db.Has(key); err != nil || recorded {
+		return err
+	}
+	return j.db.SetSync(key, []byte{})
+}
+
+// Pivots returns the recorded pivots in ascending order.
+func (j *PivotJournal) Pivots() ([]int64, error) {
+	prefix := j.prefix()
+	it, err := dbm.IteratePrefix(j.db, prefix)
+	if err != nil {
+		return nil, err
+	}
+	defer it.Close()
+	var pivots []int64
+	for ; it.Valid(); it.Next() {
+		key := it.Key()[len(prefix):]
+		if len(key) != 8 {
+			return nil, fmt.Errorf("invalid bisection pivot %q", it.Key())
+		}
+		pivots = append(pivots, int64(binary.BigEndian.Uint64(key)))
+	}
+	return pivots, it.Error()
+}
+
+// Prune forgets the pivots up to a height, once it is trusted.
+func (j *PivotJournal) Prune(height int64) error {
+	if height <= 0 {
+		return nil
+	}
+	it, err := j.db.Iterator(j.prefix(), j.key(height+1))
+	if err != nil {
+		return err
+	}
+	defer it.Close()
+	batch := j.db.NewBatch()
+	defer batch.Close()
+	for ; it.Valid(); it.Next() {
+		if err := batch.Delete(it.Key()); err != nil {
+			return err
+		}
+	}
+	if err := it.Error(); err != nil {
+		return err
+	}
+	return batch.WriteSync()
+}
+
+// Wrap returns a provider recording the heights of the light blocks it serves
+// in the journal, unless requested as the latest one. Failing to record a
+// pivot only loses the hint, the light block is still served.
+func (j *PivotJournal) Wrap(p provider.Provider) provider.Provider {
+	return &journaled{Provider: p, journal: j}
+}
+
+type journaled struct {
+	provider.Provider
+	journal *PivotJournal
+}
+
+func (p *journaled) LightBlock(ctx context.Context, height int64) (*cmttypes.LightBlock, error) {
+	lightBlock, err := p.Provider.LightBlock(ctx, height)
+	if err == nil && height > 0 {
+		_ = p.journal.Record(height)
+	}
+	return lightBlock, err
+}
+
+func (p *journaled) String() string {
+	return fmt.Sprint(p.Provider)
+}
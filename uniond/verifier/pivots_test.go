@@ -0,0 +1,62 @@
+package verifier_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	dbm "github.com/cometbft/cometbft-db"
+	"github.com/stretchr/testify/require"
+
+	"union/verifier"
+	"union/verifier/lighttest"
+)
+
+func TestPivotJournal(t *testing.T) {
+	db := dbm.NewMemDB()
+	journal := verifier.NewPivotJournal(db, "pivots-1")
+	// Another chain sharing the database.
+	other := verifier.NewPivotJournal(db, "pivots-2")
+
+	for _, height := range []int64{300, 2, 40, 2, 1 << 40} {
+		require.NoError(t, journal.Record(height))
+	}
+	require.Error(t, journal.Record(0))
+	require.Error(t, journal.Record(-1))
+	require.NoError(t, other.Record(7))
+
+	// Ordered by height, not by their encoding as text.
+	pivots, err := journal.Pivots()
+	require.NoError(t, err)
+	require.Equal(t, []int64{2, 40, 300, 1 << 40}, pivots)
+
+	require.NoError(t, journal.Prune(40))
+	pivots, err = journal.Pivots()
+	require.NoError(t, err)
+	require.Equal(t, []int64{300, 1 << 40}, pivots)
+	pivots, err = other.Pivots()
+	require.NoError(t, err)
+	require.Equal(t, []int64{7}, pivots)
+
+	// A journal reopened on the database resumes from its pivots.
+	pivots, err = verifier.NewPivotJournal(db, "pivots-1").Pivots()
+	require.NoError(t, err)
+	require.Equal(t, []int64{300, 1 << 40}, pivots)
+}
+
+func TestPivotJournalWrap(t *testing.T) {
+	chain, err := lighttest.NewChain("pivots-1", 4, 1)
+	require.NoError(t, err)
+	journal := verifier.NewPivotJournal(dbm.NewMemDB(), chain.ChainID)
+	p := journal.Wrap(lighttest.NewProvider("primary", chain, 20, false))
+
+	ctx := context.Background()
+	for _, height := range []int64{0, 12, 5, 0, 30} {
+		_, _ = p.LightBlock(ctx, height)
+	}
+	// The latest light blocks and the failed requests aren't pivots.
+	pivots, err := journal.Pivots()
+	require.NoError(t, err)
+	require.Equal(t, []int64{5, 12}, pivots)
+	require.Equal(t, "primary", fmt.Sprint(p))
+}
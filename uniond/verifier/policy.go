@@ -0,0 +1,161 @@
+package verifier
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+
+	cmtbytes "github.com/cometbft/cometbft/libs/bytes"
+	cmttypes "github.com/cometbft/cometbft/types"
+)
+
+// ErrPolicyViolation is returned when a cryptographically valid header is
+// rejected by a policy.
+var ErrPolicyViolation = errors.New("policy violation")
+
+// Policy is a check run on a transition once the untrusted header is
+// cryptographically verified, before it is accepted, e.g. to encode the
+// compliance rules of an institution. A header is rejected if any policy
+// returns an error, including when the policy itself fails, so that a
+// policy that can't be evaluated never lets a header through.
+type Policy interface {
+	Check(ctx context.Context, trusted, untrusted *cmttypes.LightBlock) error
+}
+
+// PolicyFunc adapts a function to a Policy.
+type PolicyFunc func(ctx context.Context, trusted, untrusted *cmttypes.LightBlock) error
+
+func (f PolicyFunc) Check(ctx context.Context, trusted, untrusted *cmttypes.LightBlock) error {
+	return f(ctx, trusted, untrusted)
+}
+
+// checkPolicies runs the policies in order, stopping at the first rejection.
+func checkPolicies(ctx context.Context, policies []Policy, trusted, untrusted *cmttypes.LightBlock) error {
+	for _, policy := range policies {
+		if err := policy.Check(ctx, trusted, untrusted); err != nil {
+			if errors.Is(err, ErrPolicyViolation) {
+				return err
+			}
+			return fmt.Errorf("%w: %w", ErrPolicyViolation, err)
+		}
+	}
+	return nil
+}
+
+// ProposerDenylist rejects the headers proposed by one of the validator
+// addresses.
+func ProposerDenylist(addresses [][]byte) Policy {
+	denied := make(map[string]struct{}, len(addresses))
+	for _, address := range addresses {
+		denied[string(address)] = struct{}{}
+	}
+	return PolicyFunc(func(_ context.Context, _, untrusted *cmttypes.LightBlock) error {
+		if _, ok := denied[string(untrusted.ProposerAddress)]; ok {
+			return fmt.Errorf("%w: proposer %X is denied", ErrPolicyViolation, untrusted.ProposerAddress)
+		}
+		return nil
+	})
+}
+
+// AppHashDenylist rejects the headers committing to one of the app hashes.
+func AppHashDenylist(appHashes [][]byte) Policy {
+	denied := make(map[string]struct{}, len(appHashes))
+	for _, appHash := range appHashes {
+		denied[string(appHash)] = struct{}{}
+	}
+	return PolicyFunc(func(_ context.Context, _, untrusted *cmttypes.LightBlock) error {
+		if _, ok := denied[string(untrusted.AppHash)]; ok {
+			return fmt.Errorf("%w: app hash %X is denied", ErrPolicyViolation, untrusted.AppHash)
+		}
+		return nil
+	})
+}
+
+// ReadHexList reads a file of hex encoded values, one per line, ignoring
+// empty lines and lines starting with #.
+func ReadHexList(path string) ([][]byte, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	var values [][]byte
+	scanner := bufio.NewScanner(f)
+	for line := 1; scanner.Scan(); line++ {
+		text := strings.TrimSpace(scanner.Text())
+		if text == "" || strings.HasPrefix(text, "#") {
+			continue
+		}
+		value, err := hex.DecodeString(text)
+		if err != nil {
+			return nil, fmt.Errorf("%s:%d: %w", path, line, err)
+		}
+		values = append(values, value)
+	}
+	return values, scanner.Err()
+}
+
+// PolicyRequest is the body posted by a PolicyWebhook.
+type PolicyRequest struct {
+	ChainID         string            `json:"chain_id"`
+	Height          int64             `json:"height"`
+	Hash            cmtbytes.HexBytes `json:"hash"`
+	AppHash         cmtbytes.HexBytes `json:"app_hash"`
+	ProposerAddress cmtbytes.HexBytes `json:"proposer_address"`
+	TrustedHeight   int64             `json:"trusted_height"`
+}
+
+// PolicyWebhook asks an external service whether to accept a header by
+// posting a PolicyRequest to an URL: the header is accepted on a 2xx
+// response and rejected on a 403 one, whose body is the reason. Any other
+// response is a failure of the policy, which rejects the header too.
+type PolicyWebhook struct {
+	URL string
+	// Client posts the requests, http.DefaultClient if nil.
+	Client *http.Client
+}
+
+func (w PolicyWebhook) Check(ctx context.Context, trusted, untrusted *cmttypes.LightBlock) error {
+	body, err := json.Marshal(PolicyRequest{
+		ChainID:         untrusted.ChainID,
+		Height:          untrusted.Height,
+		Hash:            untrusted.Hash(),
+		AppHash:         untrusted.AppHash,
+		ProposerAddress: untrusted.ProposerAddress,
+		TrustedHeight:   trusted.Height,
+	})
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, w.URL, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	client := w.Client
+	if client == nil {
+		client = http.DefaultClient
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return fmt.Errorf("policy webhook: %w", err)
+	}
+	defer resp.Body.Close()
+	reason, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+	switch {
+	case resp.StatusCode/100 == 2:
+		return nil
+	case resp.StatusCode == http.StatusForbidden:
+		return fmt.Errorf("%w: %s", ErrPolicyViolation, strings.TrimSpace(string(reason)))
+	default:
+		return fmt.Errorf("policy webhook: unexpected status %s", resp.Status)
+	}
+}
@@ -0,0 +1,140 @@
+package verifier
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"math/bits"
+
+	cmtmath "github.com/cometbft/cometbft/libs/math"
+	cmtproto "github.com/cometbft/cometbft/proto/tendermint/types"
+	cmttypes "github.com/cometbft/cometbft/types"
+)
+
+var (
+	// ErrVotingPowerOverflow is returned for voting powers out of the bounds
+	// of the trust math, which CometBFT assumes and panics otherwise: not
+	// positive or summing to more than cmttypes.MaxTotalVotingPower.
+	ErrVotingPowerOverflow = errors.New("voting power overflow")
+	// ErrTotalVotingPowerMismatch is returned for a validator set whose
+	// declared total voting power isn't the sum of its voting powers.
+	ErrTotalVotingPowerMismatch = errors.New("total voting power mismatch")
+	// ErrInvalidTrustLevel is returned for a trust level out of [1/3, 1].
+	ErrInvalidTrustLevel = errors.New("invalid trust level")
+)
+
+// CheckVotingPowers checks the voting powers of a protobuf validator set
+// before it is decoded, which panics when they exceed
+// cmttypes.MaxTotalVotingPower: every voting power must be positive, their
+// sum can't exceed the bound and the declared total voting power, if any,
+// must be that sum.
+func CheckVotingPowers(vals *cmtproto.ValidatorSet) error {
+	if vals == nil {
+		return nil
+	}
+	powers := make([]int64, len(vals.Validators))
+	for i, val := range vals.Validators {
+		if val == nil {
+			return fmt.Errorf("validator #%d is missing", i)
+		}
+		powers[i] = val.VotingPower
+	}
+	total, err := totalVotingPower(powers)
+	if err != nil {
+		return err
+	}
+	if vals.TotalVotingPower != 0 && vals.TotalVotingPower != total {
+		return fmt.Errorf("%w: declared %d, validators sum to %d", ErrTotalVotingPowerMismatch, vals.TotalVotingPower, total)
+	}
+	return nil
+}
+
+// CheckValidatorSetPowers checks the voting powers of a decoded validator set
+// as CheckVotingPowers does, before its total voting power is computed.
+func CheckValidatorSetPowers(vals *cmttypes.ValidatorSet) error {
+	if vals == nil {
+		return nil
+	}
+	powers := make([]int64, len(vals.Validators))
+	for i, val := range vals.Validators {
+		if val == nil {
+			return fmt.Errorf("validator #%d is missing", i)
+		}
+		powers[i] = val.VotingPower
+	}
+	_, err := totalVotingPower(powers)
+	return err
+}
+
+// totalVotingPower sums positive voting powers up to
+// cmttypes.MaxTotalVotingPower.
+func totalVotingPower(powers []int64) (int64, error) {
+	var total int64
+	for i, power := range powers {
+		if power <= 0 {
+			return 0, fmt.Errorf("%w: validator #%d has a voting power of %d", ErrVotingPowerOverflow, i, power)
+		}
+		// Both are at most MaxTotalVotingPower, the sum can't overflow.
+		if power > cmttypes.MaxTotalVotingPower || total+power > cmttypes.MaxTotalVotingPower {
+			return 0, fmt.Errorf("%w: total voting power exceeds %d at validator #%d", ErrVotingPowerOverflow, cmttypes.MaxTotalVotingPower, i)
+		}
+		total += power
+	}
+	return total, nil
+}
+
+// ValidateTrustLevel checks that a trust level is within [1/3, 1] without
+// overflowing, unlike light.ValidateTrustLevel whose numerator times 3
+// wraps, and returns it reduced, which keeps the products of the trust math
+// as small as possible.
+func ValidateTrustLevel(trustLevel cmtmath.Fraction) (cmtmath.Fraction, error) {
+	if trustLevel.Denominator == 0 {
+		return trustLevel, fmt.Errorf("%w: %s has a zero denominator", ErrInvalidTrustLevel, trustLevel)
+	}
+	reduced := trustLevel
+	if d := gcd(reduced.Numerator, reduced.Denominator); d > 1 {
+		reduced.Numerator /= d
+		reduced.Denominator /= d
+	}
+	if hi, lo := bits.Mul64(reduced.Numerator, 3); hi == 0 && lo < reduced.Denominator || reduced.Numerator > reduced.Denominator {
+		return trustLevel, fmt.Errorf("%w: %s isn't within [1/3, 1]", ErrInvalidTrustLevel, trustLevel)
+	}
+	// The trust math multiplies the numerator as an int64.
+	if reduced.Numerator > math.MaxInt64 {
+		return trustLevel, fmt.Errorf("%w: numerator of %s exceeds %d", ErrInvalidTrustLevel, trustLevel, int64(math.MaxInt64))
+	}
+	return reduced, nil
+}
+
+// CheckTrustMath checks that the voting power needed to trust a validator
+// set with a trust level can be computed, i.e. that its total voting power
+// times the numerator fits in an int64.
+func CheckTrustMath(vals *cmttypes.ValidatorSet, trustLevel cmtmath.Fraction) error {
+	if err := CheckValidatorSetPowers(vals); err != nil {
+		return err
+	}
+	hi, lo := bits.Mul64(uint64(vals.TotalVotingPower()), trustLevel.Numerator)
+	if hi != 0 || lo > math.MaxInt64 {
+		return fmt.Errorf("%w: total voting power %d times the trust level numerator %d", ErrVotingPowerOverflow, vals.TotalVotingPower(), trustLevel.Numerator)
+	}
+	return nil
+}
+
+// safeMul multiplies non negative integers, reporting whether the product
+// overflows.
+func safeMul(a, b int64) (int64, bool) {
+	if a == 0 || b == 0 {
+		return 0, false
+	}
+	if a > math.MaxInt64/b {
+		return 0, true
+	}
+	return a * b, false
+}
+
+func gcd(a, b uint64) uint64 {
+	for b != 0 {
+		a, b = b, a%b
+	}
+	return a
+}
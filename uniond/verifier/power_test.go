@@ -0,0 +1,135 @@
+package verifier_test
+
+import (
+	"math"
+	"testing"
+
+	"github.com/cometbft/cometbft/crypto/bn254"
+	cmtmath "github.com/cometbft/cometbft/libs/math"
+	cmtproto "github.com/cometbft/cometbft/proto/tendermint/types"
+	cmttypes "github.com/cometbft/cometbft/types"
+	"github.com/stretchr/testify/require"
+
+	"union/verifier"
+)
+
+func TestValidateTrustLevel(t *testing.T) {
+	for _, tc := range []struct {
+		name       string
+		trustLevel cmtmath.Fraction
+		reduced    cmtmath.Fraction
+		err        bool
+	}{
+		{name: "one third", trustLevel: cmtmath.Fraction{Numerator: 1, Denominator: 3}, reduced: cmtmath.Fraction{Numerator: 1, Denominator: 3}},
+		{name: "one", trustLevel: cmtmath.Fraction{Numerator: 1, Denominator: 1}, reduced: cmtmath.Fraction{Numerator: 1, Denominator: 1}},
+		{name: "reduced", trustLevel: cmtmath.Fraction{Numerator: 4, Denominator: 6}, reduced: cmtmath.Fraction{Numerator: 2, Denominator: 3}},
+		{name: "zero", trustLevel: cmtmath.Fraction{Numerator: 0, Denominator: 1}, err: true},
+		{name: "under one third", trustLevel: cmtmath.Fraction{Numerator: 1, Denominator: 4}, err: true},
+		{name: "over one", trustLevel: cmtmath.Fraction{Numerator: 2, Denominator: 1}, err: true},
+		{name: "zero denominator", trustLevel: cmtmath.Fraction{Numerator: 1, Denominator: 0}, err: true},
+		{name: "zero over zero", trustLevel: cmtmath.Fraction{Numerator: 0, Denominator: 0}, err: true},
+		{
+			// Times 3, the numerator wraps to 2.
+			name:       "numerator times 3 wrapping",
+			trustLevel: cmtmath.Fraction{Numerator: 6148914691236517206, Denominator: math.MaxUint64},
+			reduced:    cmtmath.Fraction{Numerator: 2049638230412172402, Denominator: 6148914691236517205},
+		},
+		{
+			name:       "under one third near the bound",
+			trustLevel: cmtmath.Fraction{Numerator: 1 << 62, Denominator: math.MaxUint64},
+			err:        true,
+		},
+		{
+			name:       "numerator exceeding int64",
+			trustLevel: cmtmath.Fraction{Numerator: math.MaxUint64 - 1, Denominator: math.MaxUint64},
+			err:        true,
+		},
+		{
+			name:       "reducing under int64",
+			trustLevel: cmtmath.Fraction{Numerator: math.MaxUint64, Denominator: math.MaxUint64},
+			reduced:    cmtmath.Fraction{Numerator: 1, Denominator: 1},
+		},
+		{
+			name:       "over one near the bound",
+			trustLevel: cmtmath.Fraction{Numerator: math.MaxUint64, Denominator: math.MaxUint64 - 1},
+			err:        true,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			reduced, err := verifier.ValidateTrustLevel(tc.trustLevel)
+			if tc.err {
+				require.ErrorIs(t, err, verifier.ErrInvalidTrustLevel)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tc.reduced, reduced)
+		})
+	}
+}
+
+func TestCheckVotingPowers(t *testing.T) {
+	for _, tc := range []struct {
+		name   string
+		powers []int64
+		total  int64
+		err    error
+	}{
+		{name: "valid", powers: []int64{1, 2, 3}},
+		{name: "declared total", powers: []int64{1, 2, 3}, total: 6},
+		{name: "maximum total", powers: []int64{cmttypes.MaxTotalVotingPower - 1, 1}},
+		{name: "declared total mismatch", powers: []int64{1, 2, 3}, total: 7, err: verifier.ErrTotalVotingPowerMismatch},
+		{name: "total past the maximum", powers: []int64{cmttypes.MaxTotalVotingPower, 1}, err: verifier.ErrVotingPowerOverflow},
+		{name: "sum wrapping int64", powers: []int64{math.MaxInt64, math.MaxInt64}, err: verifier.ErrVotingPowerOverflow},
+		{name: "zero power", powers: []int64{1, 0}, err: verifier.ErrVotingPowerOverflow},
+		{name: "negative power", powers: []int64{5, -5, 1}, err: verifier.ErrVotingPowerOverflow},
+		{name: "negative power offsetting an overflow", powers: []int64{cmttypes.MaxTotalVotingPower, -1, 2}, err: verifier.ErrVotingPowerOverflow},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			pb := &cmtproto.ValidatorSet{TotalVotingPower: tc.total}
+			vals := &cmttypes.ValidatorSet{}
+			for _, power := range tc.powers {
+				pb.Validators = append(pb.Validators, &cmtproto.Validator{VotingPower: power})
+				vals.Validators = append(vals.Validators, &cmttypes.Validator{VotingPower: power})
+			}
+			err := verifier.CheckVotingPowers(pb)
+			if tc.err == nil {
+				require.NoError(t, err)
+			} else {
+				require.ErrorIs(t, err, tc.err)
+			}
+			// The decoded set has no declared total.
+			err = verifier.CheckValidatorSetPowers(vals)
+			if tc.err == nil || tc.err == verifier.ErrTotalVotingPowerMismatch {
+				require.NoError(t, err)
+			} else {
+				require.ErrorIs(t, err, tc.err)
+			}
+		})
+	}
+}
+
+func TestCheckTrustMath(t *testing.T) {
+	vals := cmttypes.NewValidatorSet([]*cmttypes.Validator{
+		cmttypes.NewValidator(bn254.GenPrivKey().PubKey(), cmttypes.MaxTotalVotingPower),
+	})
+	for _, tc := range []struct {
+		name      string
+		numerator uint64
+		err       bool
+	}{
+		{name: "one", numerator: 1},
+		// MaxTotalVotingPower is MaxInt64 / 8.
+		{name: "largest numerator", numerator: 8},
+		{name: "product past int64", numerator: 9, err: true},
+		{name: "product past uint64", numerator: math.MaxUint64, err: true},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			err := verifier.CheckTrustMath(vals, cmtmath.Fraction{Numerator: tc.numerator, Denominator: math.MaxUint64})
+			if tc.err {
+				require.ErrorIs(t, err, verifier.ErrVotingPowerOverflow)
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
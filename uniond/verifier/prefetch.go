@@ -0,0 +1,101 @@
+package verifier
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/cometbft/cometbft/light/provider"
+	cmttypes "github.com/cometbft/cometbft/types"
+)
+
+// NewPrefetcher wraps a provider so that, once a light client requests
+// consecutive heights, e.g. when verifying sequentially or catching up, the
+// next depth heights are fetched concurrently ahead of it. The checks the
+// wrapped provider runs on each light block, such as the HTTP provider
+// validating the header hash against the commit and the validator set hash,
+// then overlap with the I/O and with the serial trust chain of the client.
+//
+// A prefetched light block is only served to the request of its height. A
+// failed prefetch is retried by that request instead, such that errors come
+// back in the order of the requests and a height which wasn't committed yet
+// when prefetched is served once it is. Prefetches the client jumped over are
+// cancelled.
+func NewPrefetcher(p provider.Provider, depth int) provider.Provider {
+	if depth <= 0 {
+		return p
+	}
+	return &prefetcher{Provider: p, depth: int64(depth), fetches: make(map[int64]*fetch)}
+}
+
+type prefetcher struct {
+	provider.Provider
+	depth int64
+
+	mtx     sync.Mutex
+	last    int64
+	fetches map[int64]*fetch
+}
+
+type fetch struct {
+	done       chan struct{}
+	lightBlock *cmttypes.LightBlock
+	err        error
+	cancel     context.CancelFunc
+}
+
+func (p *prefetcher) LightBlock(ctx context.Context, height int64) (*cmttypes.LightBlock, error) {
+	if height == 0 {
+		return p.Provider.LightBlock(ctx, 0)
+	}
+
+	p.mtx.Lock()
+	f, ok := p.fetches[height]
+	delete(p.fetches, height)
+	sequential := p.last != 0 && height == p.last+1
+	p.last = height
+	for h, stale := range p.fetches {
+		if h < height || h > height+p.depth {
+			stale.cancel()
+			delete(p.fetches, h)
+		}
+	}
+	if sequential {
+		for h := height + 1; h <= height+p.depth; h++ {
+			if _, ok := p.fetches[h]; !ok {
+				p.fetches[h] = p.start(h)
+			}
+		}
+	}
+	p.mtx.Unlock()
+
+	if !ok {
+		return p.Provider.LightBlock(ctx, height)
+	}
+	defer f.cancel()
+	select {
+	case <-f.done:
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+	if f.err != nil {
+		return p.Provider.LightBlock(ctx, height)
+	}
+	return f.lightBlock, nil
+}
+
+// start fetches the light block at height in the background, detached from
+// the request that triggered it.
+func (p *prefetcher) start(height int64) *fetch {
+	ctx, cancel := context.WithCancel(context.Background())
+	f := &fetch{done: make(chan struct{}), cancel: cancel}
+	go func() {
+		defer close(f.done)
+		f.lightBlock, f.err = p.Provider.LightBlock(ctx, height)
+	}()
+	return f
+}
+
+func (p *prefetcher) String() string {
+	return fmt.Sprint(p.Provider)
+}
@@ -0,0 +1,136 @@
+package verifier_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	dbm "github.com/cometbft/cometbft-db"
+	cmtlog "github.com/cometbft/cometbft/libs/log"
+	"github.com/cometbft/cometbft/light"
+	"github.com/cometbft/cometbft/light/provider"
+	lightdb "github.com/cometbft/cometbft/light/store/db"
+	cmttypes "github.com/cometbft/cometbft/types"
+	"github.com/stretchr/testify/require"
+
+	"union/verifier"
+	"union/verifier/lighttest"
+)
+
+// countingProvider counts the requests per height.
+type countingProvider struct {
+	provider.Provider
+
+	mu       sync.Mutex
+	requests map[int64]int
+}
+
+func (p *countingProvider) LightBlock(ctx context.Context, height int64) (*cmttypes.LightBlock, error) {
+	p.mu.Lock()
+	p.requests[height]++
+	p.mu.Unlock()
+	return p.Provider.LightBlock(ctx, height)
+}
+
+func (p *countingProvider) String() string {
+	return fmt.Sprint(p.Provider)
+}
+
+func (p *countingProvider) count(height int64) int {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.requests[height]
+}
+
+func TestPrefetcher(t *testing.T) {
+	chain, err := lighttest.NewChain("prefetch-1", 4, 1)
+	require.NoError(t, err)
+	errUnavailable := errors.New("unavailable")
+	source := lighttest.NewProvider("primary", chain, 3, false)
+	counting := &countingProvider{Provider: source, requests: make(map[int64]int)}
+	p := verifier.NewPrefetcher(counting, 2)
+	require.Equal(t, "primary", fmt.Sprint(p))
+
+	get := func(height int64) error {
+		lightBlock, err := p.LightBlock(context.Background(), height)
+		if err == nil && height != 0 {
+			require.Equal(t, height, lightBlock.Height)
+		}
+		return err
+	}
+	prefetched := func(heights ...int64) {
+		require.Eventually(t, func() bool {
+			for _, height := range heights {
+				if counting.count(height) == 0 {
+					return false
+				}
+			}
+			return true
+		}, time.Second, time.Millisecond)
+	}
+
+	// A single request prefetches nothing.
+	require.NoError(t, get(1))
+	time.Sleep(10 * time.Millisecond)
+	require.Zero(t, counting.count(2))
+
+	// Consecutive requests prefetch the next heights, above the latest one
+	// for now.
+	require.NoError(t, get(2))
+	prefetched(3, 4)
+	require.NoError(t, get(3))
+	require.Equal(t, 1, counting.count(3))
+	prefetched(5)
+
+	// The heights committed since are fetched again, and the errors of the
+	// later heights are only returned once they are requested.
+	source.SetLatest(10)
+	source.Script(6, lighttest.Response{Err: errUnavailable})
+	require.NoError(t, get(4))
+	require.Equal(t, 2, counting.count(4))
+	prefetched(6)
+	require.NoError(t, get(5))
+	require.ErrorIs(t, get(6), errUnavailable)
+	require.NoError(t, get(7))
+
+	// Jumping ahead doesn't prefetch.
+	prefetched(8, 9)
+	require.NoError(t, get(10))
+	time.Sleep(10 * time.Millisecond)
+	require.Zero(t, counting.count(11))
+
+	// The latest light block is always fetched.
+	require.NoError(t, get(0))
+	require.NoError(t, get(0))
+	require.Equal(t, 2, counting.count(0))
+}
+
+func TestPrefetcherSequentialVerification(t *testing.T) {
+	chain, err := lighttest.NewChain("prefetch-1", 4, 1, lighttest.WithChurn(1))
+	require.NoError(t, err)
+	root, err := chain.LightBlock(1, false)
+	require.NoError(t, err)
+	counting := &countingProvider{Provider: lighttest.NewProvider("primary", chain, 30, false), requests: make(map[int64]int)}
+
+	client, err := light.NewClient(
+		context.Background(),
+		chain.ChainID,
+		light.TrustOptions{Period: 24 * time.Hour, Height: 1, Hash: root.Hash()},
+		verifier.NewPrefetcher(counting, 4),
+		[]provider.Provider{lighttest.NewProvider("witness", chain, 30, false)},
+		lightdb.New(dbm.NewMemDB(), chain.ChainID),
+		light.SequentialVerification(),
+		light.Logger(cmtlog.NewNopLogger()),
+	)
+	require.NoError(t, err)
+	lightBlock, err := client.VerifyLightBlockAtHeight(context.Background(), 20, chain.Time(21))
+	require.NoError(t, err)
+	require.Equal(t, int64(20), lightBlock.Height)
+	// Every intermediate height is fetched once, ahead of its verification.
+	for height := int64(2); height < 20; height++ {
+		require.Equal(t, 1, counting.count(height), "height %d", height)
+	}
+}
@@ -0,0 +1,100 @@
+package verifier
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+
+	cmttypes "github.com/cometbft/cometbft/types"
+)
+
+// ErrInvalidProposer is returned for a header whose proposer isn't the
+// validator the proposer rotation elects.
+var ErrInvalidProposer = errors.New("invalid proposer")
+
+// maxProposerRounds bounds the rounds replayed by CheckProposer, so that a
+// hostile commit round can't make it loop: no chain commits after that many
+// rounds.
+const maxProposerRounds = 1 << 10
+
+// CheckProposer checks that the proposer of the untrusted header is a
+// validator of its set elected by the proposer rotation in one of the rounds
+// up to the commit round, a block being possibly proposed in an earlier round
+// than the one committing it.
+//
+// The proposer priorities aren't committed to by the headers. For an
+// adjacent header, the rotation is replayed from the priorities of the
+// trusted set, updated to the untrusted set as a CometBFT state would be,
+// which catches fabricated headers naming any other proposer. Otherwise, it
+// is replayed from the priorities served with the untrusted set, only
+// catching headers inconsistent with their own validator set.
+func CheckProposer(trusted, untrusted *cmttypes.LightBlock) error {
+	proposer := untrusted.ProposerAddress
+	if _, val := untrusted.ValidatorSet.GetByAddress(proposer); val == nil {
+		return fmt.Errorf("%w: %X isn't a validator of height %d", ErrInvalidProposer, proposer, untrusted.Height)
+	}
+	if untrusted.Commit.Round > maxProposerRounds {
+		return fmt.Errorf("%w: commit round %d of height %d exceeds the %d replayed rounds", ErrInvalidProposer, untrusted.Commit.Round, untrusted.Height, maxProposerRounds)
+	}
+
+	var vals *cmttypes.ValidatorSet
+	if untrusted.Height == trusted.Height+1 {
+		vals = trusted.ValidatorSet.Copy()
+		if changes := validatorChanges(trusted.ValidatorSet, untrusted.ValidatorSet); len(changes) > 0 {
+			if err := vals.UpdateWithChangeSet(changes); err != nil {
+				return fmt.Errorf("%w: %w", ErrInvalidProposer, err)
+			}
+		}
+		vals.IncrementProposerPriority(1)
+		if bytes.Equal(vals.GetProposer().Address, proposer) {
+			return nil
+		}
+	} else {
+		// The served proposer isn't trusted either, the round 0 proposer
+		// must be consistent with the served priorities.
+		vals = untrusted.ValidatorSet
+		if electedLast(vals, proposer) {
+			return nil
+		}
+	}
+	// Rounds advance one at a time, each incrementing the priorities.
+	for round := int32(1); round <= untrusted.Commit.Round; round++ {
+		vals = vals.CopyIncrementProposerPriority(1)
+		if bytes.Equal(vals.GetProposer().Address, proposer) {
+			return nil
+		}
+	}
+	return fmt.Errorf("%w: %X didn't propose in rounds 0 to %d of height %d", ErrInvalidProposer, proposer, untrusted.Commit.Round, untrusted.Height)
+}
+
+// validatorChanges returns the validator updates turning a set into the
+// next one: the removed validators with a zero power, the added ones and the
+// ones whose power changed.
+func validatorChanges(vals, next *cmttypes.ValidatorSet) []*cmttypes.Validator {
+	var changes []*cmttypes.Validator
+	for _, val := range vals.Validators {
+		if !next.HasAddress(val.Address) {
+			changes = append(changes, cmttypes.NewValidator(val.PubKey, 0))
+		}
+	}
+	for _, val := range next.Validators {
+		if _, previous := vals.GetByAddress(val.Address); previous == nil || previous.VotingPower != val.VotingPower {
+			changes = append(changes, cmttypes.NewValidator(val.PubKey, val.VotingPower))
+		}
+	}
+	return changes
+}
+
+// electedLast tells whether the last increment of the priorities of a set
+// elected a validator, i.e. whether it had the highest priority before being
+// decremented by the total voting power.
+func electedLast(vals *cmttypes.ValidatorSet, address []byte) bool {
+	_, elected := vals.GetByAddress(address)
+	elected.ProposerPriority += vals.TotalVotingPower()
+	for _, val := range vals.Validators {
+		if !bytes.Equal(val.Address, address) && elected.CompareProposerPriority(val) != elected {
+			return false
+		}
+	}
+	return true
+}
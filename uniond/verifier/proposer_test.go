@@ -0,0 +1,150 @@
+package verifier_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/cometbft/cometbft/crypto/bn254"
+	cmttypes "github.com/cometbft/cometbft/types"
+	"github.com/stretchr/testify/require"
+
+	"union/verifier"
+	"union/verifier/lighttest"
+)
+
+// withProposer returns a copy of a light block with another validator set,
+// proposer and commit round, neither hashed nor signed again as
+// CheckProposer checks neither.
+func withProposer(lightBlock *cmttypes.LightBlock, vals *cmttypes.ValidatorSet, proposer []byte, round int32) *cmttypes.LightBlock {
+	header := *lightBlock.Header
+	header.ProposerAddress = proposer
+	commit := *lightBlock.Commit
+	commit.Round = round
+	return &cmttypes.LightBlock{
+		SignedHeader: &cmttypes.SignedHeader{Header: &header, Commit: &commit},
+		ValidatorSet: vals,
+	}
+}
+
+// proposer returns the proposer elected at a round from the priorities of a
+// set updated for its height.
+func proposer(vals *cmttypes.ValidatorSet, round int32) []byte {
+	if round == 0 {
+		return vals.GetProposer().Address
+	}
+	return vals.CopyIncrementProposerPriority(round).GetProposer().Address
+}
+
+func TestCheckProposer(t *testing.T) {
+	chain, err := lighttest.NewChain("proposer-1", 6, 1, lighttest.WithChurn(1))
+	require.NoError(t, err)
+	trusted, err := chain.LightBlock(4, false)
+	require.NoError(t, err)
+	adjacent, err := chain.LightBlock(5, false)
+	require.NoError(t, err)
+	nonAdjacent, err := chain.LightBlock(9, false)
+	require.NoError(t, err)
+
+	// A later round electing another proposer than the round 0 one.
+	var round int32
+	for r := int32(1); r < 50; r++ {
+		if !bytes.Equal(proposer(adjacent.ValidatorSet, r), adjacent.ProposerAddress) {
+			round = r
+			break
+		}
+	}
+	require.NotZero(t, round)
+	// A validator never elected up to that round.
+	var forged []byte
+	for _, val := range adjacent.ValidatorSet.Validators {
+		elected := false
+		for r := int32(0); r <= round; r++ {
+			elected = elected || bytes.Equal(proposer(adjacent.ValidatorSet, r), val.Address)
+		}
+		if !elected {
+			forged = val.Address
+			break
+		}
+	}
+	require.NotNil(t, forged)
+	forgedNonAdjacent := nonAdjacent.ValidatorSet.Validators[0].Address
+	if bytes.Equal(forgedNonAdjacent, nonAdjacent.ProposerAddress) {
+		forgedNonAdjacent = nonAdjacent.ValidatorSet.Validators[1].Address
+	}
+
+	added := cmttypes.NewValidator(bn254.GenPrivKey().PubKey(), 500_000)
+	withAdded := trusted.ValidatorSet.Copy()
+	require.NoError(t, withAdded.UpdateWithChangeSet([]*cmttypes.Validator{added}))
+	withAdded.IncrementProposerPriority(1)
+	// The validator that would have proposed is removed.
+	removed := trusted.ValidatorSet.CopyIncrementProposerPriority(1).GetProposer()
+	withRemoved := trusted.ValidatorSet.Copy()
+	require.NoError(t, withRemoved.UpdateWithChangeSet([]*cmttypes.Validator{cmttypes.NewValidator(removed.PubKey, 0)}))
+	withRemoved.IncrementProposerPriority(1)
+	require.False(t, bytes.Equal(removed.Address, withRemoved.GetProposer().Address))
+
+	for _, tc := range []struct {
+		name      string
+		untrusted *cmttypes.LightBlock
+		err       error
+	}{
+		{
+			name:      "adjacent round 0",
+			untrusted: adjacent,
+		},
+		{
+			name:      "adjacent later round",
+			untrusted: withProposer(adjacent, adjacent.ValidatorSet, proposer(adjacent.ValidatorSet, round), round),
+		},
+		{
+			name:      "later round proposer committed in round 0",
+			untrusted: withProposer(adjacent, adjacent.ValidatorSet, proposer(adjacent.ValidatorSet, round), 0),
+			err:       verifier.ErrInvalidProposer,
+		},
+		{
+			name:      "non adjacent round 0",
+			untrusted: nonAdjacent,
+		},
+		{
+			name:      "non adjacent later round",
+			untrusted: withProposer(nonAdjacent, nonAdjacent.ValidatorSet, proposer(nonAdjacent.ValidatorSet, 3), 3),
+		},
+		{
+			name:      "added validator",
+			untrusted: withProposer(adjacent, withAdded, withAdded.GetProposer().Address, 0),
+		},
+		{
+			name:      "removed validator",
+			untrusted: withProposer(adjacent, withRemoved, withRemoved.GetProposer().Address, 0),
+		},
+		{
+			name:      "removed validator as proposer",
+			untrusted: withProposer(adjacent, withRemoved, removed.Address, 0),
+			err:       verifier.ErrInvalidProposer,
+		},
+		{
+			name:      "forged proposer",
+			untrusted: withProposer(adjacent, adjacent.ValidatorSet, forged, round),
+			err:       verifier.ErrInvalidProposer,
+		},
+		{
+			name:      "forged non adjacent proposer",
+			untrusted: withProposer(nonAdjacent, nonAdjacent.ValidatorSet, forgedNonAdjacent, 0),
+			err:       verifier.ErrInvalidProposer,
+		},
+		{
+			name:      "proposer outside the set",
+			untrusted: withProposer(adjacent, adjacent.ValidatorSet, added.Address, 0),
+			err:       verifier.ErrInvalidProposer,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			err := verifier.CheckProposer(trusted, tc.untrusted)
+			if tc.err == nil {
+				require.NoError(t, err)
+			} else {
+				require.ErrorIs(t, err, tc.err)
+			}
+		})
+	}
+}
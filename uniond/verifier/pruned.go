@@ -0,0 +1,65 @@
+package verifier
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/cometbft/cometbft/light/provider"
+	cmttypes "github.com/cometbft/cometbft/types"
+)
+
+// ErrHeightPruned is returned for a height pruned by the providers that no
+// archive provider serves either.
+var ErrHeightPruned = errors.New("height pruned")
+
+// NewArchiveFallback wraps a provider so that the light blocks it reports as
+// pruned are fetched from the archive providers instead, in order, such as
+// nodes not pruning their block store or serving the header archive of
+// app/archive.
+//
+// The CometBFT providers report the heights under the base of the block
+// store of a node as provider.ErrLightBlockNotFound, which is still returned
+// when no archive provider serves the height: the light client tells benign
+// errors apart by identity, and would otherwise drop the provider. The
+// verification of a height failing that way is turned into ErrHeightPruned by
+// HeightPrunedError.
+func NewArchiveFallback(p provider.Provider, archives ...provider.Provider) provider.Provider {
+	if len(archives) == 0 {
+		return p
+	}
+	return &archiveFallback{Provider: p, archives: archives}
+}
+
+type archiveFallback struct {
+	provider.Provider
+	archives []provider.Provider
+}
+
+func (p *archiveFallback) LightBlock(ctx context.Context, height int64) (*cmttypes.LightBlock, error) {
+	lightBlock, err := p.Provider.LightBlock(ctx, height)
+	// The latest height can't be pruned.
+	if height == 0 || !errors.Is(err, provider.ErrLightBlockNotFound) {
+		return lightBlock, err
+	}
+	for _, archive := range p.archives {
+		if lightBlock, archiveErr := archive.LightBlock(ctx, height); archiveErr == nil {
+			return lightBlock, nil
+		}
+	}
+	return nil, err
+}
+
+func (p *archiveFallback) String() string {
+	return fmt.Sprint(p.Provider)
+}
+
+// HeightPrunedError wraps the error of the light client verifying a height
+// into ErrHeightPruned when the height isn't served by any of its providers,
+// archive ones included.
+func HeightPrunedError(height int64, err error) error {
+	if errors.Is(err, provider.ErrLightBlockNotFound) {
+		return fmt.Errorf("%w: no provider serves height %d anymore, including the archive providers: %w", ErrHeightPruned, height, err)
+	}
+	return err
+}
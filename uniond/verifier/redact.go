@@ -0,0 +1,73 @@
+package verifier
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+)
+
+// Redaction is how the operator identifying fields of an export, such as
+// client addresses or provider URLs, are written. The verification relevant
+// content is never redacted so that exports remain verifiable.
+type Redaction string
+
+const (
+	// RedactionNone writes the fields as is.
+	RedactionNone Redaction = "none"
+	// RedactionHash replaces the fields with a keyed hash, so that the
+	// entries of a same operator can still be correlated by whoever holds
+	// the key.
+	RedactionHash Redaction = "hash"
+	// RedactionDrop omits the fields.
+	RedactionDrop Redaction = "drop"
+)
+
+// ParseRedaction parses a redaction mode.
+func ParseRedaction(s string) (Redaction, error) {
+	switch r := Redaction(s); r {
+	case RedactionNone, RedactionHash, RedactionDrop:
+		return r, nil
+	default:
+		return "", fmt.Errorf("unknown redaction %q, expected %s, %s or %s", s, RedactionNone, RedactionHash, RedactionDrop)
+	}
+}
+
+// Redactor redacts the operator identifying fields of exports.
+type Redactor struct {
+	Mode Redaction
+	// Key of the HMAC-SHA256 hashes, the same key giving the same hashes
+	// across exports.
+	Key []byte
+}
+
+// NewRedactor returns a redactor, drawing a random key for the hashes if
+// none is given: the hashes are then only consistent within the process.
+func NewRedactor(mode Redaction, key []byte) (*Redactor, error) {
+	if mode == RedactionHash && len(key) == 0 {
+		key = make([]byte, 32)
+		if _, err := rand.Read(key); err != nil {
+			return nil, err
+		}
+	}
+	return &Redactor{Mode: mode, Key: key}, nil
+}
+
+// Redact returns the value as written in exports, a nil redactor keeping it
+// as is.
+func (r *Redactor) Redact(value string) string {
+	if r == nil || value == "" {
+		return value
+	}
+	switch r.Mode {
+	case RedactionHash:
+		mac := hmac.New(sha256.New, r.Key)
+		mac.Write([]byte(value))
+		return "hmac-sha256:" + hex.EncodeToString(mac.Sum(nil))
+	case RedactionDrop:
+		return ""
+	default:
+		return value
+	}
+}
@@ -0,0 +1,385 @@
+// Package replay records the traffic of light client providers in a live
+// session and replays it deterministically, timing included, so that an
+// incident seen in production can be reproduced exactly, e.g. in a test or
+// with light follow --replay-providers.
+package replay
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/cometbft/cometbft/light/provider"
+	cmttypes "github.com/cometbft/cometbft/types"
+
+	"union/verifier"
+)
+
+var (
+	// ErrExhausted is returned by a replayed provider requested past the
+	// end of its recording.
+	ErrExhausted = errors.New("recording exhausted")
+	// ErrDiverged is returned by a replayed provider whose request doesn't
+	// match the next recorded one, the replayed session having diverged
+	// from the recorded one.
+	ErrDiverged = errors.New("replay diverged from the recording")
+)
+
+const (
+	methodLightBlock     = "light_block"
+	methodReportEvidence = "report_evidence"
+)
+
+// The kinds of the recorded errors the light client tells apart, replayed as
+// the same errors.
+const (
+	errorNotFound         = "not_found"
+	errorNoResponse       = "no_response"
+	errorHeightTooHigh    = "height_too_high"
+	errorBadLightBlock    = "bad_light_block"
+	errorCanceled         = "canceled"
+	errorDeadlineExceeded = "deadline_exceeded"
+	errorOther            = "other"
+)
+
+// Entry is a recorded request of a provider, a JSON line of a recording.
+type Entry struct {
+	Provider string `json:"provider"`
+	Method   string `json:"method"`
+	// Height requested, 0 for the latest light block.
+	Height int64 `json:"height,omitempty"`
+	// Time the request was sent at.
+	Time    time.Time     `json:"time"`
+	Latency time.Duration `json:"latency_ns"`
+	// LightBlock served, or the light block of the evidence reported.
+	LightBlock *verifier.VectorBlock `json:"light_block,omitempty"`
+	Error      string                `json:"error,omitempty"`
+	ErrorKind  string                `json:"error_kind,omitempty"`
+}
+
+func errorKind(err error) string {
+	var bad provider.ErrBadLightBlock
+	switch {
+	case errors.Is(err, provider.ErrLightBlockNotFound):
+		return errorNotFound
+	case errors.Is(err, provider.ErrNoResponse):
+		return errorNoResponse
+	case errors.Is(err, provider.ErrHeightTooHigh):
+		return errorHeightTooHigh
+	case errors.As(err, &bad):
+		return errorBadLightBlock
+	case errors.Is(err, context.Canceled):
+		return errorCanceled
+	case errors.Is(err, context.DeadlineExceeded):
+		return errorDeadlineExceeded
+	default:
+		return errorOther
+	}
+}
+
+// replayedError is a recorded error whose message differs from the one of its
+// kind, e.g. a wrapped provider error.
+type replayedError struct {
+	message string
+	kind    error
+}
+
+func (e replayedError) Error() string {
+	return e.message
+}
+
+func (e replayedError) Unwrap() error {
+	return e.kind
+}
+
+// err returns the recorded error, the very errors of the providers when the
+// recording matches them, as the light client tells them apart by identity.
+func (e *Entry) err() error {
+	var kind error
+	switch e.ErrorKind {
+	case "":
+		return nil
+	case errorNotFound:
+		kind = provider.ErrLightBlockNotFound
+	case errorNoResponse:
+		kind = provider.ErrNoResponse
+	case errorHeightTooHigh:
+		kind = provider.ErrHeightTooHigh
+	case errorBadLightBlock:
+		return provider.ErrBadLightBlock{Reason: errors.New(e.Error)}
+	case errorCanceled:
+		kind = context.Canceled
+	case errorDeadlineExceeded:
+		kind = context.DeadlineExceeded
+	default:
+		return errors.New(e.Error)
+	}
+	if e.Error == kind.Error() {
+		return kind
+	}
+	return replayedError{message: e.Error, kind: kind}
+}
+
+// Recorder records the requests of the providers it wraps as JSON lines,
+// written as soon as they are answered so that a crashed session is recorded
+// up to the crash.
+type Recorder struct {
+	mu  sync.Mutex
+	enc *json.Encoder
+	err error
+}
+
+// NewRecorder creates a recorder writing to w.
+func NewRecorder(w io.Writer) *Recorder {
+	return &Recorder{enc: json.NewEncoder(w)}
+}
+
+// Err returns the first error writing the recording, the requests being
+// served regardless.
+func (r *Recorder) Err() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.err
+}
+
+func (r *Recorder) record(entry *Entry, err error) {
+	if err != nil {
+		entry.Error, entry.ErrorKind = err.Error(), errorKind(err)
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.err != nil {
+		return
+	}
+	r.err = r.enc.Encode(entry)
+}
+
+// Wrap returns a provider recording the requests of a provider under its
+// address, which the replayed provider is looked up by.
+func (r *Recorder) Wrap(address string, p provider.Provider) provider.Provider {
+	return &recorded{Provider: p, recorder: r, address: address}
+}
+
+type recorded struct {
+	provider.Provider
+	recorder *Recorder
+	address  string
+}
+
+func (p *recorded) LightBlock(ctx context.Context, height int64) (*cmttypes.LightBlock, error) {
+	start := time.Now()
+	lightBlock, err := p.Provider.LightBlock(ctx, height)
+	entry := &Entry{Provider: p.address, Method: methodLightBlock, Height: height, Time: start.UTC(), Latency: time.Since(start)}
+	if err == nil {
+		entry.LightBlock = &verifier.VectorBlock{LightBlock: lightBlock}
+	}
+	p.recorder.record(entry, err)
+	return lightBlock, err
+}
+
+func (p *recorded) ReportEvidence(ctx context.Context, evidence cmttypes.Evidence) error {
+	start := time.Now()
+	err := p.Provider.ReportEvidence(ctx, evidence)
+	entry := &Entry{Provider: p.address, Method: methodReportEvidence, Time: start.UTC(), Latency: time.Since(start)}
+	if attack, ok := evidence.(*cmttypes.LightClientAttackEvidence); ok {
+		entry.Height = attack.Height()
+		if attack.ConflictingBlock != nil {
+			entry.LightBlock = &verifier.VectorBlock{LightBlock: attack.ConflictingBlock}
+		}
+	}
+	p.recorder.record(entry, err)
+	return err
+}
+
+func (p *recorded) String() string {
+	return fmt.Sprint(p.Provider)
+}
+
+// Session replays a recording, each provider answering its recorded requests
+// in order with the recorded light blocks and errors, after the recorded
+// latency. A request not matching the next recorded one of its provider
+// fails with ErrDiverged, the requests past the end of the recording with
+// ErrExhausted.
+//
+// The session is also the clock of the replay: Now is the time the latest
+// replayed request was answered at, so that the verifications run at the
+// recorded times. The providers being requested concurrently, the recorded
+// order is only kept per provider.
+type Session struct {
+	// Instant answers without waiting for the recorded latencies, e.g. in
+	// tests.
+	Instant bool
+
+	mu      sync.Mutex
+	entries map[string][]Entry
+	now     time.Time
+}
+
+// Load reads a recording.
+func Load(r io.Reader) (*Session, error) {
+	s := &Session{entries: make(map[string][]Entry)}
+	scanner := bufio.NewScanner(r)
+	// Light blocks of large validator sets make long lines.
+	scanner.Buffer(nil, 64<<20)
+	for line := 1; scanner.Scan(); line++ {
+		var entry Entry
+		if err := json.Unmarshal(scanner.Bytes(), &entry); err != nil {
+			return nil, fmt.Errorf("line %d: %w", line, err)
+		}
+		if entry.Method != methodLightBlock && entry.Method != methodReportEvidence {
+			return nil, fmt.Errorf("line %d: unknown method %q", line, entry.Method)
+		}
+		if s.now.IsZero() || entry.Time.Before(s.now) {
+			s.now = entry.Time
+		}
+		s.entries[entry.Provider] = append(s.entries[entry.Provider], entry)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+// Open reads a recording from a file.
+func Open(path string) (*Session, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	s, err := Load(f)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	return s, nil
+}
+
+// Providers returns the addresses of the recorded providers.
+func (s *Session) Providers() []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	addresses := make([]string, 0, len(s.entries))
+	for address := range s.entries {
+		addresses = append(addresses, address)
+	}
+	sort.Strings(addresses)
+	return addresses
+}
+
+// Now returns the time the latest replayed request was answered at, the time
+// the first recorded request was sent at until then. It implements
+// verifier.Clock.
+func (s *Session) Now() time.Time {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.now
+}
+
+// Exhausted reports whether every recorded request was replayed.
+func (s *Session) Exhausted() bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, entries := range s.entries {
+		if len(entries) > 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// next pops the next recorded request of a provider if it matches the
+// replayed one.
+func (s *Session) next(address, method string, height int64) (Entry, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	entries := s.entries[address]
+	if len(entries) == 0 {
+		return Entry{}, fmt.Errorf("%w: no request of %s left", ErrExhausted, address)
+	}
+	entry := entries[0]
+	if entry.Method != method || entry.Height != height {
+		return Entry{}, fmt.Errorf("%w: %s requested %s at height %d, recorded %s at height %d", ErrDiverged, address, method, height, entry.Method, entry.Height)
+	}
+	s.entries[address] = entries[1:]
+	return entry, nil
+}
+
+// answered advances the clock to the time a replayed request was answered at.
+func (s *Session) answered(entry Entry) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if at := entry.Time.Add(entry.Latency); at.After(s.now) {
+		s.now = at
+	}
+}
+
+func (s *Session) replay(ctx context.Context, address, method string, height int64) (Entry, error) {
+	entry, err := s.next(address, method, height)
+	if err != nil {
+		return Entry{}, err
+	}
+	if !s.Instant && entry.Latency > 0 {
+		timer := time.NewTimer(entry.Latency)
+		defer timer.Stop()
+		select {
+		case <-ctx.Done():
+			return Entry{}, ctx.Err()
+		case <-timer.C:
+		}
+	}
+	s.answered(entry)
+	return entry, nil
+}
+
+// Provider returns the provider replaying the requests recorded under an
+// address.
+func (s *Session) Provider(chainID, address string) provider.Provider {
+	return &replayed{session: s, chainID: chainID, address: address}
+}
+
+type replayed struct {
+	session *Session
+	chainID string
+	address string
+}
+
+func (p *replayed) ChainID() string {
+	return p.chainID
+}
+
+func (p *replayed) LightBlock(ctx context.Context, height int64) (*cmttypes.LightBlock, error) {
+	entry, err := p.session.replay(ctx, p.address, methodLightBlock, height)
+	if err != nil {
+		return nil, err
+	}
+	if err := entry.err(); err != nil {
+		return nil, err
+	}
+	if entry.LightBlock == nil {
+		return nil, fmt.Errorf("%w: %s served no light block at height %d", ErrDiverged, p.address, height)
+	}
+	return entry.LightBlock.LightBlock, nil
+}
+
+func (p *replayed) ReportEvidence(ctx context.Context, evidence cmttypes.Evidence) error {
+	var height int64
+	if attack, ok := evidence.(*cmttypes.LightClientAttackEvidence); ok {
+		height = attack.Height()
+	}
+	entry, err := p.session.replay(ctx, p.address, methodReportEvidence, height)
+	if err != nil {
+		return err
+	}
+	return entry.err()
+}
+
+func (p *replayed) String() string {
+	return p.address
+}
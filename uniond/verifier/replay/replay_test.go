@@ -0,0 +1,115 @@
+package replay_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/cometbft/cometbft/light/provider"
+	"github.com/stretchr/testify/require"
+
+	"union/verifier/lighttest"
+	"union/verifier/replay"
+)
+
+func TestSessionReplaysInOrder(t *testing.T) {
+	chain, err := lighttest.NewChain("replay-1", 4, 1)
+	require.NoError(t, err)
+	primary := lighttest.NewProvider("primary", chain, 10, false)
+	primary.Script(4, lighttest.Response{Err: provider.ErrNoResponse})
+	primary.Script(5, lighttest.Response{Err: fmt.Errorf("height 5: %w", provider.ErrLightBlockNotFound)})
+	witness := lighttest.NewProvider("witness", chain, 10, false)
+
+	var recording bytes.Buffer
+	recorder := replay.NewRecorder(&recording)
+	providers := map[string]provider.Provider{
+		"tcp://primary:26657": recorder.Wrap("tcp://primary:26657", primary),
+		"tcp://witness:26657": recorder.Wrap("tcp://witness:26657", witness),
+	}
+	type request struct {
+		address string
+		height  int64
+	}
+	requests := []request{
+		{"tcp://primary:26657", 0},
+		{"tcp://primary:26657", 2},
+		{"tcp://witness:26657", 2},
+		{"tcp://primary:26657", 4},
+		{"tcp://primary:26657", 5},
+		{"tcp://witness:26657", 11},
+		{"tcp://primary:26657", 2},
+	}
+	type response struct {
+		hash string
+		err  error
+	}
+	var recorded []response
+	for _, req := range requests {
+		lightBlock, err := providers[req.address].LightBlock(context.Background(), req.height)
+		res := response{err: err}
+		if err == nil {
+			res.hash = lightBlock.Hash().String()
+		}
+		recorded = append(recorded, res)
+	}
+	require.NoError(t, recorder.Err())
+
+	session, err := replay.Load(&recording)
+	require.NoError(t, err)
+	session.Instant = true
+	require.Equal(t, []string{"tcp://primary:26657", "tcp://witness:26657"}, session.Providers())
+	start := session.Now()
+	replayed := map[string]provider.Provider{
+		"tcp://primary:26657": session.Provider(chain.ChainID, "tcp://primary:26657"),
+		"tcp://witness:26657": session.Provider(chain.ChainID, "tcp://witness:26657"),
+	}
+	for i, req := range requests {
+		lightBlock, err := replayed[req.address].LightBlock(context.Background(), req.height)
+		if recorded[i].err != nil {
+			require.Error(t, err, "request %d", i)
+			require.Equal(t, recorded[i].err.Error(), err.Error(), "request %d", i)
+			// The light client tells the provider errors apart by identity.
+			for _, kind := range []error{provider.ErrNoResponse, provider.ErrLightBlockNotFound, provider.ErrHeightTooHigh} {
+				require.Equal(t, errors.Is(recorded[i].err, kind), errors.Is(err, kind), "request %d", i)
+			}
+			continue
+		}
+		require.NoError(t, err, "request %d", i)
+		require.Equal(t, recorded[i].hash, lightBlock.Hash().String(), "request %d", i)
+	}
+	require.True(t, session.Exhausted())
+	// The clock follows the recorded times of the answers.
+	require.True(t, session.Now().After(start))
+
+	// Requests past the end of the recording.
+	_, err = replayed["tcp://primary:26657"].LightBlock(context.Background(), 3)
+	require.ErrorIs(t, err, replay.ErrExhausted)
+}
+
+func TestSessionDiverged(t *testing.T) {
+	chain, err := lighttest.NewChain("replay-1", 4, 1)
+	require.NoError(t, err)
+	var recording bytes.Buffer
+	recorder := replay.NewRecorder(&recording)
+	primary := recorder.Wrap("tcp://primary:26657", lighttest.NewProvider("primary", chain, 10, false))
+	for _, height := range []int64{2, 3} {
+		_, err := primary.LightBlock(context.Background(), height)
+		require.NoError(t, err)
+	}
+
+	session, err := replay.Load(&recording)
+	require.NoError(t, err)
+	session.Instant = true
+	replayed := session.Provider(chain.ChainID, "tcp://primary:26657")
+	// Out of the recorded order.
+	_, err = replayed.LightBlock(context.Background(), 3)
+	require.ErrorIs(t, err, replay.ErrDiverged)
+	// The diverging request doesn't consume the recorded one.
+	lightBlock, err := replayed.LightBlock(context.Background(), 2)
+	require.NoError(t, err)
+	require.Equal(t, int64(2), lightBlock.Height)
+	_, err = session.Provider(chain.ChainID, "tcp://witness:26657").LightBlock(context.Background(), 2)
+	require.ErrorIs(t, err, replay.ErrExhausted)
+}
@@ -0,0 +1,157 @@
+package verifier
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"sync"
+	"time"
+
+	dbm "github.com/cometbft/cometbft-db"
+	"github.com/cometbft/cometbft/light"
+	"github.com/cometbft/cometbft/light/provider"
+	lightrpc "github.com/cometbft/cometbft/light/rpc"
+	lightdb "github.com/cometbft/cometbft/light/store/db"
+	cmttypes "github.com/cometbft/cometbft/types"
+)
+
+var _ lightrpc.LightClient = (*Replica)(nil)
+
+// Replica is a read-only light client following the trusted state another
+// process writes to a file, such as light follow with --export-state, e.g.
+// to scale a verifying RPC proxy out while a single process advances the
+// trusted store.
+//
+// The file is never written. The heights the trusted state doesn't cover are
+// verified from it by a light client whose store is in memory, and dropped
+// whenever a newer state is loaded, so that the replicas only keep the
+// shared state.
+type Replica struct {
+	path      string
+	chainID   string
+	primary   provider.Provider
+	witnesses []provider.Provider
+	options   []light.Option
+
+	// Serializes the calls verifying headers, which update the store in
+	// memory, and the loads of the state.
+	mtx    sync.Mutex
+	loaded os.FileInfo
+	state  *TrustedState
+	client *light.Client
+}
+
+// NewReplica creates a replica of the trusted state of a chain written to a
+// file, verifying the heights above it with the providers.
+func NewReplica(path, chainID string, primary provider.Provider, witnesses []provider.Provider, options ...light.Option) (*Replica, error) {
+	r := &Replica{
+		path:      path,
+		chainID:   chainID,
+		primary:   primary,
+		witnesses: witnesses,
+		options:   options,
+	}
+	if _, err := r.Reload(); err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+// Reload loads the trusted state again if the file changed since it was last
+// loaded, reporting whether a newer state was loaded. A state older than the
+// loaded one is ignored.
+func (r *Replica) Reload() (bool, error) {
+	r.mtx.Lock()
+	defer r.mtx.Unlock()
+	info, err := os.Stat(r.path)
+	if err != nil {
+		return false, err
+	}
+	if r.loaded != nil && info.ModTime().Equal(r.loaded.ModTime()) && info.Size() == r.loaded.Size() {
+		return false, nil
+	}
+	bz, err := os.ReadFile(r.path)
+	if err != nil {
+		return false, err
+	}
+	state, err := UnmarshalTrustedState(bz)
+	if err != nil {
+		return false, fmt.Errorf("%s: %w", r.path, err)
+	}
+	if state.Legacy {
+		return false, fmt.Errorf("%s: the light client doesn't support legacy light blocks", r.path)
+	}
+	if state.LightBlock.ChainID != r.chainID {
+		return false, fmt.Errorf("%s: trusted state of chain %s, following %s", r.path, state.LightBlock.ChainID, r.chainID)
+	}
+	r.loaded = info
+	if r.state != nil && state.LightBlock.Height <= r.state.LightBlock.Height {
+		return false, nil
+	}
+	store := lightdb.New(dbm.NewMemDB(), r.chainID)
+	if err := store.SaveLightBlock(state.LightBlock); err != nil {
+		return false, err
+	}
+	client, err := light.NewClientFromTrustedStore(r.chainID, state.TrustingPeriod, r.primary, r.witnesses, store, r.options...)
+	if err != nil {
+		return false, err
+	}
+	r.state, r.client = state, client
+	return true, nil
+}
+
+// Watch reloads the trusted state every interval until the context is done,
+// calling loaded with the light block of every newer state. Failing to load
+// the file, e.g. while it is being replaced, is reported to loaded with a nil
+// light block, the previous state being kept.
+func (r *Replica) Watch(ctx context.Context, interval time.Duration, loaded func(*cmttypes.LightBlock, error)) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			ok, err := r.Reload()
+			if err != nil {
+				loaded(nil, err)
+			} else if ok {
+				loaded(r.State().LightBlock, nil)
+			}
+		}
+	}
+}
+
+// State returns the loaded trusted state.
+func (r *Replica) State() *TrustedState {
+	r.mtx.Lock()
+	defer r.mtx.Unlock()
+	return r.state
+}
+
+func (r *Replica) ChainID() string {
+	return r.chainID
+}
+
+// Update returns the latest trusted light block, the one of the loaded state
+// unless a later height was verified from it, without contacting the
+// providers: the replica only advances with the shared state.
+func (r *Replica) Update(ctx context.Context, now time.Time) (*cmttypes.LightBlock, error) {
+	return r.TrustedLightBlock(0)
+}
+
+// VerifyLightBlockAtHeight returns the light block at a height, verifying it
+// from the loaded state, in memory, if it isn't trusted yet.
+func (r *Replica) VerifyLightBlockAtHeight(ctx context.Context, height int64, now time.Time) (*cmttypes.LightBlock, error) {
+	r.mtx.Lock()
+	defer r.mtx.Unlock()
+	return r.client.VerifyLightBlockAtHeight(ctx, height, now)
+}
+
+// TrustedLightBlock returns a light block trusted by the replica, the latest
+// one if height is 0.
+func (r *Replica) TrustedLightBlock(height int64) (*cmttypes.LightBlock, error) {
+	r.mtx.Lock()
+	defer r.mtx.Unlock()
+	return r.client.TrustedLightBlock(height)
+}
@@ -0,0 +1,196 @@
+package verifier
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// VerificationEvent is a verified transition, accepted or rejected, as
+// notified to the ReportHooks of a server.
+type VerificationEvent struct {
+	Time   time.Time
+	Method string
+	Report *VerificationReport
+	// ErrorClass classifies the error of a rejected transition as in the
+	// logs of the server, empty if accepted.
+	ErrorClass string
+}
+
+// ReportHook is notified of every verified transition, e.g. to feed external
+// systems such as risk engines or dashboards. Notify is called on the path
+// of the request and must not block.
+type ReportHook interface {
+	Notify(event VerificationEvent)
+}
+
+// ReportEvents selects the transitions notified by a ReportWebhook.
+type ReportEvents string
+
+const (
+	// ReportAll notifies every transition.
+	ReportAll ReportEvents = "all"
+	// ReportAccepted notifies the accepted transitions.
+	ReportAccepted ReportEvents = "accepted"
+	// ReportRejected notifies the rejected transitions.
+	ReportRejected ReportEvents = "rejected"
+)
+
+// ParseReportEvents parses a selection of the notified transitions.
+func ParseReportEvents(s string) (ReportEvents, error) {
+	switch e := ReportEvents(s); e {
+	case ReportAll, ReportAccepted, ReportRejected:
+		return e, nil
+	default:
+		return "", fmt.Errorf("unknown report events %q, expected %s, %s or %s", s, ReportAll, ReportAccepted, ReportRejected)
+	}
+}
+
+func (e ReportEvents) selects(verified bool) bool {
+	switch e {
+	case ReportAccepted:
+		return verified
+	case ReportRejected:
+		return !verified
+	default:
+		return true
+	}
+}
+
+// reportWebhookBody is the body posted by a ReportWebhook, the report being
+// rendered as by the REST API.
+type reportWebhookBody struct {
+	Time       time.Time              `json:"time"`
+	Method     string                 `json:"method"`
+	ErrorClass string                 `json:"error_class,omitempty"`
+	Report     restVerificationReport `json:"report"`
+}
+
+// ReportWebhook posts the verified transitions as JSON to an URL, e.g. for a
+// risk engine or a dashboard consuming the verdicts without linking the
+// verifier:
+//
+//	{"time": ..., "method": ..., "error_class": ..., "report": {...}}
+//
+// the report being rendered as by the REST API. The transitions are queued
+// and posted in order by a single goroutine, so that a slow or unreachable
+// endpoint never delays the verification: a transition is dropped once the
+// queue is full, or after MaxAttempts failed posts, a post failing unless
+// answered with a 2xx status.
+type ReportWebhook struct {
+	URL    string
+	Events ReportEvents
+	// Client posts the reports, with a timeout of 10 seconds if nil.
+	Client *http.Client
+	// MaxAttempts bounds the posts of a report, 3 if zero, retried after
+	// one second, then two and so on.
+	MaxAttempts int
+
+	queue   chan VerificationEvent
+	dropped atomic.Uint64
+	closed  sync.Once
+	done    chan struct{}
+}
+
+var _ ReportHook = (*ReportWebhook)(nil)
+
+// NewReportWebhook creates a webhook notifying the selected transitions to an
+// URL, queuing up to queueSize of them, and starts delivering them.
+func NewReportWebhook(url string, events ReportEvents, queueSize int) (*ReportWebhook, error) {
+	if _, err := ParseReportEvents(string(events)); err != nil {
+		return nil, err
+	}
+	if queueSize <= 0 {
+		return nil, fmt.Errorf("queue size must be positive, got %d", queueSize)
+	}
+	w := &ReportWebhook{
+		URL:    url,
+		Events: events,
+		queue:  make(chan VerificationEvent, queueSize),
+		done:   make(chan struct{}),
+	}
+	go w.deliver()
+	return w, nil
+}
+
+func (w *ReportWebhook) Notify(event VerificationEvent) {
+	if !w.Events.selects(event.Report.Verified) {
+		return
+	}
+	select {
+	case w.queue <- event:
+	default:
+		w.dropped.Add(1)
+	}
+}
+
+// Dropped returns the number of reports dropped so far, because the queue was
+// full or every post failed.
+func (w *ReportWebhook) Dropped() uint64 {
+	return w.dropped.Load()
+}
+
+// Close stops accepting reports and waits until the queued ones are
+// delivered or the context is done. Notify can't be called anymore.
+func (w *ReportWebhook) Close(ctx context.Context) error {
+	w.closed.Do(func() {
+		close(w.queue)
+	})
+	select {
+	case <-w.done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func (w *ReportWebhook) deliver() {
+	defer close(w.done)
+	client := w.Client
+	if client == nil {
+		client = &http.Client{Timeout: 10 * time.Second}
+	}
+	maxAttempts := w.MaxAttempts
+	if maxAttempts <= 0 {
+		maxAttempts = 3
+	}
+	for event := range w.queue {
+		body, err := json.Marshal(reportWebhookBody{
+			Time:       event.Time,
+			Method:     event.Method,
+			ErrorClass: event.ErrorClass,
+			Report:     newRESTVerificationReport(event.Report),
+		})
+		if err != nil {
+			w.dropped.Add(1)
+			continue
+		}
+		delivered := false
+		for attempt := 1; attempt <= maxAttempts && !delivered; attempt++ {
+			if attempt > 1 {
+				time.Sleep(time.Duration(attempt-1) * time.Second)
+			}
+			delivered = w.post(client, body) == nil
+		}
+		if !delivered {
+			w.dropped.Add(1)
+		}
+	}
+}
+
+func (w *ReportWebhook) post(client *http.Client, body []byte) error {
+	resp, err := client.Post(w.URL, "application/json", bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode/100 != 2 {
+		return fmt.Errorf("unexpected status %s", resp.Status)
+	}
+	return nil
+}
@@ -0,0 +1,289 @@
+// Package reputation scores the providers of a light client from their
+// latency, their error rate and the divergences they were caught in, and
+// persists the scores so that bad endpoints can be ranked last, or pruned,
+// across restarts.
+package reputation
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	dbm "github.com/cometbft/cometbft-db"
+	"github.com/cometbft/cometbft/light/provider"
+	cmttypes "github.com/cometbft/cometbft/types"
+)
+
+// latencyWeight is the weight of the latest latency in its moving average.
+const latencyWeight = 0.2
+
+// servedLimit is the number of served light blocks remembered per provider
+// to attribute divergences.
+const servedLimit = 256
+
+// Score is the track record of a provider.
+type Score struct {
+	Address  string `json:"address"`
+	Requests uint64 `json:"requests"`
+	// Failed requests, not counting the light blocks a provider doesn't
+	// have yet.
+	Errors uint64 `json:"errors"`
+	// Light client attacks in which the provider served the conflicting
+	// light block.
+	Divergences uint64 `json:"divergences"`
+	// Moving average of the latency of the successful requests.
+	Latency   time.Duration `json:"latency"`
+	LastError string        `json:"last_error,omitempty"`
+	UpdatedAt time.Time     `json:"updated_at"`
+	// Bytes received on the wire, and once decompressed.
+	WireBytes    uint64 `json:"wire_bytes"`
+	DecodedBytes uint64 `json:"decoded_bytes"`
+}
+
+// Value rates the provider between 0 and 1, higher being better. A provider
+// without history rates 0.5, the rate then follows its success rate, divided
+// by one plus its latency in seconds and by one plus its divergences.
+func (s Score) Value() float64 {
+	success := float64(s.Requests-s.Errors+1) / float64(s.Requests+2)
+	return success / (1 + s.Latency.Seconds()) / float64(1+s.Divergences)
+}
+
+// RatedScore is a score along with its value, as reported by Status.
+type RatedScore struct {
+	Score
+	Value float64 `json:"value"`
+}
+
+type served struct {
+	height int64
+	hash   []byte
+}
+
+// Tracker records the scores of the providers of a chain.
+type Tracker struct {
+	mtx     sync.Mutex
+	db      dbm.DB
+	chainID string
+	scores  map[string]*Score
+	dirty   map[string]bool
+	// The last light blocks served by each provider.
+	served map[string][]served
+}
+
+// NewTracker loads the scores of the providers of a chain persisted in a
+// database, e.g. the one of the light client store.
+func NewTracker(db dbm.DB, chainID string) (*Tracker, error) {
+	t := &Tracker{
+		db:      db,
+		chainID: chainID,
+		scores:  map[string]*Score{},
+		dirty:   map[string]bool{},
+		served:  map[string][]served{},
+	}
+	prefix := t.prefix()
+	it, err := dbm.IteratePrefix(db, prefix)
+	if err != nil {
+		return nil, err
+	}
+	defer it.Close()
+	for ; it.Valid(); it.Next() {
+		var score Score
+		if err := json.Unmarshal(it.Value(), &score); err != nil {
+			return nil, fmt.Errorf("invalid provider score %q: %w", it.Key(), err)
+		}
+		t.scores[score.Address] = &score
+	}
+	return t, it.Error()
+}
+
+func (t *Tracker) prefix() []byte {
+	return []byte("provider-score/" + t.chainID + "/")
+}
+
+// score returns the score of a provider, the lock being held.
+func (t *Tracker) score(address string) *Score {
+	score, ok := t.scores[address]
+	if !ok {
+		score = &Score{Address: address}
+		t.scores[address] = score
+	}
+	return score
+}
+
+// Score returns the score of a provider.
+func (t *Tracker) Score(address string) Score {
+	t.mtx.Lock()
+	defer t.mtx.Unlock()
+	return *t.score(address)
+}
+
+// Status returns the scores of every known provider, best first.
+func (t *Tracker) Status() []RatedScore {
+	t.mtx.Lock()
+	defer t.mtx.Unlock()
+	status := make([]RatedScore, 0, len(t.scores))
+	for _, score := range t.scores {
+		status = append(status, RatedScore{Score: *score, Value: score.Value()})
+	}
+	sort.SliceStable(status, func(i, j int) bool {
+		if status[i].Value != status[j].Value {
+			return status[i].Value > status[j].Value
+		}
+		return status[i].Address < status[j].Address
+	})
+	return status
+}
+
+// Select ranks the witnesses, best first. With a positive minimum score, a
+// primary scoring under it is swapped with the best witness reaching it, and
+// the witnesses under it are dropped as long as one witness remains.
+func (t *Tracker) Select(primary string, witnesses []string, minScore float64) (string, []string) {
+	value := func(address string) float64 {
+		return t.Score(address).Value()
+	}
+	ranked := append([]string{}, witnesses...)
+	sort.SliceStable(ranked, func(i, j int) bool {
+		return value(ranked[i]) > value(ranked[j])
+	})
+	if minScore <= 0 || len(ranked) == 0 {
+		return primary, ranked
+	}
+	if value(primary) < minScore && value(ranked[0]) >= minScore {
+		primary, ranked[0] = ranked[0], primary
+		sort.SliceStable(ranked, func(i, j int) bool {
+			return value(ranked[i]) > value(ranked[j])
+		})
+	}
+	for len(ranked) > 1 && value(ranked[len(ranked)-1]) < minScore {
+		ranked = ranked[:len(ranked)-1]
+	}
+	return primary, ranked
+}
+
+// Flush persists the scores updated since the last flush.
+func (t *Tracker) Flush() error {
+	t.mtx.Lock()
+	defer t.mtx.Unlock()
+	if len(t.dirty) == 0 {
+		return nil
+	}
+	batch := t.db.NewBatch()
+	defer batch.Close()
+	for address := range t.dirty {
+		bz, err := json.Marshal(t.scores[address])
+		if err != nil {
+			return err
+		}
+		if err := batch.Set(append(t.prefix(), address...), bz); err != nil {
+			return err
+		}
+	}
+	if err := batch.WriteSync(); err != nil {
+		return err
+	}
+	t.dirty = map[string]bool{}
+	return nil
+}
+
+func (t *Tracker) record(address string, lightBlock *cmttypes.LightBlock, latency time.Duration, err error) {
+	t.mtx.Lock()
+	defer t.mtx.Unlock()
+	// The light client cancels the requests to the other witnesses once one
+	// answered, this isn't the fault of the provider.
+	if errors.Is(err, context.Canceled) {
+		return
+	}
+	score := t.score(address)
+	score.Requests++
+	score.UpdatedAt = time.Now()
+	t.dirty[address] = true
+	switch {
+	case err == nil:
+		if score.Latency == 0 {
+			score.Latency = latency
+		} else {
+			score.Latency = time.Duration(latencyWeight*float64(latency) + (1-latencyWeight)*float64(score.Latency))
+		}
+		if lightBlock.SignedHeader != nil && lightBlock.Commit != nil {
+			s := append(t.served[address], served{height: lightBlock.Height, hash: lightBlock.Commit.BlockID.Hash})
+			if len(s) > servedLimit {
+				s = s[len(s)-servedLimit:]
+			}
+			t.served[address] = s
+		}
+	case errors.Is(err, provider.ErrLightBlockNotFound), errors.Is(err, provider.ErrHeightTooHigh):
+		// A lagging provider isn't failing.
+	default:
+		score.Errors++
+		score.LastError = err.Error()
+	}
+}
+
+// RecordTransfer records the size of a response served by a provider, e.g.
+// as observed by a transport.Transport.
+func (t *Tracker) RecordTransfer(address string, wireBytes, decodedBytes int64) {
+	t.mtx.Lock()
+	defer t.mtx.Unlock()
+	score := t.score(address)
+	score.WireBytes += uint64(wireBytes)
+	score.DecodedBytes += uint64(decodedBytes)
+	t.dirty[address] = true
+}
+
+// recordDivergence blames the providers that served the conflicting light
+// block of an attack.
+func (t *Tracker) recordDivergence(evidence cmttypes.Evidence) {
+	attack, ok := evidence.(*cmttypes.LightClientAttackEvidence)
+	if !ok || attack.ConflictingBlock == nil || attack.ConflictingBlock.Commit == nil {
+		return
+	}
+	height, hash := attack.ConflictingBlock.Height, attack.ConflictingBlock.Commit.BlockID.Hash
+	t.mtx.Lock()
+	defer t.mtx.Unlock()
+	for address, blocks := range t.served {
+		for _, block := range blocks {
+			if block.height == height && bytes.Equal(block.hash, hash) {
+				score := t.score(address)
+				score.Divergences++
+				score.UpdatedAt = time.Now()
+				t.dirty[address] = true
+				break
+			}
+		}
+	}
+}
+
+// Wrap returns a provider recording the requests served by a provider in its
+// score.
+func (t *Tracker) Wrap(address string, p provider.Provider) provider.Provider {
+	return &tracked{Provider: p, tracker: t, address: address}
+}
+
+type tracked struct {
+	provider.Provider
+	tracker *Tracker
+	address string
+}
+
+func (p *tracked) LightBlock(ctx context.Context, height int64) (*cmttypes.LightBlock, error) {
+	start := time.Now()
+	lightBlock, err := p.Provider.LightBlock(ctx, height)
+	p.tracker.record(p.address, lightBlock, time.Since(start), err)
+	return lightBlock, err
+}
+
+// ReportEvidence is called by the light client on the honest side of an
+// attack, with the light block served by the other side.
+func (p *tracked) ReportEvidence(ctx context.Context, evidence cmttypes.Evidence) error {
+	p.tracker.recordDivergence(evidence)
+	return p.Provider.ReportEvidence(ctx, evidence)
+}
+
+func (p *tracked) String() string {
+	return fmt.Sprint(p.Provider)
+}
@@ -0,0 +1,343 @@
+package verifier
+
+import (
+	"bytes"
+	"context"
+	_ "embed"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	cmtbytes "github.com/cometbft/cometbft/libs/bytes"
+	cmtjson "github.com/cometbft/cometbft/libs/json"
+	cmtmath "github.com/cometbft/cometbft/libs/math"
+	cmtproto "github.com/cometbft/cometbft/proto/tendermint/types"
+	cmttypes "github.com/cometbft/cometbft/types"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+//go:embed openapi.yaml
+var openAPISpec []byte
+
+// restLightBlock is a light block given either as a CometBFT JSON object, as
+// served by the RPC (hex encoded hashes, base64 encoded keys and signatures),
+// or as a string holding its protobuf encoding in base64 or 0x prefixed hex.
+type restLightBlock struct {
+	lightBlock *cmtproto.LightBlock
+}
+
+func (b *restLightBlock) UnmarshalJSON(bz []byte) error {
+	bz = bytes.TrimSpace(bz)
+	if bytes.Equal(bz, []byte("null")) {
+		return nil
+	}
+	if len(bz) > 0 && bz[0] == '"' {
+		var encoded string
+		if err := json.Unmarshal(bz, &encoded); err != nil {
+			return err
+		}
+		var (
+			raw []byte
+			err error
+		)
+		if hexEncoded, found := strings.CutPrefix(encoded, "0x"); found {
+			raw, err = hex.DecodeString(hexEncoded)
+		} else {
+			raw, err = base64.StdEncoding.DecodeString(encoded)
+		}
+		if err != nil {
+			return fmt.Errorf("invalid encoded light block: %w", err)
+		}
+		var pb cmtproto.LightBlock
+		if err := pb.Unmarshal(raw); err != nil {
+			return fmt.Errorf("invalid protobuf light block: %w", err)
+		}
+		b.lightBlock = &pb
+		return nil
+	}
+	var lightBlock cmttypes.LightBlock
+	if err := cmtjson.Unmarshal(bz, &lightBlock); err != nil {
+		return fmt.Errorf("invalid light block: %w", err)
+	}
+	if lightBlock.ValidatorSet != nil && lightBlock.ValidatorSet.Proposer == nil && len(lightBlock.ValidatorSet.Validators) > 0 {
+		// Required for the protobuf encoding.
+		lightBlock.ValidatorSet.GetProposer()
+	}
+	pb, err := lightBlock.ToProto()
+	if err != nil {
+		return fmt.Errorf("invalid light block: %w", err)
+	}
+	b.lightBlock = pb
+	return nil
+}
+
+type restVerificationOptions struct {
+	TrustingPeriod string     `json:"trusting_period,omitempty"`
+	MaxClockDrift  string     `json:"max_clock_drift,omitempty"`
+	TrustLevel     string     `json:"trust_level,omitempty"`
+	Legacy         bool       `json:"legacy,omitempty"`
+	Now            *time.Time `json:"now,omitempty"`
+}
+
+func (o *restVerificationOptions) toProto() (*VerificationOptions, error) {
+	if o == nil {
+		return nil, nil
+	}
+	options := &VerificationOptions{
+		Legacy: o.Legacy,
+		Now:    o.Now,
+	}
+	if o.TrustingPeriod != "" {
+		trustingPeriod, err := time.ParseDuration(o.TrustingPeriod)
+		if err != nil {
+			return nil, status.Errorf(codes.InvalidArgument, "invalid trusting_period: %s", err)
+		}
+		options.TrustingPeriod = &trustingPeriod
+	}
+	if o.MaxClockDrift != "" {
+		maxClockDrift, err := time.ParseDuration(o.MaxClockDrift)
+		if err != nil {
+			return nil, status.Errorf(codes.InvalidArgument, "invalid max_clock_drift: %s", err)
+		}
+		options.MaxClockDrift = &maxClockDrift
+	}
+	if o.TrustLevel != "" {
+		trustLevel, err := cmtmath.ParseFraction(o.TrustLevel)
+		if err != nil {
+			return nil, status.Errorf(codes.InvalidArgument, "invalid trust_level: %s", err)
+		}
+		options.TrustLevel = &Fraction{
+			Numerator:   trustLevel.Numerator,
+			Denominator: trustLevel.Denominator,
+		}
+	}
+	return options, nil
+}
+
+type restVerifyRequest struct {
+	Trusted   restLightBlock           `json:"trusted"`
+	Untrusted restLightBlock           `json:"untrusted"`
+	Options   *restVerificationOptions `json:"options,omitempty"`
+}
+
+type restVerifyBatchRequest struct {
+	Trusted   restLightBlock           `json:"trusted"`
+	Untrusted []restLightBlock         `json:"untrusted"`
+	Options   *restVerificationOptions `json:"options,omitempty"`
+}
+
+// restVerificationReport renders the hashes as hex, as the CometBFT RPC does.
+type restVerificationReport struct {
+	Verified        bool              `json:"verified"`
+	Adjacent        bool              `json:"adjacent"`
+	ChainID         string            `json:"chain_id"`
+	TrustedHeight   int64             `json:"trusted_height"`
+	TrustedHash     cmtbytes.HexBytes `json:"trusted_hash"`
+	UntrustedHeight int64             `json:"untrusted_height"`
+	UntrustedHash   cmtbytes.HexBytes `json:"untrusted_hash"`
+	Error           string            `json:"error,omitempty"`
+}
+
+func newRESTVerificationReport(report *VerificationReport) restVerificationReport {
+	return restVerificationReport{
+		Verified:        report.Verified,
+		Adjacent:        report.Adjacent,
+		ChainID:         report.ChainId,
+		TrustedHeight:   report.TrustedHeight,
+		TrustedHash:     report.TrustedHash,
+		UntrustedHeight: report.UntrustedHeight,
+		UntrustedHash:   report.UntrustedHash,
+		Error:           report.Error,
+	}
+}
+
+type restVerifyResponse struct {
+	Report restVerificationReport `json:"report"`
+}
+
+type restVerifyBatchResponse struct {
+	Reports  []restVerificationReport `json:"reports"`
+	Verified bool                     `json:"verified"`
+}
+
+type restStatusResponse struct {
+	Version        string                  `json:"version"`
+	DefaultOptions restVerificationOptions `json:"default_options"`
+	MaxBatchSize   uint32                  `json:"max_batch_size"`
+	ChainID        string                  `json:"chain_id,omitempty"`
+}
+
+func newRESTStatusResponse(res *StatusResponse) restStatusResponse {
+	response := restStatusResponse{
+		Version:      res.Version,
+		MaxBatchSize: res.MaxBatchSize,
+		ChainID:      res.ChainId,
+	}
+	if options := res.DefaultOptions; options != nil {
+		if options.TrustingPeriod != nil {
+			response.DefaultOptions.TrustingPeriod = options.TrustingPeriod.String()
+		}
+		if options.MaxClockDrift != nil {
+			response.DefaultOptions.MaxClockDrift = options.MaxClockDrift.String()
+		}
+		if options.TrustLevel != nil {
+			response.DefaultOptions.TrustLevel = fmt.Sprintf("%d/%d", options.TrustLevel.Numerator, options.TrustLevel.Denominator)
+		}
+	}
+	return response
+}
+
+type restError struct {
+	Code    string `json:"code"`
+	Message string `json:"message"`
+}
+
+// NewRESTHandler returns an http.Handler serving the verifier as a JSON API
+// described by the OpenAPI specification served on /openapi.yaml:
+//
+//	POST /v1/verify
+//	POST /v1/verify_non_adjacent
+//	POST /v1/verify_batch
+//	GET  /v1/status
+//
+// The middlewares wrap the API routes, outermost first, the specification is
+// always served.
+func NewRESTHandler(server VerifierServer, maxBodySize int64, middlewares ...func(http.Handler) http.Handler) http.Handler {
+	api := http.NewServeMux()
+	api.HandleFunc("/v1/verify", restHandler(maxBodySize, func(ctx context.Context, req *restVerifyRequest) (any, error) {
+		options, err := req.Options.toProto()
+		if err != nil {
+			return nil, err
+		}
+		res, err := server.Verify(ctx, &VerifyRequest{
+			Trusted:   req.Trusted.lightBlock,
+			Untrusted: req.Untrusted.lightBlock,
+			Options:   options,
+		})
+		if err != nil {
+			return nil, err
+		}
+		return restVerifyResponse{Report: newRESTVerificationReport(res.Report)}, nil
+	}))
+	api.HandleFunc("/v1/verify_non_adjacent", restHandler(maxBodySize, func(ctx context.Context, req *restVerifyRequest) (any, error) {
+		options, err := req.Options.toProto()
+		if err != nil {
+			return nil, err
+		}
+		res, err := server.VerifyNonAdjacent(ctx, &VerifyRequest{
+			Trusted:   req.Trusted.lightBlock,
+			Untrusted: req.Untrusted.lightBlock,
+			Options:   options,
+		})
+		if err != nil {
+			return nil, err
+		}
+		return restVerifyResponse{Report: newRESTVerificationReport(res.Report)}, nil
+	}))
+	api.HandleFunc("/v1/verify_batch", restHandler(maxBodySize, func(ctx context.Context, req *restVerifyBatchRequest) (any, error) {
+		options, err := req.Options.toProto()
+		if err != nil {
+			return nil, err
+		}
+		untrusted := make([]*cmtproto.LightBlock, len(req.Untrusted))
+		for i, lightBlock := range req.Untrusted {
+			untrusted[i] = lightBlock.lightBlock
+		}
+		res, err := server.VerifyBatch(ctx, &VerifyBatchRequest{
+			Trusted:   req.Trusted.lightBlock,
+			Untrusted: untrusted,
+			Options:   options,
+		})
+		if err != nil {
+			return nil, err
+		}
+		reports := make([]restVerificationReport, len(res.Reports))
+		for i, report := range res.Reports {
+			reports[i] = newRESTVerificationReport(report)
+		}
+		return restVerifyBatchResponse{Reports: reports, Verified: res.Verified}, nil
+	}))
+	api.HandleFunc("/v1/status", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			w.Header().Set("Allow", http.MethodGet)
+			writeJSON(w, http.StatusMethodNotAllowed, restError{
+				Code:    "MethodNotAllowed",
+				Message: fmt.Sprintf("method %s not allowed", r.Method),
+			})
+			return
+		}
+		res, err := server.Status(r.Context(), &StatusRequest{})
+		if err != nil {
+			writeError(w, err)
+			return
+		}
+		writeJSON(w, http.StatusOK, newRESTStatusResponse(res))
+	})
+
+	var handler http.Handler = api
+	for i := len(middlewares) - 1; i >= 0; i-- {
+		handler = middlewares[i](handler)
+	}
+
+	mux := http.NewServeMux()
+	mux.Handle("/v1/", handler)
+	mux.HandleFunc("/openapi.yaml", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/yaml")
+		_, _ = w.Write(openAPISpec)
+	})
+	return mux
+}
+
+func restHandler[Req any](maxBodySize int64, handle func(context.Context, *Req) (any, error)) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			w.Header().Set("Allow", http.MethodPost)
+			writeJSON(w, http.StatusMethodNotAllowed, restError{
+				Code:    "MethodNotAllowed",
+				Message: fmt.Sprintf("method %s not allowed", r.Method),
+			})
+			return
+		}
+		var req Req
+		decoder := json.NewDecoder(http.MaxBytesReader(w, r.Body, maxBodySize))
+		decoder.DisallowUnknownFields()
+		if err := decoder.Decode(&req); err != nil {
+			writeError(w, status.Errorf(codes.InvalidArgument, "invalid request: %s", err))
+			return
+		}
+		res, err := handle(context.WithValue(r.Context(), remoteAddrKey{}, r.RemoteAddr), &req)
+		if err != nil {
+			writeError(w, err)
+			return
+		}
+		writeJSON(w, http.StatusOK, res)
+	}
+}
+
+func writeError(w http.ResponseWriter, err error) {
+	s := status.Convert(err)
+	httpStatus := http.StatusInternalServerError
+	switch s.Code() {
+	case codes.InvalidArgument:
+		httpStatus = http.StatusBadRequest
+	case codes.Unauthenticated:
+		httpStatus = http.StatusUnauthorized
+	case codes.ResourceExhausted:
+		httpStatus = http.StatusTooManyRequests
+	}
+	writeJSON(w, httpStatus, restError{
+		Code:    s.Code().String(),
+		Message: s.Message(),
+	})
+}
+
+func writeJSON(w http.ResponseWriter, httpStatus int, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(httpStatus)
+	_ = json.NewEncoder(w).Encode(v)
+}
@@ -0,0 +1,577 @@
+package verifier
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"runtime"
+	"sync"
+	"time"
+
+	cmtmath "github.com/cometbft/cometbft/libs/math"
+	"github.com/cometbft/cometbft/light"
+	cmtproto "github.com/cometbft/cometbft/proto/tendermint/types"
+	cmttypes "github.com/cometbft/cometbft/types"
+	"github.com/cosmos/cosmos-sdk/version"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	otelcodes "go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+var _ VerifierServer = (*Server)(nil)
+
+// Spans are recorded through the global OpenTelemetry tracer provider, a no-op
+// unless the program embedding the server installs one.
+var tracer = otel.Tracer("union/verifier")
+
+func endSpan(span trace.Span, err error) {
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(otelcodes.Error, err.Error())
+	}
+	span.End()
+}
+
+// Config holds the verification defaults applied to the requests not
+// overriding them, along with the limits enforced on every request.
+type Config struct {
+	// ChainID binds the server to a chain, light blocks of other chains are
+	// rejected with ErrChainIDMismatch. Later revisions of a chain id in
+	// revision format are accepted, see CheckChainID. Any chain is accepted
+	// if empty.
+	ChainID        string
+	TrustingPeriod time.Duration
+	MaxClockDrift  time.Duration
+	// UnbondingPeriod of the verified chains, the trusting periods must be
+	// shorter. DefaultUnbondingPeriod if zero.
+	UnbondingPeriod time.Duration
+	TrustLevel      cmtmath.Fraction
+	// MaxBatchSize is the maximum number of untrusted light blocks of a
+	// VerifyBatch request.
+	MaxBatchSize int
+	// Budget bounds the resources of every request.
+	Budget Budget
+	// Logger receives a record per verified transition, at the debug level
+	// when verified and at the warn level otherwise. Nothing is logged if
+	// nil.
+	Logger *slog.Logger
+	// AuditLog records every verified transition if set, a request fails if
+	// its transitions can't be recorded.
+	AuditLog *AuditLog
+	// ReportHooks are notified of every verified transition, once recorded
+	// by the audit log.
+	ReportHooks []ReportHook
+	// Clock gives the verification time of the requests not setting it,
+	// the system clock if nil.
+	Clock Clock
+	// CheckProposer rejects the cryptographically verified headers whose
+	// proposer isn't elected by the proposer rotation, see CheckProposer.
+	CheckProposer bool
+	// Strict rejects the cryptographically verified headers failing the
+	// configured strict checks, see CheckStrict.
+	Strict Strict
+	// CheckContinuity rejects the adjacent headers that don't build on their
+	// trusted header, such as within the adjacent sequences of VerifyBatch,
+	// see CheckContinuity.
+	CheckContinuity bool
+	// DetectLegacy verifies every transition in the mode of the hashing
+	// scheme of its untrusted light block, ignoring the legacy option of the
+	// requests. Otherwise, requests whose legacy option doesn't match the
+	// scheme are rejected.
+	DetectLegacy bool
+	// Transition is the window within which the scheme of the light blocks
+	// is detected, the light blocks outside of it being rejected unless they
+	// use the scheme of their side, see DetectLegacy.
+	Transition Transition
+	// Policies are run in order on every cryptographically verified
+	// transition, the untrusted header being rejected with
+	// ErrPolicyViolation unless they all accept it.
+	Policies []Policy
+	// SignBytes builds the bytes signed by the votes of the verified chain,
+	// in either mode, for forks whose votes differ from the ones of Union.
+	// The canonical votes of the mode if nil, see CanonicalSignBytes.
+	SignBytes SignBytes
+}
+
+// Clock tells the current time, tests use a controllable implementation such
+// as lighttest.Clock to check expiry and clock drift without sleeping.
+type Clock interface {
+	Now() time.Time
+}
+
+// DefaultConfig matches the defaults of the light client.
+func DefaultConfig() Config {
+	return Config{
+		TrustingPeriod:  168 * time.Hour,
+		MaxClockDrift:   10 * time.Second,
+		UnbondingPeriod: DefaultUnbondingPeriod,
+		TrustLevel:      light.DefaultTrustLevel,
+		MaxBatchSize:    100,
+		Transition:      AnyHeight,
+	}
+}
+
+// Server implements the Verifier gRPC service on top of the light client
+// verification functions.
+type Server struct {
+	config Config
+}
+
+func NewServer(config Config) (*Server, error) {
+	trustLevel, err := ValidateTrustLevel(config.TrustLevel)
+	if err != nil {
+		return nil, err
+	}
+	config.TrustLevel = trustLevel
+	if config.UnbondingPeriod == 0 {
+		config.UnbondingPeriod = DefaultUnbondingPeriod
+	}
+	if err := ValidateBounds(config.TrustingPeriod, config.MaxClockDrift, config.UnbondingPeriod); err != nil {
+		return nil, err
+	}
+	if config.MaxBatchSize <= 0 {
+		return nil, fmt.Errorf("max batch size must be positive, got %d", config.MaxBatchSize)
+	}
+	return &Server{config: config}, nil
+}
+
+// Verify implements VerifierServer.Verify
+func (s *Server) Verify(ctx context.Context, req *VerifyRequest) (_ *VerifyResponse, err error) {
+	ctx, span := tracer.Start(ctx, "Verify")
+	defer func() { endSpan(span, err) }()
+
+	ctx, cancel := s.config.Budget.withDeadline(ctx)
+	defer cancel()
+
+	params, err := s.params(req.Options)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.config.Budget.check(ctx, req.Trusted, req.Untrusted); err != nil {
+		return nil, err
+	}
+	trusted, untrusted, err := decodeLightBlocks(ctx, req.Trusted, req.Untrusted, s.config.Transition)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.checkChainID(trusted, untrusted); err != nil {
+		return nil, err
+	}
+	report, err := s.verify(ctx, "Verify", trusted, untrusted, params)
+	if err != nil {
+		return nil, err
+	}
+	return &VerifyResponse{Report: report}, nil
+}
+
+// VerifyNonAdjacent implements VerifierServer.VerifyNonAdjacent
+func (s *Server) VerifyNonAdjacent(ctx context.Context, req *VerifyRequest) (_ *VerifyResponse, err error) {
+	ctx, span := tracer.Start(ctx, "VerifyNonAdjacent")
+	defer func() { endSpan(span, err) }()
+
+	ctx, cancel := s.config.Budget.withDeadline(ctx)
+	defer cancel()
+
+	params, err := s.params(req.Options)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.config.Budget.check(ctx, req.Trusted, req.Untrusted); err != nil {
+		return nil, err
+	}
+	trusted, untrusted, err := decodeLightBlocks(ctx, req.Trusted, req.Untrusted, s.config.Transition)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.checkChainID(trusted, untrusted); err != nil {
+		return nil, err
+	}
+	if untrusted.Height == trusted.Height+1 {
+		return nil, status.Errorf(codes.InvalidArgument, "light blocks %d and %d are adjacent", trusted.Height, untrusted.Height)
+	}
+	report, err := s.verify(ctx, "VerifyNonAdjacent", trusted, untrusted, params)
+	if err != nil {
+		return nil, err
+	}
+	return &VerifyResponse{Report: report}, nil
+}
+
+// VerifyBatch implements VerifierServer.VerifyBatch
+func (s *Server) VerifyBatch(ctx context.Context, req *VerifyBatchRequest) (_ *VerifyBatchResponse, err error) {
+	ctx, span := tracer.Start(ctx, "VerifyBatch", trace.WithAttributes(attribute.Int("batch_size", len(req.Untrusted))))
+	defer func() { endSpan(span, err) }()
+
+	ctx, cancel := s.config.Budget.withDeadline(ctx)
+	defer cancel()
+
+	params, err := s.params(req.Options)
+	if err != nil {
+		return nil, err
+	}
+	if len(req.Untrusted) == 0 {
+		return nil, status.Error(codes.InvalidArgument, "at least one untrusted light block is required")
+	}
+	if len(req.Untrusted) > s.config.MaxBatchSize {
+		return nil, status.Errorf(codes.InvalidArgument, "batch of %d light blocks exceeds the maximum of %d", len(req.Untrusted), s.config.MaxBatchSize)
+	}
+	if err := s.config.Budget.check(ctx, req.Trusted, req.Untrusted...); err != nil {
+		return nil, err
+	}
+	trusted, untrusted, err := decodeBatch(ctx, req.Trusted, req.Untrusted, s.config.Transition)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.checkChainID(append([]decodedLightBlock{trusted}, untrusted...)...); err != nil {
+		return nil, err
+	}
+
+	res := &VerifyBatchResponse{Verified: true}
+	for _, lightBlock := range untrusted {
+		report, err := s.verify(ctx, "VerifyBatch", trusted, lightBlock, params)
+		if err != nil {
+			return nil, err
+		}
+		res.Reports = append(res.Reports, report)
+		if !report.Verified {
+			res.Verified = false
+			break
+		}
+		trusted = lightBlock
+	}
+	return res, nil
+}
+
+// Status implements VerifierServer.Status
+func (s *Server) Status(ctx context.Context, req *StatusRequest) (*StatusResponse, error) {
+	trustingPeriod, maxClockDrift := s.config.TrustingPeriod, s.config.MaxClockDrift
+	return &StatusResponse{
+		Version: version.Version,
+		ChainId: s.config.ChainID,
+		DefaultOptions: &VerificationOptions{
+			TrustingPeriod: &trustingPeriod,
+			MaxClockDrift:  &maxClockDrift,
+			TrustLevel: &Fraction{
+				Numerator:   s.config.TrustLevel.Numerator,
+				Denominator: s.config.TrustLevel.Denominator,
+			},
+		},
+		MaxBatchSize: uint32(s.config.MaxBatchSize),
+	}, nil
+}
+
+// checkChainID rejects the light blocks of other chains than the bound one.
+func (s *Server) checkChainID(lightBlocks ...decodedLightBlock) error {
+	if s.config.ChainID == "" {
+		return nil
+	}
+	for _, lightBlock := range lightBlocks {
+		if err := CheckChainID(s.config.ChainID, lightBlock.ChainID); err != nil {
+			return status.Error(codes.InvalidArgument, err.Error())
+		}
+	}
+	return nil
+}
+
+func (s *Server) now() time.Time {
+	if s.config.Clock != nil {
+		return s.config.Clock.Now()
+	}
+	return time.Now()
+}
+
+type verificationParams struct {
+	trustingPeriod time.Duration
+	maxClockDrift  time.Duration
+	trustLevel     cmtmath.Fraction
+	legacy         bool
+	now            time.Time
+}
+
+func (s *Server) params(options *VerificationOptions) (verificationParams, error) {
+	params := verificationParams{
+		trustingPeriod: s.config.TrustingPeriod,
+		maxClockDrift:  s.config.MaxClockDrift,
+		trustLevel:     s.config.TrustLevel,
+		now:            s.now(),
+	}
+	if options == nil {
+		return params, nil
+	}
+	if options.TrustingPeriod != nil {
+		params.trustingPeriod = *options.TrustingPeriod
+	}
+	if options.MaxClockDrift != nil {
+		params.maxClockDrift = *options.MaxClockDrift
+	}
+	if err := ValidateBounds(params.trustingPeriod, params.maxClockDrift, s.config.UnbondingPeriod); err != nil {
+		return params, status.Error(codes.InvalidArgument, err.Error())
+	}
+	if options.TrustLevel != nil {
+		trustLevel := cmtmath.Fraction{
+			Numerator:   options.TrustLevel.Numerator,
+			Denominator: options.TrustLevel.Denominator,
+		}
+		trustLevel, err := ValidateTrustLevel(trustLevel)
+		if err != nil {
+			return params, status.Error(codes.InvalidArgument, err.Error())
+		}
+		params.trustLevel = trustLevel
+	}
+	if options.Now != nil {
+		params.now = *options.Now
+	}
+	params.legacy = options.Legacy
+	return params, nil
+}
+
+// verify checks a transition, the returned error is only set if the request
+// ran out of time or the transition couldn't be recorded in the audit log,
+// verification failures are part of the report.
+func (s *Server) verify(ctx context.Context, method string, trusted, untrusted decodedLightBlock, params verificationParams) (*VerificationReport, error) {
+	legacy, err := LegacyMode(trusted.legacy, untrusted.legacy)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "light blocks %d and %d: %s", trusted.Height, untrusted.Height, err)
+	}
+	if !s.config.DetectLegacy && params.legacy != legacy {
+		return nil, status.Errorf(codes.InvalidArgument, "light block %d uses the %s hashes, the legacy option must be %t", untrusted.Height, SchemeName(legacy), legacy)
+	}
+	params.legacy = legacy
+
+	_, span := tracer.Start(ctx, "verify", trace.WithAttributes(
+		attribute.String("chain_id", trusted.ChainID),
+		attribute.Int64("trusted_height", trusted.Height),
+		attribute.Int64("untrusted_height", untrusted.Height),
+		attribute.Int("validators", len(untrusted.ValidatorSet.Validators)),
+		attribute.Bool("legacy", params.legacy),
+	))
+	defer span.End()
+
+	if err := spent(ctx); err != nil {
+		return nil, err
+	}
+	verify := NewVerifyFunc(params.legacy, s.config.SignBytes)
+	// Non adjacent headers are trusted from a fraction of the voting power
+	// of the trusted set.
+	if untrusted.Height != trusted.Height+1 {
+		if err := CheckTrustMath(trusted.ValidatorSet, params.trustLevel); err != nil {
+			return nil, status.Errorf(codes.InvalidArgument, "trusted light block %d: %s", trusted.Height, err)
+		}
+	}
+	err = verify(
+		trusted.SignedHeader,
+		trusted.ValidatorSet,
+		untrusted.SignedHeader,
+		untrusted.ValidatorSet,
+		params.trustingPeriod,
+		params.now,
+		params.maxClockDrift,
+		params.trustLevel,
+	)
+	if err == nil && s.config.Strict.Enabled() {
+		err = CheckStrict(untrusted.LightBlock, s.config.Strict)
+	}
+	if err == nil && s.config.CheckContinuity && untrusted.Height == trusted.Height+1 {
+		err = CheckContinuity(trusted.LightBlock, untrusted.LightBlock, trusted.legacy)
+	}
+	if err == nil && s.config.CheckProposer {
+		err = CheckProposer(trusted.LightBlock, untrusted.LightBlock)
+	}
+	if err == nil {
+		err = checkPolicies(ctx, s.config.Policies, trusted.LightBlock, untrusted.LightBlock)
+	}
+	report := &VerificationReport{
+		Verified:        err == nil,
+		Adjacent:        untrusted.Height == trusted.Height+1,
+		ChainId:         trusted.ChainID,
+		TrustedHeight:   trusted.Height,
+		TrustedHash:     LightBlockHash(trusted.LightBlock, trusted.legacy),
+		UntrustedHeight: untrusted.Height,
+		UntrustedHash:   LightBlockHash(untrusted.LightBlock, untrusted.legacy),
+	}
+	if err != nil {
+		report.Error = err.Error()
+		span.SetStatus(otelcodes.Error, report.Error)
+	}
+	span.SetAttributes(attribute.Bool("verified", report.Verified))
+
+	if s.config.Logger != nil {
+		level, msg := slog.LevelDebug, "header verified"
+		attrs := []slog.Attr{
+			slog.String("chain_id", report.ChainId),
+			slog.Int64("trusted_height", report.TrustedHeight),
+			slog.Int64("untrusted_height", report.UntrustedHeight),
+			slog.Bool("adjacent", report.Adjacent),
+			slog.Bool("legacy", params.legacy),
+		}
+		if err != nil {
+			level, msg = slog.LevelWarn, "header verification failed"
+			attrs = append(attrs, slog.String("error_class", errorClass(err)), slog.String("error", report.Error))
+		}
+		s.config.Logger.LogAttrs(ctx, level, msg, attrs...)
+	}
+
+	if s.config.AuditLog != nil {
+		entry := &AuditEntry{
+			Time:                    s.now().UTC(),
+			Method:                  method,
+			ChainID:                 report.ChainId,
+			TrustedHeight:           report.TrustedHeight,
+			TrustedHash:             report.TrustedHash,
+			TrustedValidatorsHash:   trusted.ValidatorsHash,
+			UntrustedHeight:         report.UntrustedHeight,
+			UntrustedHash:           report.UntrustedHash,
+			UntrustedValidatorsHash: untrusted.ValidatorsHash,
+			TrustingPeriod:          params.trustingPeriod.String(),
+			MaxClockDrift:           params.maxClockDrift.String(),
+			TrustLevel:              params.trustLevel.String(),
+			Legacy:                  params.legacy,
+			Now:                     params.now.UTC(),
+			Verified:                report.Verified,
+			Adjacent:                report.Adjacent,
+			Error:                   report.Error,
+			Client:                  auditClient(ctx),
+		}
+		if err := s.config.AuditLog.Record(entry); err != nil {
+			return nil, status.Errorf(codes.Internal, "can't record the verification: %s", err)
+		}
+	}
+
+	if len(s.config.ReportHooks) > 0 {
+		event := VerificationEvent{Time: s.now().UTC(), Method: method, Report: report}
+		if err != nil {
+			event.ErrorClass = errorClass(err)
+		}
+		for _, hook := range s.config.ReportHooks {
+			hook.Notify(event)
+		}
+	}
+	return report, nil
+}
+
+// errorClass maps a verification error to a stable identifier suitable for
+// filtering the logs.
+func errorClass(err error) string {
+	switch {
+	case errors.As(err, &light.ErrOldHeaderExpired{}):
+		return "expired"
+	case errors.As(err, &light.ErrNewValSetCantBeTrusted{}):
+		return "untrusted_validator_set"
+	case errors.As(err, &light.ErrInvalidHeader{}):
+		return "invalid_header"
+	case errors.Is(err, ErrImplausibleCommit):
+		return "implausible_commit"
+	case errors.Is(err, ErrDiscontinuity):
+		return "discontinuity"
+	case errors.Is(err, ErrInvalidProposer):
+		return "invalid_proposer"
+	case errors.Is(err, ErrPolicyViolation):
+		return "policy"
+	default:
+		return "other"
+	}
+}
+
+// decodedLightBlock is a decoded light block along with its hashing scheme.
+type decodedLightBlock struct {
+	*cmttypes.LightBlock
+	legacy bool
+}
+
+func decodeLightBlocks(ctx context.Context, trusted, untrusted *cmtproto.LightBlock, transition Transition) (_ decodedLightBlock, _ decodedLightBlock, err error) {
+	_, span := tracer.Start(ctx, "decode")
+	defer func() { endSpan(span, err) }()
+
+	trustedLightBlock, err := decodeLightBlock("trusted", trusted, transition)
+	if err != nil {
+		return decodedLightBlock{}, decodedLightBlock{}, err
+	}
+	untrustedLightBlock, err := decodeLightBlock("untrusted", untrusted, transition)
+	if err != nil {
+		return decodedLightBlock{}, decodedLightBlock{}, err
+	}
+	return trustedLightBlock, untrustedLightBlock, nil
+}
+
+func decodeBatch(ctx context.Context, trusted *cmtproto.LightBlock, untrusted []*cmtproto.LightBlock, transition Transition) (_ decodedLightBlock, _ []decodedLightBlock, err error) {
+	_, span := tracer.Start(ctx, "decode")
+	defer func() { endSpan(span, err) }()
+
+	trustedLightBlock, err := decodeLightBlock("trusted", trusted, transition)
+	if err != nil {
+		return decodedLightBlock{}, nil, err
+	}
+	untrustedLightBlocks, err := decodeUntrustedLightBlocks(ctx, untrusted, transition)
+	if err != nil {
+		return decodedLightBlock{}, nil, err
+	}
+	return trustedLightBlock, untrustedLightBlocks, nil
+}
+
+// decodeUntrustedLightBlocks decodes and validates the light blocks of a batch
+// in parallel. Each light block is checked independently, including its
+// header hash against the commit, leaving only the trust chain to be verified
+// serially. The error of the first invalid light block is returned.
+func decodeUntrustedLightBlocks(ctx context.Context, pbs []*cmtproto.LightBlock, transition Transition) ([]decodedLightBlock, error) {
+	var (
+		lightBlocks = make([]decodedLightBlock, len(pbs))
+		errs        = make([]error, len(pbs))
+		indices     = make(chan int)
+		wg          sync.WaitGroup
+	)
+	workers := min(runtime.GOMAXPROCS(0), len(pbs))
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range indices {
+				if errs[i] = spent(ctx); errs[i] != nil {
+					continue
+				}
+				lightBlocks[i], errs[i] = decodeLightBlock(fmt.Sprintf("untrusted[%d]", i), pbs[i], transition)
+			}
+		}()
+	}
+	for i := range pbs {
+		indices <- i
+	}
+	close(indices)
+	wg.Wait()
+
+	for _, err := range errs {
+		if err != nil {
+			return nil, err
+		}
+	}
+	return lightBlocks, nil
+}
+
+// decodeLightBlock decodes and validates a light block under the hashing
+// scheme it is consistent with within the transition.
+func decodeLightBlock(name string, pb *cmtproto.LightBlock, transition Transition) (decodedLightBlock, error) {
+	if pb == nil {
+		return decodedLightBlock{}, status.Errorf(codes.InvalidArgument, "%s: missing light block", name)
+	}
+	// Decoding computes the total voting power, which panics past the
+	// CometBFT bound.
+	if err := CheckVotingPowers(pb.ValidatorSet); err != nil {
+		return decodedLightBlock{}, status.Errorf(codes.InvalidArgument, "%s: %s", name, err)
+	}
+	lightBlock, err := cmttypes.LightBlockFromProto(pb)
+	if err != nil {
+		return decodedLightBlock{}, status.Errorf(codes.InvalidArgument, "%s: %s", name, err)
+	}
+	if lightBlock.SignedHeader == nil || lightBlock.ValidatorSet == nil {
+		return decodedLightBlock{}, status.Errorf(codes.InvalidArgument, "%s: light block must contain both a signed header and a validator set", name)
+	}
+	// Hostile light blocks are rejected before being hashed, which panics on
+	// malformed keys and hashes.
+	legacy, err := DetectLegacy(lightBlock, transition)
+	if err != nil {
+		return decodedLightBlock{}, status.Errorf(codes.InvalidArgument, "%s: %s", name, err)
+	}
+	return decodedLightBlock{LightBlock: lightBlock, legacy: legacy}, nil
+}
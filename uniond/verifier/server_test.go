@@ -0,0 +1,182 @@
+package verifier_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	cmtproto "github.com/cometbft/cometbft/proto/tendermint/types"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"union/verifier"
+	"union/verifier/lighttest"
+)
+
+type hookFunc func(event verifier.VerificationEvent)
+
+func (f hookFunc) Notify(event verifier.VerificationEvent) {
+	f(event)
+}
+
+func TestServerClock(t *testing.T) {
+	chain, err := lighttest.NewChain("clock-1", 4, 1)
+	require.NoError(t, err)
+	trusted, err := chain.LightBlock(1, false)
+	require.NoError(t, err)
+	untrusted, err := chain.LightBlock(2, false)
+	require.NoError(t, err)
+	trustedPb, err := trusted.ToProto()
+	require.NoError(t, err)
+	untrustedPb, err := untrusted.ToProto()
+	require.NoError(t, err)
+
+	clock := lighttest.NewClock(untrusted.Time.Add(time.Minute))
+	var events []verifier.VerificationEvent
+	config := verifier.DefaultConfig()
+	config.Clock = clock
+	config.ReportHooks = []verifier.ReportHook{hookFunc(func(event verifier.VerificationEvent) {
+		events = append(events, event)
+	})}
+	server, err := verifier.NewServer(config)
+	require.NoError(t, err)
+	verify := func() *verifier.VerificationReport {
+		res, err := server.Verify(context.Background(), &verifier.VerifyRequest{Trusted: trustedPb, Untrusted: untrustedPb})
+		require.NoError(t, err)
+		return res.Report
+	}
+
+	require.True(t, verify().Verified)
+	require.Len(t, events, 1)
+	require.Equal(t, clock.Now().UTC(), events[0].Time)
+
+	// The trusted header expires with the clock, not with the system time.
+	clock.Advance(config.TrustingPeriod)
+	report := verify()
+	require.False(t, report.Verified)
+	require.Len(t, events, 2)
+	require.Equal(t, clock.Now().UTC(), events[1].Time)
+	require.Equal(t, "expired", events[1].ErrorClass)
+}
+
+func TestVerifyBatchDecodeErrorOrder(t *testing.T) {
+	chain, err := lighttest.NewChain("batch-1", 4, 1)
+	require.NoError(t, err)
+	trusted, err := chain.LightBlock(1, false)
+	require.NoError(t, err)
+	trustedPb, err := trusted.ToProto()
+	require.NoError(t, err)
+	var untrusted []*cmtproto.LightBlock
+	for height := int64(2); height <= 33; height++ {
+		lightBlock, err := chain.LightBlock(height, false)
+		require.NoError(t, err)
+		pb, err := lightBlock.ToProto()
+		require.NoError(t, err)
+		untrusted = append(untrusted, pb)
+	}
+	// A light block whose header doesn't match its commit.
+	untrusted[7].SignedHeader.Header.AppHash = []byte("tampered")
+	untrusted[3] = nil
+	untrusted[20] = nil
+
+	config := verifier.DefaultConfig()
+	config.Clock = lighttest.NewClock(chain.Time(34))
+	server, err := verifier.NewServer(config)
+	require.NoError(t, err)
+	// The light blocks are decoded in parallel, the first invalid one in the
+	// batch is reported whichever is decoded first.
+	for i := 0; i < 20; i++ {
+		_, err = server.VerifyBatch(context.Background(), &verifier.VerifyBatchRequest{Trusted: trustedPb, Untrusted: untrusted})
+		require.Equal(t, codes.InvalidArgument, status.Code(err))
+		require.Contains(t, err.Error(), "untrusted[3]")
+	}
+	untrusted[3] = untrusted[2]
+	_, err = server.VerifyBatch(context.Background(), &verifier.VerifyBatchRequest{Trusted: trustedPb, Untrusted: untrusted})
+	require.Equal(t, codes.InvalidArgument, status.Code(err))
+	require.Contains(t, err.Error(), "untrusted[7]")
+}
+
+func TestServerBudget(t *testing.T) {
+	chain, err := lighttest.NewChain("budget-1", 8, 1)
+	require.NoError(t, err)
+	trusted, err := chain.LightBlock(1, false)
+	require.NoError(t, err)
+	trustedPb, err := trusted.ToProto()
+	require.NoError(t, err)
+	var untrusted []*cmtproto.LightBlock
+	for _, height := range []int64{3, 4, 5} {
+		lightBlock, err := chain.LightBlock(height, false)
+		require.NoError(t, err)
+		pb, err := lightBlock.ToProto()
+		require.NoError(t, err)
+		// Tampered, such that decoding would fail with InvalidArgument.
+		pb.SignedHeader.Header.AppHash = []byte("tampered")
+		untrusted = append(untrusted, pb)
+	}
+	expired, cancel := context.WithDeadline(context.Background(), time.Now().Add(-time.Second))
+	defer cancel()
+
+	for _, tc := range []struct {
+		name   string
+		ctx    context.Context
+		config func(*verifier.Config)
+		code   codes.Code
+		reason string
+	}{
+		{
+			name:   "too many light blocks",
+			ctx:    context.Background(),
+			config: func(config *verifier.Config) { config.MaxBatchSize = 2 },
+			code:   codes.InvalidArgument,
+			reason: "exceeds the maximum of 2",
+		},
+		{
+			name:   "too many signatures",
+			ctx:    context.Background(),
+			config: func(config *verifier.Config) { config.Budget.MaxSignatures = 7 },
+			code:   codes.ResourceExhausted,
+			reason: "exceed the budget of 7",
+		},
+		{
+			// The validators of a light block are bounded by its size.
+			name: "too many validators",
+			ctx:  context.Background(),
+			config: func(config *verifier.Config) {
+				config.Budget.MaxDecodedBytes = trustedPb.Size() + untrusted[0].Size() - 1
+			},
+			code:   codes.ResourceExhausted,
+			reason: "bytes exceed the budget",
+		},
+		{
+			name:   "past the deadline",
+			ctx:    expired,
+			config: func(*verifier.Config) {},
+			code:   codes.DeadlineExceeded,
+			reason: "deadline exceeded",
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			var events int
+			config := verifier.DefaultConfig()
+			config.Clock = lighttest.NewClock(chain.Time(6))
+			config.ReportHooks = []verifier.ReportHook{hookFunc(func(verifier.VerificationEvent) { events++ })}
+			tc.config(&config)
+			server, err := verifier.NewServer(config)
+			require.NoError(t, err)
+
+			_, err = server.VerifyBatch(tc.ctx, &verifier.VerifyBatchRequest{Trusted: trustedPb, Untrusted: untrusted})
+			require.Equal(t, tc.code, status.Code(err), err)
+			require.ErrorContains(t, err, tc.reason)
+			if tc.code != codes.InvalidArgument {
+				// A single light block, within the batch size.
+				for _, verify := range []func(context.Context, *verifier.VerifyRequest) (*verifier.VerifyResponse, error){server.Verify, server.VerifyNonAdjacent} {
+					_, err = verify(tc.ctx, &verifier.VerifyRequest{Trusted: trustedPb, Untrusted: untrusted[0]})
+					require.Equal(t, tc.code, status.Code(err), err)
+					require.ErrorContains(t, err, tc.reason)
+				}
+			}
+			require.Zero(t, events)
+		})
+	}
+}
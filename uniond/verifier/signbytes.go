@@ -0,0 +1,234 @@
+package verifier
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"sort"
+	"time"
+
+	cmtmath "github.com/cometbft/cometbft/libs/math"
+	"github.com/cometbft/cometbft/light"
+	cmtproto "github.com/cometbft/cometbft/proto/tendermint/types"
+	cmttypes "github.com/cometbft/cometbft/types"
+)
+
+// SignBytes builds the bytes a validator signs for a vote of a chain, e.g.
+// cmttypes.VoteSignBytes. Forks of Union whose votes differ, e.g. signing
+// extra fields, verify their headers by supplying their own construction.
+type SignBytes func(chainID string, vote *cmtproto.Vote) []byte
+
+// CanonicalSignBytes returns the sign bytes of the votes of Union, or of the
+// CometBFT votes signed for the legacy headers.
+func CanonicalSignBytes(legacy bool) SignBytes {
+	if legacy {
+		return cmttypes.VoteSignBytesLegacy
+	}
+	return cmttypes.VoteSignBytes
+}
+
+// VerifyFunc verifies a transition between light blocks, as light.Verify.
+type VerifyFunc func(
+	trustedHeader *cmttypes.SignedHeader,
+	trustedVals *cmttypes.ValidatorSet,
+	untrustedHeader *cmttypes.SignedHeader,
+	untrustedVals *cmttypes.ValidatorSet,
+	trustingPeriod time.Duration,
+	now time.Time,
+	maxClockDrift time.Duration,
+	trustLevel cmtmath.Fraction,
+) error
+
+// NewVerifyFunc returns the verification of light.Verify, or of
+// light.VerifyLegacy, whose commits are signed over the given sign bytes.
+// Without sign bytes, it is light.Verify (or light.VerifyLegacy) itself.
+//
+// The header checks and the errors are those of light.Verify: an expired
+// trusted header fails with light.ErrOldHeaderExpired, an invalid header with
+// light.ErrInvalidHeader and a commit not signed by enough of the trusted
+// voting power with light.ErrNewValSetCantBeTrusted.
+func NewVerifyFunc(legacy bool, signBytes SignBytes) VerifyFunc {
+	if signBytes == nil {
+		if legacy {
+			return light.VerifyLegacy
+		}
+		return light.Verify
+	}
+	return func(
+		trustedHeader *cmttypes.SignedHeader,
+		trustedVals *cmttypes.ValidatorSet,
+		untrustedHeader *cmttypes.SignedHeader,
+		untrustedVals *cmttypes.ValidatorSet,
+		trustingPeriod time.Duration,
+		now time.Time,
+		maxClockDrift time.Duration,
+		trustLevel cmtmath.Fraction,
+	) error {
+		if light.HeaderExpired(trustedHeader, trustingPeriod, now) {
+			return light.ErrOldHeaderExpired{At: trustedHeader.Time.Add(trustingPeriod), Now: now}
+		}
+		if err := checkNewHeader(trustedHeader, untrustedHeader, untrustedVals, now, maxClockDrift, legacy); err != nil {
+			return light.ErrInvalidHeader{Reason: err}
+		}
+		chainID := trustedHeader.ChainID
+		if untrustedHeader.Height == trustedHeader.Height+1 {
+			if !digestsEqual(untrustedHeader.ValidatorsHash, trustedHeader.NextValidatorsHash) {
+				return fmt.Errorf("expected old header next validators (%X) to match those from new header (%X)",
+					trustedHeader.NextValidatorsHash, untrustedHeader.ValidatorsHash)
+			}
+		} else if err := VerifyCommitLightTrusting(chainID, trustedVals, untrustedHeader.Commit, trustLevel, signBytes); err != nil {
+			var notEnough cmttypes.ErrNotEnoughVotingPowerSigned
+			if errors.As(err, &notEnough) {
+				return light.ErrNewValSetCantBeTrusted{Reason: notEnough}
+			}
+			return err
+		}
+		// Last, as the untrusted set can be made large to exhaust the
+		// verifier.
+		if err := VerifyCommitLight(chainID, untrustedVals, untrustedHeader.Commit.BlockID, untrustedHeader.Height, untrustedHeader.Commit, signBytes); err != nil {
+			return light.ErrInvalidHeader{Reason: err}
+		}
+		return nil
+	}
+}
+
+// checkNewHeader checks an untrusted header and its validator set against
+// the trusted header, as light.Verify.
+func checkNewHeader(trusted, untrusted *cmttypes.SignedHeader, untrustedVals *cmttypes.ValidatorSet, now time.Time, maxClockDrift time.Duration, legacy bool) error {
+	var err error
+	if legacy {
+		err = untrusted.ValidateBasicLegacy(trusted.ChainID)
+	} else {
+		err = untrusted.ValidateBasic(trusted.ChainID)
+	}
+	if err != nil {
+		return fmt.Errorf("untrustedHeader.ValidateBasic failed: %w", err)
+	}
+	if untrusted.Height <= trusted.Height {
+		return fmt.Errorf("expected new header height %d to be greater than one of old header %d", untrusted.Height, trusted.Height)
+	}
+	if !untrusted.Time.After(trusted.Time) {
+		return fmt.Errorf("expected new header time %v to be after old header time %v", untrusted.Time, trusted.Time)
+	}
+	if !untrusted.Time.Before(now.Add(maxClockDrift)) {
+		return fmt.Errorf("new header has a time from the future %v (now: %v; max clock drift: %v)", untrusted.Time, now, maxClockDrift)
+	}
+	var valsHash []byte
+	if legacy {
+		valsHash = untrustedVals.HashSha256()
+	} else {
+		valsHash = untrustedVals.Hash()
+	}
+	if !digestsEqual(untrusted.ValidatorsHash, valsHash) {
+		return fmt.Errorf("expected new header validators (%X) to match those that were supplied (%X) at height %d", untrusted.ValidatorsHash, valsHash, untrusted.Height)
+	}
+	return nil
+}
+
+// VerifyCommitLight checks that more than 2/3 of the voting power of the
+// validator set of a commit signed it over the given sign bytes, as
+// cmttypes.ValidatorSet.VerifyCommitLight.
+func VerifyCommitLight(chainID string, vals *cmttypes.ValidatorSet, blockID cmttypes.BlockID, height int64, commit *cmttypes.Commit, signBytes SignBytes) error {
+	if vals == nil {
+		return errors.New("nil validator set")
+	}
+	if commit == nil {
+		return errors.New("nil commit")
+	}
+	if vals.Size() != len(commit.Signatures) {
+		return cmttypes.NewErrInvalidCommitSignatures(vals.Size(), len(commit.Signatures))
+	}
+	if height != commit.Height {
+		return cmttypes.NewErrInvalidCommitHeight(height, commit.Height)
+	}
+	if !blockID.Equals(commit.BlockID) {
+		return fmt.Errorf("invalid commit -- wrong block ID: want %v, got %v", blockID, commit.BlockID)
+	}
+	return verifyCommitSignatures(chainID, vals, commit, vals.TotalVotingPower()*2/3, true, signBytes)
+}
+
+// VerifyCommitLightTrusting checks that more than the trust level of the
+// voting power of a validator set, not necessarily the one of the commit,
+// signed a commit over the given sign bytes, as
+// cmttypes.ValidatorSet.VerifyCommitLightTrusting.
+func VerifyCommitLightTrusting(chainID string, vals *cmttypes.ValidatorSet, commit *cmttypes.Commit, trustLevel cmtmath.Fraction, signBytes SignBytes) error {
+	if vals == nil {
+		return errors.New("nil validator set")
+	}
+	if trustLevel.Denominator == 0 {
+		return errors.New("trustLevel has zero Denominator")
+	}
+	if commit == nil {
+		return errors.New("nil commit")
+	}
+	if err := CheckTrustMath(vals, trustLevel); err != nil {
+		return err
+	}
+	// Converted to int64, a larger trust level would wrap to a negative
+	// voting power, any signature then being enough.
+	if trustLevel.Numerator > math.MaxInt64 || trustLevel.Denominator > math.MaxInt64 {
+		return fmt.Errorf("%w: trust level %v", ErrVotingPowerOverflow, trustLevel)
+	}
+	product, overflow := safeMul(vals.TotalVotingPower(), int64(trustLevel.Numerator))
+	if overflow {
+		return fmt.Errorf("%w: total voting power %d times the trust level numerator %d", ErrVotingPowerOverflow, vals.TotalVotingPower(), trustLevel.Numerator)
+	}
+	needed := product / int64(trustLevel.Denominator)
+	return verifyCommitSignatures(chainID, vals, commit, needed, false, signBytes)
+}
+
+// verifyCommitSignatures verifies the signatures for the block of a commit
+// until they add up to more than the needed voting power. The signers are the
+// validators of the same index, or looked up by address in a set that isn't
+// the one of the commit, signing at most once.
+//
+// The signatures of a commit follow the order of its own validator set, by
+// descending voting power, such that the tally stops after its largest
+// signers. The validators of another set are ordered differently, so their
+// signatures are resolved first, every double vote being rejected, and then
+// verified by descending voting power in that set, stopping as early on
+// chains whose power is concentrated in a few validators.
+func verifyCommitSignatures(chainID string, vals *cmttypes.ValidatorSet, commit *cmttypes.Commit, needed int64, byIndex bool, signBytes SignBytes) error {
+	type signer struct {
+		index int
+		val   *cmttypes.Validator
+	}
+	signers := make([]signer, 0, len(commit.Signatures))
+	seen := make(map[int32]int, len(commit.Signatures))
+	for i, sig := range commit.Signatures {
+		if sig.BlockIDFlag != cmttypes.BlockIDFlagCommit {
+			continue
+		}
+		if byIndex {
+			signers = append(signers, signer{index: i, val: vals.Validators[i]})
+			continue
+		}
+		index, val := vals.GetByAddress(sig.ValidatorAddress)
+		if val == nil {
+			continue
+		}
+		if first, ok := seen[index]; ok {
+			return fmt.Errorf("double vote from %v (%d and %d)", val, first, i)
+		}
+		seen[index] = i
+		signers = append(signers, signer{index: i, val: val})
+	}
+	if !byIndex {
+		sort.SliceStable(signers, func(i, j int) bool {
+			return signers[i].val.VotingPower > signers[j].val.VotingPower
+		})
+	}
+
+	var tallied int64
+	for _, s := range signers {
+		sig := commit.Signatures[s.index]
+		vote := commit.GetVote(int32(s.index)).ToProto()
+		if !s.val.PubKey.VerifySignature(signBytes(chainID, vote), sig.Signature) {
+			return fmt.Errorf("wrong signature (#%d): %X", s.index, sig.Signature)
+		}
+		if tallied += s.val.VotingPower; tallied > needed {
+			return nil
+		}
+	}
+	return cmttypes.ErrNotEnoughVotingPowerSigned{Got: tallied, Needed: needed}
+}
@@ -0,0 +1,204 @@
+package verifier_test
+
+import (
+	"errors"
+	"math"
+	"testing"
+	"time"
+
+	cmtmath "github.com/cometbft/cometbft/libs/math"
+	"github.com/cometbft/cometbft/light"
+	cmtproto "github.com/cometbft/cometbft/proto/tendermint/types"
+	cmttypes "github.com/cometbft/cometbft/types"
+	"github.com/stretchr/testify/require"
+
+	"union/verifier"
+	"union/verifier/lighttest"
+)
+
+func TestVerifyCommitLightTrustingByDescendingPower(t *testing.T) {
+	chain, err := lighttest.NewChain("signbytes-1", 8, 1)
+	require.NoError(t, err)
+	lightBlock, err := chain.LightBlock(2, false)
+	require.NoError(t, err)
+
+	// The last signer of the commit holds most of the power of the trusted
+	// set.
+	vals := make([]*cmttypes.Validator, 0, lightBlock.ValidatorSet.Size())
+	for _, val := range lightBlock.ValidatorSet.Validators {
+		vals = append(vals, cmttypes.NewValidator(val.PubKey, 1))
+	}
+	vals[len(vals)-1].VotingPower = 1_000
+	trusted := cmttypes.NewValidatorSet(vals)
+
+	var verified int
+	signBytes := func(chainID string, vote *cmtproto.Vote) []byte {
+		verified++
+		return cmttypes.VoteSignBytes(chainID, vote)
+	}
+	require.NoError(t, verifier.VerifyCommitLightTrusting(chain.ChainID, trusted, lightBlock.Commit, cmtmath.Fraction{Numerator: 1, Denominator: 3}, signBytes))
+	require.Equal(t, 1, verified)
+
+	// Double votes are rejected even when the tally would stop before them.
+	commit := *lightBlock.Commit
+	commit.Signatures = append([]cmttypes.CommitSig(nil), commit.Signatures...)
+	commit.Signatures[0] = commit.Signatures[1]
+	verified = 0
+	err = verifier.VerifyCommitLightTrusting(chain.ChainID, trusted, &commit, cmtmath.Fraction{Numerator: 1, Denominator: 3}, signBytes)
+	require.ErrorContains(t, err, "double vote")
+	require.Zero(t, verified)
+}
+
+// verifyOutcome classifies the outcome of a verification by the errors of
+// light.Verify.
+func verifyOutcome(err error) string {
+	var (
+		expired     light.ErrOldHeaderExpired
+		invalid     light.ErrInvalidHeader
+		untrustable light.ErrNewValSetCantBeTrusted
+	)
+	switch {
+	case err == nil:
+		return "verified"
+	case errors.As(err, &expired):
+		return "expired"
+	case errors.As(err, &invalid):
+		return "invalid header"
+	case errors.As(err, &untrustable):
+		return "untrusted validator set"
+	default:
+		return "other"
+	}
+}
+
+func TestNewVerifyFuncParity(t *testing.T) {
+	chain, err := lighttest.NewChain("signbytes-1", 4, 1)
+	require.NoError(t, err)
+	other, err := lighttest.NewChain("signbytes-1", 4, 2)
+	require.NoError(t, err)
+	trustLevel := cmtmath.Fraction{Numerator: 1, Denominator: 3}
+
+	for _, legacy := range []bool{false, true} {
+		for _, tc := range []struct {
+			name    string
+			height  int64
+			now     time.Duration
+			mutate  func(untrusted *cmttypes.LightBlock)
+			outcome string
+		}{
+			{name: "adjacent", height: 2, outcome: "verified"},
+			{name: "non adjacent", height: 5, outcome: "verified"},
+			{name: "expired", height: 5, now: 2 * time.Hour, outcome: "expired"},
+			{name: "future time", height: 5, now: -2 * time.Minute, outcome: "invalid header"},
+			{
+				name:   "wrong validator set hash",
+				height: 5,
+				mutate: func(untrusted *cmttypes.LightBlock) {
+					vals, err := other.ValidatorSet(5)
+					require.NoError(t, err)
+					untrusted.ValidatorSet = vals
+				},
+				outcome: "invalid header",
+			},
+			{
+				name:   "wrong signature",
+				height: 5,
+				mutate: func(untrusted *cmttypes.LightBlock) {
+					sig, err := other.LightBlock(5, legacy)
+					require.NoError(t, err)
+					untrusted.Commit.Signatures[0].Signature = sig.Commit.Signatures[0].Signature
+				},
+				outcome: "other",
+			},
+			{
+				name:   "double vote by address",
+				height: 5,
+				mutate: func(untrusted *cmttypes.LightBlock) {
+					untrusted.Commit.Signatures[0] = untrusted.Commit.Signatures[1]
+				},
+				outcome: "other",
+			},
+			{
+				// Only the validator of least voting power signed.
+				name:   "not enough voting power",
+				height: 5,
+				mutate: func(untrusted *cmttypes.LightBlock) {
+					for i := range untrusted.Commit.Signatures[:len(untrusted.Commit.Signatures)-1] {
+						untrusted.Commit.Signatures[i] = cmttypes.NewCommitSigAbsent()
+					}
+				},
+				outcome: "untrusted validator set",
+			},
+		} {
+			t.Run(verifier.SchemeName(legacy)+"/"+tc.name, func(t *testing.T) {
+				trusted, err := chain.LightBlock(1, legacy)
+				require.NoError(t, err)
+				untrusted, err := chain.LightBlock(tc.height, legacy)
+				require.NoError(t, err)
+				if tc.mutate != nil {
+					tc.mutate(untrusted)
+				}
+				now := chain.Time(tc.height).Add(time.Minute + tc.now)
+				reference := light.Verify
+				if legacy {
+					reference = light.VerifyLegacy
+				}
+				for _, verify := range []verifier.VerifyFunc{reference, verifier.NewVerifyFunc(legacy, verifier.CanonicalSignBytes(legacy))} {
+					err := verify(trusted.SignedHeader, trusted.ValidatorSet, untrusted.SignedHeader, untrusted.ValidatorSet, time.Hour, now, 10*time.Second, trustLevel)
+					require.Equal(t, tc.outcome, verifyOutcome(err), "%v", err)
+				}
+			})
+		}
+	}
+}
+
+func TestNewVerifyFuncCustomSignBytes(t *testing.T) {
+	// A fork signing its votes with a prefix.
+	signBytes := func(chainID string, vote *cmtproto.Vote) []byte {
+		return append([]byte("fork"), cmttypes.VoteSignBytes(chainID, vote)...)
+	}
+	canonical, err := lighttest.NewChain("signbytes-1", 4, 1)
+	require.NoError(t, err)
+	fork, err := lighttest.NewChain("signbytes-1", 4, 1, lighttest.WithSignBytes(signBytes))
+	require.NoError(t, err)
+	trustLevel := cmtmath.Fraction{Numerator: 1, Denominator: 3}
+
+	for _, height := range []int64{2, 5} {
+		verify := func(chain *lighttest.Chain, verify verifier.VerifyFunc) error {
+			trusted, err := chain.LightBlock(1, false)
+			require.NoError(t, err)
+			untrusted, err := chain.LightBlock(height, false)
+			require.NoError(t, err)
+			return verify(trusted.SignedHeader, trusted.ValidatorSet, untrusted.SignedHeader, untrusted.ValidatorSet, time.Hour, chain.Time(height).Add(time.Minute), 10*time.Second, trustLevel)
+		}
+		require.NoError(t, verify(fork, verifier.NewVerifyFunc(false, signBytes)))
+		// The canonical signatures don't verify over the sign bytes of the
+		// fork, nor the ones of the fork over the canonical sign bytes.
+		require.Error(t, verify(canonical, verifier.NewVerifyFunc(false, signBytes)))
+		require.Error(t, verify(fork, light.Verify))
+	}
+}
+
+func TestVerifyCommitLightTrustingOverflow(t *testing.T) {
+	chain, err := lighttest.NewChain("signbytes-1", 4, 1)
+	require.NoError(t, err)
+	lightBlock, err := chain.LightBlock(2, false)
+	require.NoError(t, err)
+	trusted := cmttypes.NewValidatorSet([]*cmttypes.Validator{cmttypes.NewValidator(lightBlock.ValidatorSet.Validators[0].PubKey, 1)})
+	// A commit without any signature.
+	commit := *lightBlock.Commit
+	commit.Signatures = make([]cmttypes.CommitSig, len(lightBlock.Commit.Signatures))
+	for i := range commit.Signatures {
+		commit.Signatures[i] = cmttypes.NewCommitSigAbsent()
+	}
+
+	for _, trustLevel := range []cmtmath.Fraction{
+		// Converted to int64, the denominator would be negative and so the
+		// needed voting power, trusting the commit.
+		{Numerator: math.MaxInt64, Denominator: 1<<63 + 2},
+		{Numerator: 1 << 63, Denominator: 1<<63 + 2},
+	} {
+		err := verifier.VerifyCommitLightTrusting(chain.ChainID, trusted, &commit, trustLevel, verifier.CanonicalSignBytes(false))
+		require.ErrorIs(t, err, verifier.ErrVotingPowerOverflow, "%v", trustLevel)
+	}
+}
@@ -0,0 +1,114 @@
+package verifier
+
+import (
+	"context"
+	"fmt"
+	"math/bits"
+
+	cmtmath "github.com/cometbft/cometbft/libs/math"
+	cmttypes "github.com/cometbft/cometbft/types"
+)
+
+// ValidatorSetFunc returns the validator set at a height, e.g. fetched with
+// FetchValidatorSet.
+type ValidatorSetFunc func(ctx context.Context, height int64) (*cmttypes.ValidatorSet, error)
+
+// SkippingEstimate is the furthest height verifiable from a trusted height
+// estimated by EstimateSkippingDistance.
+type SkippingEstimate struct {
+	TrustedHeight int64 `json:"trusted_height"`
+	LatestHeight  int64 `json:"latest_height"`
+	// Height is the furthest height verifiable in a single step, the
+	// adjacent one at least.
+	Height int64 `json:"height"`
+	// Distance is Height minus TrustedHeight.
+	Distance int64 `json:"distance"`
+	// Overlap is the overlap of the trusted validator set with the one at
+	// Height, see ValidatorSetDiff.
+	Overlap float64 `json:"overlap"`
+	// Fetched is the number of validator sets fetched for the estimate.
+	Fetched int `json:"fetched"`
+}
+
+// PreferSequential tells whether verifying up to the latest height of the
+// estimate sequentially is expected to cost a light client less than skipping.
+// Skipping verification bisects, each pivot failing until the distance to the
+// trusted height falls under the skippable one, so that reaching a height at
+// a distance D costs about log2(D) verifications and as many fetched light
+// blocks. Once the skippable distance is under that, the bisections verify
+// and fetch more than the adjacent headers themselves.
+func (e *SkippingEstimate) PreferSequential() bool {
+	distance := e.LatestHeight - e.TrustedHeight
+	if distance <= 1 {
+		return false
+	}
+	return e.Distance < int64(bits.Len64(uint64(distance)))
+}
+
+// EstimateSkippingDistance estimates the furthest height up to latestHeight
+// verifiable non-adjacently from a trusted height with a trust level, i.e.
+// whose validator set overlaps the trusted one by more than the trust level,
+// so that relayers can schedule their updates.
+//
+// The overlap is assumed to shrink as the validator sets churn away from the
+// trusted one: the heights are probed at doubling distances, then bisected
+// between the furthest skippable probe and the first one that isn't, so that
+// only a logarithmic number of validator sets is fetched. A validator set
+// returning to the trusted one after a non skippable height is missed, which
+// only makes the estimate conservative. The estimate assumes every validator
+// of the overlap signs, the actual commits possibly lacking some.
+func EstimateSkippingDistance(ctx context.Context, valsets ValidatorSetFunc, trustedHeight, latestHeight int64, trustLevel cmtmath.Fraction) (*SkippingEstimate, error) {
+	if trustedHeight <= 0 || latestHeight <= trustedHeight {
+		return nil, fmt.Errorf("the latest height %d must be above the trusted height %d", latestHeight, trustedHeight)
+	}
+	estimate := &SkippingEstimate{TrustedHeight: trustedHeight, LatestHeight: latestHeight}
+	trusted, err := valsets(ctx, trustedHeight)
+	if err != nil {
+		return nil, fmt.Errorf("validator set at height %d: %w", trustedHeight, err)
+	}
+	estimate.Fetched++
+	probe := func(height int64) (*ValidatorSetDiff, error) {
+		valSet, err := valsets(ctx, height)
+		if err != nil {
+			return nil, fmt.Errorf("validator set at height %d: %w", height, err)
+		}
+		estimate.Fetched++
+		return DiffValidatorSets(trusted, valSet, trustLevel)
+	}
+
+	// The adjacent height is always verifiable, whatever the overlap.
+	good := trustedHeight + 1
+	diff, err := probe(good)
+	if err != nil {
+		return nil, err
+	}
+	estimate.Overlap = diff.Overlap
+	var bad int64
+	for step := int64(1); good < latestHeight; step *= 2 {
+		height := min(good+step, latestHeight)
+		diff, err := probe(height)
+		if err != nil {
+			return nil, err
+		}
+		if !diff.Skippable {
+			bad = height
+			break
+		}
+		good, estimate.Overlap = height, diff.Overlap
+	}
+	for bad > good+1 {
+		height := good + (bad-good)/2
+		diff, err := probe(height)
+		if err != nil {
+			return nil, err
+		}
+		if diff.Skippable {
+			good, estimate.Overlap = height, diff.Overlap
+		} else {
+			bad = height
+		}
+	}
+	estimate.Height = good
+	estimate.Distance = good - trustedHeight
+	return estimate, nil
+}
@@ -0,0 +1,98 @@
+package verifier_test
+
+import (
+	"context"
+	"fmt"
+	"math/bits"
+	"testing"
+
+	cmtmath "github.com/cometbft/cometbft/libs/math"
+	"github.com/cometbft/cometbft/light"
+	cmttypes "github.com/cometbft/cometbft/types"
+	"github.com/stretchr/testify/require"
+
+	"union/verifier"
+	"union/verifier/lighttest"
+)
+
+func TestEstimateSkippingDistance(t *testing.T) {
+	const latestHeight = 200
+	ctx := context.Background()
+	for _, tc := range []struct {
+		churn         int
+		trustedHeight int64
+		trustLevel    cmtmath.Fraction
+		// Whether bisecting up to the latest height costs more than
+		// verifying every header.
+		sequential bool
+	}{
+		{churn: 0, trustedHeight: 1, trustLevel: light.DefaultTrustLevel},
+		{churn: 1, trustedHeight: 1, trustLevel: light.DefaultTrustLevel, sequential: true},
+		{churn: 1, trustedHeight: 57, trustLevel: cmtmath.Fraction{Numerator: 2, Denominator: 3}, sequential: true},
+		{churn: 2, trustedHeight: 10, trustLevel: light.DefaultTrustLevel, sequential: true},
+		{churn: 8, trustedHeight: 3, trustLevel: light.DefaultTrustLevel, sequential: true},
+	} {
+		t.Run(fmt.Sprintf("churn %d from %d at %s", tc.churn, tc.trustedHeight, tc.trustLevel), func(t *testing.T) {
+			chain, err := lighttest.NewChain("skipping-1", 8, 1, lighttest.WithChurn(tc.churn))
+			require.NoError(t, err)
+			fetched := 0
+			valsets := func(_ context.Context, height int64) (*cmttypes.ValidatorSet, error) {
+				fetched++
+				return chain.ValidatorSet(height)
+			}
+
+			estimate, err := verifier.EstimateSkippingDistance(ctx, valsets, tc.trustedHeight, latestHeight, tc.trustLevel)
+			require.NoError(t, err)
+
+			// Brute force: the furthest height before the first one that
+			// can't be skipped to, the adjacent one aside.
+			trusted, err := chain.ValidatorSet(tc.trustedHeight)
+			require.NoError(t, err)
+			height := tc.trustedHeight + 1
+			for height < latestHeight {
+				vals, err := chain.ValidatorSet(height + 1)
+				require.NoError(t, err)
+				diff, err := verifier.DiffValidatorSets(trusted, vals, tc.trustLevel)
+				require.NoError(t, err)
+				if !diff.Skippable {
+					break
+				}
+				height++
+			}
+
+			require.Equal(t, height, estimate.Height)
+			require.Equal(t, height-tc.trustedHeight, estimate.Distance)
+			vals, err := chain.ValidatorSet(height)
+			require.NoError(t, err)
+			diff, err := verifier.DiffValidatorSets(trusted, vals, tc.trustLevel)
+			require.NoError(t, err)
+			require.Equal(t, diff.Overlap, estimate.Overlap)
+			if height > tc.trustedHeight+1 {
+				require.True(t, diff.Skippable)
+			}
+			// The trusted set, then at most twice the log of the distance.
+			require.Equal(t, fetched, estimate.Fetched)
+			require.LessOrEqual(t, estimate.Fetched, 2+2*bits.Len64(uint64(latestHeight-tc.trustedHeight)))
+
+			require.Equal(t, tc.sequential, estimate.PreferSequential())
+		})
+	}
+}
+
+func TestEstimateSkippingDistanceBounds(t *testing.T) {
+	chain, err := lighttest.NewChain("skipping-1", 4, 1)
+	require.NoError(t, err)
+	valsets := func(_ context.Context, height int64) (*cmttypes.ValidatorSet, error) {
+		return chain.ValidatorSet(height)
+	}
+	_, err = verifier.EstimateSkippingDistance(context.Background(), valsets, 5, 5, light.DefaultTrustLevel)
+	require.Error(t, err)
+	_, err = verifier.EstimateSkippingDistance(context.Background(), valsets, 0, 5, light.DefaultTrustLevel)
+	require.Error(t, err)
+
+	// The adjacent height is verifiable without any overlap.
+	estimate, err := verifier.EstimateSkippingDistance(context.Background(), valsets, 5, 6, light.DefaultTrustLevel)
+	require.NoError(t, err)
+	require.Equal(t, int64(6), estimate.Height)
+	require.False(t, estimate.PreferSequential())
+}
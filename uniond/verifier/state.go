@@ -0,0 +1,184 @@
+package verifier
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	cmtproto "github.com/cometbft/cometbft/proto/tendermint/types"
+	cmttypes "github.com/cometbft/cometbft/types"
+)
+
+// TrustedStateVersion is the version of the TrustedState encoding.
+const TrustedStateVersion = 1
+
+// trustedStateMagic starts every encoded TrustedState.
+var trustedStateMagic = []byte("UNTS")
+
+// ErrInvalidTrustedState is returned when an encoded trusted state is
+// corrupted, of an unknown version or not canonical.
+var ErrInvalidTrustedState = errors.New("invalid trusted state")
+
+// TrustedState is a root of trust handed over between processes, e.g. from
+// a bootstrap tool to a relayer or a verifying proxy, which trust it without
+// verifying it again: the light block must come from a trusted source, the
+// encoding only protects its integrity.
+type TrustedState struct {
+	LightBlock     *cmttypes.LightBlock
+	TrustingPeriod time.Duration
+	// Whether the light block uses the legacy (pre cometbls) hashes.
+	Legacy bool
+}
+
+// Marshal encodes the state deterministically, a state always having a
+// single encoding:
+//
+//	magic           "UNTS"
+//	version         1 byte
+//	flags           1 byte, bit 0 for legacy
+//	trusting period 8 bytes, big endian nanoseconds
+//	light block     4 bytes big endian length, then the protobuf encoded
+//	                tendermint.types.LightBlock
+//	checksum        32 bytes, SHA-256 of all the above
+func (s *TrustedState) Marshal() ([]byte, error) {
+	if s.LightBlock == nil {
+		return nil, errors.New("missing light block")
+	}
+	if s.TrustingPeriod <= 0 {
+		return nil, errors.New("the trusting period must be positive")
+	}
+	pb, err := s.LightBlock.ToProto()
+	if err != nil {
+		return nil, err
+	}
+	lightBlock, err := pb.Marshal()
+	if err != nil {
+		return nil, err
+	}
+	var buf bytes.Buffer
+	buf.Write(trustedStateMagic)
+	buf.WriteByte(TrustedStateVersion)
+	var flags byte
+	if s.Legacy {
+		flags |= 1
+	}
+	buf.WriteByte(flags)
+	buf.Write(binary.BigEndian.AppendUint64(nil, uint64(s.TrustingPeriod)))
+	buf.Write(binary.BigEndian.AppendUint32(nil, uint32(len(lightBlock))))
+	buf.Write(lightBlock)
+	checksum := sha256.Sum256(buf.Bytes())
+	buf.Write(checksum[:])
+	return buf.Bytes(), nil
+}
+
+// WriteTrustedState writes an encoded state to a file atomically, so that a
+// process watching the file, such as a Replica, never reads it half written.
+func WriteTrustedState(path string, state *TrustedState) error {
+	bz, err := state.Marshal()
+	if err != nil {
+		return err
+	}
+	tmp, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".*.tmp")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+	if _, err := tmp.Write(bz); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Sync(); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), path)
+}
+
+// UnmarshalTrustedState decodes a state encoded by Marshal, checking its
+// checksum, that its encoding is the canonical one and that its light block
+// is well formed.
+func UnmarshalTrustedState(bz []byte) (*TrustedState, error) {
+	invalid := func(format string, args ...any) error {
+		return fmt.Errorf("%w: %s", ErrInvalidTrustedState, fmt.Sprintf(format, args...))
+	}
+	const headerSize = 4 + 1 + 1 + 8 + 4
+	if len(bz) < headerSize+sha256.Size {
+		return nil, invalid("%d bytes is too short", len(bz))
+	}
+	if !bytes.Equal(bz[:4], trustedStateMagic) {
+		return nil, invalid("not a trusted state")
+	}
+	if version := bz[4]; version != TrustedStateVersion {
+		return nil, invalid("unknown version %d, expected %d", version, TrustedStateVersion)
+	}
+	body, checksum := bz[:len(bz)-sha256.Size], bz[len(bz)-sha256.Size:]
+	if sum := sha256.Sum256(body); !digestsEqual(sum[:], checksum) {
+		return nil, invalid("checksum mismatch")
+	}
+	flags := bz[5]
+	if flags&^1 != 0 {
+		return nil, invalid("unknown flags %#x", flags)
+	}
+	trustingPeriod := time.Duration(binary.BigEndian.Uint64(bz[6:14]))
+	if trustingPeriod <= 0 {
+		return nil, invalid("non positive trusting period")
+	}
+	if size := binary.BigEndian.Uint32(bz[14:18]); int(size) != len(body)-headerSize {
+		return nil, invalid("light block of %d bytes, %d remaining", size, len(body)-headerSize)
+	}
+	var pb cmtproto.LightBlock
+	if err := pb.Unmarshal(body[headerSize:]); err != nil {
+		return nil, invalid("%s", err)
+	}
+	lightBlock, err := cmttypes.LightBlockFromProto(&pb)
+	if err != nil {
+		return nil, invalid("%s", err)
+	}
+	state := &TrustedState{LightBlock: lightBlock, TrustingPeriod: trustingPeriod, Legacy: flags&1 == 1}
+	if err := ValidateLightBlock(lightBlock, state.Legacy); err != nil {
+		return nil, invalid("%s", err)
+	}
+	// Protobuf accepts several encodings of the same message, only the one
+	// Marshal produces is accepted so that a state has a single encoding.
+	canonical, err := state.Marshal()
+	if err != nil {
+		return nil, invalid("%s", err)
+	}
+	if !bytes.Equal(canonical, bz) {
+		return nil, invalid("non canonical encoding")
+	}
+	return state, nil
+}
+
+// ValidateLightBlock checks that a light block is well formed and its header
+// commits to its validator set, hashing with the legacy or the current
+// scheme.
+func ValidateLightBlock(lightBlock *cmttypes.LightBlock, legacy bool) error {
+	if lightBlock.SignedHeader == nil || lightBlock.ValidatorSet == nil {
+		return errors.New("light block must contain both a signed header and a validator set")
+	}
+	if err := CheckEncoding(lightBlock, legacy); err != nil {
+		return err
+	}
+	if !legacy {
+		return lightBlock.ValidateBasic(lightBlock.ChainID)
+	}
+	if err := lightBlock.SignedHeader.ValidateBasicLegacy(lightBlock.ChainID); err != nil {
+		return fmt.Errorf("invalid signed header: %w", err)
+	}
+	if err := lightBlock.ValidatorSet.ValidateBasic(); err != nil {
+		return fmt.Errorf("invalid validator set: %w", err)
+	}
+	if valsHash := lightBlock.ValidatorSet.HashSha256(); !digestsEqual(lightBlock.ValidatorsHash, valsHash) {
+		return fmt.Errorf("expected validator hash of header to match validator set hash (%X != %X)", lightBlock.ValidatorsHash, valsHash)
+	}
+	return nil
+}
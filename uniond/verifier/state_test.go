@@ -0,0 +1,67 @@
+package verifier_test
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	cmtlog "github.com/cometbft/cometbft/libs/log"
+	"github.com/cometbft/cometbft/light"
+	"github.com/cometbft/cometbft/light/provider"
+	cmttypes "github.com/cometbft/cometbft/types"
+	"github.com/stretchr/testify/require"
+
+	"union/verifier"
+	"union/verifier/lighttest"
+)
+
+func TestTrustedStateRoundTrip(t *testing.T) {
+	chain, err := lighttest.NewChain("state-1", 4, 1)
+	require.NoError(t, err)
+
+	for _, legacy := range []bool{false, true} {
+		t.Run(verifier.SchemeName(legacy), func(t *testing.T) {
+			lightBlock, err := chain.LightBlock(5, legacy)
+			require.NoError(t, err)
+			state := &verifier.TrustedState{LightBlock: lightBlock, TrustingPeriod: 24 * time.Hour, Legacy: legacy}
+			bz, err := state.Marshal()
+			require.NoError(t, err)
+			again, err := state.Marshal()
+			require.NoError(t, err)
+			require.Equal(t, bz, again)
+
+			decoded, err := verifier.UnmarshalTrustedState(bz)
+			require.NoError(t, err)
+			require.Equal(t, state.TrustingPeriod, decoded.TrustingPeriod)
+			require.Equal(t, legacy, decoded.Legacy)
+			require.Equal(t, verifier.LightBlockHash(lightBlock, legacy), verifier.LightBlockHash(decoded.LightBlock, legacy))
+
+			// A header moved to another chain no longer matches its commit.
+			header := *lightBlock.Header
+			header.ChainID = "state-2"
+			moved := *lightBlock
+			moved.SignedHeader = &cmttypes.SignedHeader{Header: &header, Commit: lightBlock.Commit}
+			bz, err = (&verifier.TrustedState{LightBlock: &moved, TrustingPeriod: 24 * time.Hour, Legacy: legacy}).Marshal()
+			require.NoError(t, err)
+			_, err = verifier.UnmarshalTrustedState(bz)
+			require.ErrorIs(t, err, verifier.ErrInvalidTrustedState)
+		})
+	}
+}
+
+func TestReplicaRejectsAnotherChain(t *testing.T) {
+	chain, err := lighttest.NewChain("state-1", 4, 1)
+	require.NoError(t, err)
+	lightBlock, err := chain.LightBlock(5, false)
+	require.NoError(t, err)
+	path := filepath.Join(t.TempDir(), "state")
+	require.NoError(t, verifier.WriteTrustedState(path, &verifier.TrustedState{LightBlock: lightBlock, TrustingPeriod: 24 * time.Hour}))
+
+	replica := func(chainID string) error {
+		primary := lighttest.NewProvider("primary", chain, 5, false)
+		_, err := verifier.NewReplica(path, chainID, primary, []provider.Provider{primary}, light.Logger(cmtlog.NewNopLogger()))
+		return err
+	}
+	require.NoError(t, replica("state-1"))
+	require.ErrorContains(t, replica("state-2"), "trusted state of chain state-1, following state-2")
+}
@@ -0,0 +1,141 @@
+package verifier
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+
+	cmtbytes "github.com/cometbft/cometbft/libs/bytes"
+	lightstore "github.com/cometbft/cometbft/light/store"
+	cmttypes "github.com/cometbft/cometbft/types"
+)
+
+// StoreViolation is an invariant of a trusted store that doesn't hold at a
+// height.
+type StoreViolation struct {
+	Height int64 `json:"height"`
+	Violation
+}
+
+// StoreReport is the outcome of the check of a trusted store.
+type StoreReport struct {
+	ChainID     string           `json:"chain_id"`
+	LightBlocks int              `json:"light_blocks"`
+	FirstHeight int64            `json:"first_height"`
+	LastHeight  int64            `json:"last_height"`
+	Violations  []StoreViolation `json:"violations,omitempty"`
+}
+
+// CheckStore scans a light client trusted store, from its latest light block
+// to its first one, for violations of the invariants the light client relies
+// on:
+//   - every light block belongs to the chain, is well formed, commits to its
+//     validator set and is signed by more than 2/3 of it,
+//   - light blocks at consecutive heights are linked by their next validators
+//     hash, and the time increases with the height,
+//   - the latest light block, which the light client resumes from, is within
+//     the trusting period,
+//   - the size maintained by the store matches its content.
+func CheckStore(store lightstore.Store, chainID string, trustingPeriod time.Duration, now time.Time) (*StoreReport, error) {
+	report := &StoreReport{ChainID: chainID}
+	violate := func(height int64, check string, v Violation) {
+		v.Check = check
+		report.Violations = append(report.Violations, StoreViolation{Height: height, Violation: v})
+	}
+
+	last, err := store.LastLightBlockHeight()
+	if err != nil {
+		return nil, err
+	}
+	if last <= 0 {
+		if size := store.Size(); size != 0 {
+			violate(0, "size", Violation{Expected: "0", Actual: strconv.Itoa(int(size))})
+		}
+		return report, nil
+	}
+	first, err := store.FirstLightBlockHeight()
+	if err != nil {
+		return nil, err
+	}
+	report.FirstHeight, report.LastHeight = first, last
+
+	// The light block right above the one being checked.
+	var above *cmttypes.LightBlock
+	for height := last + 1; height > first; {
+		lightBlock, err := store.LightBlockBefore(height)
+		if errors.Is(err, lightstore.ErrLightBlockNotFound) {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("light block before %d: %w", height, err)
+		}
+		height = lightBlock.Height
+		report.LightBlocks++
+
+		for _, v := range checkStoredLightBlock(lightBlock, chainID) {
+			violate(height, v.Check, v)
+		}
+		if above == nil {
+			expiresAt := lightBlock.Time.Add(trustingPeriod)
+			if !expiresAt.After(now) {
+				violate(height, "trusting_period", Violation{
+					Expected: "latest light block expiring after " + formatTime(now),
+					Actual:   "expired at " + formatTime(expiresAt),
+				})
+			}
+		} else {
+			if !above.Time.After(lightBlock.Time) {
+				violate(above.Height, "time", Violation{Expected: "after " + formatTime(lightBlock.Time), Actual: formatTime(above.Time)})
+			}
+			if above.Height == height+1 && !digestsEqual(above.ValidatorsHash, lightBlock.NextValidatorsHash) {
+				violate(above.Height, "next_validators_hash", Violation{Expected: lightBlock.NextValidatorsHash.String(), Actual: above.ValidatorsHash.String()})
+			}
+		}
+		above = lightBlock
+	}
+
+	if size := int(store.Size()); size != report.LightBlocks {
+		violate(0, "size", Violation{Expected: strconv.Itoa(report.LightBlocks), Actual: strconv.Itoa(size)})
+	}
+	return report, nil
+}
+
+// checkStoredLightBlock checks a stored light block on its own.
+func checkStoredLightBlock(lightBlock *cmttypes.LightBlock, chainID string) (violations []Violation) {
+	check := func(name string, fn func() *Violation) {
+		runCheck(&violations, name, fn)
+	}
+	if lightBlock.SignedHeader == nil || lightBlock.ValidatorSet == nil {
+		return []Violation{{Check: "light_block", Error: "missing signed header or validator set"}}
+	}
+
+	check("chain_id", func() *Violation {
+		if lightBlock.ChainID == chainID {
+			return nil
+		}
+		return &Violation{Expected: chainID, Actual: lightBlock.ChainID}
+	})
+	check("header", func() *Violation {
+		if err := lightBlock.SignedHeader.ValidateBasic(lightBlock.ChainID); err != nil {
+			return &Violation{Error: err.Error()}
+		}
+		return nil
+	})
+	check("validators_hash", func() *Violation {
+		valsHash := cmtbytes.HexBytes(lightBlock.ValidatorSet.Hash())
+		if digestsEqual(lightBlock.ValidatorsHash, valsHash) {
+			return nil
+		}
+		return &Violation{Expected: valsHash.String(), Actual: lightBlock.ValidatorsHash.String()}
+	})
+	check("commit", func() *Violation {
+		return votingPowerViolation(lightBlock.ValidatorSet.VerifyCommitLight(
+			lightBlock.ChainID,
+			lightBlock.Commit.BlockID,
+			lightBlock.Height,
+			lightBlock.Commit,
+		))
+	})
+	return violations
+}
@@ -0,0 +1,68 @@
+package verifier
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/cometbft/cometbft/crypto/tmhash"
+	cmttypes "github.com/cometbft/cometbft/types"
+)
+
+// ErrImplausibleCommit is returned by the strict checks for a header a
+// correct chain wouldn't have committed, even though enough validators
+// signed it.
+var ErrImplausibleCommit = errors.New("implausible commit")
+
+// Strict configures the optional checks of CheckStrict, on top of the
+// verification of the light client, for deployments such as custodians that
+// would rather stall than accept a header no honest network produces.
+type Strict struct {
+	// MaxCommitRound rejects the headers committed after that round, no
+	// bound if zero. Chains commit in the first rounds, a commit in a much
+	// later one suggests a halted network or a forged commit.
+	MaxCommitRound int32
+	// PartSetHeader requires the block ids committed to by the header and
+	// its commit to reference a complete part set: a 32 bytes hash and a
+	// number of parts a block of the maximum size could have.
+	PartSetHeader bool
+}
+
+// Enabled tells whether any strict check is configured.
+func (s Strict) Enabled() bool {
+	return s.MaxCommitRound > 0 || s.PartSetHeader
+}
+
+// CheckStrict runs the configured strict checks on a light block.
+func CheckStrict(lightBlock *cmttypes.LightBlock, strict Strict) error {
+	commit := lightBlock.Commit
+	if strict.MaxCommitRound > 0 && commit.Round > strict.MaxCommitRound {
+		return fmt.Errorf("%w: height %d committed in round %d, after round %d", ErrImplausibleCommit, lightBlock.Height, commit.Round, strict.MaxCommitRound)
+	}
+	if strict.PartSetHeader {
+		if err := checkPartSetHeader(commit.BlockID.PartSetHeader); err != nil {
+			return fmt.Errorf("%w: block id of the commit of height %d: %w", ErrImplausibleCommit, lightBlock.Height, err)
+		}
+		// Only the first block of a chain has no previous one.
+		if lastBlockID := lightBlock.LastBlockID; !lastBlockID.IsZero() {
+			if len(lastBlockID.Hash) != tmhash.Size {
+				return fmt.Errorf("%w: last block id of height %d has a %d bytes hash", ErrImplausibleCommit, lightBlock.Height, len(lastBlockID.Hash))
+			}
+			if err := checkPartSetHeader(lastBlockID.PartSetHeader); err != nil {
+				return fmt.Errorf("%w: last block id of height %d: %w", ErrImplausibleCommit, lightBlock.Height, err)
+			}
+		}
+	}
+	return nil
+}
+
+// checkPartSetHeader checks that a part set header references a complete
+// part set.
+func checkPartSetHeader(psh cmttypes.PartSetHeader) error {
+	if len(psh.Hash) != tmhash.Size {
+		return fmt.Errorf("part set hash of %d bytes", len(psh.Hash))
+	}
+	if psh.Total == 0 || psh.Total > cmttypes.MaxBlockPartsCount {
+		return fmt.Errorf("%d parts, expected 1 to %d", psh.Total, cmttypes.MaxBlockPartsCount)
+	}
+	return nil
+}
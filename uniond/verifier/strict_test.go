@@ -0,0 +1,108 @@
+package verifier_test
+
+import (
+	"testing"
+
+	cmttypes "github.com/cometbft/cometbft/types"
+	"github.com/stretchr/testify/require"
+
+	"union/verifier"
+	"union/verifier/lighttest"
+)
+
+func TestCheckStrict(t *testing.T) {
+	chain, err := lighttest.NewChain("strict-1", 4, 1, lighttest.WithLinkedHeaders())
+	require.NoError(t, err)
+	strict := verifier.Strict{MaxCommitRound: 2, PartSetHeader: true}
+
+	for _, legacy := range []bool{false, true} {
+		lightBlocks, err := chain.LightBlocks(1, 5, legacy)
+		require.NoError(t, err)
+		// The first block has no last block id.
+		require.True(t, lightBlocks[0].LastBlockID.IsZero())
+		for _, lightBlock := range lightBlocks {
+			require.NoError(t, verifier.CheckStrict(lightBlock, strict))
+		}
+	}
+
+	lightBlock, err := chain.LightBlock(3, false)
+	require.NoError(t, err)
+	for _, tc := range []struct {
+		name   string
+		strict verifier.Strict
+		modify func(header *cmttypes.Header, commit *cmttypes.Commit)
+		err    error
+	}{
+		{
+			name:   "late commit round",
+			strict: strict,
+			modify: func(_ *cmttypes.Header, commit *cmttypes.Commit) { commit.Round = 3 },
+			err:    verifier.ErrImplausibleCommit,
+		},
+		{
+			name:   "late commit round unbounded",
+			strict: verifier.Strict{PartSetHeader: true},
+			modify: func(_ *cmttypes.Header, commit *cmttypes.Commit) { commit.Round = 3 },
+		},
+		{
+			name:   "commit without parts",
+			strict: strict,
+			modify: func(_ *cmttypes.Header, commit *cmttypes.Commit) { commit.BlockID.PartSetHeader.Total = 0 },
+			err:    verifier.ErrImplausibleCommit,
+		},
+		{
+			name:   "commit with too many parts",
+			strict: strict,
+			modify: func(_ *cmttypes.Header, commit *cmttypes.Commit) {
+				commit.BlockID.PartSetHeader.Total = cmttypes.MaxBlockPartsCount + 1
+			},
+			err: verifier.ErrImplausibleCommit,
+		},
+		{
+			name:   "commit with a short part set hash",
+			strict: strict,
+			modify: func(_ *cmttypes.Header, commit *cmttypes.Commit) {
+				commit.BlockID.PartSetHeader.Hash = commit.BlockID.PartSetHeader.Hash[:20]
+			},
+			err: verifier.ErrImplausibleCommit,
+		},
+		{
+			name:   "short last block hash",
+			strict: strict,
+			modify: func(header *cmttypes.Header, _ *cmttypes.Commit) {
+				header.LastBlockID.Hash = header.LastBlockID.Hash[:20]
+			},
+			err: verifier.ErrImplausibleCommit,
+		},
+		{
+			name:   "last block without parts",
+			strict: strict,
+			modify: func(header *cmttypes.Header, _ *cmttypes.Commit) { header.LastBlockID.PartSetHeader.Total = 0 },
+			err:    verifier.ErrImplausibleCommit,
+		},
+		{
+			name:   "part set headers unchecked",
+			strict: verifier.Strict{MaxCommitRound: 2},
+			modify: func(header *cmttypes.Header, commit *cmttypes.Commit) {
+				header.LastBlockID.PartSetHeader.Total = 0
+				commit.BlockID.PartSetHeader.Total = 0
+			},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			header := *lightBlock.Header
+			commit := *lightBlock.Commit
+			tc.modify(&header, &commit)
+			modified := &cmttypes.LightBlock{
+				SignedHeader: &cmttypes.SignedHeader{Header: &header, Commit: &commit},
+				ValidatorSet: lightBlock.ValidatorSet,
+			}
+			err := verifier.CheckStrict(modified, tc.strict)
+			if tc.err == nil {
+				require.NoError(t, err)
+			} else {
+				require.ErrorIs(t, err, tc.err)
+			}
+		})
+	}
+}
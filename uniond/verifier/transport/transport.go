@@ -0,0 +1,305 @@
+// Package transport negotiates compressed responses with the RPC endpoints
+// light clients fetch light blocks from, full validator sets being a
+// significant part of the bandwidth of mobile or edge verifiers. Responses
+// are decompressed up to a bound, as the CometBFT client disables the
+// transparent compression of the standard library to prevent decompression
+// bombs. Its providers also check the validator sets they assemble from
+// several pages, and may look them up in a cache first.
+package transport
+
+import (
+	"compress/gzip"
+	"context"
+	"crypto/subtle"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/cometbft/cometbft/light/provider"
+	httpprovider "github.com/cometbft/cometbft/light/provider/http"
+	rpchttp "github.com/cometbft/cometbft/rpc/client/http"
+	ctypes "github.com/cometbft/cometbft/rpc/core/types"
+	jsonrpcclient "github.com/cometbft/cometbft/rpc/jsonrpc/client"
+	cmttypes "github.com/cometbft/cometbft/types"
+	"github.com/klauspost/compress/zstd"
+
+	"union/verifier"
+)
+
+// DefaultMaxDecodedBytes bounds the decompressed size of a response unless
+// configured otherwise.
+const DefaultMaxDecodedBytes = 64 << 20
+
+// AcceptEncoding lists the supported encodings, preferred first.
+const AcceptEncoding = "zstd, gzip"
+
+// ErrResponseTooLarge is returned when a decompressed response exceeds the
+// bound.
+var ErrResponseTooLarge = errors.New("response too large")
+
+// Transport requests compressed responses and decompresses them.
+type Transport struct {
+	// Base performs the requests, http.DefaultTransport if nil.
+	Base http.RoundTripper
+	// MaxDecodedBytes bounds the decompressed size of a response,
+	// DefaultMaxDecodedBytes if zero.
+	MaxDecodedBytes int64
+	// Observe, if set, is called once a response is consumed with the bytes
+	// received on the wire and the bytes after decompression.
+	Observe func(wireBytes, decodedBytes int64)
+}
+
+func (t *Transport) RoundTrip(req *http.Request) (*http.Response, error) {
+	base := t.Base
+	if base == nil {
+		base = http.DefaultTransport
+	}
+	// The request must not be modified by a round tripper.
+	req = req.Clone(req.Context())
+	req.Header.Set("Accept-Encoding", AcceptEncoding)
+	resp, err := base.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+
+	maxDecodedBytes := t.MaxDecodedBytes
+	if maxDecodedBytes == 0 {
+		maxDecodedBytes = DefaultMaxDecodedBytes
+	}
+	wire := &countingReader{r: resp.Body}
+	body := &decodedBody{wire: wire, raw: resp.Body, limit: maxDecodedBytes, observe: t.Observe}
+	switch encoding := strings.ToLower(strings.TrimSpace(resp.Header.Get("Content-Encoding"))); encoding {
+	case "", "identity":
+		body.decoded = wire
+	case "gzip":
+		gz, err := gzip.NewReader(wire)
+		if err != nil {
+			resp.Body.Close()
+			return nil, fmt.Errorf("invalid gzip response: %w", err)
+		}
+		body.decoded, body.decoder = gz, gz
+	case "zstd":
+		zr, err := zstd.NewReader(wire, zstd.WithDecoderConcurrency(1), zstd.WithDecoderMaxMemory(uint64(maxDecodedBytes)))
+		if err != nil {
+			resp.Body.Close()
+			return nil, fmt.Errorf("invalid zstd response: %w", err)
+		}
+		body.decoded, body.decoder = zr, zstdCloser{zr}
+	default:
+		resp.Body.Close()
+		return nil, fmt.Errorf("unsupported response encoding %q", encoding)
+	}
+	resp.Body = body
+	resp.Header.Del("Content-Encoding")
+	resp.Header.Del("Content-Length")
+	resp.ContentLength = -1
+	resp.Uncompressed = true
+	return resp, nil
+}
+
+// NewProvider creates a light client provider fetching light blocks from an
+// RPC endpoint through the transport, with the same defaults as the CometBFT
+// HTTP provider but for the validator sets, see verifier.FetchValidatorSet.
+func NewProvider(chainID, remote string, t *Transport) (provider.Provider, error) {
+	return NewCachedProvider(chainID, remote, t, nil)
+}
+
+// NewCachedProvider creates a provider as NewProvider that first looks the
+// validator sets up in a cache, by the validators hash of their header, and
+// only fetches them from the RPC endpoint when the cache misses or fails,
+// e.g. the validator set cache of a node the light client runs alongside. No
+// cache is used if nil.
+func NewCachedProvider(chainID, remote string, t *Transport, cache ValidatorSetCache) (provider.Provider, error) {
+	rpcClient, err := NewClient(remote, t)
+	if err != nil {
+		return nil, err
+	}
+	return httpprovider.NewWithClient(chainID, &validatorSetClient{
+		HTTP:   rpcClient,
+		cache:  cache,
+		hashes: make(map[int64][]byte),
+	}), nil
+}
+
+// ValidatorSetCache serves the validator sets by hash.
+type ValidatorSetCache interface {
+	// ValidatorSet returns the validator set of the given current or legacy
+	// hash, which is the validator set at the given height, nil if the
+	// cache misses it.
+	ValidatorSet(ctx context.Context, hash []byte, height int64) (*cmttypes.ValidatorSet, error)
+}
+
+// maxCachedHashes bounds the validators hashes remembered for the cache, the
+// provider fetching the validator set right after the header.
+const maxCachedHashes = 64
+
+// validatorSetClient serves the whole validator set of a height as a single
+// page, fetched and checked with verifier.FetchValidatorSet, so that the
+// provider never assembles a truncated or inconsistent set from the pages.
+// With a cache, the validators hash of every fetched header is remembered
+// until the provider asks for the set of its height.
+type validatorSetClient struct {
+	*rpchttp.HTTP
+	cache ValidatorSetCache
+
+	mu     sync.Mutex
+	hashes map[int64][]byte
+}
+
+func (c *validatorSetClient) Commit(ctx context.Context, height *int64) (*ctypes.ResultCommit, error) {
+	res, err := c.HTTP.Commit(ctx, height)
+	if err != nil || c.cache == nil || res.Header == nil {
+		return res, err
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if len(c.hashes) >= maxCachedHashes {
+		clear(c.hashes)
+	}
+	c.hashes[res.Height] = res.ValidatorsHash
+	return res, nil
+}
+
+func (c *validatorSetClient) Validators(ctx context.Context, height *int64, page, perPage *int) (*ctypes.ResultValidators, error) {
+	if page != nil && *page > 1 {
+		return nil, fmt.Errorf("the validator set is served as a single page, got page %d", *page)
+	}
+	if height != nil {
+		if vals := c.cachedValidatorSet(ctx, *height); vals != nil {
+			return singlePage(*height, vals), nil
+		}
+	}
+	setHeight, vals, err := verifier.FetchValidatorSet(ctx, c.HTTP, height)
+	if errors.Is(err, verifier.ErrInvalidValidatorPages) {
+		return nil, provider.ErrBadLightBlock{Reason: err}
+	}
+	if err != nil {
+		return nil, err
+	}
+	return singlePage(setHeight, vals), nil
+}
+
+// cachedValidatorSet returns the validator set of the header fetched at a
+// height from the cache, nil if the cache misses it, fails or serves a set of
+// another hash.
+func (c *validatorSetClient) cachedValidatorSet(ctx context.Context, height int64) (vals *cmttypes.ValidatorSet) {
+	if c.cache == nil {
+		return nil
+	}
+	c.mu.Lock()
+	hash, ok := c.hashes[height]
+	delete(c.hashes, height)
+	c.mu.Unlock()
+	if !ok {
+		return nil
+	}
+	vals, err := c.cache.ValidatorSet(ctx, hash, height)
+	if err != nil || vals == nil {
+		return nil
+	}
+	// Hashing a set under the scheme it wasn't built for may panic.
+	defer func() {
+		if recover() != nil {
+			vals = nil
+		}
+	}()
+	if subtle.ConstantTimeCompare(vals.Hash(), hash) != 1 && subtle.ConstantTimeCompare(vals.HashSha256(), hash) != 1 {
+		return nil
+	}
+	return vals
+}
+
+func singlePage(height int64, vals *cmttypes.ValidatorSet) *ctypes.ResultValidators {
+	return &ctypes.ResultValidators{
+		BlockHeight: height,
+		Validators:  vals.Validators,
+		Count:       len(vals.Validators),
+		Total:       len(vals.Validators),
+	}
+}
+
+// NewClient creates an RPC client of an endpoint requesting its responses
+// through the transport, with the timeout of the CometBFT HTTP provider.
+func NewClient(remote string, t *Transport) (*rpchttp.HTTP, error) {
+	if !strings.Contains(remote, "://") {
+		remote = "http://" + remote
+	}
+	client, err := jsonrpcclient.DefaultHTTPClient(remote)
+	if err != nil {
+		return nil, err
+	}
+	base := *t
+	base.Base = client.Transport
+	client.Transport = &base
+	client.Timeout = 5 * time.Second
+	return rpchttp.NewWithClient(remote, "/websocket", client)
+}
+
+type countingReader struct {
+	r io.Reader
+	n int64
+}
+
+func (c *countingReader) Read(p []byte) (int, error) {
+	n, err := c.r.Read(p)
+	c.n += int64(n)
+	return n, err
+}
+
+// decodedBody reads the decompressed response, failing past the limit.
+type decodedBody struct {
+	wire     *countingReader
+	raw      io.Closer
+	decoded  io.Reader
+	decoder  io.Closer
+	n        int64
+	limit    int64
+	observe  func(wireBytes, decodedBytes int64)
+	observed bool
+}
+
+func (b *decodedBody) Read(p []byte) (int, error) {
+	// Reading a byte past the limit tells a response of exactly the limit
+	// apart from a larger one.
+	if int64(len(p)) > b.limit-b.n+1 {
+		p = p[:b.limit-b.n+1]
+	}
+	n, err := b.decoded.Read(p)
+	b.n += int64(n)
+	if b.n > b.limit {
+		return 0, fmt.Errorf("%w: more than %d bytes once decompressed", ErrResponseTooLarge, b.limit)
+	}
+	if err == io.EOF {
+		b.done()
+	}
+	return n, err
+}
+
+func (b *decodedBody) Close() error {
+	b.done()
+	if b.decoder != nil {
+		b.decoder.Close()
+	}
+	return b.raw.Close()
+}
+
+func (b *decodedBody) done() {
+	if b.observe != nil && !b.observed {
+		b.observed = true
+		b.observe(b.wire.n, b.n)
+	}
+}
+
+// zstdCloser adapts the zstd decoder, whose Close doesn't return an error.
+type zstdCloser struct {
+	*zstd.Decoder
+}
+
+func (z zstdCloser) Close() error {
+	z.Decoder.Close()
+	return nil
+}
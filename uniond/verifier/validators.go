@@ -0,0 +1,122 @@
+package verifier
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+
+	ctypes "github.com/cometbft/cometbft/rpc/core/types"
+	cmttypes "github.com/cometbft/cometbft/types"
+)
+
+// ErrInvalidValidatorPages is returned when the pages of a validator set
+// served by a node don't assemble into a complete set.
+var ErrInvalidValidatorPages = errors.New("invalid validator pages")
+
+// ValidatorsPerPage is the largest page of the RPC validators endpoint.
+const ValidatorsPerPage = 100
+
+// maxValidatorPages bounds the pages fetched for a set, as a hostile node
+// could announce any total: 10,000 validators as the CometBFT HTTP provider.
+const maxValidatorPages = 100
+
+// ValidatorsClient serves the pages of the validator sets, e.g. an RPC
+// client.
+type ValidatorsClient interface {
+	Validators(ctx context.Context, height *int64, page, perPage *int) (*ctypes.ResultValidators, error)
+}
+
+// FetchValidatorSet fetches every page of the validator set at a height, the
+// latest if nil, and returns the height of the set along with the assembled
+// set. The pages are pinned to the height of the first one, so that a set
+// changing while it is fetched can't be mixed with the next one, and the
+// assembled set is checked with AssembleValidatorSet.
+func FetchValidatorSet(ctx context.Context, client ValidatorsClient, height *int64) (int64, *cmttypes.ValidatorSet, error) {
+	var pages []*ctypes.ResultValidators
+	perPage := ValidatorsPerPage
+	for page, fetched := 1, 0; ; page++ {
+		if page > maxValidatorPages {
+			return 0, nil, fmt.Errorf("%w: more than %d pages of %d validators", ErrInvalidValidatorPages, maxValidatorPages, perPage)
+		}
+		result, err := client.Validators(ctx, height, &page, &perPage)
+		if err != nil {
+			return 0, nil, err
+		}
+		height = &result.BlockHeight
+		pages = append(pages, result)
+		fetched += len(result.Validators)
+		// An empty page ends the set too, AssembleValidatorSet then
+		// reporting the missing validators.
+		if fetched >= result.Total || len(result.Validators) == 0 {
+			break
+		}
+	}
+	vals, err := AssembleValidatorSet(pages)
+	if err != nil {
+		return 0, nil, err
+	}
+	return *height, vals, nil
+}
+
+// AssembleValidatorSet assembles the pages of a validator set, in order. The
+// pages must be of the same height and total, and hold that total of
+// distinct validators sorted as CometBFT sorts the sets, by decreasing voting
+// power then increasing address, whose total voting power doesn't exceed
+// MaxTotalVotingPower. A truncated or inconsistent set is rejected, as it
+// would otherwise only fail later as a validators hash mismatch.
+func AssembleValidatorSet(pages []*ctypes.ResultValidators) (*cmttypes.ValidatorSet, error) {
+	if len(pages) == 0 {
+		return nil, fmt.Errorf("%w: no page", ErrInvalidValidatorPages)
+	}
+	first := pages[0]
+	var validators []*cmttypes.Validator
+	for i, page := range pages {
+		if page.BlockHeight != first.BlockHeight {
+			return nil, fmt.Errorf("%w: page %d of height %d, page 1 of height %d", ErrInvalidValidatorPages, i+1, page.BlockHeight, first.BlockHeight)
+		}
+		if page.Total != first.Total {
+			return nil, fmt.Errorf("%w: page %d announces %d validators, page 1 %d", ErrInvalidValidatorPages, i+1, page.Total, first.Total)
+		}
+		validators = append(validators, page.Validators...)
+	}
+	if first.Total <= 0 || len(validators) != first.Total {
+		return nil, fmt.Errorf("%w: %d validators assembled at height %d, %d announced", ErrInvalidValidatorPages, len(validators), first.BlockHeight, first.Total)
+	}
+
+	var totalVotingPower int64
+	for i, val := range validators {
+		if val == nil {
+			return nil, fmt.Errorf("%w: validator #%d is missing", ErrInvalidValidatorPages, i)
+		}
+		if err := val.ValidateBasic(); err != nil {
+			return nil, fmt.Errorf("%w: validator #%d: %w", ErrInvalidValidatorPages, i, err)
+		}
+		// Both are bounded by MaxTotalVotingPower, their sum can't
+		// overflow.
+		if totalVotingPower += val.VotingPower; totalVotingPower > cmttypes.MaxTotalVotingPower {
+			return nil, fmt.Errorf("%w: total voting power exceeds %d", ErrInvalidValidatorPages, cmttypes.MaxTotalVotingPower)
+		}
+		if i == 0 {
+			continue
+		}
+		previous := validators[i-1]
+		switch {
+		case previous.VotingPower < val.VotingPower:
+			return nil, fmt.Errorf("%w: validator #%d has more voting power than #%d", ErrInvalidValidatorPages, i, i-1)
+		case previous.VotingPower > val.VotingPower:
+		case bytes.Compare(previous.Address, val.Address) > 0:
+			return nil, fmt.Errorf("%w: validators #%d and #%d of equal voting power aren't sorted by address", ErrInvalidValidatorPages, i-1, i)
+		}
+	}
+	// Pages overlapping, e.g. as the node paginates differently than
+	// announced, serve validators twice.
+	seen := make(map[string]struct{}, len(validators))
+	for _, val := range validators {
+		if _, ok := seen[string(val.Address)]; ok {
+			return nil, fmt.Errorf("%w: validator %X is served twice", ErrInvalidValidatorPages, val.Address)
+		}
+		seen[string(val.Address)] = struct{}{}
+	}
+	return cmttypes.ValidatorSetFromExistingValidators(validators)
+}
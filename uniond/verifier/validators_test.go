@@ -0,0 +1,188 @@
+package verifier_test
+
+import (
+	"context"
+	"testing"
+
+	ctypes "github.com/cometbft/cometbft/rpc/core/types"
+	cmttypes "github.com/cometbft/cometbft/types"
+	"github.com/stretchr/testify/require"
+
+	"union/verifier"
+	"union/verifier/lighttest"
+)
+
+// validatorsFunc serves the pages of a validator set, recording the heights
+// they are requested at.
+type validatorsFunc struct {
+	page    func(page int) *ctypes.ResultValidators
+	heights []*int64
+}
+
+func (f *validatorsFunc) Validators(_ context.Context, height *int64, page, _ *int) (*ctypes.ResultValidators, error) {
+	f.heights = append(f.heights, height)
+	return f.page(*page), nil
+}
+
+// paginate splits validators into pages of the given size.
+func paginate(height int64, validators []*cmttypes.Validator, size int) []*ctypes.ResultValidators {
+	var pages []*ctypes.ResultValidators
+	for i := 0; i < len(validators); i += size {
+		pages = append(pages, &ctypes.ResultValidators{
+			BlockHeight: height,
+			Validators:  validators[i:min(i+size, len(validators))],
+			Count:       min(size, len(validators)-i),
+			Total:       len(validators),
+		})
+	}
+	return pages
+}
+
+func TestAssembleValidatorSet(t *testing.T) {
+	chain, err := lighttest.NewChain("validators-1", 7, 1)
+	require.NoError(t, err)
+	vals, err := chain.ValidatorSet(1)
+	require.NoError(t, err)
+
+	for _, tc := range []struct {
+		name   string
+		pages  func() []*ctypes.ResultValidators
+		reason string
+	}{
+		{
+			name:  "pages in order",
+			pages: func() []*ctypes.ResultValidators { return paginate(1, vals.Validators, 3) },
+		},
+		{
+			name: "validator duplicated across pages",
+			pages: func() []*ctypes.ResultValidators {
+				// The second page overlaps the first one by a validator.
+				pages := paginate(1, vals.Validators, 3)
+				pages[1].Validators = append([]*cmttypes.Validator{vals.Validators[2]}, vals.Validators[3:5]...)
+				return pages
+			},
+			reason: "served twice",
+		},
+		{
+			name: "pages out of order",
+			pages: func() []*ctypes.ResultValidators {
+				pages := paginate(1, vals.Validators, 3)
+				pages[0], pages[1] = pages[1], pages[0]
+				return pages
+			},
+			reason: "more voting power than",
+		},
+		{
+			name: "total voting power above the maximum",
+			pages: func() []*ctypes.ResultValidators {
+				validators := make([]*cmttypes.Validator, len(vals.Validators))
+				for i, val := range vals.Validators {
+					validators[i] = val.Copy()
+					validators[i].VotingPower = cmttypes.MaxTotalVotingPower/4 - int64(i)
+				}
+				return paginate(1, validators, 3)
+			},
+			reason: "total voting power exceeds",
+		},
+		{
+			name: "total disagreeing between pages",
+			pages: func() []*ctypes.ResultValidators {
+				pages := paginate(1, vals.Validators, 3)
+				pages[2].Total++
+				return pages
+			},
+			reason: "page 3 announces 8 validators, page 1 7",
+		},
+		{
+			name: "missing page",
+			pages: func() []*ctypes.ResultValidators {
+				return paginate(1, vals.Validators, 3)[:2]
+			},
+			reason: "6 validators assembled at height 1, 7 announced",
+		},
+		{
+			name: "page of another height",
+			pages: func() []*ctypes.ResultValidators {
+				pages := paginate(1, vals.Validators, 3)
+				pages[1].BlockHeight = 2
+				return pages
+			},
+			reason: "page 2 of height 2",
+		},
+		{
+			name:   "no page",
+			pages:  func() []*ctypes.ResultValidators { return nil },
+			reason: "no page",
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			assembled, err := verifier.AssembleValidatorSet(tc.pages())
+			if tc.reason == "" {
+				require.NoError(t, err)
+				require.Equal(t, vals.Hash(), assembled.Hash())
+				return
+			}
+			require.ErrorIs(t, err, verifier.ErrInvalidValidatorPages)
+			require.ErrorContains(t, err, tc.reason)
+		})
+	}
+}
+
+func TestFetchValidatorSet(t *testing.T) {
+	chain, err := lighttest.NewChain("validators-1", 7, 1)
+	require.NoError(t, err)
+	vals, err := chain.ValidatorSet(1)
+	require.NoError(t, err)
+
+	t.Run("pinned to the height of the first page", func(t *testing.T) {
+		pages := paginate(5, vals.Validators, 3)
+		client := &validatorsFunc{page: func(page int) *ctypes.ResultValidators { return pages[page-1] }}
+		height, fetched, err := verifier.FetchValidatorSet(context.Background(), client, nil)
+		require.NoError(t, err)
+		require.Equal(t, int64(5), height)
+		require.Equal(t, vals.Hash(), fetched.Hash())
+		require.Len(t, client.heights, 3)
+		require.Nil(t, client.heights[0])
+		for _, height := range client.heights[1:] {
+			require.Equal(t, int64(5), *height)
+		}
+	})
+
+	t.Run("duplicated validator", func(t *testing.T) {
+		// The node paginates by 3, but its second page starts at the third
+		// validator: the sixth one is never served.
+		pages := paginate(5, vals.Validators, 3)
+		pages[1].Validators = append([]*cmttypes.Validator{vals.Validators[2]}, vals.Validators[3:5]...)
+		client := &validatorsFunc{page: func(page int) *ctypes.ResultValidators { return pages[page-1] }}
+		_, _, err := verifier.FetchValidatorSet(context.Background(), client, nil)
+		require.ErrorIs(t, err, verifier.ErrInvalidValidatorPages)
+		require.ErrorContains(t, err, "served twice")
+	})
+
+	t.Run("total disagreeing between pages", func(t *testing.T) {
+		// A total raised after the first page can't extend the set.
+		pages := paginate(5, vals.Validators, 3)
+		pages[1].Total = 100
+		client := &validatorsFunc{page: func(page int) *ctypes.ResultValidators {
+			if page > len(pages) {
+				return &ctypes.ResultValidators{BlockHeight: 5, Total: 100}
+			}
+			return pages[page-1]
+		}}
+		_, _, err := verifier.FetchValidatorSet(context.Background(), client, nil)
+		require.ErrorIs(t, err, verifier.ErrInvalidValidatorPages)
+		require.ErrorContains(t, err, "page 2 announces 100 validators, page 1 7")
+	})
+
+	t.Run("page cap", func(t *testing.T) {
+		// A hostile node announcing a huge set, served one validator at a
+		// time.
+		client := &validatorsFunc{page: func(page int) *ctypes.ResultValidators {
+			return &ctypes.ResultValidators{BlockHeight: 5, Validators: vals.Validators[:1], Count: 1, Total: 1_000_000}
+		}}
+		_, _, err := verifier.FetchValidatorSet(context.Background(), client, nil)
+		require.ErrorIs(t, err, verifier.ErrInvalidValidatorPages)
+		require.ErrorContains(t, err, "more than 100 pages")
+		require.Len(t, client.heights, 100)
+	})
+}
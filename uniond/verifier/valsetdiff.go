@@ -0,0 +1,83 @@
+package verifier
+
+import (
+	"bytes"
+	"sort"
+
+	cmtbytes "github.com/cometbft/cometbft/libs/bytes"
+	cmtmath "github.com/cometbft/cometbft/libs/math"
+	cmttypes "github.com/cometbft/cometbft/types"
+)
+
+// ValidatorChange is a validator added to, removed from or whose voting power
+// changed between two validator sets, a power being 0 in the set the
+// validator isn't part of.
+type ValidatorChange struct {
+	Address cmtbytes.HexBytes `json:"address"`
+	PowerA  int64             `json:"power_a"`
+	PowerB  int64             `json:"power_b"`
+}
+
+// ValidatorSetDiff compares a trusted validator set A to a later set B.
+type ValidatorSetDiff struct {
+	Added   []ValidatorChange `json:"added"`
+	Removed []ValidatorChange `json:"removed"`
+	Changed []ValidatorChange `json:"changed"`
+	// TotalPowerA and TotalPowerB are the total voting powers of the sets.
+	TotalPowerA int64 `json:"total_power_a"`
+	TotalPowerB int64 `json:"total_power_b"`
+	// OverlapPower is the voting power in A of its validators still in B,
+	// the only ones of A able to sign a commit of B.
+	OverlapPower int64 `json:"overlap_power"`
+	// Overlap is OverlapPower as a fraction of TotalPowerA.
+	Overlap float64 `json:"overlap"`
+	// Skippable tells whether OverlapPower exceeds the trust level, computed
+	// as the light client does, i.e. whether a header committed by B can be
+	// verified from A once all the overlapping validators sign it.
+	Skippable bool `json:"skippable"`
+}
+
+// DiffValidatorSets compares a trusted validator set to a later one, checking
+// their overlap against a trust level. The changes are sorted by address.
+func DiffValidatorSets(a, b *cmttypes.ValidatorSet, trustLevel cmtmath.Fraction) (*ValidatorSetDiff, error) {
+	if err := CheckTrustMath(a, trustLevel); err != nil {
+		return nil, err
+	}
+	if err := CheckValidatorSetPowers(b); err != nil {
+		return nil, err
+	}
+	diff := &ValidatorSetDiff{
+		Added:       []ValidatorChange{},
+		Removed:     []ValidatorChange{},
+		Changed:     []ValidatorChange{},
+		TotalPowerA: a.TotalVotingPower(),
+		TotalPowerB: b.TotalVotingPower(),
+	}
+	for _, val := range a.Validators {
+		_, next := b.GetByAddress(val.Address)
+		if next == nil {
+			diff.Removed = append(diff.Removed, ValidatorChange{Address: val.Address, PowerA: val.VotingPower})
+			continue
+		}
+		diff.OverlapPower += val.VotingPower
+		if next.VotingPower != val.VotingPower {
+			diff.Changed = append(diff.Changed, ValidatorChange{Address: val.Address, PowerA: val.VotingPower, PowerB: next.VotingPower})
+		}
+	}
+	for _, val := range b.Validators {
+		if !a.HasAddress(val.Address) {
+			diff.Added = append(diff.Added, ValidatorChange{Address: val.Address, PowerB: val.VotingPower})
+		}
+	}
+	for _, changes := range [][]ValidatorChange{diff.Added, diff.Removed, diff.Changed} {
+		sort.Slice(changes, func(i, j int) bool {
+			return bytes.Compare(changes[i].Address, changes[j].Address) < 0
+		})
+	}
+	if diff.TotalPowerA > 0 {
+		diff.Overlap = float64(diff.OverlapPower) / float64(diff.TotalPowerA)
+	}
+	needed := diff.TotalPowerA * int64(trustLevel.Numerator) / int64(trustLevel.Denominator)
+	diff.Skippable = diff.OverlapPower > needed
+	return diff, nil
+}
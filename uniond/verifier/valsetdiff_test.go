@@ -0,0 +1,120 @@
+package verifier_test
+
+import (
+	"testing"
+
+	"github.com/cometbft/cometbft/crypto"
+	cmtmath "github.com/cometbft/cometbft/libs/math"
+	cmttypes "github.com/cometbft/cometbft/types"
+	"github.com/stretchr/testify/require"
+
+	"union/verifier"
+	"union/verifier/lighttest"
+)
+
+// addresses returns the hex addresses of changes, which sort as the
+// addresses.
+func addresses(changes []verifier.ValidatorChange) []string {
+	hex := make([]string, len(changes))
+	for i, change := range changes {
+		hex[i] = change.Address.String()
+	}
+	return hex
+}
+
+func TestDiffValidatorSets(t *testing.T) {
+	chain, err := lighttest.NewChain("valsetdiff-1", 5, 1)
+	require.NoError(t, err)
+	genesis, err := chain.ValidatorSet(1)
+	require.NoError(t, err)
+	keys := make([]crypto.PubKey, len(genesis.Validators))
+	for i, val := range genesis.Validators {
+		keys[i] = val.PubKey
+	}
+	// set builds a validator set of the given voting powers, by key index.
+	set := func(powers map[int]int64) *cmttypes.ValidatorSet {
+		vals := make([]*cmttypes.Validator, 0, len(powers))
+		for i, power := range powers {
+			vals = append(vals, cmttypes.NewValidator(keys[i], power))
+		}
+		return cmttypes.NewValidatorSet(vals)
+	}
+	change := func(i int, powerA, powerB int64) verifier.ValidatorChange {
+		return verifier.ValidatorChange{Address: keys[i].Address(), PowerA: powerA, PowerB: powerB}
+	}
+	trusted := set(map[int]int64{0: 40, 1: 30, 2: 20, 3: 10})
+	oneThird := cmtmath.Fraction{Numerator: 1, Denominator: 3}
+
+	for _, tc := range []struct {
+		name    string
+		b       *cmttypes.ValidatorSet
+		added   []verifier.ValidatorChange
+		removed []verifier.ValidatorChange
+		changed []verifier.ValidatorChange
+		overlap int64
+		skip    bool
+	}{
+		{
+			name:    "same set",
+			b:       set(map[int]int64{0: 40, 1: 30, 2: 20, 3: 10}),
+			overlap: 100,
+			skip:    true,
+		},
+		{
+			name:    "validator added",
+			b:       set(map[int]int64{0: 40, 1: 30, 2: 20, 3: 10, 4: 25}),
+			added:   []verifier.ValidatorChange{change(4, 0, 25)},
+			overlap: 100,
+			skip:    true,
+		},
+		{
+			name:    "validator removed",
+			b:       set(map[int]int64{0: 40, 1: 30, 2: 20}),
+			removed: []verifier.ValidatorChange{change(3, 10, 0)},
+			overlap: 90,
+			skip:    true,
+		},
+		{
+			// The power of the overlap is the one in the trusted set.
+			name:    "validators re-weighted",
+			b:       set(map[int]int64{0: 1, 1: 30, 2: 200, 3: 10}),
+			changed: []verifier.ValidatorChange{change(0, 40, 1), change(2, 20, 200)},
+			overlap: 100,
+			skip:    true,
+		},
+		{
+			name:    "added, removed and re-weighted",
+			b:       set(map[int]int64{0: 40, 1: 35, 2: 20, 4: 25}),
+			added:   []verifier.ValidatorChange{change(4, 0, 25)},
+			removed: []verifier.ValidatorChange{change(3, 10, 0)},
+			changed: []verifier.ValidatorChange{change(1, 30, 35)},
+			overlap: 90,
+			skip:    true,
+		},
+		{
+			// 30 of 100 doesn't exceed a third of the trusted power.
+			name:    "not skippable",
+			b:       set(map[int]int64{2: 20, 3: 10, 4: 100}),
+			added:   []verifier.ValidatorChange{change(4, 0, 100)},
+			removed: []verifier.ValidatorChange{change(0, 40, 0), change(1, 30, 0)},
+			overlap: 30,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			diff, err := verifier.DiffValidatorSets(trusted, tc.b, oneThird)
+			require.NoError(t, err)
+			require.ElementsMatch(t, tc.added, diff.Added)
+			require.ElementsMatch(t, tc.removed, diff.Removed)
+			require.ElementsMatch(t, tc.changed, diff.Changed)
+			for _, changes := range [][]verifier.ValidatorChange{diff.Added, diff.Removed, diff.Changed} {
+				require.NotNil(t, changes)
+				require.IsIncreasing(t, addresses(changes))
+			}
+			require.Equal(t, int64(100), diff.TotalPowerA)
+			require.Equal(t, tc.b.TotalVotingPower(), diff.TotalPowerB)
+			require.Equal(t, tc.overlap, diff.OverlapPower)
+			require.InDelta(t, float64(tc.overlap)/100, diff.Overlap, 1e-9)
+			require.Equal(t, tc.skip, diff.Skippable)
+		})
+	}
+}
@@ -0,0 +1,48 @@
+package verifier
+
+import (
+	"errors"
+	"fmt"
+
+	cmttypes "github.com/cometbft/cometbft/types"
+)
+
+// ErrValidatorsHashMismatch is returned for a validator set rebuilt from
+// updates that isn't the one a header commits to.
+var ErrValidatorsHashMismatch = errors.New("validators hash mismatch")
+
+// ApplyValidatorUpdates applies the changes of a validator set from one header
+// to a later one, e.g. the bonding and unbonding deltas fetched from a
+// provider, instead of fetching and decoding the whole set again. A removed
+// validator has no voting power, as for ValidatorSet.UpdateWithChangeSet.
+//
+// The resulting set is checked against the validators hash of the header,
+// current (MiMC) or legacy (SHA-256), so that missing or forged updates can't
+// go unnoticed. The given set is left untouched and the proposer priorities of
+// the result aren't the ones of the chain, which the light client doesn't
+// check.
+func ApplyValidatorUpdates(vals *cmttypes.ValidatorSet, updates []*cmttypes.Validator, header *cmttypes.Header) (*cmttypes.ValidatorSet, error) {
+	if vals == nil || header == nil {
+		return nil, errors.New("the validator set and the header are required")
+	}
+	next := vals.Copy()
+	if len(updates) > 0 {
+		changes := make([]*cmttypes.Validator, len(updates))
+		for i, update := range updates {
+			if update == nil {
+				return nil, fmt.Errorf("validator update #%d is missing", i)
+			}
+			changes[i] = update.Copy()
+		}
+		if err := next.UpdateWithChangeSet(changes); err != nil {
+			return nil, err
+		}
+	}
+	if err := CheckValidatorSetPowers(next); err != nil {
+		return nil, err
+	}
+	if !digestsEqual(header.ValidatorsHash, next.Hash()) && !digestsEqual(header.ValidatorsHash, next.HashSha256()) {
+		return nil, fmt.Errorf("%w: header at height %d commits to %X", ErrValidatorsHashMismatch, header.Height, header.ValidatorsHash)
+	}
+	return next, nil
+}
@@ -0,0 +1,239 @@
+package verifier
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	cmtmath "github.com/cometbft/cometbft/libs/math"
+	"github.com/cometbft/cometbft/light"
+	cmtproto "github.com/cometbft/cometbft/proto/tendermint/types"
+	cmttypes "github.com/cometbft/cometbft/types"
+
+	"union/verifier/lighttest"
+)
+
+// VectorsVersion is the version of the conformance vectors format.
+const VectorsVersion = 1
+
+// Vectors is a set of conformance vectors, exchanged as JSON so that every
+// light client implementation can check that it reaches the same verdicts as
+// this verifier.
+type Vectors struct {
+	Version int      `json:"version"`
+	Vectors []Vector `json:"vectors"`
+}
+
+// Vector is a verification input along with its expected verdict. Durations
+// are in seconds, the time is RFC3339.
+type Vector struct {
+	Name           string         `json:"name"`
+	Description    string         `json:"description"`
+	Trusted        VectorBlock    `json:"trusted"`
+	Untrusted      VectorBlock    `json:"untrusted"`
+	TrustingPeriod int64          `json:"trusting_period_secs"`
+	MaxClockDrift  int64          `json:"max_clock_drift_secs"`
+	TrustLevel     VectorFraction `json:"trust_level"`
+	Legacy         bool           `json:"legacy"`
+	Now            time.Time      `json:"now"`
+	Expected       VectorVerdict  `json:"expected"`
+}
+
+// VectorFraction is a trust level, e.g. 1/3.
+type VectorFraction struct {
+	Numerator   uint64 `json:"numerator"`
+	Denominator uint64 `json:"denominator"`
+}
+
+// VectorVerdict is the outcome of the verification of a vector. The error
+// class is one of expired, untrusted_validator_set, invalid_header and other,
+// empty if verified.
+type VectorVerdict struct {
+	Verified   bool   `json:"verified"`
+	ErrorClass string `json:"error_class,omitempty"`
+}
+
+// VectorBlock is a light block encoded as a 0x prefixed hex protobuf
+// tendermint.types.LightBlock.
+type VectorBlock struct {
+	*cmttypes.LightBlock
+}
+
+func (b VectorBlock) MarshalJSON() ([]byte, error) {
+	pb, err := b.ToProto()
+	if err != nil {
+		return nil, err
+	}
+	bz, err := pb.Marshal()
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal("0x" + hex.EncodeToString(bz))
+}
+
+func (b *VectorBlock) UnmarshalJSON(bz []byte) error {
+	var s string
+	if err := json.Unmarshal(bz, &s); err != nil {
+		return err
+	}
+	if !strings.HasPrefix(s, "0x") {
+		return fmt.Errorf("light block must be 0x prefixed hex")
+	}
+	raw, err := hex.DecodeString(s[2:])
+	if err != nil {
+		return err
+	}
+	var pb cmtproto.LightBlock
+	if err := pb.Unmarshal(raw); err != nil {
+		return err
+	}
+	lightBlock, err := cmttypes.LightBlockFromProto(&pb)
+	if err != nil {
+		return err
+	}
+	if lightBlock.SignedHeader == nil || lightBlock.ValidatorSet == nil {
+		return fmt.Errorf("light block must contain both a signed header and a validator set")
+	}
+	b.LightBlock = lightBlock
+	return nil
+}
+
+// Run verifies the vector and returns the verdict along with the verification
+// error.
+func (v *Vector) Run() (VectorVerdict, error) {
+	verify := light.Verify
+	if v.Legacy {
+		verify = light.VerifyLegacy
+	}
+	err := verify(
+		v.Trusted.SignedHeader,
+		v.Trusted.ValidatorSet,
+		v.Untrusted.SignedHeader,
+		v.Untrusted.ValidatorSet,
+		time.Duration(v.TrustingPeriod)*time.Second,
+		v.Now,
+		time.Duration(v.MaxClockDrift)*time.Second,
+		cmtmath.Fraction{Numerator: v.TrustLevel.Numerator, Denominator: v.TrustLevel.Denominator},
+	)
+	if err != nil {
+		return VectorVerdict{ErrorClass: errorClass(err)}, err
+	}
+	return VectorVerdict{Verified: true}, nil
+}
+
+// GenerateVectors generates the conformance vectors on chains of the given
+// number of validators derived from the seed: honest transitions, transitions
+// violating the verification rules and the lighttest attack corpus, each in
+// the current and the legacy modes. The verdict of every vector is checked
+// against this verifier.
+func GenerateVectors(validators int, seed int64) (*Vectors, error) {
+	vectors := &Vectors{Version: VectorsVersion}
+	for _, legacy := range []bool{false, true} {
+		prefix := ""
+		if legacy {
+			prefix = "legacy_"
+		}
+		chain, err := lighttest.NewChain("conformance-1", validators, seed)
+		if err != nil {
+			return nil, err
+		}
+		// Half the validators are replaced at every height, so that the
+		// trusted validators don't hold enough voting power after a few
+		// heights.
+		churning, err := lighttest.NewChain("conformance-1", validators, seed, lighttest.WithChurn((validators+1)/2))
+		if err != nil {
+			return nil, err
+		}
+		newVector := func(name, description string, chain *lighttest.Chain, trustedHeight, untrustedHeight int64, expected VectorVerdict) (Vector, error) {
+			trusted, err := chain.LightBlock(trustedHeight, legacy)
+			if err != nil {
+				return Vector{}, err
+			}
+			untrusted, err := chain.LightBlock(untrustedHeight, legacy)
+			if err != nil {
+				return Vector{}, err
+			}
+			return Vector{
+				Name:           prefix + name,
+				Description:    description,
+				Trusted:        VectorBlock{trusted},
+				Untrusted:      VectorBlock{untrusted},
+				TrustingPeriod: int64((168 * time.Hour).Seconds()),
+				MaxClockDrift:  10,
+				TrustLevel:     VectorFraction{light.DefaultTrustLevel.Numerator, light.DefaultTrustLevel.Denominator},
+				Legacy:         legacy,
+				Now:            untrusted.Time.Add(time.Minute),
+				Expected:       expected,
+			}, nil
+		}
+		verified := VectorVerdict{Verified: true}
+
+		adjacent, err := newVector("adjacent", "Adjacent headers signed by the same validators.", chain, 1, 2, verified)
+		if err != nil {
+			return nil, err
+		}
+		nonAdjacent, err := newVector("non_adjacent", "Non adjacent headers signed by the same validators.", chain, 1, 100, verified)
+		if err != nil {
+			return nil, err
+		}
+		churnAdjacent, err := newVector("churn_adjacent", "Adjacent headers across a validator set change.", churning, 1, 2, verified)
+		if err != nil {
+			return nil, err
+		}
+		churnNonAdjacent, err := newVector("churn_non_adjacent", "Non adjacent headers after most of the trusted validators left.", churning, 1, 10, VectorVerdict{ErrorClass: "untrusted_validator_set"})
+		if err != nil {
+			return nil, err
+		}
+		clockDrift, err := newVector("clock_drift", "Untrusted header from the future, beyond the allowed clock drift.", chain, 1, 2, VectorVerdict{ErrorClass: "invalid_header"})
+		if err != nil {
+			return nil, err
+		}
+		clockDrift.Now = clockDrift.Untrusted.Time.Add(-time.Minute)
+		vectors.Vectors = append(vectors.Vectors, adjacent, nonAdjacent, churnAdjacent, churnNonAdjacent, clockDrift)
+
+		attacks, err := lighttest.Attacks(validators, seed, legacy)
+		if err != nil {
+			return nil, err
+		}
+		for _, attack := range attacks {
+			vector := Vector{
+				Name:           prefix + string(attack.Kind),
+				Trusted:        VectorBlock{attack.Trusted},
+				Untrusted:      VectorBlock{attack.Untrusted},
+				TrustingPeriod: int64(attack.TrustingPeriod.Seconds()),
+				MaxClockDrift:  int64(attack.MaxClockDrift.Seconds()),
+				TrustLevel:     VectorFraction{attack.TrustLevel.Numerator, attack.TrustLevel.Denominator},
+				Legacy:         legacy,
+				Now:            attack.Now,
+			}
+			switch attack.Kind {
+			case lighttest.AttackLunatic:
+				vector.Description = "Header with a forged application state signed by unknown validators."
+				vector.Expected = VectorVerdict{ErrorClass: "untrusted_validator_set"}
+			case lighttest.AttackEquivocation:
+				vector.Description = "Header conflicting with the honest one, signed by the same validators. It verifies, only cross checking detects it."
+				vector.Expected = verified
+			case lighttest.AttackForgedValidatorsHash:
+				vector.Description = "Validator set not matching the one the header commits to."
+				vector.Expected = VectorVerdict{ErrorClass: "invalid_header"}
+			case lighttest.AttackExpiredTrustAnchor:
+				vector.Description = "Trusted header out of the trusting period."
+				vector.Expected = VectorVerdict{ErrorClass: "expired"}
+			default:
+				return nil, fmt.Errorf("no expected verdict for attack %q", attack.Kind)
+			}
+			vectors.Vectors = append(vectors.Vectors, vector)
+		}
+	}
+
+	for i := range vectors.Vectors {
+		vector := &vectors.Vectors[i]
+		verdict, err := vector.Run()
+		if verdict != vector.Expected {
+			return nil, fmt.Errorf("vector %s: expected %+v, got %+v (%v)", vector.Name, vector.Expected, verdict, err)
+		}
+	}
+	return vectors, nil
+}
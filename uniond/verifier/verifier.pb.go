@@ -0,0 +1,2937 @@
+//lint:file-ignore SA1019 This code is generated
+// Code generated by protoc-gen-gogo. DO NOT EDIT.
+// source: union/verifier/v1/verifier.proto
+
+package verifier
+
+import (
+	context "context"
+	fmt "fmt"
+	types "github.com/cometbft/cometbft/proto/tendermint/types"
+	_ "github.com/cosmos/gogoproto/gogoproto"
+	grpc1 "github.com/cosmos/gogoproto/grpc"
+	proto "github.com/cosmos/gogoproto/proto"
+	github_com_cosmos_gogoproto_types "github.com/cosmos/gogoproto/types"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+	_ "google.golang.org/protobuf/types/known/durationpb"
+	_ "google.golang.org/protobuf/types/known/timestamppb"
+	io "io"
+	math "math"
+	math_bits "math/bits"
+	time "time"
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ = proto.Marshal
+var _ = fmt.Errorf
+var _ = math.Inf
+var _ = time.Kitchen
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the proto package it is being compiled against.
+// A compilation error at this line likely means your copy of the
+// proto package needs to be updated.
+const _ = proto.GoGoProtoPackageIsVersion3 // please upgrade the proto package
+
+// Fraction is a trust level, e.g. 1/3.
+type Fraction struct {
+	Numerator   uint64 `protobuf:"varint,1,opt,name=numerator,proto3" json:"numerator,omitempty"`
+	Denominator uint64 `protobuf:"varint,2,opt,name=denominator,proto3" json:"denominator,omitempty"`
+}
+
+func (m *Fraction) Reset()         { *m = Fraction{} }
+func (m *Fraction) String() string { return proto.CompactTextString(m) }
+func (*Fraction) ProtoMessage()    {}
+func (*Fraction) Descriptor() ([]byte, []int) {
+	return fileDescriptor_e7a50883ba0682d6, []int{0}
+}
+func (m *Fraction) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *Fraction) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_Fraction.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *Fraction) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Fraction.Merge(m, src)
+}
+func (m *Fraction) XXX_Size() int {
+	return m.Size()
+}
+func (m *Fraction) XXX_DiscardUnknown() {
+	xxx_messageInfo_Fraction.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Fraction proto.InternalMessageInfo
+
+func (m *Fraction) GetNumerator() uint64 {
+	if m != nil {
+		return m.Numerator
+	}
+	return 0
+}
+
+func (m *Fraction) GetDenominator() uint64 {
+	if m != nil {
+		return m.Denominator
+	}
+	return 0
+}
+
+// VerificationOptions overrides the server defaults for a single request.
+// Unset fields fall back to the defaults.
+type VerificationOptions struct {
+	// trusting_period is the period during which the trusted header can be
+	// used to verify new headers, shorter than the unbonding period.
+	TrustingPeriod *time.Duration `protobuf:"bytes,1,opt,name=trusting_period,json=trustingPeriod,proto3,stdduration" json:"trusting_period,omitempty"`
+	// max_clock_drift is the maximum allowed drift between the untrusted
+	// header time and now, at most a minute.
+	MaxClockDrift *time.Duration `protobuf:"bytes,2,opt,name=max_clock_drift,json=maxClockDrift,proto3,stdduration" json:"max_clock_drift,omitempty"`
+	// trust_level is the fraction of the trusted validator set that must have
+	// signed a non adjacent header.
+	TrustLevel *Fraction `protobuf:"bytes,3,opt,name=trust_level,json=trustLevel,proto3" json:"trust_level,omitempty"`
+	// legacy verifies the commits using the legacy (pre CometBLS) hashes and
+	// vote sign bytes. It must match the scheme of the untrusted light block
+	// unless the server detects it.
+	Legacy bool `protobuf:"varint,4,opt,name=legacy,proto3" json:"legacy,omitempty"`
+	// now is the verification time, defaults to the server time.
+	Now *time.Time `protobuf:"bytes,5,opt,name=now,proto3,stdtime" json:"now,omitempty"`
+}
+
+func (m *VerificationOptions) Reset()         { *m = VerificationOptions{} }
+func (m *VerificationOptions) String() string { return proto.CompactTextString(m) }
+func (*VerificationOptions) ProtoMessage()    {}
+func (*VerificationOptions) Descriptor() ([]byte, []int) {
+	return fileDescriptor_e7a50883ba0682d6, []int{1}
+}
+func (m *VerificationOptions) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *VerificationOptions) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_VerificationOptions.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *VerificationOptions) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_VerificationOptions.Merge(m, src)
+}
+func (m *VerificationOptions) XXX_Size() int {
+	return m.Size()
+}
+func (m *VerificationOptions) XXX_DiscardUnknown() {
+	xxx_messageInfo_VerificationOptions.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_VerificationOptions proto.InternalMessageInfo
+
+func (m *VerificationOptions) GetTrustingPeriod() *time.Duration {
+	if m != nil {
+		return m.TrustingPeriod
+	}
+	return nil
+}
+
+func (m *VerificationOptions) GetMaxClockDrift() *time.Duration {
+	if m != nil {
+		return m.MaxClockDrift
+	}
+	return nil
+}
+
+func (m *VerificationOptions) GetTrustLevel() *Fraction {
+	if m != nil {
+		return m.TrustLevel
+	}
+	return nil
+}
+
+func (m *VerificationOptions) GetLegacy() bool {
+	if m != nil {
+		return m.Legacy
+	}
+	return false
+}
+
+func (m *VerificationOptions) GetNow() *time.Time {
+	if m != nil {
+		return m.Now
+	}
+	return nil
+}
+
+// VerificationReport is the outcome of the verification of a light block.
+type VerificationReport struct {
+	Verified        bool   `protobuf:"varint,1,opt,name=verified,proto3" json:"verified,omitempty"`
+	Adjacent        bool   `protobuf:"varint,2,opt,name=adjacent,proto3" json:"adjacent,omitempty"`
+	ChainId         string `protobuf:"bytes,3,opt,name=chain_id,json=chainId,proto3" json:"chain_id,omitempty"`
+	TrustedHeight   int64  `protobuf:"varint,4,opt,name=trusted_height,json=trustedHeight,proto3" json:"trusted_height,omitempty"`
+	TrustedHash     []byte `protobuf:"bytes,5,opt,name=trusted_hash,json=trustedHash,proto3" json:"trusted_hash,omitempty"`
+	UntrustedHeight int64  `protobuf:"varint,6,opt,name=untrusted_height,json=untrustedHeight,proto3" json:"untrusted_height,omitempty"`
+	UntrustedHash   []byte `protobuf:"bytes,7,opt,name=untrusted_hash,json=untrustedHash,proto3" json:"untrusted_hash,omitempty"`
+	// error is the reason of the verification failure, empty on success.
+	Error string `protobuf:"bytes,8,opt,name=error,proto3" json:"error,omitempty"`
+}
+
+func (m *VerificationReport) Reset()         { *m = VerificationReport{} }
+func (m *VerificationReport) String() string { return proto.CompactTextString(m) }
+func (*VerificationReport) ProtoMessage()    {}
+func (*VerificationReport) Descriptor() ([]byte, []int) {
+	return fileDescriptor_e7a50883ba0682d6, []int{2}
+}
+func (m *VerificationReport) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *VerificationReport) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_VerificationReport.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *VerificationReport) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_VerificationReport.Merge(m, src)
+}
+func (m *VerificationReport) XXX_Size() int {
+	return m.Size()
+}
+func (m *VerificationReport) XXX_DiscardUnknown() {
+	xxx_messageInfo_VerificationReport.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_VerificationReport proto.InternalMessageInfo
+
+func (m *VerificationReport) GetVerified() bool {
+	if m != nil {
+		return m.Verified
+	}
+	return false
+}
+
+func (m *VerificationReport) GetAdjacent() bool {
+	if m != nil {
+		return m.Adjacent
+	}
+	return false
+}
+
+func (m *VerificationReport) GetChainId() string {
+	if m != nil {
+		return m.ChainId
+	}
+	return ""
+}
+
+func (m *VerificationReport) GetTrustedHeight() int64 {
+	if m != nil {
+		return m.TrustedHeight
+	}
+	return 0
+}
+
+func (m *VerificationReport) GetTrustedHash() []byte {
+	if m != nil {
+		return m.TrustedHash
+	}
+	return nil
+}
+
+func (m *VerificationReport) GetUntrustedHeight() int64 {
+	if m != nil {
+		return m.UntrustedHeight
+	}
+	return 0
+}
+
+func (m *VerificationReport) GetUntrustedHash() []byte {
+	if m != nil {
+		return m.UntrustedHash
+	}
+	return nil
+}
+
+func (m *VerificationReport) GetError() string {
+	if m != nil {
+		return m.Error
+	}
+	return ""
+}
+
+// VerifyRequest is the request type for the Verifier/Verify and
+// Verifier/VerifyNonAdjacent RPC methods.
+type VerifyRequest struct {
+	Trusted   *types.LightBlock    `protobuf:"bytes,1,opt,name=trusted,proto3" json:"trusted,omitempty"`
+	Untrusted *types.LightBlock    `protobuf:"bytes,2,opt,name=untrusted,proto3" json:"untrusted,omitempty"`
+	Options   *VerificationOptions `protobuf:"bytes,3,opt,name=options,proto3" json:"options,omitempty"`
+}
+
+func (m *VerifyRequest) Reset()         { *m = VerifyRequest{} }
+func (m *VerifyRequest) String() string { return proto.CompactTextString(m) }
+func (*VerifyRequest) ProtoMessage()    {}
+func (*VerifyRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_e7a50883ba0682d6, []int{3}
+}
+func (m *VerifyRequest) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *VerifyRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_VerifyRequest.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *VerifyRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_VerifyRequest.Merge(m, src)
+}
+func (m *VerifyRequest) XXX_Size() int {
+	return m.Size()
+}
+func (m *VerifyRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_VerifyRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_VerifyRequest proto.InternalMessageInfo
+
+func (m *VerifyRequest) GetTrusted() *types.LightBlock {
+	if m != nil {
+		return m.Trusted
+	}
+	return nil
+}
+
+func (m *VerifyRequest) GetUntrusted() *types.LightBlock {
+	if m != nil {
+		return m.Untrusted
+	}
+	return nil
+}
+
+func (m *VerifyRequest) GetOptions() *VerificationOptions {
+	if m != nil {
+		return m.Options
+	}
+	return nil
+}
+
+// VerifyResponse is the response type for the Verifier/Verify and
+// Verifier/VerifyNonAdjacent RPC methods.
+type VerifyResponse struct {
+	Report *VerificationReport `protobuf:"bytes,1,opt,name=report,proto3" json:"report,omitempty"`
+}
+
+func (m *VerifyResponse) Reset()         { *m = VerifyResponse{} }
+func (m *VerifyResponse) String() string { return proto.CompactTextString(m) }
+func (*VerifyResponse) ProtoMessage()    {}
+func (*VerifyResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_e7a50883ba0682d6, []int{4}
+}
+func (m *VerifyResponse) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *VerifyResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_VerifyResponse.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *VerifyResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_VerifyResponse.Merge(m, src)
+}
+func (m *VerifyResponse) XXX_Size() int {
+	return m.Size()
+}
+func (m *VerifyResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_VerifyResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_VerifyResponse proto.InternalMessageInfo
+
+func (m *VerifyResponse) GetReport() *VerificationReport {
+	if m != nil {
+		return m.Report
+	}
+	return nil
+}
+
+// VerifyBatchRequest is the request type for the Verifier/VerifyBatch RPC
+// method.
+type VerifyBatchRequest struct {
+	Trusted   *types.LightBlock    `protobuf:"bytes,1,opt,name=trusted,proto3" json:"trusted,omitempty"`
+	Untrusted []*types.LightBlock  `protobuf:"bytes,2,rep,name=untrusted,proto3" json:"untrusted,omitempty"`
+	Options   *VerificationOptions `protobuf:"bytes,3,opt,name=options,proto3" json:"options,omitempty"`
+}
+
+func (m *VerifyBatchRequest) Reset()         { *m = VerifyBatchRequest{} }
+func (m *VerifyBatchRequest) String() string { return proto.CompactTextString(m) }
+func (*VerifyBatchRequest) ProtoMessage()    {}
+func (*VerifyBatchRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_e7a50883ba0682d6, []int{5}
+}
+func (m *VerifyBatchRequest) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *VerifyBatchRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_VerifyBatchRequest.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *VerifyBatchRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_VerifyBatchRequest.Merge(m, src)
+}
+func (m *VerifyBatchRequest) XXX_Size() int {
+	return m.Size()
+}
+func (m *VerifyBatchRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_VerifyBatchRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_VerifyBatchRequest proto.InternalMessageInfo
+
+func (m *VerifyBatchRequest) GetTrusted() *types.LightBlock {
+	if m != nil {
+		return m.Trusted
+	}
+	return nil
+}
+
+func (m *VerifyBatchRequest) GetUntrusted() []*types.LightBlock {
+	if m != nil {
+		return m.Untrusted
+	}
+	return nil
+}
+
+func (m *VerifyBatchRequest) GetOptions() *VerificationOptions {
+	if m != nil {
+		return m.Options
+	}
+	return nil
+}
+
+// VerifyBatchResponse is the response type for the Verifier/VerifyBatch RPC
+// method.
+type VerifyBatchResponse struct {
+	// reports holds one report per verified light block, up to and including
+	// the first failure.
+	Reports []*VerificationReport `protobuf:"bytes,1,rep,name=reports,proto3" json:"reports,omitempty"`
+	// verified is true if every light block has been verified.
+	Verified bool `protobuf:"varint,2,opt,name=verified,proto3" json:"verified,omitempty"`
+}
+
+func (m *VerifyBatchResponse) Reset()         { *m = VerifyBatchResponse{} }
+func (m *VerifyBatchResponse) String() string { return proto.CompactTextString(m) }
+func (*VerifyBatchResponse) ProtoMessage()    {}
+func (*VerifyBatchResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_e7a50883ba0682d6, []int{6}
+}
+func (m *VerifyBatchResponse) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *VerifyBatchResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_VerifyBatchResponse.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *VerifyBatchResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_VerifyBatchResponse.Merge(m, src)
+}
+func (m *VerifyBatchResponse) XXX_Size() int {
+	return m.Size()
+}
+func (m *VerifyBatchResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_VerifyBatchResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_VerifyBatchResponse proto.InternalMessageInfo
+
+func (m *VerifyBatchResponse) GetReports() []*VerificationReport {
+	if m != nil {
+		return m.Reports
+	}
+	return nil
+}
+
+func (m *VerifyBatchResponse) GetVerified() bool {
+	if m != nil {
+		return m.Verified
+	}
+	return false
+}
+
+// StatusRequest is the request type for the Verifier/Status RPC method.
+type StatusRequest struct {
+}
+
+func (m *StatusRequest) Reset()         { *m = StatusRequest{} }
+func (m *StatusRequest) String() string { return proto.CompactTextString(m) }
+func (*StatusRequest) ProtoMessage()    {}
+func (*StatusRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_e7a50883ba0682d6, []int{7}
+}
+func (m *StatusRequest) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *StatusRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_StatusRequest.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *StatusRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_StatusRequest.Merge(m, src)
+}
+func (m *StatusRequest) XXX_Size() int {
+	return m.Size()
+}
+func (m *StatusRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_StatusRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_StatusRequest proto.InternalMessageInfo
+
+// StatusResponse is the response type for the Verifier/Status RPC method.
+type StatusResponse struct {
+	// version is the version of the binary serving the verifier.
+	Version string `protobuf:"bytes,1,opt,name=version,proto3" json:"version,omitempty"`
+	// default_options holds the options used for the fields a request leaves
+	// unset.
+	DefaultOptions *VerificationOptions `protobuf:"bytes,2,opt,name=default_options,json=defaultOptions,proto3" json:"default_options,omitempty"`
+	// max_batch_size is the maximum number of untrusted light blocks of a
+	// VerifyBatch request.
+	MaxBatchSize uint32 `protobuf:"varint,3,opt,name=max_batch_size,json=maxBatchSize,proto3" json:"max_batch_size,omitempty"`
+	// chain_id is the chain the verifier is bound to, later revisions of it
+	// being accepted too. Light blocks of any chain are accepted if empty.
+	ChainId string `protobuf:"bytes,4,opt,name=chain_id,json=chainId,proto3" json:"chain_id,omitempty"`
+}
+
+func (m *StatusResponse) Reset()         { *m = StatusResponse{} }
+func (m *StatusResponse) String() string { return proto.CompactTextString(m) }
+func (*StatusResponse) ProtoMessage()    {}
+func (*StatusResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_e7a50883ba0682d6, []int{8}
+}
+func (m *StatusResponse) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *StatusResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_StatusResponse.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *StatusResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_StatusResponse.Merge(m, src)
+}
+func (m *StatusResponse) XXX_Size() int {
+	return m.Size()
+}
+func (m *StatusResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_StatusResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_StatusResponse proto.InternalMessageInfo
+
+func (m *StatusResponse) GetVersion() string {
+	if m != nil {
+		return m.Version
+	}
+	return ""
+}
+
+func (m *StatusResponse) GetDefaultOptions() *VerificationOptions {
+	if m != nil {
+		return m.DefaultOptions
+	}
+	return nil
+}
+
+func (m *StatusResponse) GetMaxBatchSize() uint32 {
+	if m != nil {
+		return m.MaxBatchSize
+	}
+	return 0
+}
+
+func (m *StatusResponse) GetChainId() string {
+	if m != nil {
+		return m.ChainId
+	}
+	return ""
+}
+
+func init() {
+	proto.RegisterType((*Fraction)(nil), "union.verifier.v1.Fraction")
+	proto.RegisterType((*VerificationOptions)(nil), "union.verifier.v1.VerificationOptions")
+	proto.RegisterType((*VerificationReport)(nil), "union.verifier.v1.VerificationReport")
+	proto.RegisterType((*VerifyRequest)(nil), "union.verifier.v1.VerifyRequest")
+	proto.RegisterType((*VerifyResponse)(nil), "union.verifier.v1.VerifyResponse")
+	proto.RegisterType((*VerifyBatchRequest)(nil), "union.verifier.v1.VerifyBatchRequest")
+	proto.RegisterType((*VerifyBatchResponse)(nil), "union.verifier.v1.VerifyBatchResponse")
+	proto.RegisterType((*StatusRequest)(nil), "union.verifier.v1.StatusRequest")
+	proto.RegisterType((*StatusResponse)(nil), "union.verifier.v1.StatusResponse")
+}
+
+func init() { proto.RegisterFile("union/verifier/v1/verifier.proto", fileDescriptor_e7a50883ba0682d6) }
+
+var fileDescriptor_e7a50883ba0682d6 = []byte{
+	// 804 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xbc, 0x55, 0x4f, 0x6f, 0xe3, 0x44,
+	0x14, 0xaf, 0x9d, 0x6c, 0xe2, 0xbc, 0x34, 0x09, 0x3b, 0xbb, 0x42, 0xde, 0x50, 0x65, 0x53, 0x8b,
+	0xae, 0xca, 0xc5, 0xd1, 0x16, 0x89, 0x03, 0x02, 0x01, 0x61, 0x05, 0x0b, 0xda, 0xa5, 0x68, 0x16,
+	0xad, 0x10, 0x42, 0xb2, 0xa6, 0xf6, 0x24, 0x31, 0xc4, 0x33, 0x61, 0x3c, 0x0e, 0x4d, 0x3f, 0x45,
+	0x8f, 0xf0, 0x35, 0x38, 0x73, 0x45, 0x42, 0x9c, 0x7a, 0xe4, 0x46, 0xd5, 0x7e, 0x11, 0xe4, 0x99,
+	0x71, 0x1a, 0xf7, 0x3f, 0x52, 0xc5, 0x25, 0xf2, 0x7b, 0xef, 0xf7, 0x7e, 0xf3, 0xde, 0xef, 0xbd,
+	0x99, 0x40, 0x3f, 0x63, 0x31, 0x67, 0x83, 0x39, 0x15, 0xf1, 0x28, 0xa6, 0x62, 0x30, 0x7f, 0xba,
+	0xfc, 0xf6, 0x67, 0x82, 0x4b, 0x8e, 0xee, 0x2b, 0x84, 0xbf, 0xf4, 0xce, 0x9f, 0x76, 0x1f, 0x8e,
+	0xf9, 0x98, 0xab, 0xe8, 0x20, 0xff, 0xd2, 0xc0, 0x6e, 0x6f, 0xcc, 0xf9, 0x78, 0x4a, 0x07, 0xca,
+	0xda, 0xcb, 0x46, 0x83, 0x28, 0x13, 0x44, 0xe6, 0xb9, 0x3a, 0xfe, 0xf8, 0x7c, 0x5c, 0xc6, 0x09,
+	0x4d, 0x25, 0x49, 0x66, 0x06, 0xb0, 0x21, 0x29, 0x8b, 0xa8, 0x48, 0x62, 0x26, 0x07, 0x72, 0x31,
+	0xa3, 0xa9, 0xfe, 0xd5, 0x51, 0xef, 0x4b, 0x70, 0x3e, 0x13, 0x24, 0xcc, 0x09, 0xd1, 0x06, 0x34,
+	0x58, 0x96, 0x50, 0x41, 0x24, 0x17, 0xae, 0xd5, 0xb7, 0xb6, 0xab, 0xf8, 0xcc, 0x81, 0xfa, 0xd0,
+	0x8c, 0x28, 0xe3, 0x49, 0xcc, 0x54, 0xdc, 0x56, 0xf1, 0x55, 0x97, 0xf7, 0x9b, 0x0d, 0x0f, 0x5e,
+	0xab, 0x86, 0x42, 0x55, 0xe1, 0xee, 0x2c, 0xff, 0x4d, 0xd1, 0x73, 0xe8, 0x48, 0x91, 0xa5, 0x32,
+	0x66, 0xe3, 0x60, 0x46, 0x45, 0xcc, 0x23, 0xc5, 0xde, 0xdc, 0x79, 0xe4, 0xeb, 0xe2, 0xfd, 0xa2,
+	0x78, 0xff, 0x99, 0x69, 0x6e, 0x58, 0xfd, 0xe5, 0x9f, 0xc7, 0x16, 0x6e, 0x17, 0x79, 0x5f, 0xab,
+	0x34, 0xf4, 0x39, 0x74, 0x12, 0xb2, 0x1f, 0x84, 0x53, 0x1e, 0xfe, 0x18, 0x44, 0x22, 0x1e, 0x49,
+	0xd7, 0xbe, 0x1d, 0x53, 0x2b, 0x21, 0xfb, 0x9f, 0xe6, 0x69, 0xcf, 0xf2, 0x2c, 0xf4, 0x01, 0x34,
+	0x15, 0x75, 0x30, 0xa5, 0x73, 0x3a, 0x75, 0x2b, 0x8a, 0xe4, 0x2d, 0xff, 0xc2, 0x50, 0xfc, 0x42,
+	0x1c, 0x0c, 0x0a, 0xff, 0x22, 0x87, 0xa3, 0x37, 0xa1, 0x36, 0xa5, 0x63, 0x12, 0x2e, 0xdc, 0x6a,
+	0xdf, 0xda, 0x76, 0xb0, 0xb1, 0xd0, 0x0e, 0x54, 0x18, 0xff, 0xd9, 0xbd, 0xa7, 0xd8, 0xba, 0x17,
+	0x4a, 0xfa, 0xa6, 0x98, 0xcc, 0xb0, 0x7a, 0x98, 0xd7, 0x94, 0x83, 0xbd, 0x5f, 0x6d, 0x40, 0xab,
+	0xa2, 0x61, 0x3a, 0xe3, 0x42, 0xa2, 0x2e, 0x38, 0xa6, 0x0c, 0x2d, 0x96, 0x83, 0x97, 0x76, 0x1e,
+	0x23, 0xd1, 0x0f, 0x24, 0xa4, 0x4c, 0xb7, 0xef, 0xe0, 0xa5, 0x8d, 0x1e, 0x81, 0x13, 0x4e, 0x48,
+	0xcc, 0x82, 0x38, 0x52, 0x5d, 0x35, 0x70, 0x5d, 0xd9, 0x5f, 0x44, 0x68, 0x0b, 0xb4, 0x9c, 0x34,
+	0x0a, 0x26, 0x34, 0x1e, 0x4f, 0xa4, 0xaa, 0xbe, 0x82, 0x5b, 0xc6, 0xfb, 0x5c, 0x39, 0xd1, 0x26,
+	0xac, 0x2f, 0x61, 0x24, 0x9d, 0xa8, 0x6e, 0xd6, 0x71, 0xb3, 0x00, 0x91, 0x74, 0x82, 0xde, 0x81,
+	0x37, 0x32, 0x76, 0x8e, 0xab, 0xa6, 0xb8, 0x3a, 0x19, 0x2b, 0xb3, 0x6d, 0x41, 0x3b, 0x63, 0x25,
+	0xbe, 0xba, 0xe2, 0x6b, 0x65, 0x6c, 0x95, 0xf1, 0x21, 0xdc, 0xa3, 0x42, 0x70, 0xe1, 0x3a, 0xaa,
+	0x66, 0x6d, 0x78, 0x7f, 0x58, 0xd0, 0x52, 0xda, 0x2c, 0x30, 0xfd, 0x29, 0xa3, 0xa9, 0x44, 0xef,
+	0x41, 0xdd, 0xa4, 0x99, 0x15, 0xda, 0xf0, 0xcf, 0xd6, 0xdb, 0xd7, 0x8b, 0xfd, 0x22, 0x3f, 0x78,
+	0x98, 0xcf, 0x1a, 0x17, 0x60, 0xf4, 0x3e, 0x34, 0x96, 0x07, 0xba, 0xf6, 0x2d, 0x32, 0xcf, 0xe0,
+	0xe8, 0x63, 0xa8, 0x73, 0xbd, 0xc9, 0x66, 0x4f, 0x9e, 0x5c, 0xb2, 0x27, 0x97, 0xec, 0x3d, 0x2e,
+	0xd2, 0xbc, 0x5d, 0x68, 0x17, 0x6d, 0xa4, 0x33, 0xce, 0x52, 0x8a, 0x3e, 0x84, 0x9a, 0x50, 0x83,
+	0x36, 0x6d, 0x6c, 0xdd, 0x40, 0xa9, 0xb7, 0x02, 0x9b, 0x24, 0xef, 0x2f, 0xcb, 0x2c, 0xcd, 0x62,
+	0x48, 0x64, 0x38, 0xb9, 0x63, 0x75, 0x2a, 0xff, 0xaf, 0x3a, 0x02, 0x1e, 0x94, 0x7a, 0x31, 0x12,
+	0x7d, 0x04, 0x75, 0xdd, 0x6d, 0xea, 0x5a, 0xfd, 0xca, 0xed, 0x35, 0x2a, 0xb2, 0x4a, 0x57, 0xc8,
+	0x2e, 0x5f, 0x21, 0xaf, 0x03, 0xad, 0x57, 0x92, 0xc8, 0x2c, 0x35, 0xd2, 0x79, 0xbf, 0x5b, 0xd0,
+	0x2e, 0x3c, 0xa6, 0x00, 0x17, 0xea, 0x73, 0x2a, 0xd2, 0x98, 0x33, 0xa5, 0x66, 0x03, 0x17, 0x26,
+	0xda, 0x85, 0x4e, 0x44, 0x47, 0x24, 0x9b, 0xca, 0xa0, 0xe8, 0xdd, 0xfe, 0x4f, 0xbd, 0xb7, 0x4d,
+	0xba, 0xb1, 0xd1, 0xdb, 0xd0, 0xce, 0xdf, 0xb5, 0xbd, 0x5c, 0x80, 0x20, 0x8d, 0x0f, 0xa8, 0xd2,
+	0xb2, 0x85, 0xd7, 0x13, 0xb2, 0xaf, 0x54, 0x79, 0x15, 0x1f, 0xd0, 0xd2, 0xdd, 0xae, 0x96, 0xee,
+	0xf6, 0xce, 0xb1, 0x0d, 0xce, 0x6b, 0x73, 0x28, 0x7a, 0x09, 0x35, 0x2d, 0x28, 0xea, 0x5f, 0x55,
+	0x4f, 0x71, 0xa1, 0xba, 0x9b, 0xd7, 0x20, 0x8c, 0x0e, 0xdf, 0xc2, 0x7d, 0xed, 0xf9, 0x8a, 0xb3,
+	0x4f, 0x8a, 0x77, 0xe6, 0x4e, 0x98, 0xbf, 0x87, 0xe6, 0xca, 0xe4, 0xd1, 0x95, 0x03, 0x2e, 0x6d,
+	0x79, 0xf7, 0xc9, 0x4d, 0x30, 0xc3, 0xfe, 0x12, 0x6a, 0x7a, 0xa2, 0x97, 0x16, 0x5b, 0x1a, 0x7f,
+	0x77, 0xf3, 0x1a, 0x84, 0xa6, 0x1b, 0x6e, 0xff, 0x79, 0xd2, 0xb3, 0x8e, 0x4e, 0x7a, 0xd6, 0xf1,
+	0x49, 0xcf, 0x3a, 0x3c, 0xed, 0xad, 0x1d, 0x9d, 0xf6, 0xd6, 0xfe, 0x3e, 0xed, 0xad, 0x7d, 0xd7,
+	0x2e, 0xff, 0xdb, 0xef, 0xd5, 0xd4, 0x8b, 0xff, 0xee, 0xbf, 0x03, 0x00, 0xcb, 0xfd, 0x0a, 0x2e,
+	0x06, 0x08, 0x00, 0x00,
+}
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ context.Context
+var _ grpc.ClientConn
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+const _ = grpc.SupportPackageIsVersion4
+
+// VerifierClient is the client API for Verifier service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
+type VerifierClient interface {
+	// Verify verifies the untrusted light block against the trusted one,
+	// sequentially if they are adjacent and by skipping otherwise.
+	Verify(ctx context.Context, in *VerifyRequest, opts ...grpc.CallOption) (*VerifyResponse, error)
+	// VerifyNonAdjacent verifies the untrusted light block against the trusted
+	// one, failing if they are adjacent.
+	VerifyNonAdjacent(ctx context.Context, in *VerifyRequest, opts ...grpc.CallOption) (*VerifyResponse, error)
+	// VerifyBatch verifies a chain of light blocks, each one against the
+	// previous one, starting from the trusted light block. The verification
+	// stops at the first failure.
+	VerifyBatch(ctx context.Context, in *VerifyBatchRequest, opts ...grpc.CallOption) (*VerifyBatchResponse, error)
+	// Status returns the version of the verifier along with the defaults and
+	// limits requests are verified with.
+	Status(ctx context.Context, in *StatusRequest, opts ...grpc.CallOption) (*StatusResponse, error)
+}
+
+type verifierClient struct {
+	cc grpc1.ClientConn
+}
+
+func NewVerifierClient(cc grpc1.ClientConn) VerifierClient {
+	return &verifierClient{cc}
+}
+
+func (c *verifierClient) Verify(ctx context.Context, in *VerifyRequest, opts ...grpc.CallOption) (*VerifyResponse, error) {
+	out := new(VerifyResponse)
+	err := c.cc.Invoke(ctx, "/union.verifier.v1.Verifier/Verify", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *verifierClient) VerifyNonAdjacent(ctx context.Context, in *VerifyRequest, opts ...grpc.CallOption) (*VerifyResponse, error) {
+	out := new(VerifyResponse)
+	err := c.cc.Invoke(ctx, "/union.verifier.v1.Verifier/VerifyNonAdjacent", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *verifierClient) VerifyBatch(ctx context.Context, in *VerifyBatchRequest, opts ...grpc.CallOption) (*VerifyBatchResponse, error) {
+	out := new(VerifyBatchResponse)
+	err := c.cc.Invoke(ctx, "/union.verifier.v1.Verifier/VerifyBatch", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *verifierClient) Status(ctx context.Context, in *StatusRequest, opts ...grpc.CallOption) (*StatusResponse, error) {
+	out := new(StatusResponse)
+	err := c.cc.Invoke(ctx, "/union.verifier.v1.Verifier/Status", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// VerifierServer is the server API for Verifier service.
+type VerifierServer interface {
+	// Verify verifies the untrusted light block against the trusted one,
+	// sequentially if they are adjacent and by skipping otherwise.
+	Verify(context.Context, *VerifyRequest) (*VerifyResponse, error)
+	// VerifyNonAdjacent verifies the untrusted light block against the trusted
+	// one, failing if they are adjacent.
+	VerifyNonAdjacent(context.Context, *VerifyRequest) (*VerifyResponse, error)
+	// VerifyBatch verifies a chain of light blocks, each one against the
+	// previous one, starting from the trusted light block. The verification
+	// stops at the first failure.
+	VerifyBatch(context.Context, *VerifyBatchRequest) (*VerifyBatchResponse, error)
+	// Status returns the version of the verifier along with the defaults and
+	// limits requests are verified with.
+	Status(context.Context, *StatusRequest) (*StatusResponse, error)
+}
+
+// UnimplementedVerifierServer can be embedded to have forward compatible implementations.
+type UnimplementedVerifierServer struct {
+}
+
+func (*UnimplementedVerifierServer) Verify(ctx context.Context, req *VerifyRequest) (*VerifyResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Verify not implemented")
+}
+func (*UnimplementedVerifierServer) VerifyNonAdjacent(ctx context.Context, req *VerifyRequest) (*VerifyResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method VerifyNonAdjacent not implemented")
+}
+func (*UnimplementedVerifierServer) VerifyBatch(ctx context.Context, req *VerifyBatchRequest) (*VerifyBatchResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method VerifyBatch not implemented")
+}
+func (*UnimplementedVerifierServer) Status(ctx context.Context, req *StatusRequest) (*StatusResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Status not implemented")
+}
+
+func RegisterVerifierServer(s grpc1.Server, srv VerifierServer) {
+	s.RegisterService(&_Verifier_serviceDesc, srv)
+}
+
+func _Verifier_Verify_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(VerifyRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(VerifierServer).Verify(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/union.verifier.v1.Verifier/Verify",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(VerifierServer).Verify(ctx, req.(*VerifyRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Verifier_VerifyNonAdjacent_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(VerifyRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(VerifierServer).VerifyNonAdjacent(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/union.verifier.v1.Verifier/VerifyNonAdjacent",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(VerifierServer).VerifyNonAdjacent(ctx, req.(*VerifyRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Verifier_VerifyBatch_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(VerifyBatchRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(VerifierServer).VerifyBatch(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/union.verifier.v1.Verifier/VerifyBatch",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(VerifierServer).VerifyBatch(ctx, req.(*VerifyBatchRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Verifier_Status_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(StatusRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(VerifierServer).Status(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/union.verifier.v1.Verifier/Status",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(VerifierServer).Status(ctx, req.(*StatusRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+var _Verifier_serviceDesc = grpc.ServiceDesc{
+	ServiceName: "union.verifier.v1.Verifier",
+	HandlerType: (*VerifierServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "Verify",
+			Handler:    _Verifier_Verify_Handler,
+		},
+		{
+			MethodName: "VerifyNonAdjacent",
+			Handler:    _Verifier_VerifyNonAdjacent_Handler,
+		},
+		{
+			MethodName: "VerifyBatch",
+			Handler:    _Verifier_VerifyBatch_Handler,
+		},
+		{
+			MethodName: "Status",
+			Handler:    _Verifier_Status_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "union/verifier/v1/verifier.proto",
+}
+
+func (m *Fraction) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *Fraction) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *Fraction) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.Denominator != 0 {
+		i = encodeVarintVerifier(dAtA, i, uint64(m.Denominator))
+		i--
+		dAtA[i] = 0x10
+	}
+	if m.Numerator != 0 {
+		i = encodeVarintVerifier(dAtA, i, uint64(m.Numerator))
+		i--
+		dAtA[i] = 0x8
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *VerificationOptions) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *VerificationOptions) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *VerificationOptions) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.Now != nil {
+		n1, err1 := github_com_cosmos_gogoproto_types.StdTimeMarshalTo(*m.Now, dAtA[i-github_com_cosmos_gogoproto_types.SizeOfStdTime(*m.Now):])
+		if err1 != nil {
+			return 0, err1
+		}
+		i -= n1
+		i = encodeVarintVerifier(dAtA, i, uint64(n1))
+		i--
+		dAtA[i] = 0x2a
+	}
+	if m.Legacy {
+		i--
+		if m.Legacy {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i--
+		dAtA[i] = 0x20
+	}
+	if m.TrustLevel != nil {
+		{
+			size, err := m.TrustLevel.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintVerifier(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x1a
+	}
+	if m.MaxClockDrift != nil {
+		n3, err3 := github_com_cosmos_gogoproto_types.StdDurationMarshalTo(*m.MaxClockDrift, dAtA[i-github_com_cosmos_gogoproto_types.SizeOfStdDuration(*m.MaxClockDrift):])
+		if err3 != nil {
+			return 0, err3
+		}
+		i -= n3
+		i = encodeVarintVerifier(dAtA, i, uint64(n3))
+		i--
+		dAtA[i] = 0x12
+	}
+	if m.TrustingPeriod != nil {
+		n4, err4 := github_com_cosmos_gogoproto_types.StdDurationMarshalTo(*m.TrustingPeriod, dAtA[i-github_com_cosmos_gogoproto_types.SizeOfStdDuration(*m.TrustingPeriod):])
+		if err4 != nil {
+			return 0, err4
+		}
+		i -= n4
+		i = encodeVarintVerifier(dAtA, i, uint64(n4))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *VerificationReport) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *VerificationReport) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *VerificationReport) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.Error) > 0 {
+		i -= len(m.Error)
+		copy(dAtA[i:], m.Error)
+		i = encodeVarintVerifier(dAtA, i, uint64(len(m.Error)))
+		i--
+		dAtA[i] = 0x42
+	}
+	if len(m.UntrustedHash) > 0 {
+		i -= len(m.UntrustedHash)
+		copy(dAtA[i:], m.UntrustedHash)
+		i = encodeVarintVerifier(dAtA, i, uint64(len(m.UntrustedHash)))
+		i--
+		dAtA[i] = 0x3a
+	}
+	if m.UntrustedHeight != 0 {
+		i = encodeVarintVerifier(dAtA, i, uint64(m.UntrustedHeight))
+		i--
+		dAtA[i] = 0x30
+	}
+	if len(m.TrustedHash) > 0 {
+		i -= len(m.TrustedHash)
+		copy(dAtA[i:], m.TrustedHash)
+		i = encodeVarintVerifier(dAtA, i, uint64(len(m.TrustedHash)))
+		i--
+		dAtA[i] = 0x2a
+	}
+	if m.TrustedHeight != 0 {
+		i = encodeVarintVerifier(dAtA, i, uint64(m.TrustedHeight))
+		i--
+		dAtA[i] = 0x20
+	}
+	if len(m.ChainId) > 0 {
+		i -= len(m.ChainId)
+		copy(dAtA[i:], m.ChainId)
+		i = encodeVarintVerifier(dAtA, i, uint64(len(m.ChainId)))
+		i--
+		dAtA[i] = 0x1a
+	}
+	if m.Adjacent {
+		i--
+		if m.Adjacent {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i--
+		dAtA[i] = 0x10
+	}
+	if m.Verified {
+		i--
+		if m.Verified {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i--
+		dAtA[i] = 0x8
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *VerifyRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *VerifyRequest) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *VerifyRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.Options != nil {
+		{
+			size, err := m.Options.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintVerifier(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x1a
+	}
+	if m.Untrusted != nil {
+		{
+			size, err := m.Untrusted.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintVerifier(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x12
+	}
+	if m.Trusted != nil {
+		{
+			size, err := m.Trusted.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintVerifier(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *VerifyResponse) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *VerifyResponse) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *VerifyResponse) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.Report != nil {
+		{
+			size, err := m.Report.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintVerifier(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *VerifyBatchRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *VerifyBatchRequest) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *VerifyBatchRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.Options != nil {
+		{
+			size, err := m.Options.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintVerifier(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x1a
+	}
+	if len(m.Untrusted) > 0 {
+		for iNdEx := len(m.Untrusted) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.Untrusted[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintVerifier(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0x12
+		}
+	}
+	if m.Trusted != nil {
+		{
+			size, err := m.Trusted.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintVerifier(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *VerifyBatchResponse) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *VerifyBatchResponse) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *VerifyBatchResponse) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.Verified {
+		i--
+		if m.Verified {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i--
+		dAtA[i] = 0x10
+	}
+	if len(m.Reports) > 0 {
+		for iNdEx := len(m.Reports) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.Reports[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintVerifier(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0xa
+		}
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *StatusRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *StatusRequest) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *StatusRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	return len(dAtA) - i, nil
+}
+
+func (m *StatusResponse) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *StatusResponse) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *StatusResponse) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.ChainId) > 0 {
+		i -= len(m.ChainId)
+		copy(dAtA[i:], m.ChainId)
+		i = encodeVarintVerifier(dAtA, i, uint64(len(m.ChainId)))
+		i--
+		dAtA[i] = 0x22
+	}
+	if m.MaxBatchSize != 0 {
+		i = encodeVarintVerifier(dAtA, i, uint64(m.MaxBatchSize))
+		i--
+		dAtA[i] = 0x18
+	}
+	if m.DefaultOptions != nil {
+		{
+			size, err := m.DefaultOptions.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintVerifier(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x12
+	}
+	if len(m.Version) > 0 {
+		i -= len(m.Version)
+		copy(dAtA[i:], m.Version)
+		i = encodeVarintVerifier(dAtA, i, uint64(len(m.Version)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func encodeVarintVerifier(dAtA []byte, offset int, v uint64) int {
+	offset -= sovVerifier(v)
+	base := offset
+	for v >= 1<<7 {
+		dAtA[offset] = uint8(v&0x7f | 0x80)
+		v >>= 7
+		offset++
+	}
+	dAtA[offset] = uint8(v)
+	return base
+}
+func (m *Fraction) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Numerator != 0 {
+		n += 1 + sovVerifier(uint64(m.Numerator))
+	}
+	if m.Denominator != 0 {
+		n += 1 + sovVerifier(uint64(m.Denominator))
+	}
+	return n
+}
+
+func (m *VerificationOptions) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.TrustingPeriod != nil {
+		l = github_com_cosmos_gogoproto_types.SizeOfStdDuration(*m.TrustingPeriod)
+		n += 1 + l + sovVerifier(uint64(l))
+	}
+	if m.MaxClockDrift != nil {
+		l = github_com_cosmos_gogoproto_types.SizeOfStdDuration(*m.MaxClockDrift)
+		n += 1 + l + sovVerifier(uint64(l))
+	}
+	if m.TrustLevel != nil {
+		l = m.TrustLevel.Size()
+		n += 1 + l + sovVerifier(uint64(l))
+	}
+	if m.Legacy {
+		n += 2
+	}
+	if m.Now != nil {
+		l = github_com_cosmos_gogoproto_types.SizeOfStdTime(*m.Now)
+		n += 1 + l + sovVerifier(uint64(l))
+	}
+	return n
+}
+
+func (m *VerificationReport) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Verified {
+		n += 2
+	}
+	if m.Adjacent {
+		n += 2
+	}
+	l = len(m.ChainId)
+	if l > 0 {
+		n += 1 + l + sovVerifier(uint64(l))
+	}
+	if m.TrustedHeight != 0 {
+		n += 1 + sovVerifier(uint64(m.TrustedHeight))
+	}
+	l = len(m.TrustedHash)
+	if l > 0 {
+		n += 1 + l + sovVerifier(uint64(l))
+	}
+	if m.UntrustedHeight != 0 {
+		n += 1 + sovVerifier(uint64(m.UntrustedHeight))
+	}
+	l = len(m.UntrustedHash)
+	if l > 0 {
+		n += 1 + l + sovVerifier(uint64(l))
+	}
+	l = len(m.Error)
+	if l > 0 {
+		n += 1 + l + sovVerifier(uint64(l))
+	}
+	return n
+}
+
+func (m *VerifyRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Trusted != nil {
+		l = m.Trusted.Size()
+		n += 1 + l + sovVerifier(uint64(l))
+	}
+	if m.Untrusted != nil {
+		l = m.Untrusted.Size()
+		n += 1 + l + sovVerifier(uint64(l))
+	}
+	if m.Options != nil {
+		l = m.Options.Size()
+		n += 1 + l + sovVerifier(uint64(l))
+	}
+	return n
+}
+
+func (m *VerifyResponse) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Report != nil {
+		l = m.Report.Size()
+		n += 1 + l + sovVerifier(uint64(l))
+	}
+	return n
+}
+
+func (m *VerifyBatchRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Trusted != nil {
+		l = m.Trusted.Size()
+		n += 1 + l + sovVerifier(uint64(l))
+	}
+	if len(m.Untrusted) > 0 {
+		for _, e := range m.Untrusted {
+			l = e.Size()
+			n += 1 + l + sovVerifier(uint64(l))
+		}
+	}
+	if m.Options != nil {
+		l = m.Options.Size()
+		n += 1 + l + sovVerifier(uint64(l))
+	}
+	return n
+}
+
+func (m *VerifyBatchResponse) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if len(m.Reports) > 0 {
+		for _, e := range m.Reports {
+			l = e.Size()
+			n += 1 + l + sovVerifier(uint64(l))
+		}
+	}
+	if m.Verified {
+		n += 2
+	}
+	return n
+}
+
+func (m *StatusRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	return n
+}
+
+func (m *StatusResponse) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.Version)
+	if l > 0 {
+		n += 1 + l + sovVerifier(uint64(l))
+	}
+	if m.DefaultOptions != nil {
+		l = m.DefaultOptions.Size()
+		n += 1 + l + sovVerifier(uint64(l))
+	}
+	if m.MaxBatchSize != 0 {
+		n += 1 + sovVerifier(uint64(m.MaxBatchSize))
+	}
+	l = len(m.ChainId)
+	if l > 0 {
+		n += 1 + l + sovVerifier(uint64(l))
+	}
+	return n
+}
+
+func sovVerifier(x uint64) (n int) {
+	return (math_bits.Len64(x|1) + 6) / 7
+}
+func sozVerifier(x uint64) (n int) {
+	return sovVerifier(uint64((x << 1) ^ uint64((int64(x) >> 63))))
+}
+func (m *Fraction) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowVerifier
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: Fraction: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: Fraction: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Numerator", wireType)
+			}
+			m.Numerator = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowVerifier
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Numerator |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Denominator", wireType)
+			}
+			m.Denominator = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowVerifier
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Denominator |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		default:
+			iNdEx = preIndex
+			skippy, err := skipVerifier(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthVerifier
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *VerificationOptions) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowVerifier
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: VerificationOptions: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: VerificationOptions: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field TrustingPeriod", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowVerifier
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthVerifier
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthVerifier
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.TrustingPeriod == nil {
+				m.TrustingPeriod = new(time.Duration)
+			}
+			if err := github_com_cosmos_gogoproto_types.StdDurationUnmarshal(m.TrustingPeriod, dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field MaxClockDrift", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowVerifier
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthVerifier
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthVerifier
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.MaxClockDrift == nil {
+				m.MaxClockDrift = new(time.Duration)
+			}
+			if err := github_com_cosmos_gogoproto_types.StdDurationUnmarshal(m.MaxClockDrift, dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field TrustLevel", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowVerifier
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthVerifier
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthVerifier
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.TrustLevel == nil {
+				m.TrustLevel = &Fraction{}
+			}
+			if err := m.TrustLevel.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 4:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Legacy", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowVerifier
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.Legacy = bool(v != 0)
+		case 5:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Now", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowVerifier
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthVerifier
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthVerifier
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Now == nil {
+				m.Now = new(time.Time)
+			}
+			if err := github_com_cosmos_gogoproto_types.StdTimeUnmarshal(m.Now, dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipVerifier(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthVerifier
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *VerificationReport) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowVerifier
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: VerificationReport: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: VerificationReport: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Verified", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowVerifier
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.Verified = bool(v != 0)
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Adjacent", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowVerifier
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.Adjacent = bool(v != 0)
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ChainId", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowVerifier
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthVerifier
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthVerifier
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.ChainId = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 4:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field TrustedHeight", wireType)
+			}
+			m.TrustedHeight = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowVerifier
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.TrustedHeight |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 5:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field TrustedHash", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowVerifier
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthVerifier
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthVerifier
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.TrustedHash = append(m.TrustedHash[:0], dAtA[iNdEx:postIndex]...)
+			if m.TrustedHash == nil {
+				m.TrustedHash = []byte{}
+			}
+			iNdEx = postIndex
+		case 6:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field UntrustedHeight", wireType)
+			}
+			m.UntrustedHeight = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowVerifier
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.UntrustedHeight |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 7:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field UntrustedHash", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowVerifier
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthVerifier
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthVerifier
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.UntrustedHash = append(m.UntrustedHash[:0], dAtA[iNdEx:postIndex]...)
+			if m.UntrustedHash == nil {
+				m.UntrustedHash = []byte{}
+			}
+			iNdEx = postIndex
+		case 8:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Error", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowVerifier
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthVerifier
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthVerifier
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Error = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipVerifier(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthVerifier
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *VerifyRequest) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowVerifier
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: VerifyRequest: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: VerifyRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Trusted", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowVerifier
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthVerifier
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthVerifier
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Trusted == nil {
+				m.Trusted = &types.LightBlock{}
+			}
+			if err := m.Trusted.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Untrusted", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowVerifier
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthVerifier
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthVerifier
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Untrusted == nil {
+				m.Untrusted = &types.LightBlock{}
+			}
+			if err := m.Untrusted.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Options", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowVerifier
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthVerifier
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthVerifier
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Options == nil {
+				m.Options = &VerificationOptions{}
+			}
+			if err := m.Options.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipVerifier(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthVerifier
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *VerifyResponse) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowVerifier
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: VerifyResponse: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: VerifyResponse: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Report", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowVerifier
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthVerifier
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthVerifier
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Report == nil {
+				m.Report = &VerificationReport{}
+			}
+			if err := m.Report.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipVerifier(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthVerifier
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *VerifyBatchRequest) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowVerifier
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: VerifyBatchRequest: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: VerifyBatchRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Trusted", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowVerifier
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthVerifier
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthVerifier
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Trusted == nil {
+				m.Trusted = &types.LightBlock{}
+			}
+			if err := m.Trusted.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Untrusted", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowVerifier
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthVerifier
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthVerifier
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Untrusted = append(m.Untrusted, &types.LightBlock{})
+			if err := m.Untrusted[len(m.Untrusted)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Options", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowVerifier
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthVerifier
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthVerifier
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Options == nil {
+				m.Options = &VerificationOptions{}
+			}
+			if err := m.Options.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipVerifier(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthVerifier
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *VerifyBatchResponse) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowVerifier
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: VerifyBatchResponse: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: VerifyBatchResponse: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Reports", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowVerifier
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthVerifier
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthVerifier
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Reports = append(m.Reports, &VerificationReport{})
+			if err := m.Reports[len(m.Reports)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Verified", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowVerifier
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.Verified = bool(v != 0)
+		default:
+			iNdEx = preIndex
+			skippy, err := skipVerifier(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthVerifier
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *StatusRequest) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowVerifier
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: StatusRequest: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: StatusRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		default:
+			iNdEx = preIndex
+			skippy, err := skipVerifier(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthVerifier
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *StatusResponse) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowVerifier
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: StatusResponse: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: StatusResponse: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Version", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowVerifier
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthVerifier
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthVerifier
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Version = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field DefaultOptions", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowVerifier
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthVerifier
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthVerifier
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.DefaultOptions == nil {
+				m.DefaultOptions = &VerificationOptions{}
+			}
+			if err := m.DefaultOptions.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field MaxBatchSize", wireType)
+			}
+			m.MaxBatchSize = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowVerifier
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.MaxBatchSize |= uint32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 4:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ChainId", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowVerifier
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthVerifier
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthVerifier
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.ChainId = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipVerifier(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthVerifier
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func skipVerifier(dAtA []byte) (n int, err error) {
+	l := len(dAtA)
+	iNdEx := 0
+	depth := 0
+	for iNdEx < l {
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return 0, ErrIntOverflowVerifier
+			}
+			if iNdEx >= l {
+				return 0, io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		wireType := int(wire & 0x7)
+		switch wireType {
+		case 0:
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return 0, ErrIntOverflowVerifier
+				}
+				if iNdEx >= l {
+					return 0, io.ErrUnexpectedEOF
+				}
+				iNdEx++
+				if dAtA[iNdEx-1] < 0x80 {
+					break
+				}
+			}
+		case 1:
+			iNdEx += 8
+		case 2:
+			var length int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return 0, ErrIntOverflowVerifier
+				}
+				if iNdEx >= l {
+					return 0, io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				length |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if length < 0 {
+				return 0, ErrInvalidLengthVerifier
+			}
+			iNdEx += length
+		case 3:
+			depth++
+		case 4:
+			if depth == 0 {
+				return 0, ErrUnexpectedEndOfGroupVerifier
+			}
+			depth--
+		case 5:
+			iNdEx += 4
+		default:
+			return 0, fmt.Errorf("proto: illegal wireType %d", wireType)
+		}
+		if iNdEx < 0 {
+			return 0, ErrInvalidLengthVerifier
+		}
+		if depth == 0 {
+			return iNdEx, nil
+		}
+	}
+	return 0, io.ErrUnexpectedEOF
+}
+
+var (
+	ErrInvalidLengthVerifier        = fmt.Errorf("proto: negative length found during unmarshaling")
+	ErrIntOverflowVerifier          = fmt.Errorf("proto: integer overflow")
+	ErrUnexpectedEndOfGroupVerifier = fmt.Errorf("proto: unexpected end of group")
+)
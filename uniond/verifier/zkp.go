@@ -0,0 +1,228 @@
+package verifier
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math/big"
+
+	gnarkbn254 "github.com/consensys/gnark-crypto/ecc/bn254"
+	"github.com/consensys/gnark-crypto/ecc/bn254/fp"
+	"github.com/consensys/gnark-crypto/ecc/bn254/fr"
+	"golang.org/x/crypto/sha3"
+)
+
+// ZKPSize is the size of the zero knowledge proof of a cometbls header
+// transition: the Groth16 proof (A, B, C), the proof commitment and its proof
+// of knowledge, the points being uncompressed and big endian, G2 ones with
+// the imaginary coefficients first as for the EIP-197 precompile.
+const ZKPSize = 8*32 + 2*32 + 2*32
+
+var (
+	// ErrInvalidZKP is returned for a zero knowledge proof that isn't made of
+	// points of the curve.
+	ErrInvalidZKP = errors.New("invalid zero knowledge proof encoding")
+	// ErrInvalidCommitmentPOK is returned for a proof commitment that doesn't
+	// match its proof of knowledge.
+	ErrInvalidCommitmentPOK = errors.New("invalid proof commitment proof of knowledge")
+	// ErrInvalidProof is returned for a zero knowledge proof that doesn't
+	// prove the header transition.
+	ErrInvalidProof = errors.New("zero knowledge proof doesn't verify")
+)
+
+// ZKPHeader is the part of a header the zero knowledge proof of a transition
+// commits to, as decoded by the EVM cometbls client.
+type ZKPHeader struct {
+	Height             uint64
+	Secs               uint64
+	Nanos              uint64
+	ValidatorsHash     [32]byte
+	NextValidatorsHash [32]byte
+	AppHash            [32]byte
+}
+
+// The verifying key of the cometbls circuit, as deployed with the EVM client
+// (evm/contracts/clients/Verifier.sol), the G2 points of the Groth16 check
+// being negated.
+var (
+	zkpAlpha            = mustG1("4252850302693242182654534639730627324742305503909561446344356971523664816281", "3971530409048238023625806606514600982127202826003358538821613170737831313919")
+	zkpBetaNeg          = mustG2("9609903744775525881338738176064678545439912439219033822736570321349357348980", "11402125448377072234752634956069960846261435348550776006069399216352815312229", "18012228678282290194170129154972180638950912669850573130308339510071981008545", "15756550515454626729445647420198526257176992371703002957323861385095544414838")
+	zkpGammaNeg         = mustG2("15418804173338388766896385877623893969695670309009587476846726795628238714393", "14882897597913405382982164467298010752166363844685258881581520272046793702095", "17722217720691050164784298688157009907556422267906762591449788940639280738106", "21681514378991397271958143575996358636110810782474567203218670880519258244465")
+	zkpDeltaNeg         = mustG2("2636161939055419322743684458857549714230849256995406138405588958157843793131", "18711435617866698040659011365354165232283248284733617156044102129651710736892", "2647887006311232967132848950859794223811860619760715975180654346594734512903", "9638871602237154557801043117594638698760262947775166324439744310655148732994")
+	zkpConstant         = mustG1("17683074019270049519594214298171697666582975915064153618004061598086681825921", "16826145467743906176166100307225491106961753217491843100452871479833450456070")
+	zkpPub0             = mustG1("4999724750322169039879775285047941133298355297928988655266615607529011563466", "8614448667589143428827059805500251818303043966026074735628377626634208993292")
+	zkpPub1             = mustG1("1184807858330365651919114999096473332175166887333719856514157833289677967559", "20327610427697660249999185524229068956160879388632193295649998184224119517657")
+	zkpPedersenG        = mustG2("0x13F0D8D8879885CA567EF99298C30C397E6FBA584658F4127713A814C06DE55A", "0x257DF6F8132CB0037F7DFDF1A29B04C1FF92BA082EDA513996BA2BFA9FBD1987", "0x15E80642C58DB4DBE0A87F92CE3C65E962F231278353783A691FD64078BA7F34", "0x1660EBCC60C7A3AC560EFCEA5993F528EE13685D3A39694ACD74FE67C80D798A")
+	zkpPedersenSigmaNeg = mustG2("0x02A104DF1C015F2307FA2859627098CDF9FDB521D61D323943343A12304E5BAF", "0x2FBFE141A7555CF7E3E86B092660B81CFB68A025AD817E45CEC0B0F2E2CA6368", "0x2C0838551CB9E5CF67DB57DE7E2250BB97807F6687F135A6EB910359BA7BDB8D", "0x27DA3F93ECF3BFD0B3A3354AE2162A6C230C0E539B6D9F82C0826E2B006A5922")
+)
+
+func mustInt(s string) *big.Int {
+	n, ok := new(big.Int).SetString(s, 0)
+	if !ok {
+		panic(fmt.Sprintf("invalid integer %q", s))
+	}
+	return n
+}
+
+func mustG1(x, y string) gnarkbn254.G1Affine {
+	var p gnarkbn254.G1Affine
+	p.X.SetBigInt(mustInt(x))
+	p.Y.SetBigInt(mustInt(y))
+	if !p.IsOnCurve() {
+		panic("verifying key point not on G1")
+	}
+	return p
+}
+
+// mustG2 builds a G2 point from its coefficients in powers of i.
+func mustG2(x0, x1, y0, y1 string) gnarkbn254.G2Affine {
+	var p gnarkbn254.G2Affine
+	p.X.A0.SetBigInt(mustInt(x0))
+	p.X.A1.SetBigInt(mustInt(x1))
+	p.Y.A0.SetBigInt(mustInt(y0))
+	p.Y.A1.SetBigInt(mustInt(y1))
+	if !p.IsOnCurve() || !p.IsInSubGroup() {
+		panic("verifying key point not on G2")
+	}
+	return p
+}
+
+func decodeFp(bz []byte) (fp.Element, error) {
+	var e fp.Element
+	if err := e.SetBytesCanonical(bz); err != nil {
+		return e, fmt.Errorf("%w: %s", ErrInvalidZKP, err)
+	}
+	return e, nil
+}
+
+func decodeG1(name string, bz []byte) (gnarkbn254.G1Affine, error) {
+	var p gnarkbn254.G1Affine
+	var err error
+	if p.X, err = decodeFp(bz[:32]); err != nil {
+		return p, err
+	}
+	if p.Y, err = decodeFp(bz[32:64]); err != nil {
+		return p, err
+	}
+	if !p.IsOnCurve() {
+		return p, fmt.Errorf("%w: %s not on G1", ErrInvalidZKP, name)
+	}
+	return p, nil
+}
+
+func decodeG2(name string, bz []byte) (gnarkbn254.G2Affine, error) {
+	var p gnarkbn254.G2Affine
+	var err error
+	for i, e := range []*fp.Element{&p.X.A1, &p.X.A0, &p.Y.A1, &p.Y.A0} {
+		if *e, err = decodeFp(bz[32*i : 32*(i+1)]); err != nil {
+			return p, err
+		}
+	}
+	if !p.IsOnCurve() || !p.IsInSubGroup() {
+		return p, fmt.Errorf("%w: %s not on G2", ErrInvalidZKP, name)
+	}
+	return p, nil
+}
+
+// zkpHMACKey keys the HMAC-keccak256 the proof commitment is hashed to the
+// scalar field with, H_{hmac_r} in the whitepaper.
+const zkpHMACKey = "CometBLS"
+
+func hmacKeccak(message []byte) []byte {
+	mac := hmac.New(sha3.NewLegacyKeccak256, []byte(zkpHMACKey))
+	mac.Write(message)
+	return mac.Sum(nil)
+}
+
+// zkpInputsHash is the public input the header transition is committed to,
+// the sha256 of the chain id, header and trusted validators hash with its
+// most significant byte dropped to fit in the scalar field.
+func zkpInputsHash(chainID string, trustedValidatorsHash [32]byte, header ZKPHeader) *big.Int {
+	var word [32]byte
+	h := sha256.New()
+	copy(word[32-len(chainID):], chainID)
+	h.Write(word[:])
+	for _, n := range []uint64{header.Height, header.Secs, header.Nanos} {
+		word = [32]byte{}
+		binary.BigEndian.PutUint64(word[24:], n)
+		h.Write(word[:])
+	}
+	h.Write(header.ValidatorsHash[:])
+	h.Write(header.NextValidatorsHash[:])
+	h.Write(header.AppHash[:])
+	h.Write(trustedValidatorsHash[:])
+	sum := h.Sum(nil)
+	sum[0] = 0
+	return new(big.Int).SetBytes(sum)
+}
+
+// VerifyZKP verifies the zero knowledge proof of a cometbls header transition
+// as the EVM client does: that the header of a chain was committed by more
+// than 2/3 of its validator set and, from a trusted validators hash, by more
+// than 1/3 of the trusted validator set.
+//
+// The proof only covers the signatures: the heights, times and the adjacent
+// validators hash are checked by the client, e.g. with Explain.
+func VerifyZKP(chainID string, trustedValidatorsHash [32]byte, header ZKPHeader, zkp []byte) error {
+	if len(chainID) > 31 {
+		return fmt.Errorf("chain id %q longer than 31 bytes", chainID)
+	}
+	if len(zkp) != ZKPSize {
+		return fmt.Errorf("%w: expected %d bytes, got %d", ErrInvalidZKP, ZKPSize, len(zkp))
+	}
+	a, err := decodeG1("A", zkp[0:64])
+	if err != nil {
+		return err
+	}
+	b, err := decodeG2("B", zkp[64:192])
+	if err != nil {
+		return err
+	}
+	c, err := decodeG1("C", zkp[192:256])
+	if err != nil {
+		return err
+	}
+	commitment, err := decodeG1("proof commitment", zkp[256:320])
+	if err != nil {
+		return err
+	}
+	pok, err := decodeG1("proof commitment proof of knowledge", zkp[320:384])
+	if err != nil {
+		return err
+	}
+
+	// Gnark extends the public inputs with the hash of the proof commitment.
+	commitmentHash := new(big.Int).SetBytes(hmacKeccak(zkp[256:320]))
+	commitmentHash.Mod(commitmentHash, new(big.Int).Sub(fr.Modulus(), big.NewInt(1)))
+	commitmentHash.Add(commitmentHash, big.NewInt(1))
+	inputsHash := zkpInputsHash(chainID, trustedValidatorsHash, header)
+
+	var msm, term gnarkbn254.G1Affine
+	msm.Add(&zkpConstant, &commitment)
+	msm.Add(&msm, term.ScalarMultiplication(&zkpPub0, inputsHash))
+	msm.Add(&msm, term.ScalarMultiplication(&zkpPub1, commitmentHash))
+
+	ok, err := gnarkbn254.PairingCheck(
+		[]gnarkbn254.G1Affine{commitment, pok},
+		[]gnarkbn254.G2Affine{zkpPedersenG, zkpPedersenSigmaNeg},
+	)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return ErrInvalidCommitmentPOK
+	}
+	ok, err = gnarkbn254.PairingCheck(
+		[]gnarkbn254.G1Affine{a, c, zkpAlpha, msm},
+		[]gnarkbn254.G2Affine{b, zkpDeltaNeg, zkpBetaNeg, zkpGammaNeg},
+	)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return ErrInvalidProof
+	}
+	return nil
+}
@@ -0,0 +1,58 @@
+package keeper
+
+import (
+	sdkmath "cosmossdk.io/math"
+	"github.com/cosmos/cosmos-sdk/telemetry"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+
+	"union/x/feemarket/types"
+)
+
+// SetBaseFee sets the base fee of the next block.
+func (k Keeper) SetBaseFee(ctx sdk.Context, baseFee sdkmath.LegacyDec) {
+	store := ctx.KVStore(k.storeKey)
+	bz, err := baseFee.Marshal()
+	if err != nil {
+		panic(err)
+	}
+	store.Set(types.BaseFeeKey, bz)
+}
+
+// GetBaseFee returns the base fee of the next block, defaulting to the min
+// base fee if none was stored.
+func (k Keeper) GetBaseFee(ctx sdk.Context) sdkmath.LegacyDec {
+	store := ctx.KVStore(k.storeKey)
+	bz := store.Get(types.BaseFeeKey)
+	if bz == nil {
+		return k.GetParams(ctx).MinBaseFee
+	}
+
+	var baseFee sdkmath.LegacyDec
+	if err := baseFee.Unmarshal(bz); err != nil {
+		panic(err)
+	}
+	return baseFee
+}
+
+// UpdateBaseFee adjusts the base fee according to the gas consumed by the
+// current block.
+func (k Keeper) UpdateBaseFee(ctx sdk.Context) {
+	params := k.GetParams(ctx)
+	if !params.Enabled {
+		return
+	}
+
+	gasUsed := ctx.BlockGasMeter().GasConsumedToLimit()
+	baseFee := k.GetBaseFee(ctx)
+	nextBaseFee := params.NextBaseFee(baseFee, gasUsed)
+	k.SetBaseFee(ctx, nextBaseFee)
+
+	if value, err := nextBaseFee.Float64(); err == nil {
+		telemetry.SetGauge(float32(value), types.ModuleName, "base_fee")
+	}
+	telemetry.SetGauge(float32(gasUsed), types.ModuleName, "block_gas_used")
+
+	if !nextBaseFee.Equal(baseFee) {
+		k.Logger(ctx).Debug("base fee updated", "gas_used", gasUsed, "base_fee", nextBaseFee)
+	}
+}
@@ -0,0 +1,81 @@
+package keeper
+
+import (
+	"math"
+
+	errorsmod "cosmossdk.io/errors"
+	sdkmath "cosmossdk.io/math"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+
+	"union/x/feemarket/types"
+)
+
+// CheckTxFee is an ante.TxFeeChecker requiring transactions to pay at least
+// the base fee for every unit of gas they request, in CheckTx and when
+// delivering the block. In CheckTx, the local minimum gas prices of the
+// validator are enforced as well. The priority of the transaction is its gas
+// price in the base fee denom.
+func (k Keeper) CheckTxFee(ctx sdk.Context, tx sdk.Tx) (sdk.Coins, int64, error) {
+	feeTx, ok := tx.(sdk.FeeTx)
+	if !ok {
+		return nil, 0, errorsmod.Wrap(sdkerrors.ErrTxDecode, "Tx must be a FeeTx")
+	}
+
+	fee := feeTx.GetFee()
+	gas := feeTx.GetGas()
+
+	if ctx.IsCheckTx() {
+		if err := checkMinGasPrices(ctx.MinGasPrices(), fee, gas); err != nil {
+			return nil, 0, err
+		}
+	}
+
+	params := k.GetParams(ctx)
+	// Genesis transactions don't pay fees.
+	if !params.Enabled || ctx.BlockHeight() == 0 {
+		return fee, 0, nil
+	}
+
+	baseFee := k.GetBaseFee(ctx)
+	required := baseFee.MulInt(sdkmath.NewIntFromUint64(gas)).Ceil().RoundInt()
+	paid := fee.AmountOf(params.Denom)
+	if paid.LT(required) {
+		return nil, 0, errorsmod.Wrapf(
+			types.ErrInsufficientFee,
+			"got: %s%s required: %s%s (base fee %s%s per gas)",
+			paid, params.Denom, required, params.Denom, baseFee, params.Denom,
+		)
+	}
+
+	return fee, txPriority(paid, gas), nil
+}
+
+// Mirrors the check done by the default fee checker of x/auth.
+func checkMinGasPrices(minGasPrices sdk.DecCoins, fee sdk.Coins, gas uint64) error {
+	if minGasPrices.IsZero() {
+		return nil
+	}
+
+	requiredFees := make(sdk.Coins, len(minGasPrices))
+	glDec := sdkmath.LegacyNewDecFromInt(sdkmath.NewIntFromUint64(gas))
+	for i, gp := range minGasPrices {
+		requiredFees[i] = sdk.NewCoin(gp.Denom, gp.Amount.Mul(glDec).Ceil().RoundInt())
+	}
+
+	if !fee.IsAnyGTE(requiredFees) {
+		return errorsmod.Wrapf(sdkerrors.ErrInsufficientFee, "insufficient fees; got: %s required: %s", fee, requiredFees)
+	}
+	return nil
+}
+
+func txPriority(paid sdkmath.Int, gas uint64) int64 {
+	if gas == 0 {
+		return 0
+	}
+	price := paid.Quo(sdkmath.NewIntFromUint64(gas))
+	if !price.IsInt64() {
+		return math.MaxInt64
+	}
+	return price.Int64()
+}
@@ -0,0 +1,129 @@
+package keeper_test
+
+import (
+	"testing"
+
+	sdkmath "cosmossdk.io/math"
+	storetypes "cosmossdk.io/store/types"
+	"github.com/cosmos/cosmos-sdk/testutil"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+	moduletestutil "github.com/cosmos/cosmos-sdk/types/module/testutil"
+	"github.com/stretchr/testify/require"
+	protov2 "google.golang.org/protobuf/proto"
+
+	"union/x/feemarket/keeper"
+	"union/x/feemarket/types"
+)
+
+type feeTx struct {
+	gas uint64
+	fee sdk.Coins
+}
+
+func (feeTx) GetMsgs() []sdk.Msg                    { return nil }
+func (feeTx) GetMsgsV2() ([]protov2.Message, error) { return nil, nil }
+func (tx feeTx) GetGas() uint64                     { return tx.gas }
+func (tx feeTx) GetFee() sdk.Coins                  { return tx.fee }
+func (feeTx) FeePayer() []byte                      { return nil }
+func (feeTx) FeeGranter() []byte                    { return nil }
+
+func TestCheckTxFee(t *testing.T) {
+	key := storetypes.NewKVStoreKey(types.StoreKey)
+	base := testutil.DefaultContext(key, storetypes.NewTransientStoreKey("transient_test")).WithBlockHeight(10)
+	k := keeper.NewKeeper(moduletestutil.MakeTestEncodingConfig().Codec, key, "authority")
+
+	enabled := types.NewParams(true, "muno", sdkmath.LegacyNewDecWithPrec(25, 4), 1000, 8)
+	disabled := enabled
+	disabled.Enabled = false
+
+	for _, tc := range []struct {
+		name     string
+		params   types.Params
+		baseFee  sdkmath.LegacyDec
+		checkTx  bool
+		gasPrice sdk.DecCoins
+		genesis  bool
+		fee      sdk.Coins
+		err      error
+		priority int64
+	}{
+		{
+			name:     "base fee paid",
+			params:   enabled,
+			baseFee:  sdkmath.LegacyNewDec(2),
+			fee:      sdk.NewCoins(sdk.NewInt64Coin("muno", 300_000)),
+			priority: 3,
+		},
+		{
+			name:    "insufficient fee",
+			params:  enabled,
+			baseFee: sdkmath.LegacyNewDec(2),
+			fee:     sdk.NewCoins(sdk.NewInt64Coin("muno", 199_999)),
+			err:     types.ErrInsufficientFee,
+		},
+		{
+			name:    "wrong denom",
+			params:  enabled,
+			baseFee: sdkmath.LegacyNewDec(2),
+			fee:     sdk.NewCoins(sdk.NewInt64Coin("uother", 300_000)),
+			err:     types.ErrInsufficientFee,
+		},
+		{
+			name:    "fractional base fee rounded up",
+			params:  enabled,
+			baseFee: sdkmath.LegacyNewDecWithPrec(25, 4),
+			fee:     sdk.NewCoins(sdk.NewInt64Coin("muno", 249)),
+			err:     types.ErrInsufficientFee,
+		},
+		{
+			name:    "disabled",
+			params:  disabled,
+			baseFee: sdkmath.LegacyNewDec(2),
+			fee:     sdk.NewCoins(sdk.NewInt64Coin("uother", 1)),
+		},
+		{
+			name:    "genesis transaction",
+			params:  enabled,
+			baseFee: sdkmath.LegacyNewDec(2),
+			genesis: true,
+		},
+		{
+			name:     "below the local min gas prices",
+			params:   disabled,
+			baseFee:  sdkmath.LegacyNewDec(2),
+			checkTx:  true,
+			gasPrice: sdk.NewDecCoins(sdk.NewInt64DecCoin("muno", 1)),
+			fee:      sdk.NewCoins(sdk.NewInt64Coin("muno", 99_999)),
+			err:      sdkerrors.ErrInsufficientFee,
+		},
+		{
+			name:     "base fee enforced in check tx",
+			params:   enabled,
+			baseFee:  sdkmath.LegacyNewDec(2),
+			checkTx:  true,
+			gasPrice: sdk.NewDecCoins(sdk.NewInt64DecCoin("muno", 1)),
+			fee:      sdk.NewCoins(sdk.NewInt64Coin("muno", 100_000)),
+			err:      types.ErrInsufficientFee,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			ctx, _ := base.CacheContext()
+			ctx = ctx.WithIsCheckTx(tc.checkTx).WithMinGasPrices(tc.gasPrice)
+			if tc.genesis {
+				ctx = ctx.WithBlockHeight(0)
+			}
+			require.NoError(t, k.SetParams(ctx, tc.params))
+			k.SetBaseFee(ctx, tc.baseFee)
+
+			fee, priority, err := k.CheckTxFee(ctx, feeTx{gas: 100_000, fee: tc.fee})
+			if tc.err != nil {
+				require.ErrorIs(t, err, tc.err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tc.fee, fee)
+			require.Equal(t, tc.priority, priority)
+		})
+	}
+}
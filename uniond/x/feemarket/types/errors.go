@@ -0,0 +1,14 @@
+package types
+
+// DONTCOVER
+
+import (
+	errorsmod "cosmossdk.io/errors"
+)
+
+// x/feemarket module sentinel errors
+var (
+	ErrInvalidAuthority = errorsmod.Register(ModuleName, 2, "invalid authority")
+	ErrInvalidParams    = errorsmod.Register(ModuleName, 3, "invalid params")
+	ErrInsufficientFee  = errorsmod.Register(ModuleName, 4, "insufficient fee")
+)
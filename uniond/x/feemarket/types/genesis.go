@@ -0,0 +1,27 @@
+package types
+
+import (
+	"fmt"
+)
+
+// DefaultGenesis returns the default feemarket genesis state, the base fee
+// starting at its minimum.
+func DefaultGenesis() *GenesisState {
+	params := DefaultParams()
+	return &GenesisState{
+		Params:  params,
+		BaseFee: params.MinBaseFee,
+	}
+}
+
+// Validate performs basic genesis state validation returning an error upon any
+// failure.
+func (gs GenesisState) Validate() error {
+	if err := gs.Params.Validate(); err != nil {
+		return err
+	}
+	if gs.BaseFee.IsNil() || gs.BaseFee.LT(gs.Params.MinBaseFee) {
+		return fmt.Errorf("base fee %s is lower than the min base fee %s", gs.BaseFee, gs.Params.MinBaseFee)
+	}
+	return nil
+}
@@ -0,0 +1,80 @@
+package types
+
+import (
+	"fmt"
+
+	sdkmath "cosmossdk.io/math"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+
+	appparams "union/app/params"
+)
+
+var (
+	// DefaultDenom is the bond denom of the default staking genesis, so that
+	// the fees are paid in the staking token unless configured otherwise.
+	DefaultDenom = appparams.BondDenom
+
+	// DefaultMinBaseFee is the lowest base fee, per unit of gas.
+	DefaultMinBaseFee = sdkmath.LegacyNewDecWithPrec(25, 4)
+
+	// DefaultTargetBlockGas is the gas consumed by a block for the base fee to
+	// stay constant.
+	DefaultTargetBlockGas uint64 = 50_000_000
+
+	// DefaultBaseFeeChangeDenominator bounds the change of the base fee to
+	// 12.5% per block, as in EIP-1559.
+	DefaultBaseFeeChangeDenominator uint64 = 8
+)
+
+// NewParams creates a new parameter configuration for the feemarket module.
+func NewParams(enabled bool, denom string, minBaseFee sdkmath.LegacyDec, targetBlockGas uint64, baseFeeChangeDenominator uint64) Params {
+	return Params{
+		Enabled:                  enabled,
+		Denom:                    denom,
+		MinBaseFee:               minBaseFee,
+		TargetBlockGas:           targetBlockGas,
+		BaseFeeChangeDenominator: baseFeeChangeDenominator,
+	}
+}
+
+// DefaultParams is the default parameter configuration for the feemarket
+// module. The base fee is disabled until governance turns it on.
+func DefaultParams() Params {
+	return NewParams(false, DefaultDenom, DefaultMinBaseFee, DefaultTargetBlockGas, DefaultBaseFeeChangeDenominator)
+}
+
+// Validate validates all parameters.
+func (p Params) Validate() error {
+	if err := sdk.ValidateDenom(p.Denom); err != nil {
+		return err
+	}
+	if p.MinBaseFee.IsNil() || !p.MinBaseFee.IsPositive() {
+		return fmt.Errorf("min base fee must be positive: %s", p.MinBaseFee)
+	}
+	if p.TargetBlockGas == 0 {
+		return fmt.Errorf("target block gas must be positive")
+	}
+	if p.BaseFeeChangeDenominator == 0 {
+		return fmt.Errorf("base fee change denominator must be positive")
+	}
+	return nil
+}
+
+// NextBaseFee computes the base fee of the next block given the gas consumed
+// by the current one. The change is proportional to the distance to the
+// target, consuming more than twice the target counts as twice the target.
+// As in EIP-1559, a block above the target increases the base fee by one unit
+// at least, the smallest decimal here, so that it can't stall when the
+// proportional change rounds to zero.
+func (p Params) NextBaseFee(baseFee sdkmath.LegacyDec, gasUsed uint64) sdkmath.LegacyDec {
+	target := sdkmath.NewIntFromUint64(p.TargetBlockGas)
+	used := sdkmath.MinInt(sdkmath.NewIntFromUint64(gasUsed), target.MulRaw(2))
+	delta := baseFee.
+		MulInt(used.Sub(target)).
+		QuoInt(target).
+		QuoInt(sdkmath.NewIntFromUint64(p.BaseFeeChangeDenominator))
+	if used.GT(target) {
+		delta = sdkmath.LegacyMaxDec(delta, sdkmath.LegacySmallestDec())
+	}
+	return sdkmath.LegacyMaxDec(baseFee.Add(delta), p.MinBaseFee)
+}
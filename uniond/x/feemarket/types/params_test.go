@@ -0,0 +1,62 @@
+package types_test
+
+import (
+	"testing"
+
+	sdkmath "cosmossdk.io/math"
+	"github.com/stretchr/testify/require"
+
+	"union/x/feemarket/types"
+)
+
+func TestNextBaseFee(t *testing.T) {
+	params := types.NewParams(true, "muno", sdkmath.LegacyNewDec(1), 1000, 8)
+	baseFee := sdkmath.LegacyNewDec(80)
+
+	for _, tc := range []struct {
+		name     string
+		gasUsed  uint64
+		expected sdkmath.LegacyDec
+	}{
+		{"target", 1000, sdkmath.LegacyNewDec(80)},
+		{"full", 2000, sdkmath.LegacyNewDec(90)},
+		{"over full", 10_000, sdkmath.LegacyNewDec(90)},
+		{"half", 500, sdkmath.LegacyNewDec(75)},
+		{"empty", 0, sdkmath.LegacyNewDec(70)},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			require.Equal(t, tc.expected, params.NextBaseFee(baseFee, tc.gasUsed))
+		})
+	}
+
+	// The base fee never goes below its minimum.
+	require.Equal(t, params.MinBaseFee, params.NextBaseFee(params.MinBaseFee, 0))
+
+	// A block above the target raises the base fee by one unit at least, even
+	// when the proportional change rounds to zero.
+	smallest := sdkmath.LegacySmallestDec()
+	params = types.NewParams(true, "muno", smallest, 1000, 8)
+	require.Equal(t, smallest.MulInt64(2), params.NextBaseFee(smallest, 1001))
+	require.Equal(t, smallest, params.NextBaseFee(smallest, 1000))
+}
+
+func TestParamsValidate(t *testing.T) {
+	require.NoError(t, types.DefaultParams().Validate())
+	require.NoError(t, types.DefaultGenesis().Validate())
+
+	params := types.DefaultParams()
+	params.MinBaseFee = sdkmath.LegacyZeroDec()
+	require.Error(t, params.Validate())
+
+	params = types.DefaultParams()
+	params.TargetBlockGas = 0
+	require.Error(t, params.Validate())
+
+	params = types.DefaultParams()
+	params.BaseFeeChangeDenominator = 0
+	require.Error(t, params.Validate())
+
+	params = types.DefaultParams()
+	params.Denom = ""
+	require.Error(t, params.Validate())
+}
@@ -0,0 +1,120 @@
+package icapolicy_test
+
+import (
+	"testing"
+
+	sdkmath "cosmossdk.io/math"
+	storetypes "cosmossdk.io/store/types"
+	"github.com/cosmos/cosmos-sdk/codec"
+	"github.com/cosmos/cosmos-sdk/testutil"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	moduletestutil "github.com/cosmos/cosmos-sdk/types/module/testutil"
+	banktypes "github.com/cosmos/cosmos-sdk/x/bank/types"
+	stakingtypes "github.com/cosmos/cosmos-sdk/x/staking/types"
+	"github.com/cosmos/gogoproto/proto"
+	icatypes "github.com/cosmos/ibc-go/v8/modules/apps/27-interchain-accounts/types"
+	clienttypes "github.com/cosmos/ibc-go/v8/modules/core/02-client/types"
+	channeltypes "github.com/cosmos/ibc-go/v8/modules/core/04-channel/types"
+	porttypes "github.com/cosmos/ibc-go/v8/modules/core/05-port/types"
+	ibcexported "github.com/cosmos/ibc-go/v8/modules/core/exported"
+	"github.com/stretchr/testify/require"
+
+	"union/x/icapolicy"
+	"union/x/icapolicy/keeper"
+	"union/x/icapolicy/types"
+)
+
+const (
+	hostChannelID = "channel-0"
+	connectionID  = "connection-0"
+)
+
+// hostApp stands for the interchain accounts host, executing every packet it
+// receives.
+type hostApp struct {
+	porttypes.IBCModule
+	received int
+}
+
+func (app *hostApp) OnRecvPacket(sdk.Context, channeltypes.Packet, sdk.AccAddress) ibcexported.Acknowledgement {
+	app.received++
+	return channeltypes.NewResultAcknowledgement([]byte{byte(1)})
+}
+
+// channelKeeper knows the host channel, opened over connectionID.
+type channelKeeper struct{}
+
+func (channelKeeper) GetChannel(_ sdk.Context, portID, channelID string) (channeltypes.Channel, bool) {
+	if portID != icatypes.HostPortID || channelID != hostChannelID {
+		return channeltypes.Channel{}, false
+	}
+	version := string(icatypes.ModuleCdc.MustMarshalJSON(&icatypes.Metadata{
+		Version:                icatypes.Version,
+		ControllerConnectionId: "connection-7",
+		HostConnectionId:       connectionID,
+		Encoding:               icatypes.EncodingProtobuf,
+		TxType:                 icatypes.TxTypeSDKMultiMsg,
+	}))
+	return channeltypes.NewChannel(channeltypes.OPEN, channeltypes.ORDERED, channeltypes.NewCounterparty(icatypes.ControllerPortPrefix+"owner", "channel-7"), []string{connectionID}, version), true
+}
+
+func packet(t *testing.T, cdc codec.Codec, msgs ...proto.Message) channeltypes.Packet {
+	bz, err := icatypes.SerializeCosmosTx(cdc, msgs, icatypes.EncodingProtobuf)
+	require.NoError(t, err)
+	data := icatypes.InterchainAccountPacketData{Type: icatypes.EXECUTE_TX, Data: bz}.GetBytes()
+	return channeltypes.NewPacket(data, 1, icatypes.ControllerPortPrefix+"owner", "channel-7", icatypes.HostPortID, hostChannelID, clienttypes.ZeroHeight(), 0)
+}
+
+func TestOnRecvPacket(t *testing.T) {
+	encoding := moduletestutil.MakeTestEncodingConfig()
+	banktypes.RegisterInterfaces(encoding.InterfaceRegistry)
+	stakingtypes.RegisterInterfaces(encoding.InterfaceRegistry)
+
+	from, to := sdk.AccAddress("from"), sdk.AccAddress("to")
+	send := banktypes.NewMsgSend(from, to, sdk.NewCoins(sdk.NewInt64Coin("muno", 1)))
+	delegate := stakingtypes.NewMsgDelegate(from.String(), sdk.ValAddress("val").String(), sdk.NewCoin("muno", sdkmath.OneInt()))
+
+	for _, tc := range []struct {
+		name     string
+		packet   channeltypes.Packet
+		accepted bool
+	}{
+		{
+			name:     "allowed message",
+			packet:   packet(t, encoding.Codec, send),
+			accepted: true,
+		},
+		{
+			name:   "denied message type",
+			packet: packet(t, encoding.Codec, delegate),
+		},
+		{
+			name:   "denied message type along an allowed one",
+			packet: packet(t, encoding.Codec, send, delegate),
+		},
+		{
+			// Left to the host, which rejects it.
+			name:     "not an interchain account packet",
+			packet:   channeltypes.NewPacket([]byte("garbage"), 1, icatypes.ControllerPortPrefix+"owner", "channel-7", icatypes.HostPortID, hostChannelID, clienttypes.ZeroHeight(), 0),
+			accepted: true,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			key := storetypes.NewKVStoreKey(types.StoreKey)
+			ctx := testutil.DefaultContext(key, storetypes.NewTransientStoreKey("transient_test"))
+			k := keeper.NewKeeper(encoding.Codec, key, channelKeeper{}, nil, "authority")
+			require.NoError(t, k.SetParams(ctx, types.NewParams([]string{sdk.MsgTypeURL(send)}, nil)))
+			app := &hostApp{}
+			im := icapolicy.NewIBCMiddleware(app, k)
+
+			ack := im.OnRecvPacket(ctx, tc.packet, nil)
+			require.Equal(t, tc.accepted, ack.Success())
+			if tc.accepted {
+				require.Equal(t, 1, app.received)
+			} else {
+				// The transaction never reaches the host.
+				require.Zero(t, app.received)
+			}
+		})
+	}
+}
@@ -0,0 +1,30 @@
+package keeper
+
+import (
+	"context"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"union/x/icapolicy/types"
+)
+
+var _ types.QueryServer = Keeper{}
+
+func (k Keeper) Params(ctx context.Context, req *types.QueryParamsRequest) (*types.QueryParamsResponse, error) {
+	sdkCtx := sdk.UnwrapSDKContext(ctx)
+	params := k.GetParams(sdkCtx)
+
+	return &types.QueryParamsResponse{Params: params}, nil
+}
+
+func (k Keeper) AllowedMessages(ctx context.Context, req *types.QueryAllowedMessagesRequest) (*types.QueryAllowedMessagesResponse, error) {
+	if req == nil || req.ConnectionId == "" {
+		return nil, status.Error(codes.InvalidArgument, "connection id can't be empty")
+	}
+
+	params := k.GetParams(sdk.UnwrapSDKContext(ctx))
+
+	return &types.QueryAllowedMessagesResponse{AllowedMessages: params.AllowedMessagesFor(req.ConnectionId)}, nil
+}
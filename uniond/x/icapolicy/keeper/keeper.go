@@ -0,0 +1,56 @@
+package keeper
+
+import (
+	"fmt"
+
+	"cosmossdk.io/log"
+	storetypes "cosmossdk.io/store/types"
+	"github.com/cosmos/cosmos-sdk/codec"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	porttypes "github.com/cosmos/ibc-go/v8/modules/core/05-port/types"
+
+	"union/x/icapolicy/types"
+)
+
+type (
+	Keeper struct {
+		// the codec of the application, able to decode any message an
+		// interchain account may execute.
+		cdc      codec.Codec
+		storeKey storetypes.StoreKey
+
+		channelKeeper types.ChannelKeeper
+
+		// the next ICS4 wrapper in the host stack.
+		ics4Wrapper porttypes.ICS4Wrapper
+
+		// the address capable of executing a MsgUpdateParams message. Typically, this
+		// should be the x/gov module account.
+		authority string
+	}
+)
+
+func NewKeeper(
+	cdc codec.Codec,
+	storeKey storetypes.StoreKey,
+	channelKeeper types.ChannelKeeper,
+	ics4Wrapper porttypes.ICS4Wrapper,
+	authority string,
+) Keeper {
+	return Keeper{
+		cdc:           cdc,
+		storeKey:      storeKey,
+		channelKeeper: channelKeeper,
+		ics4Wrapper:   ics4Wrapper,
+		authority:     authority,
+	}
+}
+
+// GetAuthority returns the x/icapolicy module's authority.
+func (k Keeper) GetAuthority() string {
+	return k.authority
+}
+
+func (k Keeper) Logger(ctx sdk.Context) log.Logger {
+	return ctx.Logger().With("module", fmt.Sprintf("x/%s", types.ModuleName))
+}
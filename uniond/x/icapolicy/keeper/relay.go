@@ -0,0 +1,74 @@
+package keeper
+
+import (
+	"github.com/cosmos/cosmos-sdk/telemetry"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	capabilitytypes "github.com/cosmos/ibc-go/modules/capability/types"
+	icatypes "github.com/cosmos/ibc-go/v8/modules/apps/27-interchain-accounts/types"
+	clienttypes "github.com/cosmos/ibc-go/v8/modules/core/02-client/types"
+	channeltypes "github.com/cosmos/ibc-go/v8/modules/core/04-channel/types"
+	"github.com/cosmos/ibc-go/v8/modules/core/exported"
+	"github.com/hashicorp/go-metrics"
+
+	"union/x/icapolicy/types"
+)
+
+// AuthorizePacket checks the transaction carried by an interchain account
+// packet against the policy of the connection it was received on. Packets
+// that can't be decoded are left to the host, which rejects them.
+func (k Keeper) AuthorizePacket(ctx sdk.Context, packet channeltypes.Packet) error {
+	var data icatypes.InterchainAccountPacketData
+	if err := data.UnmarshalJSON(packet.GetData()); err != nil || data.Type != icatypes.EXECUTE_TX {
+		return nil
+	}
+
+	channel, found := k.channelKeeper.GetChannel(ctx, packet.GetDestPort(), packet.GetDestChannel())
+	if !found || len(channel.ConnectionHops) == 0 {
+		return nil
+	}
+	metadata, err := icatypes.MetadataFromVersion(channel.Version)
+	if err != nil {
+		return nil
+	}
+	msgs, err := icatypes.DeserializeCosmosTx(k.cdc, data.Data, metadata.Encoding)
+	if err != nil {
+		return nil
+	}
+
+	connectionID := channel.ConnectionHops[0]
+	if err := k.GetParams(ctx).Authorize(connectionID, msgs); err != nil {
+		if !ctx.IsCheckTx() {
+			telemetry.IncrCounterWithLabels([]string{types.ModuleName, "rejected"}, 1, []metrics.Label{telemetry.NewLabel("connection_id", connectionID)})
+		}
+		return err
+	}
+	return nil
+}
+
+// SendPacket implements the ICS4Wrapper interface.
+func (k Keeper) SendPacket(
+	ctx sdk.Context,
+	chanCap *capabilitytypes.Capability,
+	sourcePort string,
+	sourceChannel string,
+	timeoutHeight clienttypes.Height,
+	timeoutTimestamp uint64,
+	data []byte,
+) (uint64, error) {
+	return k.ics4Wrapper.SendPacket(ctx, chanCap, sourcePort, sourceChannel, timeoutHeight, timeoutTimestamp, data)
+}
+
+// WriteAcknowledgement implements the ICS4Wrapper interface.
+func (k Keeper) WriteAcknowledgement(
+	ctx sdk.Context,
+	chanCap *capabilitytypes.Capability,
+	packet exported.PacketI,
+	ack exported.Acknowledgement,
+) error {
+	return k.ics4Wrapper.WriteAcknowledgement(ctx, chanCap, packet, ack)
+}
+
+// GetAppVersion implements the ICS4Wrapper interface.
+func (k Keeper) GetAppVersion(ctx sdk.Context, portID, channelID string) (string, bool) {
+	return k.ics4Wrapper.GetAppVersion(ctx, portID, channelID)
+}
@@ -0,0 +1,14 @@
+package types
+
+// DONTCOVER
+
+import (
+	errorsmod "cosmossdk.io/errors"
+)
+
+// x/icapolicy module sentinel errors
+var (
+	ErrInvalidAuthority  = errorsmod.Register(ModuleName, 2, "invalid authority")
+	ErrInvalidParams     = errorsmod.Register(ModuleName, 3, "invalid params")
+	ErrMessageNotAllowed = errorsmod.Register(ModuleName, 4, "message not allowed for interchain accounts")
+)
@@ -0,0 +1,124 @@
+package types
+
+import (
+	"fmt"
+	"strings"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	connectiontypes "github.com/cosmos/ibc-go/v8/modules/core/03-connection/types"
+)
+
+// DefaultAllowedMessages lets interchain accounts move funds and manage
+// staking, governance and contract positions. Granting authorizations is
+// deliberately left out, it would let a counterparty bypass the policy.
+var DefaultAllowedMessages = []string{
+	"/cosmos.bank.v1beta1.MsgSend",
+	"/cosmos.staking.v1beta1.MsgDelegate",
+	"/cosmos.staking.v1beta1.MsgUndelegate",
+	"/cosmos.staking.v1beta1.MsgBeginRedelegate",
+	"/cosmos.distribution.v1beta1.MsgWithdrawDelegatorReward",
+	"/cosmos.gov.v1.MsgVote",
+	"/ibc.applications.transfer.v1.MsgTransfer",
+	"/cosmwasm.wasm.v1.MsgExecuteContract",
+}
+
+// NewParams creates a new parameter configuration for the icapolicy module.
+func NewParams(allowedMessages []string, connectionPolicies []ConnectionPolicy) Params {
+	return Params{
+		AllowedMessages:    allowedMessages,
+		ConnectionPolicies: connectionPolicies,
+	}
+}
+
+// DefaultParams is the default parameter configuration for the icapolicy
+// module.
+func DefaultParams() Params {
+	return NewParams(DefaultAllowedMessages, []ConnectionPolicy{})
+}
+
+// Validate validates all parameters.
+func (p Params) Validate() error {
+	if err := validateAllowedMessages(p.AllowedMessages); err != nil {
+		return err
+	}
+	seen := make(map[string]struct{}, len(p.ConnectionPolicies))
+	for _, policy := range p.ConnectionPolicies {
+		if !connectiontypes.IsValidConnectionID(policy.ConnectionId) {
+			return fmt.Errorf("invalid connection id %q", policy.ConnectionId)
+		}
+		if _, ok := seen[policy.ConnectionId]; ok {
+			return fmt.Errorf("duplicate policy for connection %s", policy.ConnectionId)
+		}
+		seen[policy.ConnectionId] = struct{}{}
+		if err := validateAllowedMessages(policy.AllowedMessages); err != nil {
+			return fmt.Errorf("connection %s: %w", policy.ConnectionId, err)
+		}
+	}
+	return nil
+}
+
+func validateAllowedMessages(allowedMessages []string) error {
+	seen := make(map[string]struct{}, len(allowedMessages))
+	for _, typeURL := range allowedMessages {
+		if !strings.HasPrefix(typeURL, "/") || strings.TrimSpace(typeURL) != typeURL {
+			return fmt.Errorf("invalid message type url %q", typeURL)
+		}
+		if _, ok := seen[typeURL]; ok {
+			return fmt.Errorf("duplicate message type url %s", typeURL)
+		}
+		seen[typeURL] = struct{}{}
+	}
+	return nil
+}
+
+// AllowedMessagesFor returns the messages the interchain accounts registered
+// over the connection may execute.
+func (p Params) AllowedMessagesFor(connectionID string) []string {
+	for _, policy := range p.ConnectionPolicies {
+		if policy.ConnectionId == connectionID {
+			return policy.AllowedMessages
+		}
+	}
+	return p.AllowedMessages
+}
+
+// Authorize returns an error if any of the messages, or of the messages they
+// wrap (such as an authz MsgExec), isn't allowed for the interchain accounts
+// registered over the connection.
+func (p Params) Authorize(connectionID string, msgs []sdk.Msg) error {
+	allowed := make(map[string]struct{})
+	for _, typeURL := range p.AllowedMessagesFor(connectionID) {
+		allowed[typeURL] = struct{}{}
+	}
+	return authorize(allowed, msgs)
+}
+
+func authorize(allowed map[string]struct{}, msgs []sdk.Msg) error {
+	for _, msg := range msgs {
+		typeURL := sdk.MsgTypeURL(msg)
+		if _, ok := allowed[typeURL]; !ok {
+			return ErrMessageNotAllowed.Wrap(typeURL)
+		}
+		nested, err := nestedMsgs(msg)
+		if err != nil {
+			return err
+		}
+		if err := authorize(allowed, nested); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// nestedMsgs returns the messages executed on behalf of a message, as done by
+// authz and group executions or governance proposals.
+func nestedMsgs(msg sdk.Msg) ([]sdk.Msg, error) {
+	switch msg := msg.(type) {
+	case interface{ GetMessages() ([]sdk.Msg, error) }:
+		return msg.GetMessages()
+	case interface{ GetMsgs() ([]sdk.Msg, error) }:
+		return msg.GetMsgs()
+	default:
+		return nil, nil
+	}
+}
@@ -0,0 +1,57 @@
+package types_test
+
+import (
+	"testing"
+
+	sdkmath "cosmossdk.io/math"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/authz"
+	banktypes "github.com/cosmos/cosmos-sdk/x/bank/types"
+	stakingtypes "github.com/cosmos/cosmos-sdk/x/staking/types"
+	"github.com/stretchr/testify/require"
+
+	"union/x/icapolicy/types"
+)
+
+func TestParamsValidate(t *testing.T) {
+	require.NoError(t, types.DefaultParams().Validate())
+	require.NoError(t, types.DefaultGenesis().Validate())
+
+	params := types.NewParams([]string{"cosmos.bank.v1beta1.MsgSend"}, nil)
+	require.Error(t, params.Validate())
+
+	params = types.NewParams([]string{"/cosmos.bank.v1beta1.MsgSend", "/cosmos.bank.v1beta1.MsgSend"}, nil)
+	require.Error(t, params.Validate())
+
+	params = types.NewParams(nil, []types.ConnectionPolicy{{ConnectionId: "channel-0"}})
+	require.Error(t, params.Validate())
+
+	params = types.NewParams(nil, []types.ConnectionPolicy{{ConnectionId: "connection-0"}, {ConnectionId: "connection-0"}})
+	require.Error(t, params.Validate())
+}
+
+func TestAuthorize(t *testing.T) {
+	from, to := sdk.AccAddress("from"), sdk.AccAddress("to")
+	send := banktypes.NewMsgSend(from, to, sdk.NewCoins(sdk.NewInt64Coin("muno", 1)))
+	delegate := stakingtypes.NewMsgDelegate(from.String(), sdk.ValAddress("val").String(), sdk.NewCoin("muno", sdkmath.OneInt()))
+	exec := authz.NewMsgExec(to, []sdk.Msg{send})
+	grant := &authz.MsgGrant{Granter: from.String(), Grantee: to.String()}
+
+	params := types.NewParams(
+		[]string{sdk.MsgTypeURL(send), sdk.MsgTypeURL(delegate), sdk.MsgTypeURL(&exec)},
+		[]types.ConnectionPolicy{{ConnectionId: "connection-1", AllowedMessages: []string{sdk.MsgTypeURL(send)}}},
+	)
+
+	require.NoError(t, params.Authorize("connection-0", []sdk.Msg{send, delegate}))
+	require.ErrorIs(t, params.Authorize("connection-0", []sdk.Msg{send, grant}), types.ErrMessageNotAllowed)
+
+	// The connection policy overrides the default one.
+	require.NoError(t, params.Authorize("connection-1", []sdk.Msg{send}))
+	require.ErrorIs(t, params.Authorize("connection-1", []sdk.Msg{delegate}), types.ErrMessageNotAllowed)
+	require.Equal(t, []string{sdk.MsgTypeURL(send)}, params.AllowedMessagesFor("connection-1"))
+
+	// Wrapped messages are checked as well.
+	require.NoError(t, params.Authorize("connection-0", []sdk.Msg{&exec}))
+	execGrant := authz.NewMsgExec(to, []sdk.Msg{grant})
+	require.ErrorIs(t, params.Authorize("connection-0", []sdk.Msg{&execGrant}), types.ErrMessageNotAllowed)
+}
@@ -0,0 +1,31 @@
+package keeper
+
+import (
+	sdk "github.com/cosmos/cosmos-sdk/types"
+
+	"union/x/lightclient/types"
+)
+
+// InitGenesis initializes the lightclient module's state from a provided genesis
+// state.
+func (k Keeper) InitGenesis(ctx sdk.Context, genState types.GenesisState) {
+	if err := k.SetParams(ctx, genState.Params); err != nil {
+		panic(err)
+	}
+	if genState.HashSchemeTransition != nil {
+		if err := k.SetHashSchemeTransition(ctx, *genState.HashSchemeTransition); err != nil {
+			panic(err)
+		}
+	}
+}
+
+// ExportGenesis returns the lightclient module's exported genesis.
+func (k Keeper) ExportGenesis(ctx sdk.Context) *types.GenesisState {
+	genesis := &types.GenesisState{
+		Params: k.GetParams(ctx),
+	}
+	if transition, found := k.GetHashSchemeTransition(ctx); found {
+		genesis.HashSchemeTransition = &transition
+	}
+	return genesis
+}
@@ -0,0 +1,28 @@
+package keeper
+
+import (
+	"context"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+
+	"union/x/lightclient/types"
+)
+
+var _ types.QueryServer = Keeper{}
+
+func (k Keeper) Params(ctx context.Context, req *types.QueryParamsRequest) (*types.QueryParamsResponse, error) {
+	sdkCtx := sdk.UnwrapSDKContext(ctx)
+	params := k.GetParams(sdkCtx)
+
+	return &types.QueryParamsResponse{Params: params}, nil
+}
+
+func (k Keeper) HashSchemeTransition(ctx context.Context, req *types.QueryHashSchemeTransitionRequest) (*types.QueryHashSchemeTransitionResponse, error) {
+	sdkCtx := sdk.UnwrapSDKContext(ctx)
+	transition, found := k.GetHashSchemeTransition(sdkCtx)
+	if !found {
+		return &types.QueryHashSchemeTransitionResponse{}, nil
+	}
+
+	return &types.QueryHashSchemeTransitionResponse{Transition: &transition}, nil
+}
@@ -0,0 +1,35 @@
+package keeper
+
+import (
+	"union/x/lightclient/types"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// SetHashSchemeTransition records the latest header hashing transition,
+// replacing the previous one.
+func (k Keeper) SetHashSchemeTransition(ctx sdk.Context, t types.HashSchemeTransition) error {
+	if err := t.Validate(); err != nil {
+		return err
+	}
+
+	store := ctx.KVStore(k.storeKey)
+	bz := k.cdc.MustMarshal(&t)
+	store.Set(types.HashSchemeTransitionKey, bz)
+	return nil
+}
+
+// GetHashSchemeTransition returns the latest header hashing transition, if
+// any.
+func (k Keeper) GetHashSchemeTransition(ctx sdk.Context) (types.HashSchemeTransition, bool) {
+	var t types.HashSchemeTransition
+
+	store := ctx.KVStore(k.storeKey)
+	bz := store.Get(types.HashSchemeTransitionKey)
+	if bz == nil {
+		return t, false
+	}
+
+	k.cdc.MustUnmarshal(bz, &t)
+	return t, true
+}
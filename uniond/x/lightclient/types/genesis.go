@@ -0,0 +1,20 @@
+package types
+
+// DefaultGenesis returns the default lightclient genesis state.
+func DefaultGenesis() *GenesisState {
+	return &GenesisState{
+		Params: DefaultParams(),
+	}
+}
+
+// Validate performs basic genesis state validation returning an error upon any
+// failure.
+func (gs GenesisState) Validate() error {
+	if err := gs.Params.Validate(); err != nil {
+		return err
+	}
+	if gs.HashSchemeTransition != nil {
+		return gs.HashSchemeTransition.Validate()
+	}
+	return nil
+}
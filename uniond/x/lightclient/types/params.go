@@ -0,0 +1,100 @@
+package types
+
+import (
+	"fmt"
+	"time"
+
+	cmtmath "github.com/cometbft/cometbft/libs/math"
+	"github.com/cometbft/cometbft/light"
+)
+
+const (
+	// HashSchemeCometbls is the MiMC based header hashing used by CometBLS.
+	HashSchemeCometbls = "cometbls"
+	// HashSchemeLegacy is the sha256 based header hashing used before the
+	// CometBLS transition.
+	HashSchemeLegacy = "legacy"
+)
+
+var (
+	// DefaultTrustLevel requires 1/3 of the trusted validator set to sign a
+	// non-adjacent header, matching the CometBFT light client default.
+	DefaultTrustLevel = NewFractionFromTm(light.DefaultTrustLevel)
+
+	// DefaultMaxClockDrift matches the CometBFT light client default.
+	DefaultMaxClockDrift = 10 * time.Second
+
+	// DefaultAllowedHashSchemes only accepts CometBLS headers.
+	DefaultAllowedHashSchemes = []string{HashSchemeCometbls}
+)
+
+// NewFractionFromTm returns a new Fraction instance from a cmtmath.Fraction
+func NewFractionFromTm(f cmtmath.Fraction) Fraction {
+	return Fraction{
+		Numerator:   f.Numerator,
+		Denominator: f.Denominator,
+	}
+}
+
+// ToTendermint converts Fraction to cmtmath.Fraction
+func (f Fraction) ToTendermint() cmtmath.Fraction {
+	return cmtmath.Fraction{
+		Numerator:   f.Numerator,
+		Denominator: f.Denominator,
+	}
+}
+
+// NewParams creates a new parameter configuration for the lightclient module.
+func NewParams(trustLevel Fraction, maxClockDrift time.Duration, allowedHashSchemes []string) Params {
+	return Params{
+		TrustLevel:         trustLevel,
+		MaxClockDrift:      maxClockDrift,
+		AllowedHashSchemes: allowedHashSchemes,
+	}
+}
+
+// DefaultParams is the default parameter configuration for the lightclient module.
+func DefaultParams() Params {
+	return NewParams(DefaultTrustLevel, DefaultMaxClockDrift, DefaultAllowedHashSchemes)
+}
+
+// Validate the lightclient module parameters.
+func (p Params) Validate() error {
+	if err := light.ValidateTrustLevel(p.TrustLevel.ToTendermint()); err != nil {
+		return err
+	}
+	if p.MaxClockDrift <= 0 {
+		return fmt.Errorf("max clock drift must be positive, got %s", p.MaxClockDrift)
+	}
+	return validateHashSchemes(p.AllowedHashSchemes)
+}
+
+// IsHashSchemeAllowed returns true if headers hashed with the given scheme
+// may be accepted by verifiers.
+func (p Params) IsHashSchemeAllowed(scheme string) bool {
+	for _, allowed := range p.AllowedHashSchemes {
+		if allowed == scheme {
+			return true
+		}
+	}
+	return false
+}
+
+func validateHashSchemes(schemes []string) error {
+	if len(schemes) == 0 {
+		return fmt.Errorf("at least one hash scheme must be allowed")
+	}
+	seen := make(map[string]bool, len(schemes))
+	for _, scheme := range schemes {
+		switch scheme {
+		case HashSchemeCometbls, HashSchemeLegacy:
+		default:
+			return fmt.Errorf("unknown hash scheme: %s", scheme)
+		}
+		if seen[scheme] {
+			return fmt.Errorf("duplicate hash scheme: %s", scheme)
+		}
+		seen[scheme] = true
+	}
+	return nil
+}
@@ -0,0 +1,96 @@
+package types_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"union/x/lightclient/types"
+)
+
+func TestParams_Validate(t *testing.T) {
+	for _, tc := range []struct {
+		desc   string
+		params types.Params
+		valid  bool
+	}{
+		{
+			desc:   "default is valid",
+			params: types.DefaultParams(),
+			valid:  true,
+		},
+		{
+			desc: "both hash schemes",
+			params: types.NewParams(
+				types.Fraction{Numerator: 2, Denominator: 3},
+				time.Minute,
+				[]string{types.HashSchemeCometbls, types.HashSchemeLegacy},
+			),
+			valid: true,
+		},
+		{
+			desc: "trust level below 1/3",
+			params: types.NewParams(
+				types.Fraction{Numerator: 1, Denominator: 4},
+				time.Minute,
+				types.DefaultAllowedHashSchemes,
+			),
+			valid: false,
+		},
+		{
+			desc: "trust level above 1",
+			params: types.NewParams(
+				types.Fraction{Numerator: 4, Denominator: 3},
+				time.Minute,
+				types.DefaultAllowedHashSchemes,
+			),
+			valid: false,
+		},
+		{
+			desc: "zero clock drift",
+			params: types.NewParams(
+				types.DefaultTrustLevel,
+				0,
+				types.DefaultAllowedHashSchemes,
+			),
+			valid: false,
+		},
+		{
+			desc: "no hash scheme",
+			params: types.NewParams(
+				types.DefaultTrustLevel,
+				time.Minute,
+				nil,
+			),
+			valid: false,
+		},
+		{
+			desc: "unknown hash scheme",
+			params: types.NewParams(
+				types.DefaultTrustLevel,
+				time.Minute,
+				[]string{"keccak"},
+			),
+			valid: false,
+		},
+		{
+			desc: "duplicate hash scheme",
+			params: types.NewParams(
+				types.DefaultTrustLevel,
+				time.Minute,
+				[]string{types.HashSchemeLegacy, types.HashSchemeLegacy},
+			),
+			valid: false,
+		},
+	} {
+		t.Run(tc.desc, func(t *testing.T) {
+			err := tc.params.Validate()
+			if tc.valid {
+				require.NoError(t, err)
+			} else {
+				require.Error(t, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,32 @@
+package types
+
+import "fmt"
+
+// NewHashSchemeTransition creates a transition from previousScheme to scheme
+// taking effect at the given height.
+func NewHashSchemeTransition(height int64, previousScheme, scheme string) HashSchemeTransition {
+	return HashSchemeTransition{
+		Height:         height,
+		PreviousScheme: previousScheme,
+		Scheme:         scheme,
+	}
+}
+
+// Validate the hash scheme transition.
+func (t HashSchemeTransition) Validate() error {
+	if t.Height <= 0 {
+		return fmt.Errorf("transition height must be positive, got %d", t.Height)
+	}
+	if err := validateHashSchemes([]string{t.PreviousScheme, t.Scheme}); err != nil {
+		return err
+	}
+	return nil
+}
+
+// SchemeAt returns the hashing scheme of the header at the given height.
+func (t HashSchemeTransition) SchemeAt(height int64) string {
+	if height < t.Height {
+		return t.PreviousScheme
+	}
+	return t.Scheme
+}
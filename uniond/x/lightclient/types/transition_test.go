@@ -0,0 +1,55 @@
+package types_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"union/x/lightclient/types"
+)
+
+func TestHashSchemeTransition_Validate(t *testing.T) {
+	for _, tc := range []struct {
+		desc       string
+		transition types.HashSchemeTransition
+		valid      bool
+	}{
+		{
+			desc:       "legacy to cometbls",
+			transition: types.NewHashSchemeTransition(100, types.HashSchemeLegacy, types.HashSchemeCometbls),
+			valid:      true,
+		},
+		{
+			desc:       "zero height",
+			transition: types.NewHashSchemeTransition(0, types.HashSchemeLegacy, types.HashSchemeCometbls),
+			valid:      false,
+		},
+		{
+			desc:       "same scheme",
+			transition: types.NewHashSchemeTransition(100, types.HashSchemeCometbls, types.HashSchemeCometbls),
+			valid:      false,
+		},
+		{
+			desc:       "unknown scheme",
+			transition: types.NewHashSchemeTransition(100, types.HashSchemeLegacy, "keccak"),
+			valid:      false,
+		},
+	} {
+		t.Run(tc.desc, func(t *testing.T) {
+			err := tc.transition.Validate()
+			if tc.valid {
+				require.NoError(t, err)
+			} else {
+				require.Error(t, err)
+			}
+		})
+	}
+}
+
+func TestHashSchemeTransition_SchemeAt(t *testing.T) {
+	transition := types.NewHashSchemeTransition(100, types.HashSchemeLegacy, types.HashSchemeCometbls)
+	require.Equal(t, types.HashSchemeLegacy, transition.SchemeAt(1))
+	require.Equal(t, types.HashSchemeLegacy, transition.SchemeAt(99))
+	require.Equal(t, types.HashSchemeCometbls, transition.SchemeAt(100))
+	require.Equal(t, types.HashSchemeCometbls, transition.SchemeAt(101))
+}
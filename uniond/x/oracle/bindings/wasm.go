@@ -0,0 +1,35 @@
+package bindings
+
+import (
+	wasmkeeper "github.com/CosmWasm/wasmd/x/wasm/keeper"
+
+	"github.com/cosmos/cosmos-sdk/baseapp"
+	"github.com/cosmos/cosmos-sdk/codec"
+
+	"union/x/oracle/types"
+)
+
+// AcceptedQueries are the oracle queries contracts are allowed to run, either
+// as stargate or gRPC queries. They are deterministic as they only read the
+// module state.
+func AcceptedQueries() wasmkeeper.AcceptedQueries {
+	return wasmkeeper.AcceptedQueries{
+		"/union.oracle.v1.Query/Params":           &types.QueryParamsResponse{},
+		"/union.oracle.v1.Query/ChainMetadata":    &types.QueryChainMetadataResponse{},
+		"/union.oracle.v1.Query/AllChainMetadata": &types.QueryAllChainMetadataResponse{},
+	}
+}
+
+func RegisterQueryPlugins(
+	queryRouter *baseapp.GRPCQueryRouter,
+	cdc codec.Codec,
+) []wasmkeeper.Option {
+	queryPluginOpt := wasmkeeper.WithQueryPlugins(&wasmkeeper.QueryPlugins{
+		Stargate: wasmkeeper.AcceptListStargateQuerier(AcceptedQueries(), queryRouter, cdc),
+		Grpc:     wasmkeeper.AcceptListGrpcQuerier(AcceptedQueries(), queryRouter, cdc),
+	})
+
+	return []wasmkeeper.Option{
+		queryPluginOpt,
+	}
+}
@@ -0,0 +1,79 @@
+package keeper
+
+import (
+	storetypes "cosmossdk.io/store/types"
+	"github.com/cosmos/cosmos-sdk/telemetry"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/hashicorp/go-metrics"
+
+	"union/x/oracle/types"
+)
+
+// SetChainMetadata stores the metadata of a counterparty chain.
+func (k Keeper) SetChainMetadata(ctx sdk.Context, metadata types.ChainMetadata) {
+	store := ctx.KVStore(k.storeKey)
+	bz := k.cdc.MustMarshal(&metadata)
+	store.Set(types.ChainMetadataKey(metadata.ChainId), bz)
+}
+
+// GetChainMetadata returns the metadata of a counterparty chain, if any was
+// reported.
+func (k Keeper) GetChainMetadata(ctx sdk.Context, chainID string) (types.ChainMetadata, bool) {
+	var metadata types.ChainMetadata
+
+	store := ctx.KVStore(k.storeKey)
+	bz := store.Get(types.ChainMetadataKey(chainID))
+	if bz == nil {
+		return metadata, false
+	}
+
+	k.cdc.MustUnmarshal(bz, &metadata)
+	return metadata, true
+}
+
+// IterateChainMetadata calls cb with the metadata of every counterparty chain,
+// ordered by chain id, until it returns true.
+func (k Keeper) IterateChainMetadata(ctx sdk.Context, cb func(metadata types.ChainMetadata) (stop bool)) {
+	store := ctx.KVStore(k.storeKey)
+	iterator := storetypes.KVStorePrefixIterator(store, types.ChainMetadataKeyPrefix)
+	defer iterator.Close()
+
+	for ; iterator.Valid(); iterator.Next() {
+		var metadata types.ChainMetadata
+		k.cdc.MustUnmarshal(iterator.Value(), &metadata)
+		if cb(metadata) {
+			break
+		}
+	}
+}
+
+// Report records the metadata of a counterparty chain reported by a
+// whitelisted reporter. Finalized heights only move forward, finality being
+// irreversible a lower height is necessarily stale.
+func (k Keeper) Report(ctx sdk.Context, msg *types.MsgReport) error {
+	if !k.GetParams(ctx).IsReporter(msg.Reporter) {
+		return types.ErrUnauthorizedReporter.Wrapf("%s is not a whitelisted reporter", msg.Reporter)
+	}
+	if previous, found := k.GetChainMetadata(ctx, msg.ChainId); found && msg.FinalizedHeight < previous.FinalizedHeight {
+		return types.ErrStaleFinalizedHeight.Wrapf("chain %s: reported %d, current %d", msg.ChainId, msg.FinalizedHeight, previous.FinalizedHeight)
+	}
+
+	k.SetChainMetadata(ctx, types.ChainMetadata{
+		ChainId:         msg.ChainId,
+		FinalizedHeight: msg.FinalizedHeight,
+		GasPrice:        msg.GasPrice,
+		Reporter:        msg.Reporter,
+		UpdatedHeight:   ctx.BlockHeight(),
+		UpdatedAt:       ctx.BlockTime(),
+	})
+
+	if !ctx.IsCheckTx() {
+		labels := []metrics.Label{telemetry.NewLabel("chain_id", msg.ChainId)}
+		telemetry.SetGaugeWithLabels([]string{types.ModuleName, "finalized_height"}, float32(msg.FinalizedHeight), labels)
+		if value, err := msg.GasPrice.Amount.Float64(); err == nil {
+			telemetry.SetGaugeWithLabels([]string{types.ModuleName, "gas_price"}, float32(value), append(labels, telemetry.NewLabel("denom", msg.GasPrice.Denom)))
+		}
+	}
+
+	return nil
+}
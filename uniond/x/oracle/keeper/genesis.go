@@ -0,0 +1,31 @@
+package keeper
+
+import (
+	sdk "github.com/cosmos/cosmos-sdk/types"
+
+	"union/x/oracle/types"
+)
+
+// InitGenesis initializes the oracle module's state from a provided genesis
+// state.
+func (k Keeper) InitGenesis(ctx sdk.Context, genState types.GenesisState) {
+	if err := k.SetParams(ctx, genState.Params); err != nil {
+		panic(err)
+	}
+	for _, metadata := range genState.ChainMetadata {
+		k.SetChainMetadata(ctx, metadata)
+	}
+}
+
+// ExportGenesis returns the oracle module's exported genesis.
+func (k Keeper) ExportGenesis(ctx sdk.Context) *types.GenesisState {
+	chainMetadata := []types.ChainMetadata{}
+	k.IterateChainMetadata(ctx, func(metadata types.ChainMetadata) bool {
+		chainMetadata = append(chainMetadata, metadata)
+		return false
+	})
+	return &types.GenesisState{
+		Params:        k.GetParams(ctx),
+		ChainMetadata: chainMetadata,
+	}
+}
@@ -0,0 +1,57 @@
+package keeper
+
+import (
+	"context"
+
+	"cosmossdk.io/store/prefix"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/types/query"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"union/x/oracle/types"
+)
+
+var _ types.QueryServer = Keeper{}
+
+func (k Keeper) Params(ctx context.Context, req *types.QueryParamsRequest) (*types.QueryParamsResponse, error) {
+	sdkCtx := sdk.UnwrapSDKContext(ctx)
+	params := k.GetParams(sdkCtx)
+
+	return &types.QueryParamsResponse{Params: params}, nil
+}
+
+func (k Keeper) ChainMetadata(ctx context.Context, req *types.QueryChainMetadataRequest) (*types.QueryChainMetadataResponse, error) {
+	if req == nil || req.ChainId == "" {
+		return nil, status.Error(codes.InvalidArgument, "chain id can't be empty")
+	}
+
+	metadata, found := k.GetChainMetadata(sdk.UnwrapSDKContext(ctx), req.ChainId)
+	if !found {
+		return nil, status.Errorf(codes.NotFound, "%s: %s", types.ErrChainMetadataNotFound, req.ChainId)
+	}
+
+	return &types.QueryChainMetadataResponse{ChainMetadata: metadata}, nil
+}
+
+func (k Keeper) AllChainMetadata(ctx context.Context, req *types.QueryAllChainMetadataRequest) (*types.QueryAllChainMetadataResponse, error) {
+	if req == nil {
+		return nil, status.Error(codes.InvalidArgument, "empty request")
+	}
+
+	store := prefix.NewStore(sdk.UnwrapSDKContext(ctx).KVStore(k.storeKey), types.ChainMetadataKeyPrefix)
+	chainMetadata := []types.ChainMetadata{}
+	pageRes, err := query.Paginate(store, req.Pagination, func(_, value []byte) error {
+		var metadata types.ChainMetadata
+		if err := k.cdc.Unmarshal(value, &metadata); err != nil {
+			return err
+		}
+		chainMetadata = append(chainMetadata, metadata)
+		return nil
+	})
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	return &types.QueryAllChainMetadataResponse{ChainMetadata: chainMetadata, Pagination: pageRes}, nil
+}
@@ -0,0 +1,54 @@
+package keeper
+
+import (
+	"context"
+	"strconv"
+
+	errorsmod "cosmossdk.io/errors"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	govtypes "github.com/cosmos/cosmos-sdk/x/gov/types"
+
+	"union/x/oracle/types"
+)
+
+type msgServer struct {
+	Keeper
+}
+
+func NewMsgServerImpl(keeper Keeper) types.MsgServer {
+	return msgServer{Keeper: keeper}
+}
+
+var _ types.MsgServer = msgServer{}
+
+func (server msgServer) UpdateParams(goCtx context.Context, req *types.MsgUpdateParams) (*types.MsgUpdateParamsResponse, error) {
+	if server.authority != req.Authority {
+		return nil, errorsmod.Wrapf(govtypes.ErrInvalidSigner, "invalid authority; expected %s, got %s", server.authority, req.Authority)
+	}
+
+	ctx := sdk.UnwrapSDKContext(goCtx)
+	if err := server.SetParams(ctx, req.Params); err != nil {
+		return nil, errorsmod.Wrap(types.ErrInvalidParams, err.Error())
+	}
+
+	return &types.MsgUpdateParamsResponse{}, nil
+}
+
+func (server msgServer) Report(goCtx context.Context, req *types.MsgReport) (*types.MsgReportResponse, error) {
+	ctx := sdk.UnwrapSDKContext(goCtx)
+	if err := server.Keeper.Report(ctx, req); err != nil {
+		return nil, err
+	}
+
+	ctx.EventManager().EmitEvents(sdk.Events{
+		sdk.NewEvent(
+			types.TypeMsgReport,
+			sdk.NewAttribute(types.AttributeReporter, req.Reporter),
+			sdk.NewAttribute(types.AttributeChainID, req.ChainId),
+			sdk.NewAttribute(types.AttributeFinalizedHeight, strconv.FormatUint(req.FinalizedHeight, 10)),
+			sdk.NewAttribute(types.AttributeGasPrice, req.GasPrice.String()),
+		),
+	})
+
+	return &types.MsgReportResponse{}, nil
+}
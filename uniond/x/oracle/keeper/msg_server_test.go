@@ -0,0 +1,107 @@
+package keeper_test
+
+import (
+	"testing"
+
+	sdkmath "cosmossdk.io/math"
+	storetypes "cosmossdk.io/store/types"
+	"github.com/cosmos/cosmos-sdk/testutil"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	moduletestutil "github.com/cosmos/cosmos-sdk/types/module/testutil"
+	govtypes "github.com/cosmos/cosmos-sdk/x/gov/types"
+	"github.com/stretchr/testify/require"
+
+	"union/x/oracle/keeper"
+	"union/x/oracle/types"
+)
+
+func TestMsgServerReport(t *testing.T) {
+	reporter := sdk.AccAddress("reporter____________").String()
+	outsider := sdk.AccAddress("outsider____________").String()
+	gasPrice := sdk.NewDecCoinFromDec("muno", sdkmath.LegacyNewDecWithPrec(15, 1))
+
+	for _, tc := range []struct {
+		name   string
+		msgs   []*types.MsgReport
+		err    error
+		height uint64
+	}{
+		{
+			name:   "whitelisted reporter",
+			msgs:   []*types.MsgReport{types.NewMsgReport(reporter, "union-1", 100, gasPrice)},
+			height: 100,
+		},
+		{
+			name: "unauthorized submitter",
+			msgs: []*types.MsgReport{types.NewMsgReport(outsider, "union-1", 100, gasPrice)},
+			err:  types.ErrUnauthorizedReporter,
+		},
+		{
+			name: "unauthorized submitter overwriting a report",
+			msgs: []*types.MsgReport{
+				types.NewMsgReport(reporter, "union-1", 100, gasPrice),
+				types.NewMsgReport(outsider, "union-1", 200, gasPrice),
+			},
+			err:    types.ErrUnauthorizedReporter,
+			height: 100,
+		},
+		{
+			name: "stale finalized height",
+			msgs: []*types.MsgReport{
+				types.NewMsgReport(reporter, "union-1", 100, gasPrice),
+				types.NewMsgReport(reporter, "union-1", 99, gasPrice),
+			},
+			err:    types.ErrStaleFinalizedHeight,
+			height: 100,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			key := storetypes.NewKVStoreKey(types.StoreKey)
+			ctx := testutil.DefaultContext(key, storetypes.NewTransientStoreKey("transient_test")).WithBlockHeight(10)
+			k := keeper.NewKeeper(moduletestutil.MakeTestEncodingConfig().Codec, key, "authority")
+			require.NoError(t, k.SetParams(ctx, types.NewParams([]string{reporter})))
+			server := keeper.NewMsgServerImpl(k)
+
+			var err error
+			for _, msg := range tc.msgs {
+				ctx = ctx.WithEventManager(sdk.NewEventManager())
+				if _, err = server.Report(ctx, msg); err != nil {
+					break
+				}
+			}
+			if tc.err != nil {
+				require.ErrorIs(t, err, tc.err)
+				// Nothing is emitted for a rejected report.
+				require.Empty(t, ctx.EventManager().Events())
+			} else {
+				require.NoError(t, err)
+				require.Len(t, ctx.EventManager().Events(), 1)
+				require.Equal(t, types.TypeMsgReport, ctx.EventManager().Events()[0].Type)
+			}
+
+			metadata, found := k.GetChainMetadata(ctx, "union-1")
+			require.Equal(t, tc.height != 0, found)
+			if found {
+				require.Equal(t, tc.height, metadata.FinalizedHeight)
+				require.Equal(t, reporter, metadata.Reporter)
+				require.Equal(t, int64(10), metadata.UpdatedHeight)
+			}
+		})
+	}
+}
+
+func TestMsgServerUpdateParams(t *testing.T) {
+	key := storetypes.NewKVStoreKey(types.StoreKey)
+	ctx := testutil.DefaultContext(key, storetypes.NewTransientStoreKey("transient_test"))
+	k := keeper.NewKeeper(moduletestutil.MakeTestEncodingConfig().Codec, key, "authority")
+	server := keeper.NewMsgServerImpl(k)
+	params := types.NewParams([]string{sdk.AccAddress("reporter____________").String()})
+
+	_, err := server.UpdateParams(ctx, types.NewMsgUpdateParams("outsider", params))
+	require.ErrorIs(t, err, govtypes.ErrInvalidSigner)
+	require.Empty(t, k.GetParams(ctx).Reporters)
+
+	_, err = server.UpdateParams(ctx, types.NewMsgUpdateParams("authority", params))
+	require.NoError(t, err)
+	require.Equal(t, params, k.GetParams(ctx))
+}
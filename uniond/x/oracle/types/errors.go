@@ -0,0 +1,17 @@
+package types
+
+// DONTCOVER
+
+import (
+	errorsmod "cosmossdk.io/errors"
+)
+
+// x/oracle module sentinel errors
+var (
+	ErrInvalidAuthority      = errorsmod.Register(ModuleName, 2, "invalid authority")
+	ErrInvalidParams         = errorsmod.Register(ModuleName, 3, "invalid params")
+	ErrUnauthorizedReporter  = errorsmod.Register(ModuleName, 4, "unauthorized reporter")
+	ErrInvalidReport         = errorsmod.Register(ModuleName, 5, "invalid report")
+	ErrStaleFinalizedHeight  = errorsmod.Register(ModuleName, 6, "finalized height lower than the reported one")
+	ErrChainMetadataNotFound = errorsmod.Register(ModuleName, 7, "chain metadata not found")
+)
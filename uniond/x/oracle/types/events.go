@@ -0,0 +1,9 @@
+package types
+
+// event types
+const (
+	AttributeReporter        = "reporter"
+	AttributeChainID         = "chain_id"
+	AttributeFinalizedHeight = "finalized_height"
+	AttributeGasPrice        = "gas_price"
+)
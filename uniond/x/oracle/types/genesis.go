@@ -0,0 +1,32 @@
+package types
+
+import (
+	"fmt"
+)
+
+// DefaultGenesis returns the default oracle genesis state.
+func DefaultGenesis() *GenesisState {
+	return &GenesisState{
+		Params:        DefaultParams(),
+		ChainMetadata: []ChainMetadata{},
+	}
+}
+
+// Validate performs basic genesis state validation returning an error upon any
+// failure.
+func (gs GenesisState) Validate() error {
+	if err := gs.Params.Validate(); err != nil {
+		return err
+	}
+	seen := make(map[string]struct{}, len(gs.ChainMetadata))
+	for _, metadata := range gs.ChainMetadata {
+		if err := metadata.Validate(); err != nil {
+			return fmt.Errorf("chain %s: %w", metadata.ChainId, err)
+		}
+		if _, ok := seen[metadata.ChainId]; ok {
+			return fmt.Errorf("duplicate metadata for chain %s", metadata.ChainId)
+		}
+		seen[metadata.ChainId] = struct{}{}
+	}
+	return nil
+}
@@ -0,0 +1,26 @@
+package types
+
+const (
+	// ModuleName defines the module name
+	ModuleName = "oracle"
+
+	// StoreKey defines the primary module store key
+	StoreKey = ModuleName
+
+	// RouterKey is the message route for oracle
+	RouterKey = ModuleName
+
+	// QuerierRoute defines the module's query routing key
+	QuerierRoute = ModuleName
+)
+
+var (
+	ParamsKey              = []byte{0x00}
+	ChainMetadataKeyPrefix = []byte{0x01}
+)
+
+// ChainMetadataKey returns the store key of the metadata of a counterparty
+// chain.
+func ChainMetadataKey(chainID string) []byte {
+	return append(append([]byte{}, ChainMetadataKeyPrefix...), chainID...)
+}
@@ -0,0 +1,60 @@
+package types
+
+import (
+	errorsmod "cosmossdk.io/errors"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+const (
+	TypeMsgUpdateParams = "update_params"
+	TypeMsgReport       = "report"
+)
+
+var (
+	_ sdk.Msg = &MsgUpdateParams{}
+	_ sdk.Msg = &MsgReport{}
+)
+
+// NewMsgUpdateParams creates a new MsgUpdateParams instance
+func NewMsgUpdateParams(authority string, params Params) *MsgUpdateParams {
+	return &MsgUpdateParams{
+		Authority: authority,
+		Params:    params,
+	}
+}
+
+func (m MsgUpdateParams) Type() string { return TypeMsgUpdateParams }
+
+// ValidateBasic performs a stateless validation of the authority and params
+func (m MsgUpdateParams) ValidateBasic() error {
+	if _, err := sdk.AccAddressFromBech32(m.Authority); err != nil {
+		return errorsmod.Wrapf(ErrInvalidAuthority, "invalid authority address (%s)", err)
+	}
+	if err := m.Params.Validate(); err != nil {
+		return errorsmod.Wrap(ErrInvalidParams, err.Error())
+	}
+	return nil
+}
+
+// NewMsgReport creates a new MsgReport instance
+func NewMsgReport(reporter string, chainID string, finalizedHeight uint64, gasPrice sdk.DecCoin) *MsgReport {
+	return &MsgReport{
+		Reporter:        reporter,
+		ChainId:         chainID,
+		FinalizedHeight: finalizedHeight,
+		GasPrice:        gasPrice,
+	}
+}
+
+func (m MsgReport) Type() string { return TypeMsgReport }
+
+// ValidateBasic performs a stateless validation of the reported metadata
+func (m MsgReport) ValidateBasic() error {
+	if _, err := sdk.AccAddressFromBech32(m.Reporter); err != nil {
+		return errorsmod.Wrapf(ErrUnauthorizedReporter, "invalid reporter address (%s)", err)
+	}
+	if err := validateChainMetadata(m.ChainId, m.FinalizedHeight, m.GasPrice); err != nil {
+		return errorsmod.Wrap(ErrInvalidReport, err.Error())
+	}
+	return nil
+}
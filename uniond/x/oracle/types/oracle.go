@@ -0,0 +1,34 @@
+package types
+
+import (
+	"fmt"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// Validate performs a stateless validation of the chain metadata.
+func (m ChainMetadata) Validate() error {
+	if err := validateChainMetadata(m.ChainId, m.FinalizedHeight, m.GasPrice); err != nil {
+		return err
+	}
+	if _, err := sdk.AccAddressFromBech32(m.Reporter); err != nil {
+		return fmt.Errorf("invalid reporter address %q: %w", m.Reporter, err)
+	}
+	if m.UpdatedHeight < 0 {
+		return fmt.Errorf("updated height can't be negative: %d", m.UpdatedHeight)
+	}
+	return nil
+}
+
+func validateChainMetadata(chainID string, finalizedHeight uint64, gasPrice sdk.DecCoin) error {
+	if chainID == "" {
+		return fmt.Errorf("chain id can't be empty")
+	}
+	if finalizedHeight == 0 {
+		return fmt.Errorf("finalized height must be positive")
+	}
+	if err := gasPrice.Validate(); err != nil {
+		return fmt.Errorf("invalid gas price: %w", err)
+	}
+	return nil
+}
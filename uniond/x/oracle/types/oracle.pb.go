@@ -0,0 +1,572 @@
+//lint:file-ignore SA1019 This code is generated
+// Code generated by protoc-gen-gogo. DO NOT EDIT.
+// source: union/oracle/v1/oracle.proto
+
+package types
+
+import (
+	fmt "fmt"
+	_ "github.com/cosmos/cosmos-proto"
+	types "github.com/cosmos/cosmos-sdk/types"
+	_ "github.com/cosmos/cosmos-sdk/types/tx/amino"
+	_ "github.com/cosmos/gogoproto/gogoproto"
+	proto "github.com/cosmos/gogoproto/proto"
+	github_com_cosmos_gogoproto_types "github.com/cosmos/gogoproto/types"
+	_ "google.golang.org/protobuf/types/known/timestamppb"
+	io "io"
+	math "math"
+	math_bits "math/bits"
+	time "time"
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ = proto.Marshal
+var _ = fmt.Errorf
+var _ = math.Inf
+var _ = time.Kitchen
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the proto package it is being compiled against.
+// A compilation error at this line likely means your copy of the
+// proto package needs to be updated.
+const _ = proto.GoGoProtoPackageIsVersion3 // please upgrade the proto package
+
+// ChainMetadata is the latest metadata reported for a counterparty chain.
+type ChainMetadata struct {
+	// chain_id is the identifier of the counterparty chain.
+	ChainId string `protobuf:"bytes,1,opt,name=chain_id,json=chainId,proto3" json:"chain_id,omitempty"`
+	// finalized_height is the latest finalized height of the counterparty
+	// chain.
+	FinalizedHeight uint64 `protobuf:"varint,2,opt,name=finalized_height,json=finalizedHeight,proto3" json:"finalized_height,omitempty"`
+	// gas_price is the gas price on the counterparty chain, in its native
+	// denomination.
+	GasPrice types.DecCoin `protobuf:"bytes,3,opt,name=gas_price,json=gasPrice,proto3" json:"gas_price"`
+	// reporter is the address that reported this metadata.
+	Reporter string `protobuf:"bytes,4,opt,name=reporter,proto3" json:"reporter,omitempty"`
+	// updated_height is the Union height at which the metadata was reported.
+	UpdatedHeight int64 `protobuf:"varint,5,opt,name=updated_height,json=updatedHeight,proto3" json:"updated_height,omitempty"`
+	// updated_at is the Union block time at which the metadata was reported.
+	UpdatedAt time.Time `protobuf:"bytes,6,opt,name=updated_at,json=updatedAt,proto3,stdtime" json:"updated_at"`
+}
+
+func (m *ChainMetadata) Reset()         { *m = ChainMetadata{} }
+func (m *ChainMetadata) String() string { return proto.CompactTextString(m) }
+func (*ChainMetadata) ProtoMessage()    {}
+func (*ChainMetadata) Descriptor() ([]byte, []int) {
+	return fileDescriptor_36edab9411b9caa1, []int{0}
+}
+func (m *ChainMetadata) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *ChainMetadata) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_ChainMetadata.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *ChainMetadata) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ChainMetadata.Merge(m, src)
+}
+func (m *ChainMetadata) XXX_Size() int {
+	return m.Size()
+}
+func (m *ChainMetadata) XXX_DiscardUnknown() {
+	xxx_messageInfo_ChainMetadata.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ChainMetadata proto.InternalMessageInfo
+
+func (m *ChainMetadata) GetChainId() string {
+	if m != nil {
+		return m.ChainId
+	}
+	return ""
+}
+
+func (m *ChainMetadata) GetFinalizedHeight() uint64 {
+	if m != nil {
+		return m.FinalizedHeight
+	}
+	return 0
+}
+
+func (m *ChainMetadata) GetGasPrice() types.DecCoin {
+	if m != nil {
+		return m.GasPrice
+	}
+	return types.DecCoin{}
+}
+
+func (m *ChainMetadata) GetReporter() string {
+	if m != nil {
+		return m.Reporter
+	}
+	return ""
+}
+
+func (m *ChainMetadata) GetUpdatedHeight() int64 {
+	if m != nil {
+		return m.UpdatedHeight
+	}
+	return 0
+}
+
+func (m *ChainMetadata) GetUpdatedAt() time.Time {
+	if m != nil {
+		return m.UpdatedAt
+	}
+	return time.Time{}
+}
+
+func init() {
+	proto.RegisterType((*ChainMetadata)(nil), "union.oracle.v1.ChainMetadata")
+}
+
+func init() { proto.RegisterFile("union/oracle/v1/oracle.proto", fileDescriptor_36edab9411b9caa1) }
+
+var fileDescriptor_36edab9411b9caa1 = []byte{
+	// 397 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x44, 0x92, 0xbf, 0x8e, 0xd3, 0x30,
+	0x1c, 0xc7, 0xe3, 0xbb, 0xe3, 0x48, 0x8c, 0x8e, 0x83, 0xa8, 0x43, 0xae, 0x3a, 0xa5, 0x11, 0x12,
+	0x52, 0x40, 0xc2, 0x56, 0x81, 0x17, 0x68, 0xda, 0x01, 0x06, 0x24, 0x14, 0x98, 0x58, 0x2a, 0x27,
+	0x71, 0x5d, 0x4b, 0x8d, 0x1d, 0xc5, 0x6e, 0x05, 0x3c, 0x45, 0x1f, 0x83, 0x91, 0x81, 0x95, 0xbd,
+	0x63, 0xc5, 0xc4, 0x04, 0xa8, 0x1d, 0x78, 0x0d, 0x64, 0xc7, 0x29, 0x4b, 0xe4, 0xef, 0x1f, 0x3b,
+	0x1f, 0xfd, 0xf4, 0x83, 0xb7, 0x6b, 0xc1, 0xa5, 0xc0, 0xb2, 0x25, 0xe5, 0x8a, 0xe2, 0xcd, 0xd8,
+	0x9d, 0x50, 0xd3, 0x4a, 0x2d, 0xc3, 0x6b, 0x9b, 0x22, 0xe7, 0x6d, 0xc6, 0xc3, 0x01, 0x93, 0x4c,
+	0xda, 0x0c, 0x9b, 0x53, 0x57, 0x1b, 0x3e, 0x24, 0x35, 0x17, 0x12, 0xdb, 0xaf, 0xb3, 0x6e, 0x4a,
+	0xa9, 0x6a, 0xa9, 0xe6, 0x5d, 0xb7, 0x13, 0x2e, 0x8a, 0x3b, 0x85, 0x0b, 0xa2, 0xcc, 0x1f, 0x0b,
+	0xaa, 0xc9, 0x18, 0x97, 0x92, 0x0b, 0x97, 0x8f, 0x98, 0x94, 0x6c, 0x45, 0xb1, 0x55, 0xc5, 0x7a,
+	0x81, 0x35, 0xaf, 0xa9, 0xd2, 0xa4, 0x6e, 0xba, 0xc2, 0xa3, 0xef, 0x67, 0xf0, 0x6a, 0xba, 0x24,
+	0x5c, 0xbc, 0xa1, 0x9a, 0x54, 0x44, 0x93, 0xf0, 0x06, 0xfa, 0xa5, 0x31, 0xe6, 0xbc, 0x8a, 0x40,
+	0x02, 0xd2, 0x20, 0xbf, 0x6b, 0xf5, 0xeb, 0x2a, 0x7c, 0x02, 0x1f, 0x2c, 0xb8, 0x20, 0x2b, 0xfe,
+	0x99, 0x56, 0xf3, 0x25, 0xe5, 0x6c, 0xa9, 0xa3, 0xb3, 0x04, 0xa4, 0x17, 0xf9, 0xf5, 0xc9, 0x7f,
+	0x65, 0xed, 0x70, 0x06, 0x03, 0x46, 0x0c, 0x32, 0x2f, 0x69, 0x74, 0x9e, 0x80, 0xf4, 0xde, 0xf3,
+	0x5b, 0xe4, 0xd0, 0x0d, 0x2c, 0x72, 0xb0, 0x68, 0x46, 0xcb, 0xa9, 0xe4, 0x22, 0x0b, 0x76, 0xbf,
+	0x46, 0xde, 0x97, 0xbf, 0x5f, 0x9f, 0x82, 0xdc, 0x67, 0x44, 0xbd, 0x35, 0x17, 0xc3, 0x97, 0xd0,
+	0x6f, 0x69, 0x23, 0x5b, 0x4d, 0xdb, 0xe8, 0xc2, 0xb0, 0x64, 0xd1, 0x8f, 0x6f, 0xcf, 0x06, 0xee,
+	0x9d, 0x49, 0x55, 0xb5, 0x54, 0xa9, 0x77, 0xba, 0xe5, 0x82, 0xe5, 0xa7, 0x66, 0xf8, 0x18, 0xde,
+	0x5f, 0x37, 0x15, 0xd1, 0xff, 0x21, 0xef, 0x24, 0x20, 0x3d, 0xcf, 0xaf, 0x9c, 0xeb, 0x10, 0xa7,
+	0x10, 0xf6, 0x35, 0xa2, 0xa3, 0x4b, 0xcb, 0x38, 0x44, 0xdd, 0xc0, 0x50, 0x3f, 0x30, 0xf4, 0xbe,
+	0x1f, 0x58, 0xe6, 0x1b, 0xc2, 0xed, 0xef, 0x11, 0xc8, 0x03, 0x77, 0x6f, 0xa2, 0x33, 0xb4, 0x3b,
+	0xc4, 0x60, 0x7f, 0x88, 0xc1, 0x9f, 0x43, 0x0c, 0xb6, 0xc7, 0xd8, 0xdb, 0x1f, 0x63, 0xef, 0xe7,
+	0x31, 0xf6, 0x3e, 0x0c, 0xba, 0x6d, 0xf8, 0xd8, 0xef, 0x83, 0xfe, 0xd4, 0x50, 0x55, 0x5c, 0xda,
+	0x87, 0x5f, 0xfc, 0x1b, 0x00, 0x31, 0x97, 0xeb, 0xb7, 0x2c, 0x02, 0x00, 0x00,
+}
+
+func (m *ChainMetadata) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *ChainMetadata) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *ChainMetadata) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	n1, err1 := github_com_cosmos_gogoproto_types.StdTimeMarshalTo(m.UpdatedAt, dAtA[i-github_com_cosmos_gogoproto_types.SizeOfStdTime(m.UpdatedAt):])
+	if err1 != nil {
+		return 0, err1
+	}
+	i -= n1
+	i = encodeVarintOracle(dAtA, i, uint64(n1))
+	i--
+	dAtA[i] = 0x32
+	if m.UpdatedHeight != 0 {
+		i = encodeVarintOracle(dAtA, i, uint64(m.UpdatedHeight))
+		i--
+		dAtA[i] = 0x28
+	}
+	if len(m.Reporter) > 0 {
+		i -= len(m.Reporter)
+		copy(dAtA[i:], m.Reporter)
+		i = encodeVarintOracle(dAtA, i, uint64(len(m.Reporter)))
+		i--
+		dAtA[i] = 0x22
+	}
+	{
+		size, err := m.GasPrice.MarshalToSizedBuffer(dAtA[:i])
+		if err != nil {
+			return 0, err
+		}
+		i -= size
+		i = encodeVarintOracle(dAtA, i, uint64(size))
+	}
+	i--
+	dAtA[i] = 0x1a
+	if m.FinalizedHeight != 0 {
+		i = encodeVarintOracle(dAtA, i, uint64(m.FinalizedHeight))
+		i--
+		dAtA[i] = 0x10
+	}
+	if len(m.ChainId) > 0 {
+		i -= len(m.ChainId)
+		copy(dAtA[i:], m.ChainId)
+		i = encodeVarintOracle(dAtA, i, uint64(len(m.ChainId)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func encodeVarintOracle(dAtA []byte, offset int, v uint64) int {
+	offset -= sovOracle(v)
+	base := offset
+	for v >= 1<<7 {
+		dAtA[offset] = uint8(v&0x7f | 0x80)
+		v >>= 7
+		offset++
+	}
+	dAtA[offset] = uint8(v)
+	return base
+}
+func (m *ChainMetadata) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.ChainId)
+	if l > 0 {
+		n += 1 + l + sovOracle(uint64(l))
+	}
+	if m.FinalizedHeight != 0 {
+		n += 1 + sovOracle(uint64(m.FinalizedHeight))
+	}
+	l = m.GasPrice.Size()
+	n += 1 + l + sovOracle(uint64(l))
+	l = len(m.Reporter)
+	if l > 0 {
+		n += 1 + l + sovOracle(uint64(l))
+	}
+	if m.UpdatedHeight != 0 {
+		n += 1 + sovOracle(uint64(m.UpdatedHeight))
+	}
+	l = github_com_cosmos_gogoproto_types.SizeOfStdTime(m.UpdatedAt)
+	n += 1 + l + sovOracle(uint64(l))
+	return n
+}
+
+func sovOracle(x uint64) (n int) {
+	return (math_bits.Len64(x|1) + 6) / 7
+}
+func sozOracle(x uint64) (n int) {
+	return sovOracle(uint64((x << 1) ^ uint64((int64(x) >> 63))))
+}
+func (m *ChainMetadata) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowOracle
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: ChainMetadata: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: ChainMetadata: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ChainId", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowOracle
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthOracle
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthOracle
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.ChainId = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field FinalizedHeight", wireType)
+			}
+			m.FinalizedHeight = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowOracle
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.FinalizedHeight |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field GasPrice", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowOracle
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthOracle
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthOracle
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if err := m.GasPrice.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 4:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Reporter", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowOracle
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthOracle
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthOracle
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Reporter = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 5:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field UpdatedHeight", wireType)
+			}
+			m.UpdatedHeight = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowOracle
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.UpdatedHeight |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 6:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field UpdatedAt", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowOracle
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthOracle
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthOracle
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if err := github_com_cosmos_gogoproto_types.StdTimeUnmarshal(&m.UpdatedAt, dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipOracle(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthOracle
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func skipOracle(dAtA []byte) (n int, err error) {
+	l := len(dAtA)
+	iNdEx := 0
+	depth := 0
+	for iNdEx < l {
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return 0, ErrIntOverflowOracle
+			}
+			if iNdEx >= l {
+				return 0, io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		wireType := int(wire & 0x7)
+		switch wireType {
+		case 0:
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return 0, ErrIntOverflowOracle
+				}
+				if iNdEx >= l {
+					return 0, io.ErrUnexpectedEOF
+				}
+				iNdEx++
+				if dAtA[iNdEx-1] < 0x80 {
+					break
+				}
+			}
+		case 1:
+			iNdEx += 8
+		case 2:
+			var length int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return 0, ErrIntOverflowOracle
+				}
+				if iNdEx >= l {
+					return 0, io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				length |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if length < 0 {
+				return 0, ErrInvalidLengthOracle
+			}
+			iNdEx += length
+		case 3:
+			depth++
+		case 4:
+			if depth == 0 {
+				return 0, ErrUnexpectedEndOfGroupOracle
+			}
+			depth--
+		case 5:
+			iNdEx += 4
+		default:
+			return 0, fmt.Errorf("proto: illegal wireType %d", wireType)
+		}
+		if iNdEx < 0 {
+			return 0, ErrInvalidLengthOracle
+		}
+		if depth == 0 {
+			return iNdEx, nil
+		}
+	}
+	return 0, io.ErrUnexpectedEOF
+}
+
+var (
+	ErrInvalidLengthOracle        = fmt.Errorf("proto: negative length found during unmarshaling")
+	ErrIntOverflowOracle          = fmt.Errorf("proto: integer overflow")
+	ErrUnexpectedEndOfGroupOracle = fmt.Errorf("proto: unexpected end of group")
+)
@@ -0,0 +1,45 @@
+package types
+
+import (
+	"fmt"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// NewParams creates a new parameter configuration for the oracle module.
+func NewParams(reporters []string) Params {
+	return Params{
+		Reporters: reporters,
+	}
+}
+
+// DefaultParams is the default parameter configuration for the oracle
+// module. No one is allowed to report until governance whitelists reporters.
+func DefaultParams() Params {
+	return NewParams([]string{})
+}
+
+// Validate validates all parameters.
+func (p Params) Validate() error {
+	seen := make(map[string]struct{}, len(p.Reporters))
+	for _, reporter := range p.Reporters {
+		if _, err := sdk.AccAddressFromBech32(reporter); err != nil {
+			return fmt.Errorf("invalid reporter address %q: %w", reporter, err)
+		}
+		if _, ok := seen[reporter]; ok {
+			return fmt.Errorf("duplicate reporter %s", reporter)
+		}
+		seen[reporter] = struct{}{}
+	}
+	return nil
+}
+
+// IsReporter returns whether the address is allowed to report metadata.
+func (p Params) IsReporter(address string) bool {
+	for _, reporter := range p.Reporters {
+		if reporter == address {
+			return true
+		}
+	}
+	return false
+}
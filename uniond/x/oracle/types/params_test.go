@@ -0,0 +1,57 @@
+package types_test
+
+import (
+	"testing"
+
+	sdkmath "cosmossdk.io/math"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/stretchr/testify/require"
+
+	"union/x/oracle/types"
+)
+
+func TestParamsValidate(t *testing.T) {
+	reporter := sdk.AccAddress("reporter").String()
+
+	require.NoError(t, types.DefaultParams().Validate())
+	require.NoError(t, types.DefaultGenesis().Validate())
+	require.NoError(t, types.NewParams([]string{reporter}).Validate())
+
+	require.Error(t, types.NewParams([]string{"invalid"}).Validate())
+	require.Error(t, types.NewParams([]string{reporter, reporter}).Validate())
+
+	params := types.NewParams([]string{reporter})
+	require.True(t, params.IsReporter(reporter))
+	require.False(t, params.IsReporter(sdk.AccAddress("someone").String()))
+}
+
+func TestMsgReportValidateBasic(t *testing.T) {
+	reporter := sdk.AccAddress("reporter").String()
+	gasPrice := sdk.NewDecCoinFromDec("wei", sdkmath.LegacyNewDec(1_200_000_000))
+
+	require.NoError(t, types.NewMsgReport(reporter, "11155111", 1, gasPrice).ValidateBasic())
+	require.ErrorIs(t, types.NewMsgReport("invalid", "11155111", 1, gasPrice).ValidateBasic(), types.ErrUnauthorizedReporter)
+	require.ErrorIs(t, types.NewMsgReport(reporter, "", 1, gasPrice).ValidateBasic(), types.ErrInvalidReport)
+	require.ErrorIs(t, types.NewMsgReport(reporter, "11155111", 0, gasPrice).ValidateBasic(), types.ErrInvalidReport)
+	require.ErrorIs(t, types.NewMsgReport(reporter, "11155111", 1, sdk.DecCoin{Denom: "wei", Amount: sdkmath.LegacyNewDec(-1)}).ValidateBasic(), types.ErrInvalidReport)
+}
+
+func TestGenesisValidate(t *testing.T) {
+	reporter := sdk.AccAddress("reporter").String()
+	metadata := types.ChainMetadata{
+		ChainId:         "11155111",
+		FinalizedHeight: 10,
+		GasPrice:        sdk.NewDecCoinFromDec("wei", sdkmath.LegacyNewDec(1)),
+		Reporter:        reporter,
+		UpdatedHeight:   1,
+	}
+
+	genesis := types.GenesisState{
+		Params:        types.NewParams([]string{reporter}),
+		ChainMetadata: []types.ChainMetadata{metadata},
+	}
+	require.NoError(t, genesis.Validate())
+
+	genesis.ChainMetadata = append(genesis.ChainMetadata, metadata)
+	require.Error(t, genesis.Validate())
+}
@@ -0,0 +1,124 @@
+package ratelimit_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	sdkmath "cosmossdk.io/math"
+	storetypes "cosmossdk.io/store/types"
+	"github.com/cosmos/cosmos-sdk/testutil"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	moduletestutil "github.com/cosmos/cosmos-sdk/types/module/testutil"
+	capabilitytypes "github.com/cosmos/ibc-go/modules/capability/types"
+	transfertypes "github.com/cosmos/ibc-go/v8/modules/apps/transfer/types"
+	clienttypes "github.com/cosmos/ibc-go/v8/modules/core/02-client/types"
+	channeltypes "github.com/cosmos/ibc-go/v8/modules/core/04-channel/types"
+	porttypes "github.com/cosmos/ibc-go/v8/modules/core/05-port/types"
+	"github.com/stretchr/testify/require"
+
+	"union/x/ratelimit"
+	"union/x/ratelimit/keeper"
+	"union/x/ratelimit/types"
+)
+
+const (
+	channelID = "channel-0"
+	denom     = "muno"
+)
+
+var start = time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+// transferApp stands for the transfer application, refunding nothing.
+type transferApp struct {
+	porttypes.IBCModule
+}
+
+func (transferApp) OnAcknowledgementPacket(sdk.Context, channeltypes.Packet, []byte, sdk.AccAddress) error {
+	return nil
+}
+
+func (transferApp) OnTimeoutPacket(sdk.Context, channeltypes.Packet, sdk.AccAddress) error {
+	return nil
+}
+
+// ics4Wrapper hands out increasing sequences for the sent packets.
+type ics4Wrapper struct {
+	porttypes.ICS4Wrapper
+	sequence uint64
+}
+
+func (w *ics4Wrapper) SendPacket(sdk.Context, *capabilitytypes.Capability, string, string, clienttypes.Height, uint64, []byte) (uint64, error) {
+	w.sequence++
+	return w.sequence, nil
+}
+
+func setup(t *testing.T) (sdk.Context, keeper.Keeper, ratelimit.IBCMiddleware) {
+	key := storetypes.NewKVStoreKey(types.StoreKey)
+	ctx := testutil.DefaultContext(key, storetypes.NewTransientStoreKey("transient_test")).WithBlockTime(start)
+	k := keeper.NewKeeper(moduletestutil.MakeTestEncodingConfig().Codec, key, &ics4Wrapper{}, "authority")
+	require.NoError(t, k.SetRateLimit(ctx, types.NewRateLimit(channelID, denom, sdkmath.ZeroInt(), sdkmath.NewInt(100), time.Hour)))
+	return ctx, k, ratelimit.NewIBCMiddleware(transferApp{}, k)
+}
+
+// send sends a transfer through the middleware, returning the packet as the
+// counterparty would relay it back.
+func send(t *testing.T, ctx sdk.Context, im ratelimit.IBCMiddleware, amount int64) channeltypes.Packet {
+	data := transfertypes.NewFungibleTokenPacketData(denom, sdkmath.NewInt(amount).String(), "sender", "receiver", "").GetBytes()
+	sequence, err := im.SendPacket(ctx, nil, transfertypes.PortID, channelID, clienttypes.ZeroHeight(), 0, data)
+	require.NoError(t, err)
+	return channeltypes.NewPacket(data, sequence, transfertypes.PortID, channelID, transfertypes.PortID, "channel-7", clienttypes.ZeroHeight(), 0)
+}
+
+func TestOnAcknowledgementPacket(t *testing.T) {
+	for _, tc := range []struct {
+		name    string
+		ack     channeltypes.Acknowledgement
+		outflow int64
+	}{
+		{
+			name:    "error acknowledgement",
+			ack:     channeltypes.NewErrorAcknowledgement(errors.New("failed")),
+			outflow: 0,
+		},
+		{
+			name:    "successful acknowledgement",
+			ack:     channeltypes.NewResultAcknowledgement([]byte{byte(1)}),
+			outflow: 60,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			ctx, k, im := setup(t)
+			packet := send(t, ctx, im, 60)
+
+			require.NoError(t, im.OnAcknowledgementPacket(ctx, packet, tc.ack.Acknowledgement(), nil))
+			require.Equal(t, sdkmath.NewInt(tc.outflow), k.GetFlow(ctx, channelID, denom).Outflow)
+			_, pending := k.TakePendingSend(ctx, channelID, packet.Sequence)
+			require.False(t, pending)
+
+			// A second acknowledgement of the same packet refunds nothing.
+			require.NoError(t, im.OnAcknowledgementPacket(ctx, packet, channeltypes.NewErrorAcknowledgement(errors.New("failed")).Acknowledgement(), nil))
+			require.Equal(t, sdkmath.NewInt(tc.outflow), k.GetFlow(ctx, channelID, denom).Outflow)
+		})
+	}
+}
+
+func TestOnTimeoutPacket(t *testing.T) {
+	ctx, k, im := setup(t)
+	sent := ctx.WithBlockTime(start.Add(30 * time.Minute))
+	first := send(t, sent, im, 60)
+	second := send(t, sent, im, 30)
+
+	require.NoError(t, im.OnTimeoutPacket(sent, first, nil))
+	require.Equal(t, sdkmath.NewInt(30), k.GetFlow(sent, channelID, denom).Outflow)
+	_, pending := k.TakePendingSend(sent, channelID, first.Sequence)
+	require.False(t, pending)
+
+	// Timing out once the window moved on credits the window it was sent in.
+	timedOut := ctx.WithBlockTime(start.Add(90 * time.Minute))
+	require.NoError(t, im.OnTimeoutPacket(timedOut, second, nil))
+	flow := k.GetFlow(timedOut, channelID, denom)
+	require.Equal(t, start.Add(time.Hour), flow.WindowStart)
+	require.Equal(t, sdkmath.ZeroInt(), flow.PreviousOutflow)
+	require.Equal(t, sdkmath.ZeroInt(), flow.Outflow)
+}
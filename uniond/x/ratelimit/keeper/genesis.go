@@ -0,0 +1,28 @@
+package keeper
+
+import (
+	sdk "github.com/cosmos/cosmos-sdk/types"
+
+	"union/x/ratelimit/types"
+)
+
+// InitGenesis initializes the ratelimit module's state from a provided genesis
+// state.
+func (k Keeper) InitGenesis(ctx sdk.Context, genState types.GenesisState) {
+	for _, rateLimit := range genState.RateLimits {
+		if err := k.SetRateLimit(ctx, rateLimit); err != nil {
+			panic(err)
+		}
+	}
+	for _, flow := range genState.Flows {
+		k.SetFlow(ctx, flow)
+	}
+}
+
+// ExportGenesis returns the ratelimit module's exported genesis.
+func (k Keeper) ExportGenesis(ctx sdk.Context) *types.GenesisState {
+	return &types.GenesisState{
+		RateLimits: k.GetAllRateLimits(ctx),
+		Flows:      k.GetAllFlows(ctx),
+	}
+}
@@ -0,0 +1,37 @@
+package keeper
+
+import (
+	"context"
+
+	errorsmod "cosmossdk.io/errors"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"union/x/ratelimit/types"
+)
+
+var _ types.QueryServer = Keeper{}
+
+func (k Keeper) RateLimits(ctx context.Context, req *types.QueryRateLimitsRequest) (*types.QueryRateLimitsResponse, error) {
+	sdkCtx := sdk.UnwrapSDKContext(ctx)
+	rateLimits := k.GetAllRateLimits(sdkCtx)
+
+	return &types.QueryRateLimitsResponse{RateLimits: rateLimits}, nil
+}
+
+func (k Keeper) RateLimit(ctx context.Context, req *types.QueryRateLimitRequest) (*types.QueryRateLimitResponse, error) {
+	if req == nil {
+		return nil, status.Error(codes.InvalidArgument, "empty request")
+	}
+
+	sdkCtx := sdk.UnwrapSDKContext(ctx)
+	rateLimit, found := k.GetRateLimit(sdkCtx, req.ChannelId, req.Denom)
+	if !found {
+		return nil, errorsmod.Wrapf(types.ErrRateLimitNotFound, "%s on %s", req.Denom, req.ChannelId)
+	}
+	flow := k.GetFlow(sdkCtx, req.ChannelId, req.Denom)
+	flow.Advance(sdkCtx.BlockTime(), rateLimit.Window)
+
+	return &types.QueryRateLimitResponse{RateLimit: rateLimit, Flow: flow}, nil
+}
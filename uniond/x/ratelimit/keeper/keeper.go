@@ -0,0 +1,52 @@
+package keeper
+
+import (
+	"fmt"
+
+	"cosmossdk.io/log"
+	storetypes "cosmossdk.io/store/types"
+	"github.com/cosmos/cosmos-sdk/codec"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	porttypes "github.com/cosmos/ibc-go/v8/modules/core/05-port/types"
+
+	"union/x/ratelimit/types"
+)
+
+type (
+	Keeper struct {
+		cdc      codec.BinaryCodec
+		storeKey storetypes.StoreKey
+
+		// the next ICS4 wrapper in the transfer stack, outgoing packets are
+		// forwarded to it once accounted for.
+		ics4Wrapper porttypes.ICS4Wrapper
+
+		// the address capable of executing a MsgSetRateLimit and
+		// MsgRemoveRateLimit messages. Typically, this should be the x/gov
+		// module account.
+		authority string
+	}
+)
+
+func NewKeeper(
+	cdc codec.BinaryCodec,
+	storeKey storetypes.StoreKey,
+	ics4Wrapper porttypes.ICS4Wrapper,
+	authority string,
+) Keeper {
+	return Keeper{
+		cdc:         cdc,
+		storeKey:    storeKey,
+		ics4Wrapper: ics4Wrapper,
+		authority:   authority,
+	}
+}
+
+// GetAuthority returns the x/ratelimit module's authority.
+func (k Keeper) GetAuthority() string {
+	return k.authority
+}
+
+func (k Keeper) Logger(ctx sdk.Context) log.Logger {
+	return ctx.Logger().With("module", fmt.Sprintf("x/%s", types.ModuleName))
+}
@@ -0,0 +1,214 @@
+package keeper
+
+import (
+	"time"
+
+	errorsmod "cosmossdk.io/errors"
+	sdkmath "cosmossdk.io/math"
+	"cosmossdk.io/store/prefix"
+	storetypes "cosmossdk.io/store/types"
+	"github.com/cosmos/cosmos-sdk/telemetry"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/hashicorp/go-metrics"
+
+	"union/x/ratelimit/types"
+)
+
+// SetRateLimit stores the rate limit of a channel and denom, starting a fresh
+// flow for it.
+func (k Keeper) SetRateLimit(ctx sdk.Context, rateLimit types.RateLimit) error {
+	if err := rateLimit.Validate(); err != nil {
+		return err
+	}
+
+	store := ctx.KVStore(k.storeKey)
+	store.Set(types.RateLimitKey(rateLimit.ChannelId, rateLimit.Denom), k.cdc.MustMarshal(&rateLimit))
+	k.SetFlow(ctx, types.NewFlow(rateLimit.ChannelId, rateLimit.Denom, ctx.BlockTime()))
+	return nil
+}
+
+// GetRateLimit returns the rate limit of a channel and denom, if any.
+func (k Keeper) GetRateLimit(ctx sdk.Context, channelID, denom string) (types.RateLimit, bool) {
+	var rateLimit types.RateLimit
+
+	store := ctx.KVStore(k.storeKey)
+	bz := store.Get(types.RateLimitKey(channelID, denom))
+	if bz == nil {
+		return rateLimit, false
+	}
+
+	k.cdc.MustUnmarshal(bz, &rateLimit)
+	return rateLimit, true
+}
+
+// RemoveRateLimit deletes the rate limit of a channel and denom along with its
+// flow.
+func (k Keeper) RemoveRateLimit(ctx sdk.Context, channelID, denom string) error {
+	store := ctx.KVStore(k.storeKey)
+	key := types.RateLimitKey(channelID, denom)
+	if !store.Has(key) {
+		return errorsmod.Wrapf(types.ErrRateLimitNotFound, "%s on %s", denom, channelID)
+	}
+	store.Delete(key)
+	store.Delete(types.FlowKey(channelID, denom))
+	return nil
+}
+
+// GetAllRateLimits returns every configured rate limit.
+func (k Keeper) GetAllRateLimits(ctx sdk.Context) []types.RateLimit {
+	store := prefix.NewStore(ctx.KVStore(k.storeKey), types.RateLimitKeyPrefix)
+	iterator := storetypes.KVStorePrefixIterator(store, nil)
+	defer iterator.Close()
+
+	rateLimits := []types.RateLimit{}
+	for ; iterator.Valid(); iterator.Next() {
+		var rateLimit types.RateLimit
+		k.cdc.MustUnmarshal(iterator.Value(), &rateLimit)
+		rateLimits = append(rateLimits, rateLimit)
+	}
+	return rateLimits
+}
+
+// SetFlow stores the flow of a channel and denom.
+func (k Keeper) SetFlow(ctx sdk.Context, flow types.Flow) {
+	store := ctx.KVStore(k.storeKey)
+	store.Set(types.FlowKey(flow.ChannelId, flow.Denom), k.cdc.MustMarshal(&flow))
+}
+
+// GetFlow returns the flow of a channel and denom, an empty one starting at
+// the current block is returned if none was recorded.
+func (k Keeper) GetFlow(ctx sdk.Context, channelID, denom string) types.Flow {
+	store := ctx.KVStore(k.storeKey)
+	bz := store.Get(types.FlowKey(channelID, denom))
+	if bz == nil {
+		return types.NewFlow(channelID, denom, ctx.BlockTime())
+	}
+
+	var flow types.Flow
+	k.cdc.MustUnmarshal(bz, &flow)
+	return flow
+}
+
+// GetAllFlows returns the flows of every rate limited channel and denom.
+func (k Keeper) GetAllFlows(ctx sdk.Context) []types.Flow {
+	store := prefix.NewStore(ctx.KVStore(k.storeKey), types.FlowKeyPrefix)
+	iterator := storetypes.KVStorePrefixIterator(store, nil)
+	defer iterator.Close()
+
+	flows := []types.Flow{}
+	for ; iterator.Valid(); iterator.Next() {
+		var flow types.Flow
+		k.cdc.MustUnmarshal(iterator.Value(), &flow)
+		flows = append(flows, flow)
+	}
+	return flows
+}
+
+// ReceiveFlow accounts for an incoming transfer, failing if the rate limit of
+// the channel and denom is exceeded.
+func (k Keeper) ReceiveFlow(ctx sdk.Context, channelID, denom string, amount sdkmath.Int) error {
+	rateLimit, found := k.GetRateLimit(ctx, channelID, denom)
+	if !found {
+		return nil
+	}
+
+	flow := k.GetFlow(ctx, channelID, denom)
+	if err := flow.AddInflow(rateLimit, amount, ctx.BlockTime()); err != nil {
+		incrRejectedCounter(ctx, channelID, denom, types.DirectionInflow)
+		return errorsmod.Wrap(types.ErrQuotaExceeded, err.Error())
+	}
+	k.SetFlow(ctx, flow)
+	setUtilizationGauge(ctx, channelID, denom, types.DirectionInflow, flow.SlidingInflow(ctx.BlockTime(), rateLimit.Window), rateLimit.MaxInflow)
+	return nil
+}
+
+// SendFlow accounts for an outgoing transfer, failing if the rate limit of the
+// channel and denom is exceeded. It reports whether the channel and denom are
+// rate limited and, if so, the start of the fixed window the transfer is
+// accounted in.
+func (k Keeper) SendFlow(ctx sdk.Context, channelID, denom string, amount sdkmath.Int) (time.Time, bool, error) {
+	rateLimit, found := k.GetRateLimit(ctx, channelID, denom)
+	if !found {
+		return time.Time{}, false, nil
+	}
+
+	flow := k.GetFlow(ctx, channelID, denom)
+	if err := flow.AddOutflow(rateLimit, amount, ctx.BlockTime()); err != nil {
+		incrRejectedCounter(ctx, channelID, denom, types.DirectionOutflow)
+		return time.Time{}, true, errorsmod.Wrap(types.ErrQuotaExceeded, err.Error())
+	}
+	k.SetFlow(ctx, flow)
+	setUtilizationGauge(ctx, channelID, denom, types.DirectionOutflow, flow.SlidingOutflow(ctx.BlockTime(), rateLimit.Window), rateLimit.MaxOutflow)
+	return flow.WindowStart, true, nil
+}
+
+// RefundFlow gives back the quota consumed by an outgoing transfer that has
+// been refunded because of a timeout or an error acknowledgement, sent in the
+// fixed window starting at sentWindowStart.
+func (k Keeper) RefundFlow(ctx sdk.Context, channelID, denom string, amount sdkmath.Int, sentWindowStart time.Time) {
+	rateLimit, found := k.GetRateLimit(ctx, channelID, denom)
+	if !found {
+		return
+	}
+
+	flow := k.GetFlow(ctx, channelID, denom)
+	flow.RevertOutflow(rateLimit, amount, sentWindowStart, ctx.BlockTime())
+	k.SetFlow(ctx, flow)
+	setUtilizationGauge(ctx, channelID, denom, types.DirectionOutflow, flow.SlidingOutflow(ctx.BlockTime(), rateLimit.Window), rateLimit.MaxOutflow)
+}
+
+// SetPendingSend records that a rate limited packet is in flight, along with
+// the start of the fixed window it was accounted in.
+func (k Keeper) SetPendingSend(ctx sdk.Context, channelID string, sequence uint64, windowStart time.Time) {
+	store := ctx.KVStore(k.storeKey)
+	store.Set(types.PendingSendKey(channelID, sequence), sdk.FormatTimeBytes(windowStart))
+}
+
+// TakePendingSend deletes the in flight record of a packet and reports whether
+// it existed, along with the start of the fixed window it was accounted in.
+// Records lacking the window, from before it was recorded, return the zero
+// time, which no window is credited for.
+func (k Keeper) TakePendingSend(ctx sdk.Context, channelID string, sequence uint64) (time.Time, bool) {
+	store := ctx.KVStore(k.storeKey)
+	key := types.PendingSendKey(channelID, sequence)
+	bz := store.Get(key)
+	if bz == nil {
+		return time.Time{}, false
+	}
+	store.Delete(key)
+	windowStart, err := sdk.ParseTimeBytes(bz)
+	if err != nil {
+		return time.Time{}, true
+	}
+	return windowStart, true
+}
+
+func flowLabels(channelID, denom, direction string) []metrics.Label {
+	return []metrics.Label{
+		telemetry.NewLabel("channel_id", channelID),
+		telemetry.NewLabel("denom", denom),
+		telemetry.NewLabel("direction", direction),
+	}
+}
+
+// Transfers are replayed when checking transactions, only the delivered ones
+// are reported.
+func incrRejectedCounter(ctx sdk.Context, channelID, denom, direction string) {
+	if ctx.IsCheckTx() {
+		return
+	}
+	telemetry.IncrCounterWithLabels([]string{types.ModuleName, "rejected"}, 1, flowLabels(channelID, denom, direction))
+}
+
+// setUtilizationGauge exports the fraction of the quota consumed over the
+// sliding window. Unlimited directions are skipped.
+func setUtilizationGauge(ctx sdk.Context, channelID, denom, direction string, amount, max sdkmath.Int) {
+	if ctx.IsCheckTx() || max.IsZero() {
+		return
+	}
+	utilization, err := sdkmath.LegacyNewDecFromInt(amount).QuoInt(max).Float64()
+	if err != nil {
+		return
+	}
+	telemetry.SetGaugeWithLabels([]string{types.ModuleName, "utilization"}, float32(utilization), flowLabels(channelID, denom, direction))
+}
@@ -0,0 +1,153 @@
+package keeper_test
+
+import (
+	"testing"
+	"time"
+
+	sdkmath "cosmossdk.io/math"
+	storetypes "cosmossdk.io/store/types"
+	"github.com/cosmos/cosmos-sdk/testutil"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	moduletestutil "github.com/cosmos/cosmos-sdk/types/module/testutil"
+	capabilitytypes "github.com/cosmos/ibc-go/modules/capability/types"
+	transfertypes "github.com/cosmos/ibc-go/v8/modules/apps/transfer/types"
+	clienttypes "github.com/cosmos/ibc-go/v8/modules/core/02-client/types"
+	porttypes "github.com/cosmos/ibc-go/v8/modules/core/05-port/types"
+	"github.com/stretchr/testify/require"
+
+	"union/x/ratelimit/keeper"
+	"union/x/ratelimit/types"
+)
+
+const (
+	channelID = "channel-0"
+	denom     = "muno"
+)
+
+var start = time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+// ics4Wrapper hands out increasing sequences for the sent packets.
+type ics4Wrapper struct {
+	porttypes.ICS4Wrapper
+	sequence uint64
+}
+
+func (w *ics4Wrapper) SendPacket(sdk.Context, *capabilitytypes.Capability, string, string, clienttypes.Height, uint64, []byte) (uint64, error) {
+	w.sequence++
+	return w.sequence, nil
+}
+
+func setup(t *testing.T) (sdk.Context, keeper.Keeper) {
+	key := storetypes.NewKVStoreKey(types.StoreKey)
+	ctx := testutil.DefaultContext(key, storetypes.NewTransientStoreKey("transient_test")).WithBlockTime(start)
+	k := keeper.NewKeeper(moduletestutil.MakeTestEncodingConfig().Codec, key, &ics4Wrapper{}, "authority")
+	require.NoError(t, k.SetRateLimit(ctx, types.NewRateLimit(channelID, denom, sdkmath.ZeroInt(), sdkmath.NewInt(100), time.Hour)))
+	return ctx, k
+}
+
+func transfer(amount int64) []byte {
+	return transfertypes.NewFungibleTokenPacketData(denom, sdkmath.NewInt(amount).String(), "sender", "receiver", "").GetBytes()
+}
+
+func TestSendFlowWindowStart(t *testing.T) {
+	ctx, k := setup(t)
+
+	windowStart, limited, err := k.SendFlow(ctx, channelID, denom, sdkmath.NewInt(30))
+	require.NoError(t, err)
+	require.True(t, limited)
+	require.Equal(t, start, windowStart)
+
+	// Accounted in the fixed window containing the block time.
+	windowStart, limited, err = k.SendFlow(ctx.WithBlockTime(start.Add(90*time.Minute)), channelID, denom, sdkmath.NewInt(30))
+	require.NoError(t, err)
+	require.True(t, limited)
+	require.Equal(t, start.Add(time.Hour), windowStart)
+
+	windowStart, limited, err = k.SendFlow(ctx, channelID, "uother", sdkmath.NewInt(30))
+	require.NoError(t, err)
+	require.False(t, limited)
+	require.True(t, windowStart.IsZero())
+}
+
+func TestSendPacketRecordsWindowStart(t *testing.T) {
+	ctx, k := setup(t)
+
+	later := ctx.WithBlockTime(start.Add(90 * time.Minute))
+	sequence, err := k.SendPacket(later, nil, transfertypes.PortID, channelID, clienttypes.ZeroHeight(), 0, transfer(10))
+	require.NoError(t, err)
+
+	windowStart, pending := k.TakePendingSend(later, channelID, sequence)
+	require.True(t, pending)
+	require.Equal(t, start.Add(time.Hour), windowStart)
+	_, pending = k.TakePendingSend(later, channelID, sequence)
+	require.False(t, pending)
+
+	// Packets of channels and denoms without rate limit aren't tracked.
+	sequence, err = k.SendPacket(later, nil, transfertypes.PortID, "channel-1", clienttypes.ZeroHeight(), 0, transfer(10))
+	require.NoError(t, err)
+	_, pending = k.TakePendingSend(later, "channel-1", sequence)
+	require.False(t, pending)
+}
+
+func TestRefundFlow(t *testing.T) {
+	for _, tc := range []struct {
+		name string
+		// Time of the refund after the transfer, sent 30 minutes into the
+		// first window.
+		after           time.Duration
+		refund          int64
+		outflow         int64
+		previousOutflow int64
+	}{
+		{
+			name:    "same window",
+			after:   10 * time.Minute,
+			refund:  40,
+			outflow: 20,
+		},
+		{
+			name:    "more than sent",
+			after:   10 * time.Minute,
+			refund:  100,
+			outflow: 0,
+		},
+		{
+			name:            "next window",
+			after:           time.Hour,
+			refund:          40,
+			previousOutflow: 20,
+		},
+		{
+			// The transfer no longer counts against the sliding window.
+			name:   "window moved on",
+			after:  2 * time.Hour,
+			refund: 40,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			ctx, k := setup(t)
+			sent := ctx.WithBlockTime(start.Add(30 * time.Minute))
+			windowStart, _, err := k.SendFlow(sent, channelID, denom, sdkmath.NewInt(60))
+			require.NoError(t, err)
+
+			refunded := ctx.WithBlockTime(sent.BlockTime().Add(tc.after))
+			k.RefundFlow(refunded, channelID, denom, sdkmath.NewInt(tc.refund), windowStart)
+			flow := k.GetFlow(refunded, channelID, denom)
+			require.Equal(t, sdkmath.NewInt(tc.outflow), flow.Outflow)
+			require.Equal(t, sdkmath.NewInt(tc.previousOutflow), flow.PreviousOutflow)
+		})
+	}
+}
+
+func TestRefundFlowRestoresQuota(t *testing.T) {
+	ctx, k := setup(t)
+
+	windowStart, _, err := k.SendFlow(ctx, channelID, denom, sdkmath.NewInt(100))
+	require.NoError(t, err)
+	_, _, err = k.SendFlow(ctx, channelID, denom, sdkmath.NewInt(1))
+	require.ErrorIs(t, err, types.ErrQuotaExceeded)
+
+	k.RefundFlow(ctx, channelID, denom, sdkmath.NewInt(100), windowStart)
+	_, _, err = k.SendFlow(ctx, channelID, denom, sdkmath.NewInt(100))
+	require.NoError(t, err)
+}
@@ -0,0 +1,15 @@
+package types
+
+// DONTCOVER
+
+import (
+	errorsmod "cosmossdk.io/errors"
+)
+
+// x/ratelimit module sentinel errors
+var (
+	ErrInvalidAuthority  = errorsmod.Register(ModuleName, 2, "invalid authority")
+	ErrInvalidRateLimit  = errorsmod.Register(ModuleName, 3, "invalid rate limit")
+	ErrRateLimitNotFound = errorsmod.Register(ModuleName, 4, "rate limit not found")
+	ErrQuotaExceeded     = errorsmod.Register(ModuleName, 5, "quota exceeded")
+)
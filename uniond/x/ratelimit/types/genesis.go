@@ -0,0 +1,36 @@
+package types
+
+import (
+	"fmt"
+)
+
+// DefaultGenesis returns the default ratelimit genesis state, without any
+// rate limit.
+func DefaultGenesis() *GenesisState {
+	return &GenesisState{
+		RateLimits: []RateLimit{},
+		Flows:      []Flow{},
+	}
+}
+
+// Validate performs basic genesis state validation returning an error upon any
+// failure.
+func (gs GenesisState) Validate() error {
+	rateLimits := make(map[string]struct{}, len(gs.RateLimits))
+	for _, rateLimit := range gs.RateLimits {
+		if err := rateLimit.Validate(); err != nil {
+			return err
+		}
+		key := string(RateLimitKey(rateLimit.ChannelId, rateLimit.Denom))
+		if _, found := rateLimits[key]; found {
+			return fmt.Errorf("duplicate rate limit for %s on %s", rateLimit.Denom, rateLimit.ChannelId)
+		}
+		rateLimits[key] = struct{}{}
+	}
+	for _, flow := range gs.Flows {
+		if _, found := rateLimits[string(RateLimitKey(flow.ChannelId, flow.Denom))]; !found {
+			return fmt.Errorf("flow for %s on %s has no rate limit", flow.Denom, flow.ChannelId)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,53 @@
+package types
+
+import (
+	"encoding/binary"
+)
+
+const (
+	// ModuleName defines the module name
+	ModuleName = "ratelimit"
+
+	// StoreKey defines the primary module store key
+	StoreKey = ModuleName
+
+	// RouterKey is the message route for ratelimit
+	RouterKey = ModuleName
+
+	// QuerierRoute defines the module's query routing key
+	QuerierRoute = ModuleName
+)
+
+// Directions of a flow, used as telemetry label.
+const (
+	DirectionInflow  = "inflow"
+	DirectionOutflow = "outflow"
+)
+
+var (
+	RateLimitKeyPrefix   = []byte{0x01}
+	FlowKeyPrefix        = []byte{0x02}
+	PendingSendKeyPrefix = []byte{0x03}
+)
+
+// Channel identifiers never contain a slash, the separator keeps the keys of
+// a channel contiguous while allowing denoms such as ibc/{hash}.
+func channelDenomKey(channelID, denom string) []byte {
+	return []byte(channelID + "/" + denom)
+}
+
+// RateLimitKey returns the store key of the rate limit of a channel and denom.
+func RateLimitKey(channelID, denom string) []byte {
+	return append(RateLimitKeyPrefix, channelDenomKey(channelID, denom)...)
+}
+
+// FlowKey returns the store key of the flow of a channel and denom.
+func FlowKey(channelID, denom string) []byte {
+	return append(FlowKeyPrefix, channelDenomKey(channelID, denom)...)
+}
+
+// PendingSendKey returns the store key of an in-flight rate limited packet.
+func PendingSendKey(channelID string, sequence uint64) []byte {
+	key := append(PendingSendKeyPrefix, []byte(channelID+"/")...)
+	return binary.BigEndian.AppendUint64(key, sequence)
+}
@@ -0,0 +1,56 @@
+package types
+
+import (
+	errorsmod "cosmossdk.io/errors"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+const (
+	TypeMsgSetRateLimit    = "set_rate_limit"
+	TypeMsgRemoveRateLimit = "remove_rate_limit"
+)
+
+var (
+	_ sdk.Msg = &MsgSetRateLimit{}
+	_ sdk.Msg = &MsgRemoveRateLimit{}
+)
+
+// NewMsgSetRateLimit creates a new MsgSetRateLimit instance
+func NewMsgSetRateLimit(authority string, rateLimit RateLimit) *MsgSetRateLimit {
+	return &MsgSetRateLimit{
+		Authority: authority,
+		RateLimit: rateLimit,
+	}
+}
+
+func (m MsgSetRateLimit) Type() string { return TypeMsgSetRateLimit }
+
+// ValidateBasic performs a stateless validation of the authority and rate limit
+func (m MsgSetRateLimit) ValidateBasic() error {
+	if _, err := sdk.AccAddressFromBech32(m.Authority); err != nil {
+		return errorsmod.Wrapf(ErrInvalidAuthority, "invalid authority address (%s)", err)
+	}
+	if err := m.RateLimit.Validate(); err != nil {
+		return errorsmod.Wrap(ErrInvalidRateLimit, err.Error())
+	}
+	return nil
+}
+
+// NewMsgRemoveRateLimit creates a new MsgRemoveRateLimit instance
+func NewMsgRemoveRateLimit(authority, channelID, denom string) *MsgRemoveRateLimit {
+	return &MsgRemoveRateLimit{
+		Authority: authority,
+		ChannelId: channelID,
+		Denom:     denom,
+	}
+}
+
+func (m MsgRemoveRateLimit) Type() string { return TypeMsgRemoveRateLimit }
+
+// ValidateBasic performs a stateless validation of the authority
+func (m MsgRemoveRateLimit) ValidateBasic() error {
+	if _, err := sdk.AccAddressFromBech32(m.Authority); err != nil {
+		return errorsmod.Wrapf(ErrInvalidAuthority, "invalid authority address (%s)", err)
+	}
+	return nil
+}
@@ -0,0 +1,38 @@
+package types
+
+import (
+	sdkmath "cosmossdk.io/math"
+	transfertypes "github.com/cosmos/ibc-go/v8/modules/apps/transfer/types"
+	channeltypes "github.com/cosmos/ibc-go/v8/modules/core/04-channel/types"
+)
+
+// SentDenom returns the local denom of the tokens escrowed or burnt by an
+// outgoing transfer, the packet carrying the full denom trace.
+func SentDenom(packetDenom string) string {
+	return transfertypes.ParseDenomTrace(packetDenom).IBCDenom()
+}
+
+// ReceivedDenom returns the local denom of the tokens unescrowed or minted by
+// an incoming transfer, following the same rules as the transfer module.
+func ReceivedDenom(packet channeltypes.Packet, packetDenom string) string {
+	if transfertypes.ReceiverChainIsSource(packet.GetSourcePort(), packet.GetSourceChannel(), packetDenom) {
+		prefix := transfertypes.GetDenomPrefix(packet.GetSourcePort(), packet.GetSourceChannel())
+		return transfertypes.ParseDenomTrace(packetDenom[len(prefix):]).IBCDenom()
+	}
+	prefixedDenom := transfertypes.GetPrefixedDenom(packet.GetDestPort(), packet.GetDestChannel(), packetDenom)
+	return transfertypes.ParseDenomTrace(prefixedDenom).IBCDenom()
+}
+
+// ParseTransfer decodes the ICS-20 packet data of a transfer, reporting
+// whether the packet is a transfer of a positive amount.
+func ParseTransfer(data []byte) (transfertypes.FungibleTokenPacketData, sdkmath.Int, bool) {
+	var packetData transfertypes.FungibleTokenPacketData
+	if err := transfertypes.ModuleCdc.UnmarshalJSON(data, &packetData); err != nil {
+		return packetData, sdkmath.Int{}, false
+	}
+	amount, ok := sdkmath.NewIntFromString(packetData.Amount)
+	if !ok || !amount.IsPositive() {
+		return packetData, sdkmath.Int{}, false
+	}
+	return packetData, amount, true
+}
@@ -0,0 +1,141 @@
+package types
+
+import (
+	"fmt"
+	"time"
+
+	sdkmath "cosmossdk.io/math"
+	host "github.com/cosmos/ibc-go/v8/modules/core/24-host"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// NewRateLimit creates a new RateLimit instance
+func NewRateLimit(channelID, denom string, maxInflow, maxOutflow sdkmath.Int, window time.Duration) RateLimit {
+	return RateLimit{
+		ChannelId:  channelID,
+		Denom:      denom,
+		MaxInflow:  maxInflow,
+		MaxOutflow: maxOutflow,
+		Window:     window,
+	}
+}
+
+// Validate performs a stateless validation of the rate limit.
+func (r RateLimit) Validate() error {
+	if err := host.ChannelIdentifierValidator(r.ChannelId); err != nil {
+		return err
+	}
+	if err := sdk.ValidateDenom(r.Denom); err != nil {
+		return err
+	}
+	if r.MaxInflow.IsNil() || r.MaxInflow.IsNegative() {
+		return fmt.Errorf("max inflow must be non-negative")
+	}
+	if r.MaxOutflow.IsNil() || r.MaxOutflow.IsNegative() {
+		return fmt.Errorf("max outflow must be non-negative")
+	}
+	if r.MaxInflow.IsZero() && r.MaxOutflow.IsZero() {
+		return fmt.Errorf("at least one of max inflow and max outflow must be set")
+	}
+	if r.Window <= 0 {
+		return fmt.Errorf("window must be positive: %s", r.Window)
+	}
+	return nil
+}
+
+// NewFlow creates an empty flow whose first window starts at the given time.
+func NewFlow(channelID, denom string, windowStart time.Time) Flow {
+	return Flow{
+		ChannelId:       channelID,
+		Denom:           denom,
+		WindowStart:     windowStart,
+		PreviousInflow:  sdkmath.ZeroInt(),
+		PreviousOutflow: sdkmath.ZeroInt(),
+		Inflow:          sdkmath.ZeroInt(),
+		Outflow:         sdkmath.ZeroInt(),
+	}
+}
+
+// Advance moves the fixed window forward so that it contains now. The
+// current amounts become the previous ones if the windows are consecutive and
+// are dropped otherwise.
+func (f *Flow) Advance(now time.Time, window time.Duration) {
+	elapsed := now.Sub(f.WindowStart)
+	if elapsed < window {
+		return
+	}
+	windows := elapsed / window
+	if windows == 1 {
+		f.PreviousInflow = f.Inflow
+		f.PreviousOutflow = f.Outflow
+	} else {
+		f.PreviousInflow = sdkmath.ZeroInt()
+		f.PreviousOutflow = sdkmath.ZeroInt()
+	}
+	f.Inflow = sdkmath.ZeroInt()
+	f.Outflow = sdkmath.ZeroInt()
+	f.WindowStart = f.WindowStart.Add(windows * window)
+}
+
+// Estimate the amount that flowed over the sliding window ending at now. The
+// previous window is weighted by the fraction that still overlaps with the
+// sliding window. The flow must have been advanced to now.
+func slidingAmount(previous, current sdkmath.Int, windowStart, now time.Time, window time.Duration) sdkmath.Int {
+	remaining := window - now.Sub(windowStart)
+	if remaining <= 0 || previous.IsZero() {
+		return current
+	}
+	weighted := previous.Mul(sdkmath.NewInt(int64(remaining))).Quo(sdkmath.NewInt(int64(window)))
+	return current.Add(weighted)
+}
+
+// SlidingInflow returns the estimated inflow over the sliding window ending at
+// now.
+func (f Flow) SlidingInflow(now time.Time, window time.Duration) sdkmath.Int {
+	return slidingAmount(f.PreviousInflow, f.Inflow, f.WindowStart, now, window)
+}
+
+// SlidingOutflow returns the estimated outflow over the sliding window ending
+// at now.
+func (f Flow) SlidingOutflow(now time.Time, window time.Duration) sdkmath.Int {
+	return slidingAmount(f.PreviousOutflow, f.Outflow, f.WindowStart, now, window)
+}
+
+// AddInflow records a received amount, failing if it would exceed the limit.
+func (f *Flow) AddInflow(limit RateLimit, amount sdkmath.Int, now time.Time) error {
+	f.Advance(now, limit.Window)
+	if !limit.MaxInflow.IsZero() {
+		if total := f.SlidingInflow(now, limit.Window).Add(amount); total.GT(limit.MaxInflow) {
+			return fmt.Errorf("inflow of %s%s on %s would reach %s over the window, max is %s", amount, limit.Denom, limit.ChannelId, total, limit.MaxInflow)
+		}
+	}
+	f.Inflow = f.Inflow.Add(amount)
+	return nil
+}
+
+// AddOutflow records a sent amount, failing if it would exceed the limit.
+func (f *Flow) AddOutflow(limit RateLimit, amount sdkmath.Int, now time.Time) error {
+	f.Advance(now, limit.Window)
+	if !limit.MaxOutflow.IsZero() {
+		if total := f.SlidingOutflow(now, limit.Window).Add(amount); total.GT(limit.MaxOutflow) {
+			return fmt.Errorf("outflow of %s%s on %s would reach %s over the window, max is %s", amount, limit.Denom, limit.ChannelId, total, limit.MaxOutflow)
+		}
+	}
+	f.Outflow = f.Outflow.Add(amount)
+	return nil
+}
+
+// RevertOutflow gives back the quota consumed by a packet that got refunded,
+// crediting the fixed window it was sent in, identified by its start. Nothing
+// is credited if that window is neither the current nor the previous one, as
+// the amount no longer counts against the sliding window.
+func (f *Flow) RevertOutflow(limit RateLimit, amount sdkmath.Int, sentWindowStart, now time.Time) {
+	f.Advance(now, limit.Window)
+	switch {
+	case sentWindowStart.Equal(f.WindowStart):
+		f.Outflow = sdkmath.MaxInt(f.Outflow.Sub(amount), sdkmath.ZeroInt())
+	case sentWindowStart.Equal(f.WindowStart.Add(-limit.Window)):
+		f.PreviousOutflow = sdkmath.MaxInt(f.PreviousOutflow.Sub(amount), sdkmath.ZeroInt())
+	}
+}
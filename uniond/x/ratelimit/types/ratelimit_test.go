@@ -0,0 +1,115 @@
+package types_test
+
+import (
+	"testing"
+	"time"
+
+	sdkmath "cosmossdk.io/math"
+	"github.com/stretchr/testify/require"
+
+	"union/x/ratelimit/types"
+)
+
+func TestRateLimitValidate(t *testing.T) {
+	for _, tc := range []struct {
+		name      string
+		rateLimit types.RateLimit
+		valid     bool
+	}{
+		{
+			name:      "valid",
+			rateLimit: types.NewRateLimit("channel-0", "muno", sdkmath.NewInt(100), sdkmath.NewInt(200), time.Hour),
+			valid:     true,
+		},
+		{
+			name:      "voucher denom",
+			rateLimit: types.NewRateLimit("channel-0", "ibc/27394FB092D2ECCD56123C74F36E4C1F926001CEADA9CA97EA622B25F41E5EB2", sdkmath.ZeroInt(), sdkmath.NewInt(200), time.Hour),
+			valid:     true,
+		},
+		{
+			name:      "invalid channel",
+			rateLimit: types.NewRateLimit("channel/0", "muno", sdkmath.NewInt(100), sdkmath.NewInt(200), time.Hour),
+			valid:     false,
+		},
+		{
+			name:      "negative max",
+			rateLimit: types.NewRateLimit("channel-0", "muno", sdkmath.NewInt(-1), sdkmath.NewInt(200), time.Hour),
+			valid:     false,
+		},
+		{
+			name:      "no max",
+			rateLimit: types.NewRateLimit("channel-0", "muno", sdkmath.ZeroInt(), sdkmath.ZeroInt(), time.Hour),
+			valid:     false,
+		},
+		{
+			name:      "zero window",
+			rateLimit: types.NewRateLimit("channel-0", "muno", sdkmath.NewInt(100), sdkmath.NewInt(200), 0),
+			valid:     false,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			err := tc.rateLimit.Validate()
+			if tc.valid {
+				require.NoError(t, err)
+			} else {
+				require.Error(t, err)
+			}
+		})
+	}
+}
+
+func TestFlowSlidingWindow(t *testing.T) {
+	start := time.Unix(1_700_000_000, 0)
+	rateLimit := types.NewRateLimit("channel-0", "muno", sdkmath.ZeroInt(), sdkmath.NewInt(100), time.Hour)
+	flow := types.NewFlow("channel-0", "muno", start)
+
+	require.NoError(t, flow.AddOutflow(rateLimit, sdkmath.NewInt(80), start))
+	require.Error(t, flow.AddOutflow(rateLimit, sdkmath.NewInt(21), start.Add(time.Minute)))
+	require.NoError(t, flow.AddOutflow(rateLimit, sdkmath.NewInt(20), start.Add(time.Minute)))
+
+	// A quarter into the next window, three quarters of the previous one
+	// still count: 100 * 3/4 = 75.
+	now := start.Add(time.Hour + 15*time.Minute)
+	flow.Advance(now, rateLimit.Window)
+	require.Equal(t, sdkmath.NewInt(75), flow.SlidingOutflow(now, rateLimit.Window))
+	require.Error(t, flow.AddOutflow(rateLimit, sdkmath.NewInt(26), now))
+	require.NoError(t, flow.AddOutflow(rateLimit, sdkmath.NewInt(25), now))
+
+	// Refunds give back the quota of the window the packet was sent in.
+	flow.RevertOutflow(rateLimit, sdkmath.NewInt(50), start.Add(time.Hour), now)
+	require.Equal(t, sdkmath.ZeroInt(), flow.Outflow)
+	require.Equal(t, sdkmath.NewInt(100), flow.PreviousOutflow)
+
+	// Skipping a whole window forgets everything.
+	now = start.Add(3 * time.Hour)
+	flow.Advance(now, rateLimit.Window)
+	require.Equal(t, start.Add(3*time.Hour), flow.WindowStart)
+	require.Equal(t, sdkmath.ZeroInt(), flow.SlidingOutflow(now, rateLimit.Window))
+
+	// The inflow is unlimited.
+	require.NoError(t, flow.AddInflow(rateLimit, sdkmath.NewInt(1_000_000), now))
+}
+
+func TestFlowRevertOutflowAcrossWindows(t *testing.T) {
+	start := time.Unix(1_700_000_000, 0)
+	rateLimit := types.NewRateLimit("channel-0", "muno", sdkmath.ZeroInt(), sdkmath.NewInt(100), time.Hour)
+	flow := types.NewFlow("channel-0", "muno", start)
+
+	require.NoError(t, flow.AddOutflow(rateLimit, sdkmath.NewInt(60), start))
+	now := start.Add(time.Hour + 30*time.Minute)
+	require.NoError(t, flow.AddOutflow(rateLimit, sdkmath.NewInt(40), now))
+
+	// The packet sent in the previous window is refunded from it, leaving the
+	// sends of the current window accounted for.
+	flow.RevertOutflow(rateLimit, sdkmath.NewInt(60), start, now)
+	require.Equal(t, sdkmath.ZeroInt(), flow.PreviousOutflow)
+	require.Equal(t, sdkmath.NewInt(40), flow.Outflow)
+	require.Equal(t, sdkmath.NewInt(40), flow.SlidingOutflow(now, rateLimit.Window))
+	require.Error(t, flow.AddOutflow(rateLimit, sdkmath.NewInt(61), now))
+
+	// Once the window of the packet expired, nothing is credited.
+	now = start.Add(2*time.Hour + 30*time.Minute)
+	flow.RevertOutflow(rateLimit, sdkmath.NewInt(40), start, now)
+	require.Equal(t, sdkmath.NewInt(40), flow.PreviousOutflow)
+	require.Equal(t, sdkmath.ZeroInt(), flow.Outflow)
+}